@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+)
+
+// isTerminal reports whether f is a terminal rather than a pipe or a regular file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// useColor reports whether output to f should be colored: only for terminals, and
+// never when disabled by -no-color or by a non-empty NO_COLOR variable (https://no-color.org)
+func useColor(f *os.File, noColor bool) bool {
+	return !noColor && os.Getenv("NO_COLOR") == "" && isTerminal(f)
+}
+
+// colorizer wraps text in ANSI escape sequences, or leaves it as it is when disabled
+type colorizer struct {
+	enabled bool
+}
+
+// bold returns s in bold
+func (c colorizer) bold(s string) string {
+	if !c.enabled {
+		return s
+	}
+	return "\x1b[1m" + s + "\x1b[0m"
+}
+
+// aci returns s tinted with an ACI color. Layers that are off have a negative color,
+// which is tinted like its absolute value; colors without an RGB value are left plain.
+func (c colorizer) aci(s string, color int) string {
+	if color < 0 {
+		color = -color
+	}
+	rgb, ok := data.ACIToRGB(color)
+	if !c.enabled || !ok {
+		return s
+	}
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm%s\x1b[0m", rgb.R, rgb.G, rgb.B, s)
+}
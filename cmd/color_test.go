@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseColor(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	t.Setenv("NO_COLOR", "")
+	assert.False(t, isTerminal(file), "a regular file is not a terminal")
+	assert.False(t, useColor(file, false))
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+	assert.False(t, isTerminal(w), "a pipe is not a terminal")
+
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		defer tty.Close()
+		assert.True(t, useColor(tty, false))
+		assert.False(t, useColor(tty, true), "-no-color turns colors off")
+		t.Setenv("NO_COLOR", "1")
+		assert.False(t, useColor(tty, false), "NO_COLOR turns colors off")
+	}
+}
+
+func TestColorizer(t *testing.T) {
+	colors := colorizer{enabled: true}
+	assert.Equal(t, "\x1b[1mTitle\x1b[0m", colors.bold("Title"))
+	assert.Equal(t, "\x1b[38;2;255;0;0mWalls\x1b[0m", colors.aci("Walls", 1))
+	assert.Equal(t, "\x1b[38;2;0;255;0mOff\x1b[0m", colors.aci("Off", -3), "layers that are off keep their tint")
+	assert.Equal(t, "ByLayer", colors.aci("ByLayer", data.ColorByLayer))
+
+	plain := colorizer{}
+	assert.Equal(t, "Title", plain.bold("Title"))
+	assert.Equal(t, "Walls", plain.aci("Walls", 1))
+}
+
+func TestPrintExtractSummary(t *testing.T) {
+	dxfData := &data.ExtractedData{
+		DXFVersion: "R2018",
+		Units:      "Millimeters",
+		Layers: []data.LayerInfo{
+			{Name: "Walls", Color: 1, LineType: "CONTINUOUS", IsOn: true},
+			{Name: "Hidden", Color: -5, LineType: "DASHED", IsFrozen: true},
+		},
+	}
+
+	var plain bytes.Buffer
+	printExtractSummary(&plain, dxfData, colorizer{}, 0)
+	assert.Equal(t, "Successfully extracted DXF information:\n"+
+		"DXF Version: R2018\n"+
+		"Units: Millimeters\n"+
+		"Number of layers: 2\n"+
+		"\nLayer: Walls\n"+
+		"  Color: 1, Line Type: CONTINUOUS, ON\n"+
+		"\nLayer: Hidden\n"+
+		"  Color: -5, Line Type: DASHED, OFF (FROZEN)\n", plain.String())
+
+	var colored bytes.Buffer
+	printExtractSummary(&colored, dxfData, colorizer{enabled: true}, 0)
+	output := colored.String()
+	assert.Contains(t, output, "\x1b[1mSuccessfully extracted DXF information:\x1b[0m\n")
+	assert.Contains(t, output, "\x1b[1mLayer:\x1b[0m \x1b[38;2;255;0;0mWalls\x1b[0m\n")
+	assert.Contains(t, output, "\x1b[1mLayer:\x1b[0m \x1b[38;2;0;0;255mHidden\x1b[0m\n")
+}
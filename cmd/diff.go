@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/remym/go-dwg-extractor/pkg/clipboard"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/remym/go-dwg-extractor/pkg/tui"
+)
+
+// runDiffTUI shows two drawings side by side in the TUI; a variable so tests can replace it
+var runDiffTUI = func(beforeName, afterName string, before, after *data.ExtractedData) error {
+	app := tui.NewApp()
+	app.SetMouseEnabled(true)
+	app.ShowDiff(beforeName, afterName, before, after)
+	return app.Run()
+}
+
+// RunDiff runs the diff command, comparing a drawing with its revision and printing a
+// summary of the differences to w, or showing them side by side in the TUI with -tui
+func RunDiff(args []string, w io.Writer) error {
+	diffCmd := flag.NewFlagSet("diff", flag.ContinueOnError)
+	output := diffCmd.String("output", "", "Output directory for converted files (default: same as each input file)")
+	interactive := diffCmd.Bool("tui", false, "Show the differences side by side in the Terminal User Interface")
+	if err := diffCmd.Parse(args); err != nil {
+		return err
+	}
+
+	if diffCmd.NArg() != 2 {
+		return fmt.Errorf("diff needs two files. Usage: diff [options] <before> <after>")
+	}
+	beforePath, afterPath := diffCmd.Arg(0), diffCmd.Arg(1)
+
+	before, err := loadDrawing(beforePath, *output)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", beforePath, err)
+	}
+	after, err := loadDrawing(afterPath, *output)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", afterPath, err)
+	}
+
+	if *interactive {
+		return runDiffTUI(filepath.Base(beforePath), filepath.Base(afterPath), before, after)
+	}
+
+	printDiff(w, data.Diff(before, after))
+	return nil
+}
+
+// printDiff writes a summary of the differences, one change per line
+func printDiff(w io.Writer, result data.DiffResult) {
+	if result.IsEmpty() {
+		fmt.Fprintln(w, "No differences")
+		return
+	}
+
+	fmt.Fprintf(w, "Layers: %d added, %d removed, %d changed\n",
+		len(result.AddedLayers), len(result.RemovedLayers), len(result.ChangedLayers))
+	for _, name := range result.AddedLayers {
+		fmt.Fprintf(w, "  + %s\n", name)
+	}
+	for _, name := range result.RemovedLayers {
+		fmt.Fprintf(w, "  - %s\n", name)
+	}
+	for _, change := range result.ChangedLayers {
+		fmt.Fprintf(w, "  ~ %s: %s\n", change.Name, strings.Join(change.Changes, ", "))
+	}
+
+	formatter := clipboard.NewClipboardFormatter()
+	fmt.Fprintf(w, "Entities: %d added, %d removed\n", len(result.AddedEntities), len(result.RemovedEntities))
+	for _, entity := range result.AddedEntities {
+		fmt.Fprintf(w, "  + %s\n", formatter.FormatEntityForClipboard(entity))
+	}
+	for _, entity := range result.RemovedEntities {
+		fmt.Fprintf(w, "  - %s\n", formatter.FormatEntityForClipboard(entity))
+	}
+}
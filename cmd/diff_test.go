@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/remym/go-dwg-extractor/pkg/dxfparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDiff(t *testing.T) {
+	oldNewParser := newParser
+	oldRunDiffTUI := runDiffTUI
+	defer func() {
+		newParser = oldNewParser
+		runDiffTUI = oldRunDiffTUI
+	}()
+
+	tempDir := t.TempDir()
+	beforePath := filepath.Join(tempDir, "rev1.dxf")
+	afterPath := filepath.Join(tempDir, "rev2.dxf")
+	require.NoError(t, os.WriteFile(beforePath, []byte("0\nEOF\n"), 0644))
+	require.NoError(t, os.WriteFile(afterPath, []byte("0\nEOF\n"), 0644))
+
+	drawings := map[string]*data.ExtractedData{
+		beforePath: {
+			Layers: []data.LayerInfo{{Name: "Walls", IsOn: true, Color: 1}, {Name: "Old", IsOn: true}},
+			Lines:  []data.LineInfo{{Layer: "Walls", EndPoint: data.Point{X: 1}}},
+		},
+		afterPath: {
+			Layers:  []data.LayerInfo{{Name: "Walls", IsOn: false, Color: 1}, {Name: "New", IsOn: true}},
+			Lines:   []data.LineInfo{{Layer: "Walls", EndPoint: data.Point{X: 1}}},
+			Circles: []data.CircleInfo{{Layer: "New", Radius: 2}},
+		},
+	}
+	newParser = func() dxfparser.ParserInterface {
+		return &MockParser{
+			ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+				return drawings[dxfPath], nil
+			},
+		}
+	}
+
+	t.Run("prints a summary", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RunDiff([]string{beforePath, afterPath}, &out))
+		assert.Equal(t, "Layers: 1 added, 1 removed, 1 changed\n"+
+			"  + New\n"+
+			"  - Old\n"+
+			"  ~ Walls: turned off\n"+
+			"Entities: 1 added, 0 removed\n"+
+			"  + Circle: Center (0.0, 0.0), Radius: 2.0, Layer: New, Color: 0\n", out.String())
+	})
+
+	t.Run("identical drawings", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RunDiff([]string{beforePath, beforePath}, &out))
+		assert.Equal(t, "No differences\n", out.String())
+	})
+
+	t.Run("tui shows both drawings", func(t *testing.T) {
+		var names []string
+		runDiffTUI = func(beforeName, afterName string, before, after *data.ExtractedData) error {
+			names = []string{beforeName, afterName}
+			assert.Same(t, drawings[beforePath], before)
+			assert.Same(t, drawings[afterPath], after)
+			return nil
+		}
+
+		var out bytes.Buffer
+		require.NoError(t, RunDiff([]string{"-tui", beforePath, afterPath}, &out))
+		assert.Equal(t, []string{"rev1.dxf", "rev2.dxf"}, names)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("needs two files", func(t *testing.T) {
+		err := RunDiff([]string{beforePath}, &bytes.Buffer{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "diff needs two files")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		err := RunDiff([]string{beforePath, filepath.Join(tempDir, "missing.dwg")}, &bytes.Buffer{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load")
+	})
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+)
+
+// layerListing is the JSON form of a layer printed by the layers command
+type layerListing struct {
+	Name     string `json:"name"`
+	On       bool   `json:"on"`
+	Frozen   bool   `json:"frozen"`
+	Entities int    `json:"entities"`
+}
+
+// RunLayers runs the layers command, printing the layer names of a DWG file to w one
+// per line, or as a JSON array with -json. Nothing but the layers is written to w.
+func RunLayers(args []string, w io.Writer) error {
+	layersCmd := flag.NewFlagSet("layers", flag.ContinueOnError)
+	fileFlag := layersCmd.String("file", "", "Path to the DWG file to process")
+	output := layersCmd.String("output", "", "Output directory for converted files (default: same as input file)")
+	asJSON := layersCmd.Bool("json", false, "Print the layers as a JSON array with their state and entity counts")
+	if err := layersCmd.Parse(args); err != nil {
+		return err
+	}
+
+	if *fileFlag == "" {
+		return fmt.Errorf("no DWG file specified. Please provide a file using the -file flag")
+	}
+
+	dxfData, err := loadDrawing(*fileFlag, *output)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return writeLayersJSON(w, dxfData.Layers)
+	}
+	for _, layer := range dxfData.Layers {
+		fmt.Fprintln(w, layer.Name)
+	}
+	return nil
+}
+
+// writeLayersJSON writes the layers to w as an indented JSON array, empty rather than null when there are none
+func writeLayersJSON(w io.Writer, layers []data.LayerInfo) error {
+	listings := make([]layerListing, len(layers))
+	for i, layer := range layers {
+		listings[i] = layerListing{
+			Name:     layer.Name,
+			On:       layer.IsOn,
+			Frozen:   layer.IsFrozen,
+			Entities: len(layer.Entities),
+		}
+	}
+
+	content, err := json.MarshalIndent(listings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format layers as JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(content))
+	return err
+}
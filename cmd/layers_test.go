@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/converter"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/remym/go-dwg-extractor/pkg/dxfparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLayers(t *testing.T) {
+	dxfData := &data.ExtractedData{
+		Layers: []data.LayerInfo{
+			{Name: "Walls", IsOn: true, Entities: []data.Entity{&data.LineInfo{Layer: "Walls"}, &data.LineInfo{Layer: "Walls"}}},
+			{Name: "Hidden", IsOn: false, IsFrozen: true},
+		},
+		Warnings: []string{"Layer Hidden has no entities"},
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		parseErr    error
+		expected    string
+		errContains string
+	}{
+		{
+			name:     "names one per line",
+			args:     []string{},
+			expected: "Walls\nHidden\n",
+		},
+		{
+			name: "json with state and entity counts",
+			args: []string{"-json"},
+			expected: `[
+  {
+    "name": "Walls",
+    "on": true,
+    "frozen": false,
+    "entities": 2
+  },
+  {
+    "name": "Hidden",
+    "on": false,
+    "frozen": true,
+    "entities": 0
+  }
+]
+`,
+		},
+		{
+			name:        "parse failure",
+			args:        []string{},
+			parseErr:    errors.New("bad dxf"),
+			errContains: "failed to parse DXF file: bad dxf",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldNewDWGConverter := newDWGConverter
+			oldNewParser := newParser
+			defer func() {
+				newDWGConverter = oldNewDWGConverter
+				newParser = oldNewParser
+			}()
+
+			tempDir := t.TempDir()
+			testDWGPath := filepath.Join(tempDir, "test.dwg")
+			require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+			newDWGConverter = func(path string) (converter.DWGConverter, error) {
+				return &MockDWGConverter{
+					ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+						return filepath.Join(outputDir, "test.dxf"), nil
+					},
+				}, nil
+			}
+			newParser = func() dxfparser.ParserInterface {
+				return &MockParser{
+					ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+						return dxfData, tt.parseErr
+					},
+				}
+			}
+
+			var out bytes.Buffer
+			err := RunLayers(append([]string{"-file", testDWGPath}, tt.args...), &out)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestRunLayers_RequiresFile(t *testing.T) {
+	err := RunLayers([]string{"-json"}, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no DWG file specified")
+}
@@ -1,25 +1,64 @@
 package cmd
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/remym/go-dwg-extractor/pkg/clipboard"
 	"github.com/remym/go-dwg-extractor/pkg/config"
+	"github.com/remym/go-dwg-extractor/pkg/converter"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/remym/go-dwg-extractor/pkg/dxfparser"
+	"github.com/remym/go-dwg-extractor/pkg/tui"
 )
 
 var (
-	rootCmd   string
-	outputDir string
-	cfg       *config.AppConfig
+	rootCmd    string
+	outputDir  string
+	htmlReport string
+	csvPath    string
+	jsonPath   string
+	ndjsonPath string
+	svgPath    string
+	colorMode  string
+	format     string
+	dxfVersion string
+	onlyTypes  string
+	rawCodes   bool
+	flatten    bool
+	noColor    bool
+	limit      int
+	cfg        *config.AppConfig
 )
 
+// rawCodeKeeper is implemented by parsers that can retain raw DXF group codes
+type rawCodeKeeper interface {
+	SetKeepRawCodes(keep bool)
+}
+
+// readerParser is implemented by parsers that can parse DXF content from a reader
+type readerParser interface {
+	ParseDXFReader(r io.Reader) (*data.ExtractedData, error)
+}
+
+// stdin is where "-file -" reads DXF content from; tests replace it
+var stdin io.Reader = os.Stdin
+
+// entityFilterer is implemented by parsers that can restrict which entity types they parse
+type entityFilterer interface {
+	SetEntityFilter(types []string)
+}
+
 // Execute runs the root command
 func Execute() error {
 	// Check if no command is provided
 	if len(os.Args) < 2 {
-		return fmt.Errorf("no command provided. Use 'extract' or 'tui'")
+		return fmt.Errorf("no command provided. Use 'extract', 'layers', 'diff' or 'tui'")
 	}
 
 	// Handle the command
@@ -27,6 +66,10 @@ func Execute() error {
 	if command == "tui" {
 		// For TUI, just run it without any file requirements
 		return ExecuteTUI()
+	} else if command == "layers" {
+		return RunLayers(os.Args[2:], os.Stdout)
+	} else if command == "diff" {
+		return RunDiff(os.Args[2:], os.Stdout)
 	} else if command == "extract" {
 		// For extract, a DWG file is required
 		if len(os.Args) < 3 {
@@ -36,8 +79,21 @@ func Execute() error {
 		os.Args = append(os.Args[:1], os.Args[2:]...)
 
 		// Parse command line flags for extract command
-		fileFlag := flag.String("file", "", "Path to the DWG file to process")
+		fileFlag := flag.String("file", "", "Path to the DWG file to process, or - to read DXF from standard input")
 		flag.StringVar(&outputDir, "output", "", "Output directory for converted files (default: same as input file)")
+		flag.StringVar(&htmlReport, "html", "", "Write an HTML report of the extracted entities to this path")
+		flag.StringVar(&csvPath, "csv", "", "Write the extracted entities as CSV to this path")
+		flag.StringVar(&jsonPath, "json", "", "Write the extracted entities as a JSON array to this path")
+		flag.StringVar(&ndjsonPath, "ndjson", "", "Write the extracted entities as JSON Lines (one object per line) to this path")
+		flag.StringVar(&svgPath, "svg", "", "Write the lines, circles and polylines of the drawing as SVG to this path")
+		flag.StringVar(&colorMode, "color-mode", string(clipboard.ColorModeEntity), "Colors exported entities show: entity keeps each entity's own, layer resolves ByLayer and ByBlock to the layer color")
+		flag.StringVar(&format, "format", "dxf", "Conversion output format: dxf or pdf (pdf skips extraction)")
+		flag.StringVar(&dxfVersion, "dxf-version", converter.DefaultDXFVersion, "DXF version to convert to, e.g. ACAD2000 or ACAD2010")
+		flag.StringVar(&onlyTypes, "only", "", "Comma-separated entity types to extract, e.g. line,text (default: all)")
+		flag.BoolVar(&rawCodes, "raw", false, "Print the raw DXF group codes of each entity")
+		flag.BoolVar(&flatten, "flatten", false, "Replace block insertions with their definitions' geometry")
+		flag.BoolVar(&noColor, "no-color", false, "Print without colors (colors are also off when NO_COLOR is set or output is not a terminal)")
+		flag.IntVar(&limit, "limit", 0, "List at most this many layers and raw entities, with a count of the rest (default: no limit)")
 		flag.Parse()
 
 		// Set the root command from the flag
@@ -48,6 +104,33 @@ func Execute() error {
 			return fmt.Errorf("no DWG file specified. Please provide a file using the -file flag")
 		}
 
+		format = strings.ToLower(format)
+		if format != "dxf" && format != "pdf" {
+			return fmt.Errorf("unsupported format %q: use dxf or pdf", format)
+		}
+
+		// Standard input is parsed as DXF, since converting a DWG needs a file
+		readStdin := rootCmd == "-"
+		if readStdin && format == "pdf" {
+			return fmt.Errorf("-format pdf needs a DWG file: standard input can only be DXF")
+		}
+		if err := converter.ValidateDXFVersion(dxfVersion); err != nil {
+			return err
+		}
+		if _, err := clipboard.ParseColorMode(colorMode); err != nil {
+			return err
+		}
+		if limit < 0 {
+			return fmt.Errorf("invalid -limit %d: must be 0 (no limit) or more", limit)
+		}
+		var entityTypes []string
+		if onlyTypes != "" {
+			entityTypes = strings.Split(onlyTypes, ",")
+			if err := dxfparser.ValidateEntityTypes(entityTypes); err != nil {
+				return err
+			}
+		}
+
 		// Load configuration
 		var err error
 		cfg, err = config.LoadConfig()
@@ -60,49 +143,366 @@ func Execute() error {
 			outputDir = filepath.Dir(rootCmd)
 		}
 
-		// Create a new DWG converter (use DI for testing)
-		dwgConverter, err := newDWGConverter(cfg.ODAConverterPath)
-		if err != nil {
-			return fmt.Errorf("failed to create DWG converter: %w", err)
+		// A DXF input is parsed as it is, so the ODA converter is not needed for it
+		inputIsDXF := readStdin
+		if !readStdin {
+			inputIsDXF, err = dxfparser.IsDXF(rootCmd)
+			if err != nil {
+				return err
+			}
 		}
 
-		// Convert DWG to DXF
-		dxfFile, err := dwgConverter.ConvertToDXF(rootCmd, outputDir)
-		if err != nil {
-			return fmt.Errorf("conversion failed: %w", err)
+		dxfFile := rootCmd
+		if !inputIsDXF || format == "pdf" {
+			// Create a new DWG converter (use DI for testing)
+			dwgConverter, err := newDWGConverter(cfg.ODAConverterPath)
+			if err != nil {
+				return fmt.Errorf("failed to create DWG converter: %w", err)
+			}
+
+			// A PDF is a rendering of the drawing, so there is nothing to extract from it
+			if format == "pdf" {
+				pdfFile, err := dwgConverter.ConvertToPDF(rootCmd, outputDir)
+				if err != nil {
+					return fmt.Errorf("conversion failed: %w", err)
+				}
+				fmt.Printf("PDF written to %s\n", pdfFile)
+				return nil
+			}
+
+			// Convert DWG to DXF
+			dxfFile, err = dwgConverter.ConvertToDXFVersion(rootCmd, outputDir, dxfVersion)
+			if err != nil {
+				return fmt.Errorf("conversion failed: %w", err)
+			}
 		}
 
 		// Parse the DXF file
 		dxfParser := newParser()
-		dxfData, err := dxfParser.ParseDXF(dxfFile)
+		if rawCodes {
+			keeper, ok := dxfParser.(rawCodeKeeper)
+			if !ok {
+				return fmt.Errorf("parser does not support raw group codes")
+			}
+			keeper.SetKeepRawCodes(true)
+		}
+		if len(entityTypes) > 0 {
+			filterer, ok := dxfParser.(entityFilterer)
+			if !ok {
+				return fmt.Errorf("parser does not support entity filtering")
+			}
+			filterer.SetEntityFilter(entityTypes)
+		}
+		var dxfData *data.ExtractedData
+		if readStdin {
+			streamer, ok := dxfParser.(readerParser)
+			if !ok {
+				return fmt.Errorf("parser does not support reading from standard input")
+			}
+			dxfData, err = streamer.ParseDXFReader(stdin)
+		} else {
+			dxfData, err = dxfParser.ParseDXF(dxfFile)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to parse DXF file: %w", err)
 		}
 
+		if flatten {
+			dxfData = dxfData.FlattenBlocks()
+		}
+
+		// Report non-fatal parser diagnostics, including entities filed under the wrong layer
+		dxfData.Warnings = append(dxfData.Warnings, dxfData.ValidateLayerConsistency()...)
+		for _, warning := range dxfData.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+
 		// Display the extracted information
-		fmt.Println("Successfully extracted DXF information:")
-		fmt.Printf("DXF Version: %s\n", dxfData.DXFVersion)
-		fmt.Printf("Number of layers: %d\n", len(dxfData.Layers))
-		for _, layer := range dxfData.Layers {
-			onOff := "ON"
-			if !layer.IsOn {
-				onOff = "OFF"
+		printExtractSummary(os.Stdout, dxfData, colorizer{enabled: useColor(os.Stdout, noColor)}, limit)
+
+		// Print raw group codes if requested
+		if rawCodes {
+			printRawCodes(os.Stdout, dxfData, limit)
+		}
+
+		// Write the HTML report if requested
+		if htmlReport != "" {
+			if err := writeHTMLReport(htmlReport, dxfData); err != nil {
+				return err
 			}
-			frozen := ""
-			if layer.IsFrozen {
-				frozen = " (FROZEN)"
+			fmt.Printf("\nHTML report written to %s\n", htmlReport)
+		}
+
+		// Write the CSV export if requested
+		if csvPath != "" {
+			if err := writeCSVExport(csvPath, dxfData); err != nil {
+				return err
 			}
+			fmt.Printf("\nCSV written to %s\n", csvPath)
+		}
 
-			fmt.Printf("\nLayer: %s\n", layer.Name)
-			fmt.Printf("  Color: %d, Line Type: %s, %s%s\n", layer.Color, layer.LineType, onOff, frozen)
+		// Write the JSON export if requested
+		if jsonPath != "" {
+			if err := writeJSONExport(jsonPath, outputDir, dxfData); err != nil {
+				return err
+			}
+			fmt.Printf("\nJSON written to %s\n", jsonPath)
+		}
+
+		// Stream the entities as JSON Lines if requested
+		if ndjsonPath != "" {
+			if err := writeJSONLines(ndjsonPath, dxfData); err != nil {
+				return err
+			}
+			fmt.Printf("\nJSON Lines written to %s\n", ndjsonPath)
+		}
+
+		// Write the SVG drawing if requested
+		if svgPath != "" {
+			if err := writeSVGExport(svgPath, dxfData); err != nil {
+				return err
+			}
+			fmt.Printf("\nSVG written to %s\n", svgPath)
 		}
 
 		return nil
 	}
 
-	return fmt.Errorf("unknown command: %s. Use 'extract' or 'tui'", command)
+	return fmt.Errorf("unknown command: %s. Use 'extract', 'layers', 'diff' or 'tui'", command)
+}
 
-	// No duplicate code here
+// printExtractSummary prints the drawing version and units and the properties of each layer,
+// with headers in bold and layer names in their layer color when colors is enabled. At most
+// limit layers are listed when limit is positive; the layer count is always the full count.
+func printExtractSummary(w io.Writer, dxfData *data.ExtractedData, colors colorizer, limit int) {
+	fmt.Fprintln(w, colors.bold("Successfully extracted DXF information:"))
+	fmt.Fprintf(w, "DXF Version: %s\n", dxfData.DXFVersion)
+	fmt.Fprintf(w, "Units: %s\n", dxfData.Units)
+	fmt.Fprintf(w, "Number of layers: %d\n", len(dxfData.Layers))
+	shown := listLimit(len(dxfData.Layers), limit)
+	for _, layer := range dxfData.Layers[:shown] {
+		onOff := "ON"
+		if !layer.IsOn {
+			onOff = "OFF"
+		}
+		frozen := ""
+		if layer.IsFrozen {
+			frozen = " (FROZEN)"
+		}
+
+		fmt.Fprintf(w, "\n%s %s\n", colors.bold("Layer:"), colors.aci(layer.Name, layer.Color))
+		fmt.Fprintf(w, "  Color: %d, Line Type: %s, %s%s\n", layer.Color, layer.LineType, onOff, frozen)
+	}
+	printMore(w, len(dxfData.Layers)-shown, "layers")
+}
+
+// listLimit returns how many of total items to list when listing at most limit of them,
+// where a limit of 0 lists them all
+func listLimit(total, limit int) int {
+	if limit > 0 && limit < total {
+		return limit
+	}
+	return total
+}
+
+// printMore prints a footer counting the items left out of a listing, if any
+func printMore(w io.Writer, hidden int, noun string) {
+	if hidden > 0 {
+		fmt.Fprintf(w, "\n... and %d more %s\n", hidden, noun)
+	}
+}
+
+// printRawCodes prints the raw DXF group codes of every entity, delimited per entity.
+// Only the first limit entities are printed when limit is positive.
+func printRawCodes(w io.Writer, dxfData *data.ExtractedData, limit int) {
+	fmt.Fprintf(w, "\nRaw DXF codes:\n")
+	entities := dxfData.AllEntities()
+	shown := listLimit(len(entities), limit)
+	for i, entity := range entities[:shown] {
+		var codes []data.GroupCode
+		if holder, ok := entity.(data.RawCodeHolder); ok {
+			codes = holder.GetRawCodes()
+		}
+
+		kind := fmt.Sprintf("%T", entity)
+		if len(codes) > 0 && codes[0].Code == 0 {
+			kind = codes[0].Value
+		}
+
+		fmt.Fprintf(w, "\n--- Entity %d: %s (layer %s) ---\n", i+1, kind, entity.GetLayer())
+		if len(codes) == 0 {
+			fmt.Fprintf(w, "  (no raw codes captured)\n")
+		}
+		for _, gc := range codes {
+			fmt.Fprintf(w, "%5d  %s\n", gc.Code, gc.Value)
+		}
+		fmt.Fprintf(w, "--- End of entity %d ---\n", i+1)
+	}
+	printMore(w, len(entities)-shown, "entities")
+}
+
+// loadDrawing converts a DWG file to DXF in outputDir, or in the file's own directory
+// when outputDir is empty, and parses it. DXF files are parsed directly, without the ODA converter.
+func loadDrawing(path, outputDir string) (*data.ExtractedData, error) {
+	dxfFile := path
+	isDXF, err := dxfparser.IsDXF(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isDXF {
+		appConfig, err := config.LoadConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		dwgConverter, err := newDWGConverter(appConfig.ODAConverterPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DWG converter: %w", err)
+		}
+		if outputDir == "" {
+			outputDir = filepath.Dir(path)
+		}
+		dxfFile, err = dwgConverter.ConvertToDXF(path, outputDir)
+		if err != nil {
+			return nil, fmt.Errorf("conversion failed: %w", err)
+		}
+	}
+
+	dxfData, err := newParser().ParseDXF(dxfFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DXF file: %w", err)
+	}
+	return dxfData, nil
+}
+
+// exportEntities returns all extracted entities for export
+func exportEntities(dxfData *data.ExtractedData) []data.Entity {
+	return dxfData.AllEntities()
+}
+
+// exportColorMode returns the color mode chosen with -color-mode, keeping each entity's
+// own color unless layer mode was asked for
+func exportColorMode() clipboard.ColorMode {
+	mode, err := clipboard.ParseColorMode(colorMode)
+	if err != nil {
+		return clipboard.ColorModeEntity
+	}
+	return mode
+}
+
+// newExportFormatter creates a formatter for exporting the drawing in the chosen color mode
+func newExportFormatter(dxfData *data.ExtractedData) *clipboard.ClipboardFormatter {
+	formatter := clipboard.NewClipboardFormatter()
+	formatter.SetColorMode(exportColorMode())
+	formatter.SetLayers(dxfData.Layers)
+	return formatter
+}
+
+// writeCSVExport writes all extracted entities to the given path as CSV, one row per
+// line terminated by "\n" on every platform. The header row is written even when
+// there are no entities.
+func writeCSVExport(path string, dxfData *data.ExtractedData) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create CSV output directory: %w", err)
+	}
+
+	formatter := newExportFormatter(dxfData)
+	rows := formatter.FormatAsCSV(exportEntities(dxfData))
+	content := strings.Join(rows, "\n") + "\n"
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write CSV export: %w", err)
+	}
+
+	return nil
+}
+
+// writeJSONExport writes all extracted entities to the given path as a JSON array.
+// The target directory must exist unless it is the -output directory, which is created.
+func writeJSONExport(path, outputDir string, dxfData *data.ExtractedData) error {
+	dir := filepath.Dir(path)
+	if _, err := os.Stat(dir); err != nil {
+		if !os.IsNotExist(err) || filepath.Clean(dir) != filepath.Clean(outputDir) {
+			return fmt.Errorf("JSON export directory %s is not accessible: %w", dir, err)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create JSON output directory: %w", err)
+		}
+	}
+
+	formatter := newExportFormatter(dxfData)
+	content, err := formatter.FormatAsJSON(exportEntities(dxfData))
+	if err != nil {
+		return fmt.Errorf("failed to generate JSON export: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(content+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON export: %w", err)
+	}
+
+	return nil
+}
+
+// writeJSONLines streams all extracted entities to the given path as JSON Lines
+func writeJSONLines(path string, dxfData *data.ExtractedData) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create JSON Lines file: %w", err)
+	}
+	// Closes the file on early returns; the close that reports errors is below
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	formatter := newExportFormatter(dxfData)
+	if err := formatter.WriteJSONLines(writer, exportEntities(dxfData)); err != nil {
+		return fmt.Errorf("failed to write JSON Lines: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write JSON Lines: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write JSON Lines: %w", err)
+	}
+
+	return nil
+}
+
+// writeSVGExport writes the geometry of the drawing to the given path as SVG
+func writeSVGExport(path string, dxfData *data.ExtractedData) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create SVG file: %w", err)
+	}
+	// Closes the file on early returns; the close that reports errors is below
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	renderer := tui.NewPreviewRenderer()
+	renderer.SetColorMode(exportColorMode())
+	if err := renderer.WriteSVG(writer, dxfData); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write SVG: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write SVG: %w", err)
+	}
+
+	return nil
+}
+
+// writeHTMLReport writes an HTML report of all extracted entities to the given path
+func writeHTMLReport(path string, dxfData *data.ExtractedData) error {
+	formatter := newExportFormatter(dxfData)
+	report, err := formatter.FormatAsHTML(exportEntities(dxfData))
+	if err != nil {
+		return fmt.Errorf("failed to generate HTML report: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(report), 0644); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
 
 	return nil
 }
@@ -1,16 +1,23 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/remym/go-dwg-extractor/pkg/config"
 	"github.com/remym/go-dwg-extractor/pkg/converter"
 	"github.com/remym/go-dwg-extractor/pkg/data"
 	"github.com/remym/go-dwg-extractor/pkg/dxfparser"
+	"github.com/remym/go-dwg-extractor/pkg/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,6 +25,13 @@ import (
 // MockDWGConverter is a mock implementation of the DWGConverter interface
 type MockDWGConverter struct {
 	ConvertToDXFFunc func(dwgPath, outputDir string) (string, error)
+	ConvertToPDFFunc func(dwgPath, outputDir string) (string, error)
+
+	// ConvertToDXFVersionFunc defaults to ConvertToDXFFunc when unset
+	ConvertToDXFVersionFunc func(dwgPath, outputDir, version string) (string, error)
+
+	// ConvertToDXFContextFunc defaults to ConvertToDXFFunc when unset
+	ConvertToDXFContextFunc func(ctx context.Context, dwgPath, outputDir string) (string, error)
 }
 
 // MockParser is a mock implementation of the Parser interface
@@ -29,6 +43,34 @@ func (m *MockDWGConverter) ConvertToDXF(dwgPath, outputDir string) (string, erro
 	return m.ConvertToDXFFunc(dwgPath, outputDir)
 }
 
+func (m *MockDWGConverter) ConvertToDXFContext(ctx context.Context, dwgPath, outputDir string) (string, error) {
+	if m.ConvertToDXFContextFunc == nil {
+		return m.ConvertToDXFFunc(dwgPath, outputDir)
+	}
+	return m.ConvertToDXFContextFunc(ctx, dwgPath, outputDir)
+}
+
+func (m *MockDWGConverter) ConvertToDXFVersion(dwgPath, outputDir, version string) (string, error) {
+	if m.ConvertToDXFVersionFunc == nil {
+		return m.ConvertToDXFFunc(dwgPath, outputDir)
+	}
+	return m.ConvertToDXFVersionFunc(dwgPath, outputDir, version)
+}
+
+func (m *MockDWGConverter) ConvertToPDF(dwgPath, outputDir string) (string, error) {
+	return m.ConvertToPDFFunc(dwgPath, outputDir)
+}
+
+func (m *MockDWGConverter) ConvertDirectoryConcurrent(ctx context.Context, inputDir, outputDir string, workers int) ([]string, error) {
+	return nil, nil
+}
+
+func (m *MockDWGConverter) SetCache(cache *converter.ConversionCache) {}
+
+func (m *MockDWGConverter) SetLogger(logger logging.Logger) {}
+
+func (m *MockDWGConverter) SetArgTemplate(template []string) {}
+
 func (m *MockParser) ParseDXF(dxfPath string) (*data.ExtractedData, error) {
 	return m.ParseDXFFunc(dxfPath)
 }
@@ -74,7 +116,7 @@ func TestRootCommand(t *testing.T) {
 			args:        []string{"cmd"},
 			setup:       func() { newDWGConverter = converter.NewDWGConverter },
 			wantErr:     true,
-			errContains: "no command provided. Use 'extract' or 'tui'",
+			errContains: "no command provided. Use 'extract', 'layers', 'diff' or 'tui'",
 		},
 		{
 			name:        "extract command without file argument",
@@ -95,7 +137,7 @@ func TestRootCommand(t *testing.T) {
 			args:        []string{"cmd", "unknown"},
 			setup:       func() { newDWGConverter = converter.NewDWGConverter },
 			wantErr:     true,
-			errContains: "unknown command: unknown. Use 'extract' or 'tui'",
+			errContains: "unknown command: unknown. Use 'extract', 'layers', 'diff' or 'tui'",
 		},
 		{
 			name: "successful conversion with default output",
@@ -290,6 +332,870 @@ EOF`
 	}
 }
 
+func TestExtractHTMLReport(t *testing.T) {
+	oldArgs := os.Args
+	oldNewDWGConverter := newDWGConverter
+	oldNewParser := newParser
+	defer func() {
+		os.Args = oldArgs
+		newDWGConverter = oldNewDWGConverter
+		newParser = oldNewParser
+	}()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+	reportPath := filepath.Join(tempDir, "report.html")
+
+	newDWGConverter = func(path string) (converter.DWGConverter, error) {
+		return &MockDWGConverter{
+			ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+				return filepath.Join(outputDir, "test.dxf"), nil
+			},
+		}, nil
+	}
+	newParser = func() dxfparser.ParserInterface {
+		return &MockParser{
+			ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+				return &data.ExtractedData{
+					DXFVersion: "R2020",
+					Layers:     []data.LayerInfo{{Name: "Walls", IsOn: true, Color: 1}},
+					Texts: []data.TextInfo{
+						{Value: "Room <101>", Layer: "Walls", Height: 2.5},
+					},
+				}, nil
+			},
+		}
+	}
+
+	flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+	os.Args = []string{"cmd", "extract", "-file", testDWGPath, "-html", reportPath}
+
+	require.NoError(t, Execute())
+
+	report, err := os.ReadFile(reportPath)
+	require.NoError(t, err, "Expected HTML report to be written")
+	assert.Contains(t, string(report), "<h2>Layer: Walls (1)</h2>")
+	assert.Contains(t, string(report), "Room &lt;101&gt;")
+}
+
+func TestExtractNDJSON(t *testing.T) {
+	oldArgs := os.Args
+	oldNewDWGConverter := newDWGConverter
+	oldNewParser := newParser
+	defer func() {
+		os.Args = oldArgs
+		newDWGConverter = oldNewDWGConverter
+		newParser = oldNewParser
+	}()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+	ndjsonFile := filepath.Join(tempDir, "entities.ndjson")
+
+	newDWGConverter = func(path string) (converter.DWGConverter, error) {
+		return &MockDWGConverter{
+			ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+				return filepath.Join(outputDir, "test.dxf"), nil
+			},
+		}, nil
+	}
+	newParser = func() dxfparser.ParserInterface {
+		return &MockParser{
+			ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+				return &data.ExtractedData{
+					Layers: []data.LayerInfo{{Name: "Walls", IsOn: true}},
+					Texts:  []data.TextInfo{{Value: "Room 101", Layer: "Walls"}},
+					Lines:  []data.LineInfo{{Layer: "Walls", EndPoint: data.Point{X: 3, Y: 4}}},
+				}, nil
+			},
+		}
+	}
+
+	flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+	os.Args = []string{"cmd", "extract", "-file", testDWGPath, "-ndjson", ndjsonFile}
+
+	require.NoError(t, Execute())
+
+	content, err := os.ReadFile(ndjsonFile)
+	require.NoError(t, err, "Expected JSON Lines file to be written")
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"type":"Text"`)
+	assert.Contains(t, lines[0], `"value":"Room 101"`)
+	assert.Contains(t, lines[1], `"type":"Line"`)
+}
+
+func TestExtractCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     *data.ExtractedData
+		expected string
+	}{
+		{
+			name: "entities are written one row per line",
+			data: &data.ExtractedData{
+				Layers: []data.LayerInfo{{Name: "Walls", IsOn: true}},
+				Circles: []data.CircleInfo{
+					{Center: data.Point{X: 1, Y: 2}, Radius: 3, Layer: "Walls"},
+				},
+			},
+			expected: "Type,Layer,Details\nCircle,Walls,\"Center (1.0,2.0), Radius: 3.0, Color: 0\"\n",
+		},
+		{
+			name:     "empty drawing still writes the header row",
+			data:     &data.ExtractedData{},
+			expected: "Type,Layer,Details\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldArgs := os.Args
+			oldNewDWGConverter := newDWGConverter
+			oldNewParser := newParser
+			defer func() {
+				os.Args = oldArgs
+				newDWGConverter = oldNewDWGConverter
+				newParser = oldNewParser
+			}()
+
+			tempDir := t.TempDir()
+			testDWGPath := filepath.Join(tempDir, "test.dwg")
+			require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+			csvFile := filepath.Join(tempDir, "exports", "entities.csv")
+
+			newDWGConverter = func(path string) (converter.DWGConverter, error) {
+				return &MockDWGConverter{
+					ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+						return filepath.Join(outputDir, "test.dxf"), nil
+					},
+				}, nil
+			}
+			newParser = func() dxfparser.ParserInterface {
+				return &MockParser{
+					ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+						return tt.data, nil
+					},
+				}
+			}
+
+			flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+			os.Args = []string{"cmd", "extract", "-file", testDWGPath, "-csv", csvFile}
+
+			require.NoError(t, Execute())
+
+			content, err := os.ReadFile(csvFile)
+			require.NoError(t, err, "Expected CSV file to be written in the given directory")
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+}
+
+func TestExtractFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		expectPDF   bool
+		expectParse bool
+		errContains string
+	}{
+		{name: "dxf extracts entities", format: "dxf", expectParse: true},
+		{name: "pdf converts without extracting", format: "PDF", expectPDF: true},
+		{name: "unknown format is rejected", format: "svg", errContains: `unsupported format "svg"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldArgs := os.Args
+			oldNewDWGConverter := newDWGConverter
+			oldNewParser := newParser
+			defer func() {
+				os.Args = oldArgs
+				newDWGConverter = oldNewDWGConverter
+				newParser = oldNewParser
+			}()
+
+			tempDir := t.TempDir()
+			testDWGPath := filepath.Join(tempDir, "test.dwg")
+			require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+			pdfConverted, parsed := false, false
+			newDWGConverter = func(path string) (converter.DWGConverter, error) {
+				return &MockDWGConverter{
+					ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+						return filepath.Join(outputDir, "test.dxf"), nil
+					},
+					ConvertToPDFFunc: func(dwgPath, outputDir string) (string, error) {
+						pdfConverted = true
+						return filepath.Join(outputDir, "test.pdf"), nil
+					},
+				}, nil
+			}
+			newParser = func() dxfparser.ParserInterface {
+				return &MockParser{
+					ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+						parsed = true
+						return &data.ExtractedData{}, nil
+					},
+				}
+			}
+
+			flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+			os.Args = []string{"cmd", "extract", "-file", testDWGPath, "-format", tt.format}
+
+			err := Execute()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectPDF, pdfConverted)
+			assert.Equal(t, tt.expectParse, parsed)
+		})
+	}
+}
+
+func TestExtractDXFVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedVersion string
+		errContains     string
+	}{
+		{name: "defaults to ACAD2018", expectedVersion: "ACAD2018"},
+		{name: "older version is passed to the converter", args: []string{"-dxf-version", "ACAD2000"}, expectedVersion: "ACAD2000"},
+		{name: "unknown version is rejected", args: []string{"-dxf-version", "R12"}, errContains: `unknown DXF version "R12"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldArgs := os.Args
+			oldNewDWGConverter := newDWGConverter
+			oldNewParser := newParser
+			defer func() {
+				os.Args = oldArgs
+				newDWGConverter = oldNewDWGConverter
+				newParser = oldNewParser
+			}()
+
+			tempDir := t.TempDir()
+			testDWGPath := filepath.Join(tempDir, "test.dwg")
+			require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+			var gotVersion string
+			newDWGConverter = func(path string) (converter.DWGConverter, error) {
+				return &MockDWGConverter{
+					ConvertToDXFVersionFunc: func(dwgPath, outputDir, version string) (string, error) {
+						gotVersion = version
+						return filepath.Join(outputDir, "test.dxf"), nil
+					},
+				}, nil
+			}
+			newParser = func() dxfparser.ParserInterface {
+				return &MockParser{
+					ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+						return &data.ExtractedData{}, nil
+					},
+				}
+			}
+
+			flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+			os.Args = append([]string{"cmd", "extract", "-file", testDWGPath}, tt.args...)
+
+			err := Execute()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedVersion, gotVersion)
+		})
+	}
+}
+
+func TestExtractJSON(t *testing.T) {
+	withEntities := &data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "Walls", IsOn: true}},
+		Texts:  []data.TextInfo{{Value: "Room 101", Layer: "Walls"}},
+	}
+
+	tests := []struct {
+		name          string
+		data          *data.ExtractedData
+		jsonPath      func(tempDir string) string
+		extraArgs     func(tempDir string) []string
+		expectedCount int
+		errContains   string
+	}{
+		{
+			name:          "entities are written as a JSON array",
+			data:          withEntities,
+			jsonPath:      func(tempDir string) string { return filepath.Join(tempDir, "out.json") },
+			expectedCount: 1,
+		},
+		{
+			name:          "empty drawing writes an empty array",
+			data:          &data.ExtractedData{},
+			jsonPath:      func(tempDir string) string { return filepath.Join(tempDir, "out.json") },
+			expectedCount: 0,
+		},
+		{
+			name:     "output directory is created",
+			data:     withEntities,
+			jsonPath: func(tempDir string) string { return filepath.Join(tempDir, "exports", "out.json") },
+			extraArgs: func(tempDir string) []string {
+				return []string{"-output", filepath.Join(tempDir, "exports")}
+			},
+			expectedCount: 1,
+		},
+		{
+			name:        "missing directory is reported",
+			data:        withEntities,
+			jsonPath:    func(tempDir string) string { return filepath.Join(tempDir, "missing", "out.json") },
+			errContains: "JSON export directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldArgs := os.Args
+			oldNewDWGConverter := newDWGConverter
+			oldNewParser := newParser
+			defer func() {
+				os.Args = oldArgs
+				newDWGConverter = oldNewDWGConverter
+				newParser = oldNewParser
+			}()
+
+			tempDir := t.TempDir()
+			testDWGPath := filepath.Join(tempDir, "test.dwg")
+			require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+			jsonFile := tt.jsonPath(tempDir)
+
+			newDWGConverter = func(path string) (converter.DWGConverter, error) {
+				return &MockDWGConverter{
+					ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+						return filepath.Join(outputDir, "test.dxf"), nil
+					},
+				}, nil
+			}
+			newParser = func() dxfparser.ParserInterface {
+				return &MockParser{
+					ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+						return tt.data, nil
+					},
+				}
+			}
+
+			flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+			os.Args = []string{"cmd", "extract", "-file", testDWGPath, "-json", jsonFile}
+			if tt.extraArgs != nil {
+				os.Args = append(os.Args, tt.extraArgs(tempDir)...)
+			}
+
+			err := Execute()
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(jsonFile)
+			require.NoError(t, err, "Expected JSON file to be written")
+			var entities []map[string]interface{}
+			require.NoError(t, json.Unmarshal(content, &entities), "Expected valid JSON")
+			assert.NotNil(t, entities, "Expected a JSON array rather than null")
+			assert.Len(t, entities, tt.expectedCount)
+		})
+	}
+}
+
+func TestExtractPrintsWarnings(t *testing.T) {
+	oldArgs := os.Args
+	oldNewDWGConverter := newDWGConverter
+	oldNewParser := newParser
+	defer func() {
+		os.Args = oldArgs
+		newDWGConverter = oldNewDWGConverter
+		newParser = oldNewParser
+	}()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+	newDWGConverter = func(path string) (converter.DWGConverter, error) {
+		return &MockDWGConverter{
+			ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+				return filepath.Join(outputDir, "test.dxf"), nil
+			},
+		}, nil
+	}
+	newParser = func() dxfparser.ParserInterface {
+		return &MockParser{
+			ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+				return &data.ExtractedData{
+					DXFVersion: "R14",
+					Layers: []data.LayerInfo{
+						{Name: "Walls", Entities: []data.Entity{&data.LineInfo{Layer: "Doors"}}},
+					},
+					Lines:    []data.LineInfo{{Layer: "Ghost"}},
+					Warnings: []string{"Skipped 2 unrecognized SPLINE entities", "Layer Empty has no entities"},
+				}, nil
+			},
+		}
+	}
+
+	flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+	os.Args = []string{"cmd", "extract", "-file", testDWGPath}
+
+	var err error
+	stderr := captureStderr(t, func() {
+		err = Execute()
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "Warning: Skipped 2 unrecognized SPLINE entities\n")
+	assert.Contains(t, stderr, "Warning: Layer Empty has no entities\n")
+	assert.Contains(t, stderr, "Warning: Line 1 on layer Walls reports layer \"Doors\"\n")
+	assert.Contains(t, stderr, "Warning: 1 entity references nonexistent layer \"Ghost\"\n")
+}
+
+// rawCodeParser is a mock parser that records whether raw group codes were requested
+type rawCodeParser struct {
+	MockParser
+	keepRawCodes bool
+}
+
+func (p *rawCodeParser) SetKeepRawCodes(keep bool) {
+	p.keepRawCodes = keep
+}
+
+func TestExtractRawFlag(t *testing.T) {
+	oldArgs := os.Args
+	oldNewDWGConverter := newDWGConverter
+	oldNewParser := newParser
+	defer func() {
+		os.Args = oldArgs
+		newDWGConverter = oldNewDWGConverter
+		newParser = oldNewParser
+	}()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+	newDWGConverter = func(path string) (converter.DWGConverter, error) {
+		return &MockDWGConverter{
+			ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+				return filepath.Join(outputDir, "test.dxf"), nil
+			},
+		}, nil
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{"without flag", []string{"cmd", "extract", "-file", testDWGPath}, false},
+		{"with flag", []string{"cmd", "extract", "-file", testDWGPath, "-raw"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := &rawCodeParser{MockParser: MockParser{
+				ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+					return &data.ExtractedData{DXFVersion: "AC1032"}, nil
+				},
+			}}
+			newParser = func() dxfparser.ParserInterface { return parser }
+
+			flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+			os.Args = tt.args
+
+			require.NoError(t, Execute())
+			assert.Equal(t, tt.want, parser.keepRawCodes)
+		})
+	}
+}
+
+// entityFilterParser is a mock parser that records the entity filter it was given
+type entityFilterParser struct {
+	MockParser
+	types []string
+}
+
+func (p *entityFilterParser) SetEntityFilter(types []string) {
+	p.types = types
+}
+
+func TestExtractOnlyFlag(t *testing.T) {
+	oldArgs := os.Args
+	oldNewDWGConverter := newDWGConverter
+	oldNewParser := newParser
+	defer func() {
+		os.Args = oldArgs
+		newDWGConverter = oldNewDWGConverter
+		newParser = oldNewParser
+	}()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+	newDWGConverter = func(path string) (converter.DWGConverter, error) {
+		return &MockDWGConverter{
+			ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+				return filepath.Join(outputDir, "test.dxf"), nil
+			},
+		}, nil
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		want        []string
+		errContains string
+	}{
+		{name: "without flag", want: nil},
+		{name: "with types", args: []string{"-only", "line,text"}, want: []string{"line", "text"}},
+		{name: "unknown type", args: []string{"-only", "line,spline"}, errContains: `unknown entity type "spline"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := &entityFilterParser{MockParser: MockParser{
+				ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+					return &data.ExtractedData{DXFVersion: "AC1032"}, nil
+				},
+			}}
+			newParser = func() dxfparser.ParserInterface { return parser }
+
+			flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+			os.Args = append([]string{"cmd", "extract", "-file", testDWGPath}, tt.args...)
+
+			err := Execute()
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, parser.types)
+		})
+	}
+}
+
+func TestExtractFlattenFlag(t *testing.T) {
+	oldArgs := os.Args
+	oldNewDWGConverter := newDWGConverter
+	oldNewParser := newParser
+	defer func() {
+		os.Args = oldArgs
+		newDWGConverter = oldNewDWGConverter
+		newParser = oldNewParser
+	}()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+	csvFile := filepath.Join(tempDir, "entities.csv")
+
+	newDWGConverter = func(path string) (converter.DWGConverter, error) {
+		return &MockDWGConverter{
+			ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+				return filepath.Join(outputDir, "test.dxf"), nil
+			},
+		}, nil
+	}
+	newParser = func() dxfparser.ParserInterface {
+		return &MockParser{
+			ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+				return &data.ExtractedData{
+					Layers: []data.LayerInfo{{Name: "Doors", IsOn: true}},
+					Blocks: []data.BlockInfo{{Name: "DOOR", Layer: "Doors", InsertionPoint: data.Point{X: 10}, Scale: data.Point{X: 1, Y: 1, Z: 1}}},
+					BlockDefinitions: map[string]*data.BlockDefinition{
+						"DOOR": {Name: "DOOR", Entities: []data.Entity{&data.CircleInfo{Radius: 1, Layer: "0"}}},
+					},
+				}, nil
+			},
+		}
+	}
+
+	flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+	os.Args = []string{"cmd", "extract", "-file", testDWGPath, "-flatten", "-csv", csvFile}
+
+	require.NoError(t, Execute())
+
+	content, err := os.ReadFile(csvFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Type,Layer,Details\nCircle,Doors,\"Center (10.0,0.0), Radius: 1.0, Color: 0\"\n", string(content))
+}
+
+func TestExtractDXFInput(t *testing.T) {
+	oldArgs := os.Args
+	oldNewDWGConverter := newDWGConverter
+	oldNewParser := newParser
+	defer func() {
+		os.Args = oldArgs
+		newDWGConverter = oldNewDWGConverter
+		newParser = oldNewParser
+	}()
+
+	tempDir := t.TempDir()
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+	}{
+		{"dxf extension", "drawing.dxf", "0\nEOF\n"},
+		{"dxf content", "drawing.export", "0\nSECTION\n2\nENTITIES\n0\nENDSEC\n0\nEOF\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputPath := filepath.Join(tempDir, tt.fileName)
+			require.NoError(t, os.WriteFile(inputPath, []byte(tt.content), 0644))
+
+			newDWGConverter = func(path string) (converter.DWGConverter, error) {
+				t.Error("The converter should not be created for DXF input")
+				return nil, errors.New("no converter")
+			}
+			var parsedPath string
+			newParser = func() dxfparser.ParserInterface {
+				return &MockParser{
+					ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+						parsedPath = dxfPath
+						return &data.ExtractedData{}, nil
+					},
+				}
+			}
+
+			flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+			os.Args = []string{"cmd", "extract", "-file", inputPath}
+
+			require.NoError(t, Execute())
+			assert.Equal(t, inputPath, parsedPath, "The DXF input should be parsed as it is")
+		})
+	}
+}
+
+func TestExtractStdin(t *testing.T) {
+	oldArgs := os.Args
+	oldStdin := stdin
+	oldNewDWGConverter := newDWGConverter
+	defer func() {
+		os.Args = oldArgs
+		stdin = oldStdin
+		newDWGConverter = oldNewDWGConverter
+	}()
+	newDWGConverter = func(path string) (converter.DWGConverter, error) {
+		t.Error("The converter should not be created for standard input")
+		return nil, errors.New("no converter")
+	}
+
+	t.Run("dxf is parsed", func(t *testing.T) {
+		jsonFile := filepath.Join(t.TempDir(), "entities.json")
+		stdin = strings.NewReader("0\nSECTION\n2\nENTITIES\n0\nTEXT\n8\nNotes\n1\nRoom 101\n0\nENDSEC\n0\nEOF\n")
+
+		flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+		os.Args = []string{"cmd", "extract", "-file", "-", "-json", jsonFile}
+
+		require.NoError(t, Execute())
+		content, err := os.ReadFile(jsonFile)
+		require.NoError(t, err, "Expected JSON file to be written")
+		assert.Contains(t, string(content), `"value": "Room 101"`)
+	})
+
+	t.Run("dwg is rejected", func(t *testing.T) {
+		stdin = strings.NewReader("AC1032\x00\x00\x00\x00\x00\x01")
+
+		flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+		os.Args = []string{"cmd", "extract", "-file", "-"}
+
+		err := Execute()
+		assert.ErrorIs(t, err, dxfparser.ErrDWGInput)
+	})
+}
+
+func TestExtractSVGFlag(t *testing.T) {
+	oldArgs := os.Args
+	oldNewParser := newParser
+	defer func() {
+		os.Args = oldArgs
+		newParser = oldNewParser
+	}()
+
+	tempDir := t.TempDir()
+	inputPath := filepath.Join(tempDir, "drawing.dxf")
+	require.NoError(t, os.WriteFile(inputPath, []byte("0\nEOF\n"), 0644))
+	svgFile := filepath.Join(tempDir, "drawing.svg")
+
+	newParser = func() dxfparser.ParserInterface {
+		return &MockParser{
+			ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+				return &data.ExtractedData{
+					Layers: []data.LayerInfo{{Name: "Walls", IsOn: true}},
+					Lines:  []data.LineInfo{{Layer: "Walls", EndPoint: data.Point{X: 3, Y: 4}}},
+				}, nil
+			},
+		}
+	}
+
+	flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+	os.Args = []string{"cmd", "extract", "-file", inputPath, "-svg", svgFile}
+
+	require.NoError(t, Execute())
+
+	content, err := os.ReadFile(svgFile)
+	require.NoError(t, err, "Expected SVG file to be written")
+	assert.Contains(t, string(content), `<line x1="0" y1="0" x2="3" y2="4"/>`)
+	assert.True(t, strings.HasSuffix(string(content), "</svg>\n"))
+}
+
+func TestPrintRawCodes(t *testing.T) {
+	dxfData := &data.ExtractedData{
+		Lines: []data.LineInfo{{Layer: "WALLS", RawCodes: []data.GroupCode{
+			{Code: 0, Value: "LINE"},
+			{Code: 8, Value: "WALLS"},
+			{Code: 10, Value: "1.0"},
+		}}},
+		Points: []data.PointInfo{{Layer: "0"}},
+	}
+
+	var buf bytes.Buffer
+	printRawCodes(&buf, dxfData, 0)
+	output := buf.String()
+
+	assert.Contains(t, output, "--- Entity 1: LINE (layer WALLS) ---\n    0  LINE\n    8  WALLS\n   10  1.0\n--- End of entity 1 ---\n")
+	assert.Contains(t, output, "--- Entity 2: *data.PointInfo (layer 0) ---\n  (no raw codes captured)\n--- End of entity 2 ---\n")
+	assert.NotContains(t, output, "more entities")
+
+	buf.Reset()
+	printRawCodes(&buf, dxfData, 1)
+	output = buf.String()
+	assert.Contains(t, output, "--- End of entity 1 ---\n\n... and 1 more entities\n")
+	assert.NotContains(t, output, "Entity 2")
+}
+
+func TestPrintExtractSummary_Limit(t *testing.T) {
+	dxfData := &data.ExtractedData{Layers: []data.LayerInfo{
+		{Name: "A", IsOn: true}, {Name: "B", IsOn: true}, {Name: "C", IsOn: true}, {Name: "D", IsOn: true},
+	}}
+
+	var buf bytes.Buffer
+	printExtractSummary(&buf, dxfData, colorizer{}, 2)
+	output := buf.String()
+	assert.Contains(t, output, "Number of layers: 4\n", "the count covers every layer, not just those listed")
+	assert.Contains(t, output, "Layer: A\n")
+	assert.Contains(t, output, "Layer: B\n")
+	assert.NotContains(t, output, "Layer: C")
+	assert.True(t, strings.HasSuffix(output, "\n... and 2 more layers\n"))
+
+	// A limit at or above the total lists everything without a footer
+	for _, limit := range []int{0, 4, 10} {
+		buf.Reset()
+		printExtractSummary(&buf, dxfData, colorizer{}, limit)
+		assert.Contains(t, buf.String(), "Layer: D\n", "limit %d", limit)
+		assert.NotContains(t, buf.String(), "more layers", "limit %d", limit)
+	}
+}
+
+func TestExtractRejectsNegativeLimit(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	testDWGPath := filepath.Join(t.TempDir(), "test.dwg")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+	flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+	os.Args = []string{"cmd", "extract", "-file", testDWGPath, "-limit", "-1"}
+
+	err := Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid -limit -1")
+}
+
+func TestExtractColorModeFlag(t *testing.T) {
+	oldArgs := os.Args
+	oldNewParser := newParser
+	defer func() {
+		os.Args = oldArgs
+		newParser = oldNewParser
+	}()
+
+	tempDir := t.TempDir()
+	inputPath := filepath.Join(tempDir, "drawing.dxf")
+	require.NoError(t, os.WriteFile(inputPath, []byte("0\nEOF\n"), 0644))
+	newParser = func() dxfparser.ParserInterface {
+		return &MockParser{
+			ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+				return &data.ExtractedData{
+					Layers: []data.LayerInfo{{Name: "Walls", IsOn: true, Color: 3}},
+					Lines:  []data.LineInfo{{Layer: "Walls", Color: data.ColorByLayer, EndPoint: data.Point{X: 1}}},
+				}, nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"entity colors by default", nil, `"color": 256`},
+		{"layer colors", []string{"-color-mode", "layer"}, `"color": 3`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonFile := filepath.Join(t.TempDir(), "entities.json")
+			flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+			os.Args = append([]string{"cmd", "extract", "-file", inputPath, "-json", jsonFile}, tt.args...)
+
+			require.NoError(t, Execute())
+
+			content, err := os.ReadFile(jsonFile)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), tt.expected)
+		})
+	}
+
+	t.Run("unknown mode", func(t *testing.T) {
+		flag.CommandLine = flag.NewFlagSet("cmd", flag.ExitOnError)
+		os.Args = []string{"cmd", "extract", "-file", inputPath, "-color-mode", "block"}
+
+		err := Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unsupported color mode "block"`)
+	})
+}
+
+// captureStderr captures stderr written during f
+func captureStderr(t *testing.T, f func()) string {
+	t.Helper()
+	oldStderr := os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = w
+
+	outputChan := make(chan string)
+	go func() {
+		output, _ := io.ReadAll(r)
+		outputChan <- string(output)
+	}()
+
+	f()
+
+	w.Close()
+	os.Stderr = oldStderr
+	output := <-outputChan
+	r.Close()
+	return output
+}
+
 func TestConfigLoading(t *testing.T) {
 	// Save original environment variable and function
 	oldEnv := os.Getenv("ODA_CONVERTER_PATH")
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+)
+
+// builtinSample returns the drawing the TUI shows when given neither a file nor a sample
+func builtinSample() *data.ExtractedData {
+	return &data.ExtractedData{
+		DXFVersion: "R2020 (Sample Data)",
+		Units:      data.UnitName(0),
+		Layers: []data.LayerInfo{
+			{Name: "0", IsOn: true, IsFrozen: false, Color: 7, LineType: "CONTINUOUS"},
+			{Name: "Walls", IsOn: true, IsFrozen: false, Color: 1, LineType: "CONTINUOUS"},
+			{Name: "Doors", IsOn: true, IsFrozen: false, Color: 2, LineType: "DASHED"},
+			{Name: "Windows", IsOn: true, IsFrozen: true, Color: 3, LineType: "HIDDEN"},
+		},
+	}
+}
+
+// LoadSampleData reads sample data for the TUI from a JSON file in the format written by
+// the JSON clipboard copy. An empty path returns the built-in sample.
+func LoadSampleData(path string) (*data.ExtractedData, error) {
+	if path == "" {
+		return builtinSample(), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sample data: %w", err)
+	}
+	defer file.Close()
+
+	sample, err := data.FromJSON(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sample data from %s: %w", path, err)
+	}
+	sample.DXFVersion = fmt.Sprintf("Sample Data (%s)", filepath.Base(path))
+	return sample, nil
+}
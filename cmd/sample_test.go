@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleJSON is a small drawing in the JSON clipboard format
+const sampleJSON = `[
+  {"type": "Line", "layer": "Survey", "startPoint": {"x": 0, "y": 0}, "endPoint": {"x": 10, "y": 0}, "color": 1},
+  {"type": "Text", "layer": "Labels", "value": "North", "insertionPoint": {"x": 5, "y": 5}, "height": 1}
+]`
+
+func TestLoadSampleData(t *testing.T) {
+	t.Run("built-in sample", func(t *testing.T) {
+		sample, err := LoadSampleData("")
+		require.NoError(t, err)
+		assert.Equal(t, "R2020 (Sample Data)", sample.DXFVersion)
+		require.Len(t, sample.Layers, 4)
+		assert.Equal(t, "Walls", sample.Layers[1].Name)
+
+		// Each call returns its own copy
+		sample.Layers[1].Name = "Changed"
+		again, err := LoadSampleData("")
+		require.NoError(t, err)
+		assert.Equal(t, "Walls", again.Layers[1].Name)
+	})
+
+	t.Run("JSON file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "demo.json")
+		require.NoError(t, os.WriteFile(path, []byte(sampleJSON), 0644))
+
+		sample, err := LoadSampleData(path)
+		require.NoError(t, err)
+		assert.Equal(t, "Sample Data (demo.json)", sample.DXFVersion)
+		require.Len(t, sample.Layers, 2)
+		assert.Equal(t, "Survey", sample.Layers[0].Name)
+		assert.Equal(t, "Labels", sample.Layers[1].Name)
+		require.Len(t, sample.Texts, 1)
+		assert.Equal(t, "North", sample.Texts[0].Value)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadSampleData(filepath.Join(t.TempDir(), "missing.json"))
+		assert.ErrorContains(t, err, "failed to open sample data")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "broken.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"type": "Line"`), 0644))
+
+		_, err := LoadSampleData(path)
+		assert.ErrorContains(t, err, "failed to load sample data from "+path)
+	})
+}
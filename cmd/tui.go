@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"sync"
 	"time"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/remym/go-dwg-extractor/pkg/config"
 	"github.com/remym/go-dwg-extractor/pkg/converter"
 	"github.com/remym/go-dwg-extractor/pkg/data"
@@ -18,105 +21,254 @@ import (
 var tuiCmd = flag.NewFlagSet("tui", flag.ExitOnError)
 var tuiOutputDir string
 var tuiFileFlag string
+var tuiSamplePath string
+var tuiRawCodes bool
 
 func init() {
 	tuiCmd.StringVar(&tuiOutputDir, "output", "", "Output directory for converted files (default: same as input file)")
 	tuiCmd.StringVar(&tuiFileFlag, "file", "", "Path to the DWG file to process")
+	tuiCmd.StringVar(&tuiSamplePath, "sample", "", "JSON file of sample data to show when no file is given (default: built-in sample)")
+	tuiCmd.BoolVar(&tuiRawCodes, "raw", false, "Keep the raw DXF group codes of each entity, shown with 'r' in entity details")
+}
+
+// TUIDeps are the collaborators RunTUIWithDeps builds the TUI from, replaceable in tests
+type TUIDeps struct {
+	// NewConverter creates the converter for DWG files at the configured path
+	NewConverter func(converterPath string) (converter.DWGConverter, error)
+	// NewParser creates the parser for DXF files
+	NewParser func() dxfparser.ParserInterface
+	// LoadConfig loads the application configuration
+	LoadConfig func() (*config.AppConfig, error)
+	// Screen is drawn to; nil means the terminal, failing fast with a tui.SystemError without one
+	Screen tcell.Screen
+	// Sessions saves and restores the view state of opened drawings; nil disables sessions
+	Sessions *tui.SessionManager
+}
+
+// tuiRun is what the opens and reloads of one TUI run share
+type tuiRun struct {
+	mu      sync.Mutex
+	tempDir string                     // Directory DWG files are converted into without -output; created on first use
+	cache   *converter.ConversionCache // Conversions reused when an unchanged drawing is opened again
+}
+
+// outputDir returns the directory to convert DWG files into: the -output directory, or
+// one temporary directory for the whole run, so reloads find their earlier conversions
+func (r *tuiRun) outputDir() (string, error) {
+	if tuiOutputDir != "" {
+		return tuiOutputDir, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tempDir == "" {
+		tempDir, err := os.MkdirTemp("", "dwg-extractor-*")
+		if err != nil {
+			return "", err
+		}
+		r.tempDir = tempDir
+	}
+	return r.tempDir, nil
+}
+
+// cleanup removes the temporary directory of the run, if one was created
+func (r *tuiRun) cleanup() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tempDir != "" {
+		_ = os.RemoveAll(r.tempDir)
+		r.tempDir = ""
+	}
+}
+
+// DefaultTUIDeps returns the dependencies of the TUI command in production
+func DefaultTUIDeps() TUIDeps {
+	deps := TUIDeps{
+		NewConverter: converter.NewDWGConverter,
+		NewParser: func() dxfparser.ParserInterface {
+			return dxfparser.NewParser()
+		},
+		LoadConfig: config.LoadConfig,
+	}
+	if sessionPath, err := tui.DefaultSessionPath(); err == nil {
+		deps.Sessions = tui.NewSessionManager(sessionPath)
+	}
+	return deps
 }
 
 // RunTUI runs the TUI command
 func RunTUI(args []string) error {
-	app := tui.NewApp()
+	return RunTUIWithDeps(args, DefaultTUIDeps())
+}
 
-	// Start the app and handle initialization after event loop starts
-	go func() {
-		// Wait a moment for the app to start
-		time.Sleep(100 * time.Millisecond)
+// RunTUIWithDeps runs the TUI command built from deps
+func RunTUIWithDeps(args []string, deps TUIDeps) error {
+	// Without a file, sample data is shown; a bad sample file fails before the TUI starts
+	var sample *data.ExtractedData
+	if len(args) == 0 {
+		var err error
+		if sample, err = LoadSampleData(tuiSamplePath); err != nil {
+			return err
+		}
+	}
 
-		if args != nil && len(args) > 0 {
-			dwgFile := args[0]
-
-			// Check if the file is a DXF file (for testing)
-			if strings.ToLower(filepath.Ext(dwgFile)) == ".dxf" {
-				app.ShowStatus("Parsing DXF file: " + dwgFile)
-				var dxfParser dxfparser.ParserInterface = dxfparser.NewParser()
-				dxfData, err := dxfParser.ParseDXF(dwgFile)
-				if err != nil {
-					app.ShowError("Failed to parse DXF file: " + err.Error())
-					return
-				}
-				app.ShowStatus("DXF parsing successful!")
-				app.UpdateDXFData(dxfData)
-				return
+	// -raw keeps the group codes entity details show with 'r'
+	if tuiRawCodes && deps.NewParser != nil {
+		newParser := deps.NewParser
+		deps.NewParser = func() dxfparser.ParserInterface {
+			parser := newParser()
+			if keeper, ok := parser.(rawCodeKeeper); ok {
+				keeper.SetKeepRawCodes(true)
 			}
+			return parser
+		}
+	}
 
-			// Process DWG file
-			// Load configuration
-			cfg, err := config.LoadConfig()
-			if err != nil {
-				app.ShowError("Failed to load configuration: " + err.Error())
-				return
-			}
+	run := &tuiRun{cache: converter.NewConversionCache()}
+	defer run.cleanup()
 
-			// Create a new DWG converter
-			dwgConverter, err := converter.NewDWGConverter(cfg.ODAConverterPath)
-			if err != nil {
-				app.ShowError("Failed to create DWG converter: " + err.Error())
-				return
-			}
+	app := tui.NewApp()
+	app.SetScreen(deps.Screen)
+	app.SetMouseEnabled(true)
 
-			// Convert DWG to DXF
-			app.ShowStatus("Converting: " + dwgFile)
-
-			// Determine output directory
-			outputDir := tuiOutputDir
-			if outputDir == "" {
-				// If no output directory specified, use a temp directory
-				tempDir, err := os.MkdirTemp("", "dwg-extractor-*")
-				if err != nil {
-					app.ShowError("Failed to create temp directory: " + err.Error())
-					return
-				}
-				outputDir = tempDir
-			}
+	// Restore the view state of the previous launch on each drawing once it is shown
+	app.SetSession(deps.Sessions, "")
 
-			dxfFile, err := dwgConverter.ConvertToDXF(dwgFile, outputDir)
-			if err != nil {
-				app.ShowError("Conversion failed: " + err.Error())
-				return
-			}
+	// Ctrl+O opens another drawing in the running TUI
+	app.SetOpenHandler(func(path string) {
+		go openInTUI(app, deps, run, path)
+	})
 
-			// Parse the DXF file
-			app.ShowStatus("Parsing DXF file...")
-			var dxfParser dxfparser.ParserInterface = dxfparser.NewParser()
-			dxfData, err := dxfParser.ParseDXF(dxfFile)
-			if err != nil {
-				app.ShowError("Failed to parse DXF file: " + err.Error())
-				return
-			}
+	// Ctrl+R reads the drawing shown again, or the sample data when no drawing is shown
+	app.SetReloadHandler(func(path string) {
+		if path == "" {
+			showSampleData(app)
+			app.ShowStatus("Sample data reloaded")
+			return
+		}
+		go openInTUI(app, deps, run, path)
+	})
 
-			// Update the UI with the parsed data
-			app.ShowStatus("Conversion and parsing successful!")
-			app.UpdateDXFData(dxfData)
+	// Start the app and handle initialization after event loop starts
+	go func() {
+		// Wait a moment for the app to start
+		time.Sleep(100 * time.Millisecond)
+
+		if args != nil && len(args) > 0 {
+			openInTUI(app, deps, run, args[0])
 		} else {
 			// Use sample data if no file is provided
-			dxfData := &data.ExtractedData{
-				DXFVersion: "R2020 (Sample Data)",
-				Layers: []data.LayerInfo{
-					{Name: "0", IsOn: true, IsFrozen: false, Color: 7, LineType: "CONTINUOUS"},
-					{Name: "Walls", IsOn: true, IsFrozen: false, Color: 1, LineType: "CONTINUOUS"},
-					{Name: "Doors", IsOn: true, IsFrozen: false, Color: 2, LineType: "DASHED"},
-					{Name: "Windows", IsOn: true, IsFrozen: true, Color: 3, LineType: "HIDDEN"},
-				},
-			}
 			app.ShowStatus("No DWG file provided. Using sample data.")
-			app.UpdateDXFData(dxfData)
+			app.UpdateDXFData(sample)
 		}
 	}()
 
 	return app.Run()
 }
 
+// openInTUI converts the DWG or parses the DXF file at dwgFile and shows it in app.
+// Errors are shown in app rather than returned.
+func openInTUI(app *tui.App, deps TUIDeps, run *tuiRun, dwgFile string) {
+	// DXF files are parsed directly, without the ODA converter
+	isDXF, err := dxfparser.IsDXF(dwgFile)
+	if err != nil {
+		app.ShowError(err.Error())
+		return
+	}
+	if isDXF {
+		app.ShowStatus("Parsing DXF file: " + dwgFile)
+		app.ShowLoading("Parsing "+filepath.Base(dwgFile)+"...", nil)
+		dxfData, err := deps.NewParser().ParseDXF(dwgFile)
+		app.HideLoading()
+		if err != nil {
+			app.ShowError("Failed to parse DXF file: " + err.Error())
+			return
+		}
+		showDrawing(app, dwgFile, dxfData, "DXF parsing successful!")
+		return
+	}
+
+	// Process DWG file
+	// Load configuration
+	cfg, err := deps.LoadConfig()
+	if err != nil {
+		app.ShowError("Failed to load configuration: " + err.Error())
+		return
+	}
+
+	// Create a new DWG converter
+	dwgConverter, err := deps.NewConverter(cfg.ODAConverterPath)
+	if err != nil {
+		app.ShowError("Failed to create DWG converter: " + err.Error())
+		return
+	}
+	dwgConverter.SetCache(run.cache)
+
+	outputDir, err := run.outputDir()
+	if err != nil {
+		app.ShowError("Failed to create temp directory: " + err.Error())
+		return
+	}
+
+	// Convert DWG to DXF behind a spinner, which Esc cancels
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	app.ShowStatus("Converting: " + dwgFile)
+	app.ShowLoading("Converting "+filepath.Base(dwgFile)+"...", cancel)
+
+	dxfData, err := convertAndParse(ctx, app, dwgConverter, deps.NewParser, dwgFile, outputDir)
+	app.HideLoading()
+	if ctx.Err() != nil {
+		app.ShowStatus("Conversion cancelled")
+		// Keep the drawing shown before, or show sample data rather than a blank view
+		if !app.HasData() {
+			showSampleData(app)
+		}
+		return
+	}
+	if err != nil {
+		app.ShowError(err.Error())
+		return
+	}
+
+	showDrawing(app, dwgFile, dxfData, "Conversion and parsing successful!")
+}
+
+// showDrawing shows the drawing parsed from path in app with status, flagging entities
+// filed under the wrong layer alongside the parser warnings
+func showDrawing(app *tui.App, path string, dxfData *data.ExtractedData, status string) {
+	dxfData.Warnings = append(dxfData.Warnings, dxfData.ValidateLayerConsistency()...)
+	app.ShowStatus(status)
+	app.ShowDrawing(path, dxfData)
+}
+
+// showSampleData shows the sample data chosen with -sample in app, or the built-in
+// sample when that cannot be loaded
+func showSampleData(app *tui.App) {
+	sample, err := LoadSampleData(tuiSamplePath)
+	if err != nil {
+		sample = builtinSample()
+	}
+	app.ShowSampleData(sample)
+}
+
+// convertAndParse converts dwgFile to DXF in outputDir and parses the result,
+// updating the loading spinner between the steps
+func convertAndParse(ctx context.Context, app *tui.App, dwgConverter converter.DWGConverter, newParser func() dxfparser.ParserInterface, dwgFile, outputDir string) (*data.ExtractedData, error) {
+	dxfFile, err := dwgConverter.ConvertToDXFContext(ctx, dwgFile, outputDir)
+	if err != nil {
+		return nil, fmt.Errorf("Conversion failed: %w", err)
+	}
+
+	app.ShowStatus("Parsing DXF file...")
+	app.ShowLoading("Parsing "+filepath.Base(dxfFile)+"...", nil)
+	dxfData, err := newParser().ParseDXF(dxfFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse DXF file: %w", err)
+	}
+	return dxfData, nil
+}
+
 // ExecuteTUI executes the TUI command
 func ExecuteTUI() error {
 	if err := tuiCmd.Parse(os.Args[2:]); err != nil {
@@ -1,11 +1,23 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/config"
+	"github.com/remym/go-dwg-extractor/pkg/converter"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/remym/go-dwg-extractor/pkg/dxfparser"
+	"github.com/remym/go-dwg-extractor/pkg/tui"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -341,13 +353,303 @@ func TestTUIFlagSet(t *testing.T) {
 	flag := tuiCmd.Lookup("output")
 	assert.NotNil(t, flag, "output flag should be defined")
 	assert.Equal(t, "", flag.DefValue, "output flag default should be empty")
+
+	flag = tuiCmd.Lookup("sample")
+	assert.NotNil(t, flag, "sample flag should be defined")
+	assert.Equal(t, "", flag.DefValue, "sample flag default should be the built-in sample")
+}
+
+// failingScreen is a simulated screen whose terminal cannot be initialized
+type failingScreen struct {
+	tcell.SimulationScreen
+}
+
+func (failingScreen) Init() error {
+	return errors.New("open /dev/tty: no such device or address")
+}
+
+// TestRunTUI_NoTerminal tests that RunTUI fails fast when there is no terminal
+func TestRunTUI_NoTerminal(t *testing.T) {
+	done := make(chan error, 1)
+	go func() {
+		deps := DefaultTUIDeps()
+		deps.Screen = failingScreen{tcell.NewSimulationScreen("")}
+		done <- RunTUIWithDeps(nil, deps)
+	}()
+
+	select {
+	case err := <-done:
+		var systemErr *tui.SystemError
+		require.ErrorAs(t, err, &systemErr)
+		assert.Equal(t, "no interactive terminal available; use the extract command for headless output", err.Error())
+	case <-time.After(time.Second):
+		t.Fatal("RunTUI should fail fast without a terminal")
+	}
+}
+
+// runSimulatedTUI runs the TUI on args against a simulated terminal until text is drawn,
+// then quits it with Esc
+func runSimulatedTUI(t *testing.T, args []string, deps TUIDeps, text string) {
+	t.Helper()
+	screen, done := startSimulatedTUI(args, deps)
+	waitForText(t, screen, text)
+	quitSimulatedTUI(t, screen, done)
+}
+
+// startSimulatedTUI runs the TUI on args against a simulated terminal in the background
+func startSimulatedTUI(args []string, deps TUIDeps) (tcell.SimulationScreen, chan error) {
+	screen := tcell.NewSimulationScreen("")
+	deps.Screen = screen
+	done := make(chan error, 1)
+	go func() {
+		done <- RunTUIWithDeps(args, deps)
+	}()
+	return screen, done
+}
+
+// waitForText waits until text is drawn on screen
+func waitForText(t *testing.T, screen tcell.SimulationScreen, text string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		cells, width, _ := screen.GetContents()
+		var drawn strings.Builder
+		for _, cell := range cells {
+			drawn.WriteString(string(cell.Runes))
+		}
+		return width > 0 && strings.Contains(drawn.String(), text)
+	}, 3*time.Second, 20*time.Millisecond, "%q should be drawn", text)
+}
+
+// quitSimulatedTUI quits the TUI with Esc and waits for it to stop
+func quitSimulatedTUI(t *testing.T, screen tcell.SimulationScreen, done chan error) {
+	t.Helper()
+	screen.InjectKey(tcell.KeyEsc, 0, tcell.ModNone)
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("Esc should stop the TUI")
+	}
+}
+
+// TestRunTUI_SimulatedScreen tests running the TUI on sample data against a simulated terminal
+func TestRunTUI_SimulatedScreen(t *testing.T) {
+	runSimulatedTUI(t, nil, DefaultTUIDeps(), "Walls")
+}
+
+func TestRunTUI_SampleFlag(t *testing.T) {
+	oldSamplePath := tuiSamplePath
+	defer func() { tuiSamplePath = oldSamplePath }()
+
+	tuiSamplePath = filepath.Join(t.TempDir(), "demo.json")
+	require.NoError(t, os.WriteFile(tuiSamplePath, []byte(sampleJSON), 0644))
+	runSimulatedTUI(t, nil, TUIDeps{}, "Survey")
+
+	// A sample file that cannot be loaded fails before the TUI starts
+	tuiSamplePath = filepath.Join(t.TempDir(), "missing.json")
+	err := RunTUIWithDeps(nil, TUIDeps{Screen: tcell.NewSimulationScreen("")})
+	assert.ErrorContains(t, err, "failed to open sample data")
+}
+
+// TestRunTUI_TempOutputDir tests that without -output every conversion of a run shares one
+// temporary directory, removed when the TUI quits
+func TestRunTUI_TempOutputDir(t *testing.T) {
+	dwgFile := filepath.Join(t.TempDir(), "plan.dwg")
+	require.NoError(t, os.WriteFile(dwgFile, []byte("dummy dwg content"), 0644))
+	oldOutputDir := tuiOutputDir
+	tuiOutputDir = ""
+	defer func() { tuiOutputDir = oldOutputDir }()
+
+	var mu sync.Mutex
+	var outputDirs []string
+	deps := TUIDeps{
+		LoadConfig: func() (*config.AppConfig, error) {
+			return &config.AppConfig{ODAConverterPath: "fake/converter"}, nil
+		},
+		NewConverter: func(string) (converter.DWGConverter, error) {
+			return &MockDWGConverter{
+				ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					outputDirs = append(outputDirs, outputDir)
+					return filepath.Join(outputDir, "plan.dxf"), nil
+				},
+			}, nil
+		},
+		NewParser: func() dxfparser.ParserInterface {
+			return &MockParser{ParseDXFFunc: func(string) (*data.ExtractedData, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				name := fmt.Sprintf("Revision%d", len(outputDirs))
+				return &data.ExtractedData{Layers: []data.LayerInfo{{Name: name, IsOn: true}}}, nil
+			}}
+		},
+	}
+
+	screen, done := startSimulatedTUI([]string{dwgFile}, deps)
+	waitForText(t, screen, "Revision1")
+	screen.InjectKey(tcell.KeyCtrlR, 0, tcell.ModCtrl)
+	waitForText(t, screen, "Revision2")
+	quitSimulatedTUI(t, screen, done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, outputDirs, 2)
+	assert.Equal(t, outputDirs[0], outputDirs[1], "a reload converts into the same directory")
+	assert.NoDirExists(t, outputDirs[0], "the temporary directory is removed on quit")
 }
 
 // TestRunTUI_DependencyInjection tests RunTUI with mocked dependencies
 func TestRunTUI_DependencyInjection(t *testing.T) {
-	// This should fail initially - RunTUI may not support dependency injection
-	// We would need to refactor RunTUI to accept interfaces for testing
-	t.Skip("Need to implement dependency injection for RunTUI testing")
+	dwgFile := filepath.Join(t.TempDir(), "plan.dwg")
+	require.NoError(t, os.WriteFile(dwgFile, []byte("dummy dwg content"), 0644))
+	outputDir := t.TempDir()
+	oldOutputDir := tuiOutputDir
+	tuiOutputDir = outputDir
+	defer func() { tuiOutputDir = oldOutputDir }()
+
+	t.Run("converts and parses the drawing", func(t *testing.T) {
+		var convertedPath, parsedPath string
+		deps := TUIDeps{
+			LoadConfig: func() (*config.AppConfig, error) {
+				return &config.AppConfig{ODAConverterPath: "fake/converter"}, nil
+			},
+			NewConverter: func(converterPath string) (converter.DWGConverter, error) {
+				assert.Equal(t, "fake/converter", converterPath)
+				return &MockDWGConverter{
+					ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+						convertedPath = dwgPath
+						return filepath.Join(outputDir, "plan.dxf"), nil
+					},
+				}, nil
+			},
+			NewParser: func() dxfparser.ParserInterface {
+				return &MockParser{ParseDXFFunc: func(dxfPath string) (*data.ExtractedData, error) {
+					parsedPath = dxfPath
+					return &data.ExtractedData{Layers: []data.LayerInfo{{Name: "InjectedLayer", IsOn: true}}}, nil
+				}}
+			},
+		}
+
+		runSimulatedTUI(t, []string{dwgFile}, deps, "InjectedLayer")
+		assert.Equal(t, dwgFile, convertedPath)
+		assert.Equal(t, filepath.Join(outputDir, "plan.dxf"), parsedPath)
+	})
+
+	t.Run("Ctrl+R reloads the drawing", func(t *testing.T) {
+		var parses atomic.Int32
+		deps := TUIDeps{
+			LoadConfig: func() (*config.AppConfig, error) {
+				return &config.AppConfig{ODAConverterPath: "fake/converter"}, nil
+			},
+			NewConverter: func(string) (converter.DWGConverter, error) {
+				return &MockDWGConverter{
+					ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+						return filepath.Join(outputDir, "plan.dxf"), nil
+					},
+				}, nil
+			},
+			NewParser: func() dxfparser.ParserInterface {
+				return &MockParser{ParseDXFFunc: func(string) (*data.ExtractedData, error) {
+					name := fmt.Sprintf("Revision%d", parses.Add(1))
+					return &data.ExtractedData{Layers: []data.LayerInfo{{Name: name, IsOn: true}}}, nil
+				}}
+			},
+		}
+
+		screen, done := startSimulatedTUI([]string{dwgFile}, deps)
+		waitForText(t, screen, "Revision1")
+		screen.InjectKey(tcell.KeyCtrlR, 0, tcell.ModCtrl)
+		waitForText(t, screen, "Revision2")
+		waitForText(t, screen, "Conversion and parsing successful!")
+		quitSimulatedTUI(t, screen, done)
+	})
+
+	t.Run("-raw keeps raw group codes", func(t *testing.T) {
+		oldRawCodes := tuiRawCodes
+		tuiRawCodes = true
+		defer func() { tuiRawCodes = oldRawCodes }()
+
+		parser := &rawCodeParser{MockParser: MockParser{
+			ParseDXFFunc: func(string) (*data.ExtractedData, error) {
+				return &data.ExtractedData{Layers: []data.LayerInfo{{Name: "RawLayer", IsOn: true}}}, nil
+			},
+		}}
+		deps := TUIDeps{
+			LoadConfig: func() (*config.AppConfig, error) {
+				return &config.AppConfig{ODAConverterPath: "fake/converter"}, nil
+			},
+			NewConverter: func(string) (converter.DWGConverter, error) {
+				return &MockDWGConverter{
+					ConvertToDXFFunc: func(dwgPath, outputDir string) (string, error) {
+						return filepath.Join(outputDir, "plan.dxf"), nil
+					},
+				}, nil
+			},
+			NewParser: func() dxfparser.ParserInterface { return parser },
+		}
+
+		runSimulatedTUI(t, []string{dwgFile}, deps, "RawLayer")
+		assert.True(t, parser.keepRawCodes)
+	})
+
+	t.Run("DXF files are checked for misfiled entities", func(t *testing.T) {
+		dxfFile := filepath.Join(t.TempDir(), "plan.dxf")
+		require.NoError(t, os.WriteFile(dxfFile, []byte("0\nEOF\n"), 0644))
+		deps := TUIDeps{
+			NewParser: func() dxfparser.ParserInterface {
+				return &MockParser{ParseDXFFunc: func(string) (*data.ExtractedData, error) {
+					return &data.ExtractedData{Layers: []data.LayerInfo{{Name: "Walls", IsOn: true, Entities: []data.Entity{
+						&data.LineInfo{Layer: "Doors"},
+					}}}}, nil
+				}}
+			},
+		}
+
+		runSimulatedTUI(t, []string{dxfFile}, deps, "1 warnings")
+	})
+
+	t.Run("configuration error is shown", func(t *testing.T) {
+		deps := TUIDeps{
+			LoadConfig: func() (*config.AppConfig, error) {
+				return nil, errors.New("broken config")
+			},
+			NewConverter: func(string) (converter.DWGConverter, error) {
+				t.Error("no converter should be created without configuration")
+				return nil, errors.New("unexpected")
+			},
+		}
+
+		runSimulatedTUI(t, []string{dwgFile}, deps, "broken config")
+	})
+
+	t.Run("Esc cancels the conversion", func(t *testing.T) {
+		deps := TUIDeps{
+			LoadConfig: func() (*config.AppConfig, error) {
+				return &config.AppConfig{ODAConverterPath: "fake/converter"}, nil
+			},
+			NewConverter: func(string) (converter.DWGConverter, error) {
+				return &MockDWGConverter{
+					ConvertToDXFContextFunc: func(ctx context.Context, dwgPath, outputDir string) (string, error) {
+						<-ctx.Done()
+						return "", ctx.Err()
+					},
+				}, nil
+			},
+			NewParser: func() dxfparser.ParserInterface {
+				t.Error("nothing should be parsed after cancelling")
+				return nil
+			},
+		}
+
+		screen, done := startSimulatedTUI([]string{dwgFile}, deps)
+		waitForText(t, screen, "Converting plan.dwg...")
+		screen.InjectKey(tcell.KeyEsc, 0, tcell.ModNone)
+		waitForText(t, screen, "Conversion cancelled")
+		waitForText(t, screen, "R2020 (Sample Data)")
+		quitSimulatedTUI(t, screen, done)
+	})
 }
 
 // TestRunTUI_ErrorHandling tests various error scenarios in RunTUI
@@ -30,7 +30,7 @@ func main() {
 
 	// Ensure at least one command is provided
 	if len(os.Args) < 2 {
-		log.Fatalf("No command provided. Usage: %s [extract|tui] [options]", os.Args[0])
+		log.Fatalf("No command provided. Usage: %s [extract|layers|diff|tui] [options]", os.Args[0])
 	}
 
 	if err := cmd.Execute(); err != nil {
@@ -52,14 +52,28 @@ func showHelp() {
 	fmt.Printf("Usage: %s [command] [options]\n\n", os.Args[0])
 	fmt.Printf("Commands:\n")
 	fmt.Printf("  extract    Extract data from DWG file and output to console\n")
+	fmt.Printf("  layers     Print the layer names of a DWG file, one per line\n")
+	fmt.Printf("  diff       Compare two drawings: diff [-tui] <before> <after>\n")
 	fmt.Printf("  tui        Launch Terminal User Interface\n")
 	fmt.Printf("  version    Show version information\n")
 	fmt.Printf("  help       Show this help message\n\n")
 	fmt.Printf("Options:\n")
-	fmt.Printf("  -file      Path to DWG file (required for extract command)\n")
-	fmt.Printf("  -output    Output directory for conversion (optional)\n\n")
+	fmt.Printf("  -file      Path to DWG file (required for extract and layers commands)\n")
+	fmt.Printf("  -output    Output directory for conversion (optional)\n")
+	fmt.Printf("  -html      Write an HTML report to the given path (extract only)\n")
+	fmt.Printf("  -csv       Write entities as CSV to the given path (extract only)\n")
+	fmt.Printf("  -json      Write entities as a JSON array to the given path (extract);\n")
+	fmt.Printf("             print layers as JSON with their state and entity counts (layers)\n")
+	fmt.Printf("  -ndjson    Write entities as JSON Lines to the given path (extract only)\n")
+	fmt.Printf("  -format    Conversion output format: dxf (default) or pdf (extract only)\n")
+	fmt.Printf("  -dxf-version DXF version to convert to, e.g. ACAD2000 (default ACAD2018, extract only)\n")
+	fmt.Printf("  -only      Comma-separated entity types to extract, e.g. line,text (extract only)\n")
+	fmt.Printf("  -flatten   Replace block insertions with their geometry (extract only)\n")
+	fmt.Printf("  -raw       Print the raw DXF group codes of each entity (extract only)\n\n")
 	fmt.Printf("Examples:\n")
 	fmt.Printf("  %s extract -file sample.dwg\n", os.Args[0])
+	fmt.Printf("  %s layers -file sample.dwg -json\n", os.Args[0])
+	fmt.Printf("  %s diff rev1.dwg rev2.dwg\n", os.Args[0])
 	fmt.Printf("  %s tui -file sample.dwg\n", os.Args[0])
 	fmt.Printf("  %s tui  # Uses sample data if no file specified\n", os.Args[0])
 	fmt.Printf("  %s version\n", os.Args[0])
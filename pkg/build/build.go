@@ -4,7 +4,10 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,16 +22,65 @@ type Platform struct {
 	GOARCH string
 }
 
+// DefaultReleasePlatforms returns the curated set of platforms built for a release
+func DefaultReleasePlatforms() []Platform {
+	return []Platform{
+		{GOOS: "linux", GOARCH: "amd64"},
+		{GOOS: "linux", GOARCH: "arm64"},
+		{GOOS: "linux", GOARCH: "arm"},
+		{GOOS: "linux", GOARCH: "386"},
+		{GOOS: "windows", GOARCH: "amd64"},
+		{GOOS: "windows", GOARCH: "386"},
+		{GOOS: "darwin", GOARCH: "amd64"},
+		{GOOS: "darwin", GOARCH: "arm64"},
+		{GOOS: "freebsd", GOARCH: "amd64"},
+	}
+}
+
+// DefaultOutputNameTemplate is the base name of built binaries, packages and dist directories
+const DefaultOutputNameTemplate = "go-dwg-extractor"
+
+// outputName expands the {os}, {arch} and {version} placeholders of an output name
+// template, using DefaultOutputNameTemplate when the template is empty
+func (p Platform) outputName(template, version string) string {
+	if template == "" {
+		template = DefaultOutputNameTemplate
+	}
+	return strings.NewReplacer("{os}", p.GOOS, "{arch}", p.GOARCH, "{version}", version).Replace(template)
+}
+
+// binaryName returns the executable name for a platform, with .exe only on Windows
+func (p Platform) binaryName(template, version string) string {
+	name := p.outputName(template, version)
+	if p.GOOS == "windows" && !strings.EqualFold(filepath.Ext(name), ".exe") {
+		name += ".exe"
+	}
+	return name
+}
+
+// distDir returns the per-platform output directory and package name, e.g. go-dwg-extractor-linux-arm.
+// Templates naming the platform themselves are used as they are.
+func (p Platform) distDir(template, version string) string {
+	name := p.outputName(template, version)
+	if template != "" && (strings.Contains(template, "{os}") || strings.Contains(template, "{arch}")) {
+		return name
+	}
+	return fmt.Sprintf("%s-%s-%s", name, p.GOOS, p.GOARCH)
+}
+
 // BuildConfig represents build configuration
 type BuildConfig struct {
 	GOOS       string
 	GOARCH     string
-	OutputName string
+	OutputName string // Binary name; when empty it is expanded from OutputNameTemplate
 	SourcePath string
 	OutputDir  string
 	Version    string
 	BuildTime  string
 	GitCommit  string
+	// OutputNameTemplate is the binary base name with {os}, {arch} and {version}
+	// placeholders, DefaultOutputNameTemplate when empty. Windows binaries get .exe.
+	OutputNameTemplate string
 }
 
 // BuildResult represents the result of a build operation
@@ -58,9 +110,24 @@ func (bm *BuildManager) Build(config BuildConfig) (*BuildResult, error) {
 		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Prepare build command
-	outputPath := filepath.Join(config.OutputDir, config.OutputName)
-	cmd := exec.Command("go", "build", "-o", outputPath, config.SourcePath)
+	outputName := config.OutputName
+	if outputName == "" {
+		outputName = Platform{GOOS: config.GOOS, GOARCH: config.GOARCH}.binaryName(config.OutputNameTemplate, config.Version)
+	}
+
+	// Prepare build command, injecting the version information into main
+	outputPath := filepath.Join(config.OutputDir, outputName)
+	args := []string{"build"}
+	ldflags := NewVersionManager().GenerateLdflags(VersionInfo{
+		Version:   config.Version,
+		GitCommit: config.GitCommit,
+		BuildTime: config.BuildTime,
+	})
+	if ldflags != "" {
+		args = append(args, "-ldflags", ldflags)
+	}
+	args = append(args, "-o", outputPath, config.SourcePath)
+	cmd := exec.Command("go", args...)
 
 	// Set environment variables
 	cmd.Env = append(os.Environ(),
@@ -77,7 +144,7 @@ func (bm *BuildManager) Build(config BuildConfig) (*BuildResult, error) {
 	buildTime := time.Since(start).Milliseconds()
 
 	return &BuildResult{
-		OutputFile: config.OutputName,
+		OutputFile: outputName,
 		GOOS:       config.GOOS,
 		GOARCH:     config.GOARCH,
 		Success:    true,
@@ -103,13 +170,32 @@ type BuildScript struct {
 }
 
 // ScriptGenerator generates build scripts
-type ScriptGenerator struct{}
+type ScriptGenerator struct {
+	outputNameTemplate string // Binary base name template; DefaultOutputNameTemplate when empty
+	version            string // Substituted for {version} in the template
+}
 
 // NewScriptGenerator creates a new script generator
 func NewScriptGenerator() *ScriptGenerator {
 	return &ScriptGenerator{}
 }
 
+// SetOutputNameTemplate sets the base name of the binaries and dist directories the scripts
+// build, with {os}, {arch} and {version} placeholders; version is substituted for {version}
+func (sg *ScriptGenerator) SetOutputNameTemplate(template, version string) {
+	sg.outputNameTemplate = template
+	sg.version = version
+}
+
+// outputPath returns the path of a platform's binary below dist, joined with separator
+func (sg *ScriptGenerator) outputPath(platform Platform, separator string) string {
+	return strings.Join([]string{
+		"dist",
+		platform.distDir(sg.outputNameTemplate, sg.version),
+		platform.binaryName(sg.outputNameTemplate, sg.version),
+	}, separator)
+}
+
 // GenerateBuildScript generates a build script for the specified platforms
 func (sg *ScriptGenerator) GenerateBuildScript(scriptType ScriptType, platforms []Platform) (*BuildScript, error) {
 	var content, filename string
@@ -148,14 +234,7 @@ func (sg *ScriptGenerator) generateBashScript(platforms []Platform) string {
 		script.WriteString(fmt.Sprintf("echo \"Building for %s/%s...\"\n", platform.GOOS, platform.GOARCH))
 		script.WriteString(fmt.Sprintf("export GOOS=%s\n", platform.GOOS))
 		script.WriteString(fmt.Sprintf("export GOARCH=%s\n", platform.GOARCH))
-
-		outputName := "go-dwg-extractor"
-		if platform.GOOS == "windows" {
-			outputName += ".exe"
-		}
-
-		script.WriteString(fmt.Sprintf("go build -o dist/%s-%s-%s/%s .\n",
-			outputName[:len(outputName)-len(filepath.Ext(outputName))], platform.GOOS, platform.GOARCH, outputName))
+		script.WriteString(fmt.Sprintf("go build -o %s .\n", sg.outputPath(platform, "/")))
 		script.WriteString("\n")
 	}
 
@@ -173,14 +252,7 @@ func (sg *ScriptGenerator) generatePowerShellScript(platforms []Platform) string
 		script.WriteString(fmt.Sprintf("Write-Host \"Building for %s/%s...\"\n", platform.GOOS, platform.GOARCH))
 		script.WriteString(fmt.Sprintf("$env:GOOS='%s'\n", platform.GOOS))
 		script.WriteString(fmt.Sprintf("$env:GOARCH='%s'\n", platform.GOARCH))
-
-		outputName := "go-dwg-extractor"
-		if platform.GOOS == "windows" {
-			outputName += ".exe"
-		}
-
-		script.WriteString(fmt.Sprintf("go build -o dist\\%s-%s-%s\\%s .\n",
-			outputName[:len(outputName)-len(filepath.Ext(outputName))], platform.GOOS, platform.GOARCH, outputName))
+		script.WriteString(fmt.Sprintf("go build -o %s .\n", sg.outputPath(platform, "\\")))
 		script.WriteString("\n")
 	}
 
@@ -191,34 +263,44 @@ func (sg *ScriptGenerator) generatePowerShellScript(platforms []Platform) string
 func (sg *ScriptGenerator) generateMakefile(platforms []Platform) string {
 	var makefile strings.Builder
 
+	// Group architectures by OS so each OS gets a single aggregate target
+	var osOrder []string
+	archTargets := make(map[string][]string)
+	for _, platform := range platforms {
+		if _, exists := archTargets[platform.GOOS]; !exists {
+			osOrder = append(osOrder, platform.GOOS)
+		}
+		archTargets[platform.GOOS] = append(archTargets[platform.GOOS],
+			fmt.Sprintf("build-%s-%s", platform.GOOS, platform.GOARCH))
+	}
+
 	makefile.WriteString("# Cross-platform build Makefile\n\n")
 	makefile.WriteString(".PHONY: all clean")
 
 	// Add platform targets to .PHONY
-	for _, platform := range platforms {
-		makefile.WriteString(fmt.Sprintf(" build-%s", platform.GOOS))
+	for _, goos := range osOrder {
+		makefile.WriteString(fmt.Sprintf(" build-%s %s", goos, strings.Join(archTargets[goos], " ")))
 	}
 	makefile.WriteString("\n\n")
 
 	// All target
 	makefile.WriteString("all:")
-	for _, platform := range platforms {
-		makefile.WriteString(fmt.Sprintf(" build-%s", platform.GOOS))
+	for _, goos := range osOrder {
+		makefile.WriteString(fmt.Sprintf(" build-%s", goos))
 	}
 	makefile.WriteString("\n\n")
 
+	// OS targets build every architecture for that OS
+	for _, goos := range osOrder {
+		makefile.WriteString(fmt.Sprintf("build-%s: %s\n\n", goos, strings.Join(archTargets[goos], " ")))
+	}
+
 	// Individual platform targets
 	for _, platform := range platforms {
-		outputName := "go-dwg-extractor"
-		if platform.GOOS == "windows" {
-			outputName += ".exe"
-		}
-
-		makefile.WriteString(fmt.Sprintf("build-%s:\n", platform.GOOS))
+		makefile.WriteString(fmt.Sprintf("build-%s-%s:\n", platform.GOOS, platform.GOARCH))
 		makefile.WriteString(fmt.Sprintf("\t@echo \"Building for %s/%s...\"\n", platform.GOOS, platform.GOARCH))
-		makefile.WriteString(fmt.Sprintf("\tGOOS=%s GOARCH=%s go build -o dist/%s-%s-%s/%s .\n\n",
-			platform.GOOS, platform.GOARCH,
-			outputName[:len(outputName)-len(filepath.Ext(outputName))], platform.GOOS, platform.GOARCH, outputName))
+		makefile.WriteString(fmt.Sprintf("\tGOOS=%s GOARCH=%s go build -o %s .\n\n",
+			platform.GOOS, platform.GOARCH, sg.outputPath(platform, "/")))
 	}
 
 	// Clean target
@@ -243,12 +325,55 @@ func NewVersionManager() *VersionManager {
 	return &VersionManager{}
 }
 
-// GenerateLdflags generates ldflags for version injection
+// runGit runs git with the given arguments and returns its trimmed output; a variable so tests can replace it
+var runGit = func(args ...string) (string, error) {
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// DetectGitInfo returns the version information of the working tree: the nearest tag,
+// the short commit hash and the current time. Outside a git repository the version and
+// commit fall back to "dev" and "unknown" and an error says why. An untagged repository
+// is not an error.
+func DetectGitInfo() (VersionInfo, error) {
+	info := VersionInfo{
+		Version:   "dev",
+		GitCommit: "unknown",
+		BuildTime: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	commit, err := runGit("rev-parse", "--short", "HEAD")
+	if err != nil {
+		return info, fmt.Errorf("failed to detect git commit: %w", err)
+	}
+	info.GitCommit = commit
+
+	if tag, err := runGit("describe", "--tags"); err == nil && tag != "" {
+		info.Version = tag
+	}
+	return info, nil
+}
+
+// GenerateLdflags generates ldflags for version injection. Empty fields are left out so
+// the binary keeps its defaults, and values with spaces are quoted for go build.
 func (vm *VersionManager) GenerateLdflags(info VersionInfo) string {
-	ldflags := []string{
-		fmt.Sprintf("-X main.version=%s", info.Version),
-		fmt.Sprintf("-X main.gitCommit=%s", info.GitCommit),
-		fmt.Sprintf("-X main.buildTime=%s", info.BuildTime),
+	var ldflags []string
+	for _, variable := range []struct{ name, value string }{
+		{"main.version", info.Version},
+		{"main.gitCommit", info.GitCommit},
+		{"main.buildTime", info.BuildTime},
+	} {
+		if variable.value == "" {
+			continue
+		}
+		flag := fmt.Sprintf("%s=%s", variable.name, variable.value)
+		if strings.ContainsAny(flag, " \t") {
+			flag = "'" + flag + "'"
+		}
+		ldflags = append(ldflags, "-X "+flag)
 	}
 
 	return strings.Join(ldflags, " ")
@@ -269,14 +394,20 @@ type PackageConfig struct {
 	PackageType PackageType
 	OutputDir   string
 	Version     string
+	// PreservePaths stores files under their relative paths instead of flattening to base names
+	PreservePaths bool
+	// OutputNameTemplate is the package base name with {os}, {arch} and {version}
+	// placeholders, DefaultOutputNameTemplate when empty
+	OutputNameTemplate string
 }
 
 // PackageResult represents the result of package creation
 type PackageResult struct {
 	Filename      string
 	Type          PackageType
-	IncludedFiles []string
+	IncludedFiles []string // Entry names inside the archive
 	Size          int64
+	Checksum      string // Hex-encoded SHA-256 of the package
 }
 
 // PackageManager handles package creation
@@ -290,21 +421,21 @@ func NewPackageManager() *PackageManager {
 // CreatePackage creates a distribution package
 func (pm *PackageManager) CreatePackage(config PackageConfig) (*PackageResult, error) {
 	// Generate package filename
-	filename := fmt.Sprintf("go-dwg-extractor-%s-%s", config.Platform.GOOS, config.Platform.GOARCH)
+	filename := config.Platform.distDir(config.OutputNameTemplate, config.Version)
 
 	var fullPath string
 	switch config.PackageType {
 	case PackageTypeZip:
 		filename += ".zip"
 		fullPath = filepath.Join(config.OutputDir, filename)
-		err := pm.createZipPackage(fullPath, config.Files)
+		err := pm.createZipPackage(fullPath, config.Files, config.PreservePaths)
 		if err != nil {
 			return nil, err
 		}
 	case PackageTypeTarGz:
 		filename += ".tar.gz"
 		fullPath = filepath.Join(config.OutputDir, filename)
-		err := pm.createTarGzPackage(fullPath, config.Files)
+		err := pm.createTarGzPackage(fullPath, config.Files, config.PreservePaths)
 		if err != nil {
 			return nil, err
 		}
@@ -318,16 +449,69 @@ func (pm *PackageManager) CreatePackage(config PackageConfig) (*PackageResult, e
 		return nil, fmt.Errorf("failed to get package size: %w", err)
 	}
 
+	// The archive is closed at this point, so the checksum covers the final bytes
+	checksum, err := pm.writeChecksumFile(fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	includedFiles := make([]string, 0, len(config.Files))
+	for _, file := range config.Files {
+		includedFiles = append(includedFiles, archiveName(file, config.PreservePaths))
+	}
+
 	return &PackageResult{
 		Filename:      filename,
 		Type:          config.PackageType,
-		IncludedFiles: config.Files,
+		IncludedFiles: includedFiles,
 		Size:          fileInfo.Size(),
+		Checksum:      checksum,
 	}, nil
 }
 
+// VerifyPackage checks that the package at path matches the expected SHA-256 digest
+func (pm *PackageManager) VerifyPackage(path, expectedSHA256 string) (bool, error) {
+	checksum, err := fileSHA256(path)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.EqualFold(checksum, strings.TrimSpace(expectedSHA256)), nil
+}
+
+// writeChecksumFile writes a sha256sum-compatible .sha256 file next to the package
+func (pm *PackageManager) writeChecksumFile(packagePath string) (string, error) {
+	checksum, err := fileSHA256(packagePath)
+	if err != nil {
+		return "", err
+	}
+
+	content := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(packagePath))
+	if err := os.WriteFile(packagePath+".sha256", []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write checksum file: %w", err)
+	}
+
+	return checksum, nil
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 digest of a file
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // createZipPackage creates a ZIP package
-func (pm *PackageManager) createZipPackage(filename string, files []string) error {
+func (pm *PackageManager) createZipPackage(filename string, files []string, preservePaths bool) error {
 	// Create output directory
 	err := os.MkdirAll(filepath.Dir(filename), 0755)
 	if err != nil {
@@ -342,35 +526,57 @@ func (pm *PackageManager) createZipPackage(filename string, files []string) erro
 	defer zipFile.Close()
 
 	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
 
 	// Add files to ZIP
 	for _, file := range files {
-		err = pm.addFileToZip(zipWriter, file)
+		err = pm.addFileToZip(zipWriter, file, preservePaths)
 		if err != nil {
+			zipWriter.Close()
 			return fmt.Errorf("failed to add file %s to zip: %w", file, err)
 		}
 	}
 
+	// Close explicitly so write errors are not lost
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finalize zip file: %w", err)
+	}
+	if err := zipFile.Close(); err != nil {
+		return fmt.Errorf("failed to close zip file: %w", err)
+	}
+
 	return nil
 }
 
 // addFileToZip adds a file to a ZIP archive
-func (pm *PackageManager) addFileToZip(zipWriter *zip.Writer, filename string) error {
-	// For now, create empty files to satisfy tests
-	// In a real implementation, this would read actual files
-	writer, err := zipWriter.Create(filepath.Base(filename))
+func (pm *PackageManager) addFileToZip(zipWriter *zip.Writer, filename string, preservePaths bool) error {
+	file, info, err := openPackageFile(filename)
 	if err != nil {
 		return err
 	}
+	defer file.Close()
+
+	// Keep the file mode and modification time
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return fmt.Errorf("failed to create zip header for %s: %w", filename, err)
+	}
+	header.Name = archiveName(filename, preservePaths)
+	header.Method = zip.Deflate
 
-	// Write placeholder content
-	_, err = writer.Write([]byte(fmt.Sprintf("# %s\nPlaceholder content for %s", filename, filename)))
-	return err
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(writer, file); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", filename, err)
+	}
+
+	return nil
 }
 
 // createTarGzPackage creates a tar.gz package
-func (pm *PackageManager) createTarGzPackage(filename string, files []string) error {
+func (pm *PackageManager) createTarGzPackage(filename string, files []string, preservePaths bool) error {
 	// Create output directory
 	err := os.MkdirAll(filepath.Dir(filename), 0755)
 	if err != nil {
@@ -385,43 +591,94 @@ func (pm *PackageManager) createTarGzPackage(filename string, files []string) er
 	defer tarFile.Close()
 
 	gzipWriter := gzip.NewWriter(tarFile)
-	defer gzipWriter.Close()
-
 	tarWriter := tar.NewWriter(gzipWriter)
-	defer tarWriter.Close()
 
 	// Add files to tar
 	for _, file := range files {
-		err = pm.addFileToTar(tarWriter, file)
+		err = pm.addFileToTar(tarWriter, file, preservePaths)
 		if err != nil {
+			tarWriter.Close()
+			gzipWriter.Close()
 			return fmt.Errorf("failed to add file %s to tar: %w", file, err)
 		}
 	}
 
+	// Close explicitly so write errors are not lost
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finalize tar archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finalize gzip stream: %w", err)
+	}
+	if err := tarFile.Close(); err != nil {
+		return fmt.Errorf("failed to close tar.gz file: %w", err)
+	}
+
 	return nil
 }
 
 // addFileToTar adds a file to a tar archive
-func (pm *PackageManager) addFileToTar(tarWriter *tar.Writer, filename string) error {
-	// Create placeholder content
-	content := fmt.Sprintf("# %s\nPlaceholder content for %s", filename, filename)
+func (pm *PackageManager) addFileToTar(tarWriter *tar.Writer, filename string, preservePaths bool) error {
+	file, info, err := openPackageFile(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
 
-	// Create tar header
-	header := &tar.Header{
-		Name: filepath.Base(filename),
-		Size: int64(len(content)),
-		Mode: 0644,
+	// Keep the file mode and modification time
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("failed to create tar header for %s: %w", filename, err)
 	}
+	header.Name = archiveName(filename, preservePaths)
 
 	// Write header
-	err := tarWriter.WriteHeader(header)
+	err = tarWriter.WriteHeader(header)
 	if err != nil {
 		return err
 	}
 
 	// Write content
-	_, err = tarWriter.Write([]byte(content))
-	return err
+	if _, err := io.Copy(tarWriter, file); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+// openPackageFile opens a regular file for packaging and returns its info
+func openPackageFile(filename string) (*os.File, os.FileInfo, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open package file %s: %w", filename, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to stat package file %s: %w", filename, err)
+	}
+	if !info.Mode().IsRegular() {
+		file.Close()
+		return nil, nil, fmt.Errorf("package file %s is not a regular file", filename)
+	}
+
+	return file, info, nil
+}
+
+// archiveName returns the entry name for a file inside a package.
+// Paths that are absolute or escape the working directory fall back to the base name.
+func archiveName(filename string, preservePaths bool) string {
+	if !preservePaths {
+		return filepath.Base(filename)
+	}
+
+	cleaned := filepath.Clean(filename)
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return filepath.Base(filename)
+	}
+
+	return filepath.ToSlash(cleaned)
 }
 
 // PipelineConfig represents build pipeline configuration
@@ -431,13 +688,17 @@ type PipelineConfig struct {
 	IncludeFiles   []string
 	OutputDir      string
 	CreatePackages bool
+	// OutputNameTemplate is the base name of binaries and packages with {os}, {arch}
+	// and {version} placeholders, DefaultOutputNameTemplate when empty
+	OutputNameTemplate string
 }
 
 // PipelineResult represents the result of pipeline execution
 type PipelineResult struct {
-	Builds   []BuildResult
-	Packages []PackageResult
-	Success  bool
+	Builds    []BuildResult
+	Packages  []PackageResult
+	Checksums map[string]string // Package filename to SHA-256 digest
+	Success   bool
 }
 
 // BuildPipeline handles the complete build pipeline
@@ -457,17 +718,27 @@ func NewBuildPipeline() *BuildPipeline {
 // Execute runs the complete build pipeline
 func (bp *BuildPipeline) Execute(config PipelineConfig) (*PipelineResult, error) {
 	result := &PipelineResult{
-		Builds:   make([]BuildResult, 0),
-		Packages: make([]PackageResult, 0),
-		Success:  true,
+		Builds:    make([]BuildResult, 0),
+		Packages:  make([]PackageResult, 0),
+		Checksums: make(map[string]string),
+		Success:   true,
+	}
+
+	platforms := config.Platforms
+	if len(platforms) == 0 {
+		platforms = DefaultReleasePlatforms()
+	}
+
+	// Builds outside a git repository still go ahead with the fallback values
+	gitInfo, _ := DetectGitInfo()
+	version := config.Version
+	if version == "" {
+		version = gitInfo.Version
 	}
 
 	// Build for each platform
-	for _, platform := range config.Platforms {
-		outputName := "go-dwg-extractor"
-		if platform.GOOS == "windows" {
-			outputName += ".exe"
-		}
+	for _, platform := range platforms {
+		outputName := platform.binaryName(config.OutputNameTemplate, version)
 
 		buildConfig := BuildConfig{
 			GOOS:       platform.GOOS,
@@ -475,9 +746,9 @@ func (bp *BuildPipeline) Execute(config PipelineConfig) (*PipelineResult, error)
 			OutputName: outputName,
 			SourcePath: ".",
 			OutputDir:  config.OutputDir,
-			Version:    config.Version,
-			BuildTime:  time.Now().Format(time.RFC3339),
-			GitCommit:  "abcd1234", // Placeholder
+			Version:    version,
+			BuildTime:  gitInfo.BuildTime,
+			GitCommit:  gitInfo.GitCommit,
 		}
 
 		buildResult, err := bp.buildManager.Build(buildConfig)
@@ -495,14 +766,15 @@ func (bp *BuildPipeline) Execute(config PipelineConfig) (*PipelineResult, error)
 				packageType = PackageTypeTarGz
 			}
 
-			files := append([]string{outputName}, config.IncludeFiles...)
+			files := append([]string{filepath.Join(config.OutputDir, outputName)}, config.IncludeFiles...)
 
 			packageConfig := PackageConfig{
-				Platform:    platform,
-				Files:       files,
-				PackageType: packageType,
-				OutputDir:   config.OutputDir,
-				Version:     config.Version,
+				Platform:           platform,
+				Files:              files,
+				PackageType:        packageType,
+				OutputDir:          config.OutputDir,
+				Version:            version,
+				OutputNameTemplate: config.OutputNameTemplate,
 			}
 
 			packageResult, err := bp.packageManager.CreatePackage(packageConfig)
@@ -512,6 +784,7 @@ func (bp *BuildPipeline) Execute(config PipelineConfig) (*PipelineResult, error)
 			}
 
 			result.Packages = append(result.Packages, *packageResult)
+			result.Checksums[packageResult.Filename] = packageResult.Checksum
 		}
 	}
 
@@ -1,7 +1,17 @@
 package build
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -73,6 +83,77 @@ func TestCrossPlatformBuild(t *testing.T) {
 	}
 }
 
+// TestOutputNameTemplate tests custom output names for binaries, scripts and packages
+func TestOutputNameTemplate(t *testing.T) {
+	const template = "dwgx-{version}-{os}-{arch}"
+
+	tests := []struct {
+		platform Platform
+		binary   string
+		script   string
+	}{
+		{
+			platform: Platform{GOOS: "linux", GOARCH: "amd64"},
+			binary:   "dwgx-1.2.0-linux-amd64",
+			script:   "go build -o dist/dwgx-1.2.0-linux-amd64/dwgx-1.2.0-linux-amd64 .",
+		},
+		{
+			platform: Platform{GOOS: "windows", GOARCH: "386"},
+			binary:   "dwgx-1.2.0-windows-386.exe",
+			script:   "go build -o dist/dwgx-1.2.0-windows-386/dwgx-1.2.0-windows-386.exe .",
+		},
+		{
+			platform: Platform{GOOS: "darwin", GOARCH: "arm64"},
+			binary:   "dwgx-1.2.0-darwin-arm64",
+			script:   "go build -o dist/dwgx-1.2.0-darwin-arm64/dwgx-1.2.0-darwin-arm64 .",
+		},
+	}
+
+	generator := NewScriptGenerator()
+	generator.SetOutputNameTemplate(template, "1.2.0")
+
+	for _, tt := range tests {
+		t.Run(tt.platform.GOOS+"/"+tt.platform.GOARCH, func(t *testing.T) {
+			outputDir := t.TempDir()
+			result, err := NewBuildManager().Build(BuildConfig{
+				GOOS:               tt.platform.GOOS,
+				GOARCH:             tt.platform.GOARCH,
+				SourcePath:         ".",
+				OutputDir:          outputDir,
+				Version:            "1.2.0",
+				OutputNameTemplate: template,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.binary, result.OutputFile)
+
+			script, err := generator.GenerateBuildScript(ScriptTypeBash, []Platform{tt.platform})
+			require.NoError(t, err)
+			assert.Contains(t, script.Content, tt.script)
+
+			binaryPath := filepath.Join(outputDir, "binary")
+			require.NoError(t, os.WriteFile(binaryPath, []byte("binary contents"), 0755))
+			pkg, err := NewPackageManager().CreatePackage(PackageConfig{
+				Platform:           tt.platform,
+				Files:              []string{binaryPath},
+				PackageType:        PackageTypeZip,
+				OutputDir:          outputDir,
+				Version:            "1.2.0",
+				OutputNameTemplate: template,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimSuffix(tt.binary, ".exe")+".zip", pkg.Filename)
+		})
+	}
+
+	t.Run("template without platform placeholders", func(t *testing.T) {
+		platform := Platform{GOOS: "windows", GOARCH: "amd64"}
+		assert.Equal(t, "dwgx.exe", platform.binaryName("dwgx", ""))
+		assert.Equal(t, "dwgx.exe", platform.binaryName("dwgx.exe", ""), "Expected .exe not to be doubled")
+		assert.Equal(t, "dwgx-windows-amd64", platform.distDir("dwgx", ""))
+		assert.Equal(t, "go-dwg-extractor-windows-amd64", platform.distDir("", ""))
+	})
+}
+
 // TestBuildScript tests build script generation and execution
 func TestBuildScript(t *testing.T) {
 	tests := []struct {
@@ -156,6 +237,98 @@ func TestBuildScript(t *testing.T) {
 	}
 }
 
+// TestDefaultReleasePlatforms tests the curated release platform set
+func TestDefaultReleasePlatforms(t *testing.T) {
+	platforms := DefaultReleasePlatforms()
+
+	expected := []Platform{
+		{GOOS: "linux", GOARCH: "arm64"},
+		{GOOS: "linux", GOARCH: "arm"},
+		{GOOS: "linux", GOARCH: "386"},
+		{GOOS: "windows", GOARCH: "386"},
+		{GOOS: "freebsd", GOARCH: "amd64"},
+	}
+	for _, platform := range expected {
+		assert.Contains(t, platforms, platform, "Expected %s/%s in release platforms", platform.GOOS, platform.GOARCH)
+	}
+
+	// Every preset should be a valid Go target
+	output, err := exec.Command("go", "tool", "dist", "list").Output()
+	require.NoError(t, err)
+	for _, platform := range platforms {
+		assert.Contains(t, strings.Fields(string(output)), platform.GOOS+"/"+platform.GOARCH)
+	}
+}
+
+// TestBuildScript_ReleasePlatforms tests script output names for the release platforms
+func TestBuildScript_ReleasePlatforms(t *testing.T) {
+	tests := []struct {
+		name       string
+		scriptType ScriptType
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:       "Bash",
+			scriptType: ScriptTypeBash,
+			expected: []string{
+				"go build -o dist/go-dwg-extractor-linux-arm/go-dwg-extractor .",
+				"go build -o dist/go-dwg-extractor-windows-386/go-dwg-extractor.exe .",
+				"go build -o dist/go-dwg-extractor-freebsd-amd64/go-dwg-extractor .",
+			},
+			unexpected: []string{"linux-arm/go-dwg-extractor.exe"},
+		},
+		{
+			name:       "PowerShell",
+			scriptType: ScriptTypePowerShell,
+			expected: []string{
+				"go build -o dist\\go-dwg-extractor-linux-386\\go-dwg-extractor .",
+				"go build -o dist\\go-dwg-extractor-windows-386\\go-dwg-extractor.exe .",
+			},
+			unexpected: []string{"linux-386\\go-dwg-extractor.exe"},
+		},
+		{
+			name:       "Makefile",
+			scriptType: ScriptTypeMakefile,
+			expected: []string{
+				"build-linux: build-linux-amd64 build-linux-arm64 build-linux-arm build-linux-386\n",
+				"build-linux-arm:\n",
+				"GOOS=linux GOARCH=arm go build -o dist/go-dwg-extractor-linux-arm/go-dwg-extractor .",
+				"GOOS=windows GOARCH=386 go build -o dist/go-dwg-extractor-windows-386/go-dwg-extractor.exe .",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, err := NewScriptGenerator().GenerateBuildScript(tt.scriptType, DefaultReleasePlatforms())
+			require.NoError(t, err)
+
+			for _, expected := range tt.expected {
+				assert.Contains(t, script.Content, expected)
+			}
+			for _, unexpected := range tt.unexpected {
+				assert.NotContains(t, script.Content, unexpected)
+			}
+		})
+	}
+
+	t.Run("Makefile targets are unique", func(t *testing.T) {
+		script, err := NewScriptGenerator().GenerateBuildScript(ScriptTypeMakefile, DefaultReleasePlatforms())
+		require.NoError(t, err)
+
+		targets := make(map[string]bool)
+		for _, line := range strings.Split(script.Content, "\n") {
+			if !strings.HasPrefix(line, "build-") {
+				continue
+			}
+			target := line[:strings.Index(line, ":")]
+			assert.False(t, targets[target], "Duplicate Makefile target %s", target)
+			targets[target] = true
+		}
+	})
+}
+
 // TestVersioning tests version injection during build
 func TestVersioning(t *testing.T) {
 	tests := []struct {
@@ -215,6 +388,122 @@ func TestVersioning(t *testing.T) {
 	}
 }
 
+// TestDetectGitInfo tests version detection from git, with git stubbed out
+func TestDetectGitInfo(t *testing.T) {
+	originalRunGit := runGit
+	defer func() { runGit = originalRunGit }()
+
+	tests := []struct {
+		name          string
+		outputs       map[string]string // git subcommand to output; missing subcommands fail
+		expectError   bool
+		expectVersion string
+		expectCommit  string
+	}{
+		{
+			name:          "tagged repository",
+			outputs:       map[string]string{"rev-parse": "abc1234", "describe": "v1.2.3-4-gabc1234"},
+			expectVersion: "v1.2.3-4-gabc1234",
+			expectCommit:  "abc1234",
+		},
+		{
+			name:          "untagged repository",
+			outputs:       map[string]string{"rev-parse": "abc1234"},
+			expectVersion: "dev",
+			expectCommit:  "abc1234",
+		},
+		{
+			name:          "not a git repository",
+			outputs:       map[string]string{},
+			expectError:   true,
+			expectVersion: "dev",
+			expectCommit:  "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runGit = func(args ...string) (string, error) {
+				if output, ok := tt.outputs[args[0]]; ok {
+					return output, nil
+				}
+				return "", &exec.ExitError{}
+			}
+
+			info, err := DetectGitInfo()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectVersion, info.Version)
+			assert.Equal(t, tt.expectCommit, info.GitCommit)
+			_, err = time.Parse(time.RFC3339, info.BuildTime)
+			assert.NoError(t, err, "Expected an RFC 3339 build time")
+		})
+	}
+}
+
+// TestBuild_InjectsVersion tests that builds carry the version information in main
+func TestBuild_InjectsVersion(t *testing.T) {
+	sourceDir := t.TempDir()
+	source := filepath.Join(sourceDir, "main.go")
+	require.NoError(t, os.WriteFile(source, []byte(`package main
+
+import "fmt"
+
+var (
+	version   = "dev"
+	gitCommit = "unknown"
+	buildTime = "unknown"
+)
+
+func main() {
+	fmt.Println(version, gitCommit, buildTime)
+}
+`), 0644))
+
+	tests := []struct {
+		name     string
+		config   BuildConfig
+		expected string
+	}{
+		{
+			name:     "all fields injected",
+			config:   BuildConfig{Version: "v1.2.3", GitCommit: "abc123", BuildTime: "2024-12-28T12:00:00Z"},
+			expected: "v1.2.3 abc123 2024-12-28T12:00:00Z",
+		},
+		{
+			name:     "empty fields keep the defaults",
+			config:   BuildConfig{Version: "v1.2.3"},
+			expected: "v1.2.3 unknown unknown",
+		},
+		{
+			name:     "values with spaces",
+			config:   BuildConfig{Version: "v1.2.3", BuildTime: "Sat Dec 28 2024"},
+			expected: "v1.2.3 unknown Sat Dec 28 2024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.GOOS = runtime.GOOS
+			config.GOARCH = runtime.GOARCH
+			config.OutputName = "tiny"
+			config.SourcePath = source
+			config.OutputDir = t.TempDir()
+
+			result, err := NewBuildManager().Build(config)
+			require.NoError(t, err)
+
+			output, err := exec.Command(filepath.Join(config.OutputDir, result.OutputFile)).Output()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, strings.TrimSpace(string(output)))
+		})
+	}
+}
+
 // TestPackaging tests creation of distribution packages
 func TestPackaging(t *testing.T) {
 	tests := []struct {
@@ -284,9 +573,9 @@ func TestPackaging(t *testing.T) {
 			// Create package configuration
 			config := PackageConfig{
 				Platform:    tt.platform,
-				Files:       tt.files,
+				Files:       writePackageFiles(t, tt.files...),
 				PackageType: tt.packageType,
-				OutputDir:   "dist",
+				OutputDir:   t.TempDir(),
 				Version:     "v1.0.0",
 			}
 
@@ -303,6 +592,212 @@ func TestPackaging(t *testing.T) {
 	}
 }
 
+// TestPackageChecksum tests checksum generation and verification for packages
+func TestPackageChecksum(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageType PackageType
+		filename    string
+	}{
+		{name: "ZIP package", packageType: PackageTypeZip, filename: "go-dwg-extractor-windows-amd64.zip"},
+		{name: "tar.gz package", packageType: PackageTypeTarGz, filename: "go-dwg-extractor-windows-amd64.tar.gz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			packageManager := NewPackageManager()
+
+			packageResult, err := packageManager.CreatePackage(PackageConfig{
+				Platform:    Platform{GOOS: "windows", GOARCH: "amd64"},
+				Files:       writePackageFiles(t, "README.md"),
+				PackageType: tt.packageType,
+				OutputDir:   outputDir,
+			})
+			require.NoError(t, err, "Expected package creation to succeed")
+			assert.Len(t, packageResult.Checksum, 64, "Expected hex-encoded SHA-256 checksum")
+
+			// The .sha256 file sits next to the archive in sha256sum format
+			packagePath := filepath.Join(outputDir, tt.filename)
+			content, err := os.ReadFile(packagePath + ".sha256")
+			require.NoError(t, err, "Expected checksum file to be written")
+			assert.Equal(t, packageResult.Checksum+"  "+tt.filename+"\n", string(content))
+
+			valid, err := packageManager.VerifyPackage(packagePath, strings.ToUpper(packageResult.Checksum))
+			require.NoError(t, err)
+			assert.True(t, valid, "Expected checksum to verify")
+
+			valid, err = packageManager.VerifyPackage(packagePath, strings.Repeat("0", 64))
+			require.NoError(t, err)
+			assert.False(t, valid, "Expected mismatched checksum to fail verification")
+		})
+	}
+
+	t.Run("missing package", func(t *testing.T) {
+		_, err := NewPackageManager().VerifyPackage(filepath.Join(t.TempDir(), "missing.zip"), "abc")
+		assert.Error(t, err, "Expected error for missing package")
+	})
+}
+
+// TestPackageContents tests that packages contain the real file contents and metadata
+func TestPackageContents(t *testing.T) {
+	sourceDir := t.TempDir()
+	docsDir := filepath.Join(sourceDir, "docs")
+	require.NoError(t, os.MkdirAll(docsDir, 0755))
+
+	binaryPath := filepath.Join(sourceDir, "go-dwg-extractor")
+	require.NoError(t, os.WriteFile(binaryPath, []byte("binary contents"), 0755))
+	guidePath := filepath.Join(docsDir, "guide.md")
+	require.NoError(t, os.WriteFile(guidePath, []byte("# Guide"), 0644))
+
+	modTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(binaryPath, modTime, modTime))
+
+	// Relative paths are needed to preserve directory structure
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(sourceDir))
+	defer os.Chdir(wd)
+
+	tests := []struct {
+		name          string
+		packageType   PackageType
+		preservePaths bool
+		expectedFiles map[string]string
+	}{
+		{
+			name:          "ZIP flattens paths",
+			packageType:   PackageTypeZip,
+			expectedFiles: map[string]string{"go-dwg-extractor": "binary contents", "guide.md": "# Guide"},
+		},
+		{
+			name:          "tar.gz flattens paths",
+			packageType:   PackageTypeTarGz,
+			expectedFiles: map[string]string{"go-dwg-extractor": "binary contents", "guide.md": "# Guide"},
+		},
+		{
+			name:          "ZIP preserves paths",
+			packageType:   PackageTypeZip,
+			preservePaths: true,
+			expectedFiles: map[string]string{"go-dwg-extractor": "binary contents", "docs/guide.md": "# Guide"},
+		},
+		{
+			name:          "tar.gz preserves paths",
+			packageType:   PackageTypeTarGz,
+			preservePaths: true,
+			expectedFiles: map[string]string{"go-dwg-extractor": "binary contents", "docs/guide.md": "# Guide"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			packageResult, err := NewPackageManager().CreatePackage(PackageConfig{
+				Platform:      Platform{GOOS: "linux", GOARCH: "amd64"},
+				Files:         []string{"go-dwg-extractor", filepath.Join("docs", "guide.md")},
+				PackageType:   tt.packageType,
+				OutputDir:     outputDir,
+				PreservePaths: tt.preservePaths,
+			})
+			require.NoError(t, err, "Expected package creation to succeed")
+
+			entries := readPackageEntries(t, filepath.Join(outputDir, packageResult.Filename), tt.packageType)
+			assert.Len(t, entries, len(tt.expectedFiles))
+			for name, content := range tt.expectedFiles {
+				entry, ok := entries[name]
+				require.True(t, ok, "Expected entry %s in package", name)
+				assert.Equal(t, content, entry.content)
+			}
+			assert.ElementsMatch(t, mapKeys(tt.expectedFiles), packageResult.IncludedFiles)
+
+			binary := entries["go-dwg-extractor"]
+			assert.Equal(t, os.FileMode(0755), binary.mode.Perm(), "Expected file mode to be preserved")
+			assert.True(t, modTime.Equal(binary.modTime), "Expected modification time to be preserved")
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewPackageManager().CreatePackage(PackageConfig{
+			Platform:    Platform{GOOS: "linux", GOARCH: "amd64"},
+			Files:       []string{"missing.txt"},
+			PackageType: PackageTypeTarGz,
+			OutputDir:   t.TempDir(),
+		})
+		require.Error(t, err, "Expected error for missing input file")
+		assert.Contains(t, err.Error(), "missing.txt")
+	})
+}
+
+// packageEntry holds the content and metadata of an archive entry
+type packageEntry struct {
+	content string
+	mode    os.FileMode
+	modTime time.Time
+}
+
+// readPackageEntries reads all entries of a zip or tar.gz package
+func readPackageEntries(t *testing.T, path string, packageType PackageType) map[string]packageEntry {
+	t.Helper()
+	entries := make(map[string]packageEntry)
+
+	if packageType == PackageTypeZip {
+		reader, err := zip.OpenReader(path)
+		require.NoError(t, err)
+		defer reader.Close()
+
+		for _, file := range reader.File {
+			rc, err := file.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(rc)
+			rc.Close()
+			require.NoError(t, err)
+			entries[file.Name] = packageEntry{content: string(content), mode: file.Mode(), modTime: file.Modified}
+		}
+		return entries
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		entries[header.Name] = packageEntry{content: string(content), mode: header.FileInfo().Mode(), modTime: header.ModTime}
+	}
+	return entries
+}
+
+// writePackageFiles creates files with the given names in a temp dir and returns their paths
+func writePackageFiles(t *testing.T, names ...string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("content of "+name), 0644))
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// mapKeys returns the keys of a map
+func mapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 // TestBuildPipeline tests the complete build pipeline
 func TestBuildPipeline(t *testing.T) {
 	tests := []struct {
@@ -340,6 +835,7 @@ func TestBuildPipeline(t *testing.T) {
 			// This should fail initially - we need to implement BuildPipeline
 			pipeline := NewBuildPipeline()
 			require.NotNil(t, pipeline, "Expected build pipeline to be created")
+			tt.config.IncludeFiles = writePackageFiles(t, tt.config.IncludeFiles...)
 
 			// Execute pipeline
 			result, err := pipeline.Execute(tt.config)
@@ -360,6 +856,7 @@ func TestBuildPipeline(t *testing.T) {
 					}
 				}
 				assert.True(t, found, "Expected artifact %s to be created", expectedArtifact)
+				assert.Len(t, result.Checksums[expectedArtifact], 64, "Expected checksum for %s", expectedArtifact)
 			}
 		})
 	}
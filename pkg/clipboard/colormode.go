@@ -0,0 +1,70 @@
+package clipboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+)
+
+// ColorMode selects which color number formatted entities show
+type ColorMode string
+
+const (
+	// ColorModeEntity shows each entity's own color, so ByLayer entities show 256
+	ColorModeEntity ColorMode = "entity"
+	// ColorModeLayer resolves ByLayer and ByBlock colors to the color of the entity's layer
+	ColorModeLayer ColorMode = "layer"
+)
+
+// ParseColorMode returns the color mode named by s, ignoring case
+func ParseColorMode(s string) (ColorMode, error) {
+	switch mode := ColorMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case ColorModeEntity, ColorModeLayer:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported color mode %q: use entity or layer", s)
+	}
+}
+
+// SetColorMode sets which color formatted entities show. In layer mode, colors are
+// resolved against the layers given to SetLayers; FormatGroupedByLayer uses the layers
+// of the drawing it formats instead. Unknown modes restore entity mode.
+func (f *ClipboardFormatter) SetColorMode(mode ColorMode) {
+	if mode != ColorModeLayer {
+		mode = ColorModeEntity
+	}
+	f.colorMode = mode
+}
+
+// ColorMode returns which color formatted entities show
+func (f *ClipboardFormatter) ColorMode() ColorMode {
+	return f.colorMode
+}
+
+// SetLayers sets the layers entity colors are resolved against in layer mode
+func (f *ClipboardFormatter) SetLayers(layers []data.LayerInfo) {
+	f.layers = layers
+}
+
+// entityColor returns the color an entity shows in the color mode: its own color in entity
+// mode, and in layer mode its layer's color when it is ByLayer or ByBlock.
+func (f *ClipboardFormatter) entityColor(entity data.Entity) int {
+	if f.colorMode == ColorModeLayer {
+		for _, layer := range f.layers {
+			if layer.Name == entity.GetLayer() {
+				return data.ResolveEntityColor(entity, layer)
+			}
+		}
+	}
+	return entity.GetColor()
+}
+
+// resolveColors returns the entities with the colors the color mode shows,
+// leaving the originals untouched
+func (f *ClipboardFormatter) resolveColors(entities []data.Entity, layers []data.LayerInfo) []data.Entity {
+	if f.colorMode != ColorModeLayer {
+		return entities
+	}
+	return data.ResolveColors(entities, layers)
+}
@@ -0,0 +1,93 @@
+package clipboard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColorMode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ColorMode
+		wantErr  bool
+	}{
+		{input: "entity", expected: ColorModeEntity},
+		{input: " Layer ", expected: ColorModeLayer},
+		{input: "block", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			mode, err := ParseColorMode(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "unsupported color mode")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mode)
+		})
+	}
+}
+
+func TestClipboardFormatter_ColorMode(t *testing.T) {
+	layers := []data.LayerInfo{{Name: "Walls", Color: 3}, {Name: "Doors", Color: -5}}
+	byLayer := &data.LineInfo{Layer: "Walls", Color: data.ColorByLayer, EndPoint: data.Point{X: 1}}
+	byBlock := &data.CircleInfo{Layer: "Doors", Color: data.ColorByBlock, Radius: 1}
+	explicit := &data.PointInfo{Layer: "Walls", Color: 1}
+	entities := []data.Entity{byLayer, byBlock, explicit}
+
+	formatter := NewClipboardFormatter()
+	formatter.SetLayers(layers)
+	assert.Equal(t, ColorModeEntity, formatter.ColorMode(), "entity mode is the default")
+	assert.Contains(t, formatter.FormatEntityForClipboard(byLayer), "Color: 256")
+
+	formatter.SetColorMode(ColorModeLayer)
+	assert.Equal(t, ColorModeLayer, formatter.ColorMode())
+
+	t.Run("text", func(t *testing.T) {
+		lines := formatter.FormatMultipleEntitiesForClipboard(entities)
+		require.Len(t, lines, 3)
+		assert.Contains(t, lines[0], "Color: 3")
+		assert.Contains(t, lines[1], "Color: 5", "the color of a layer that is off is its absolute value")
+		assert.Contains(t, lines[2], "Color: 1", "explicit colors are kept")
+		assert.Contains(t, formatter.FormatEntityForClipboard(byLayer), "Color: 3")
+	})
+
+	t.Run("csv and json", func(t *testing.T) {
+		csv := strings.Join(formatter.FormatAsCSVWithColumns(entities, []string{"type", "color"}), "\n")
+		assert.Equal(t, "Type,Color\nLine,3\nCircle,5\nPoint,1", csv)
+
+		text := &data.TextInfo{Layer: "Doors", Value: "D1"}
+		csv = strings.Join(formatter.FormatAsCSVWithColumns([]data.Entity{text}, []string{"type", "color"}), "\n")
+		assert.Equal(t, "Type,Color\nText,5", csv, "entities without a color of their own follow their layer")
+
+		content, err := formatter.FormatAsJSON(entities)
+		require.NoError(t, err)
+		assert.Contains(t, content, `"color": 3`)
+		assert.NotContains(t, content, `"color": 256`)
+
+		var buf bytes.Buffer
+		require.NoError(t, formatter.WriteJSONLines(&buf, entities))
+		assert.Contains(t, buf.String(), `"color":3`)
+	})
+
+	t.Run("grouped by layer uses the drawing's layers", func(t *testing.T) {
+		formatter.SetLayers(nil)
+		lines := formatter.FormatGroupedByLayer(&data.ExtractedData{Layers: []data.LayerInfo{
+			{Name: "Walls", Color: 3, Entities: []data.Entity{byLayer}},
+		}})
+		assert.Contains(t, lines[1], "Color: 3")
+	})
+
+	assert.Equal(t, data.ColorByLayer, byLayer.Color, "formatting does not modify the entities")
+
+	formatter.SetColorMode("unknown")
+	assert.Equal(t, ColorModeEntity, formatter.ColorMode())
+}
@@ -0,0 +1,19 @@
+package clipboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/remym/go-dwg-extractor/pkg/dxfexport"
+)
+
+// FormatAsDXFSnippet formats entities as a DXF ENTITIES section that can be pasted into
+// AutoCAD-compatible tools to recreate the geometry. Nil entities are skipped.
+func (f *ClipboardFormatter) FormatAsDXFSnippet(entities []data.Entity) (string, error) {
+	var builder strings.Builder
+	if err := dxfexport.WriteEntities(&builder, f.prepare(entities, f.layers)); err != nil {
+		return "", fmt.Errorf("failed to format as DXF: %w", err)
+	}
+	return builder.String(), nil
+}
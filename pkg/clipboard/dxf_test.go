@@ -0,0 +1,43 @@
+package clipboard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/remym/go-dwg-extractor/pkg/dxfparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAsDXFSnippet(t *testing.T) {
+	formatter := NewClipboardFormatter()
+	entities := []data.Entity{
+		&data.LineInfo{StartPoint: data.Point{X: 1, Y: 2}, EndPoint: data.Point{X: 3, Y: 4}, Layer: "Walls", Color: 1},
+		nil,
+		&data.CircleInfo{Center: data.Point{X: 5, Y: 5}, Radius: 2, Layer: "Walls"},
+		&data.TextInfo{Value: "Label", InsertionPoint: data.Point{X: 0, Y: 1}, Height: 2.5, HorizontalAlign: "Left", VerticalAlign: "Baseline", Layer: "Notes"},
+	}
+
+	snippet, err := formatter.FormatAsDXFSnippet(entities)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(snippet, "  0\nSECTION\n  2\nENTITIES\n"))
+
+	// The snippet parses back into the same entities, without the nil one
+	dxfPath := filepath.Join(t.TempDir(), "snippet.dxf")
+	require.NoError(t, os.WriteFile(dxfPath, []byte(snippet+"  0\nEOF\n"), 0644))
+	parsed, err := dxfparser.NewParser().ParseDXF(dxfPath)
+	require.NoError(t, err)
+	require.Len(t, parsed.Lines, 1)
+	require.Len(t, parsed.Circles, 1)
+	require.Len(t, parsed.Texts, 1)
+	assert.Equal(t, *entities[0].(*data.LineInfo), parsed.Lines[0])
+	assert.Equal(t, *entities[2].(*data.CircleInfo), parsed.Circles[0])
+	assert.Equal(t, *entities[3].(*data.TextInfo), parsed.Texts[0])
+
+	empty, err := formatter.FormatAsDXFSnippet(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "  0\nSECTION\n  2\nENTITIES\n  0\nENDSEC\n", empty)
+}
@@ -0,0 +1,101 @@
+package clipboard
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// unavailableMarkers are fragments of errors returned when no system clipboard can be reached
+var unavailableMarkers = []string{
+	"no clipboard",
+	"clipboard utilities",
+	"can't open display",
+	"cannot open display",
+}
+
+// IsClipboardUnavailable reports whether err means there is no system clipboard to write to
+func IsClipboardUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, marker := range unavailableMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Copier copies text to a clipboard
+type Copier interface {
+	CopyToClipboard(text string) error
+}
+
+// CopyResult describes where copied content ended up
+type CopyResult struct {
+	ToFile   bool
+	FilePath string
+}
+
+// FallbackClipboardManager copies to a clipboard and falls back to a temp file
+// when no clipboard is available, e.g. in headless or SSH sessions
+type FallbackClipboardManager struct {
+	primary Copier
+	tempDir string
+}
+
+// NewFallbackClipboardManager creates a clipboard manager that falls back to a temp file
+func NewFallbackClipboardManager(primary Copier) *FallbackClipboardManager {
+	return &FallbackClipboardManager{
+		primary: primary,
+	}
+}
+
+// SetTempDir sets the directory for fallback files (default: the system temp dir)
+func (f *FallbackClipboardManager) SetTempDir(dir string) {
+	f.tempDir = dir
+}
+
+// CopyToClipboard copies the given text to the clipboard, or to a temp file if there is none
+func (f *FallbackClipboardManager) CopyToClipboard(text string) error {
+	_, err := f.Copy(text)
+	return err
+}
+
+// Copy copies the given text and reports whether it went to the clipboard or to a file
+func (f *FallbackClipboardManager) Copy(text string) (CopyResult, error) {
+	if f.primary == nil {
+		return f.copyToFile(text)
+	}
+
+	err := f.primary.CopyToClipboard(text)
+	if err == nil {
+		return CopyResult{}, nil
+	}
+	if !IsClipboardUnavailable(err) {
+		return CopyResult{}, err
+	}
+
+	return f.copyToFile(text)
+}
+
+// copyToFile writes the text to a new temp file
+func (f *FallbackClipboardManager) copyToFile(text string) (CopyResult, error) {
+	file, err := os.CreateTemp(f.tempDir, "dwg-extractor-clipboard-*.txt")
+	if err != nil {
+		return CopyResult{}, fmt.Errorf("failed to create clipboard fallback file: %w", err)
+	}
+
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return CopyResult{}, fmt.Errorf("failed to write clipboard fallback file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return CopyResult{}, fmt.Errorf("failed to close clipboard fallback file: %w", err)
+	}
+
+	return CopyResult{ToFile: true, FilePath: file.Name()}, nil
+}
@@ -0,0 +1,70 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsClipboardUnavailable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil error", err: nil, expected: false},
+		{name: "missing clipboard utilities", err: errors.New("No clipboard utilities available. Please install xsel, xclip"), expected: true},
+		{name: "no display", err: errors.New("Error: Can't open display: (null)"), expected: true},
+		{name: "wrapped no clipboard", err: errors.New("failed to copy to clipboard: no clipboard available"), expected: true},
+		{name: "other failure", err: errors.New("clipboard access denied"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsClipboardUnavailable(tt.err))
+		})
+	}
+}
+
+func TestFallbackClipboardManager_Copy(t *testing.T) {
+	tests := []struct {
+		name          string
+		writeErr      error
+		expectFile    bool
+		expectedError bool
+	}{
+		{name: "clipboard available", writeErr: nil, expectFile: false},
+		{name: "no clipboard falls back to file", writeErr: errors.New("No clipboard utilities available"), expectFile: true},
+		{name: "other errors are returned", writeErr: errors.New("clipboard access denied"), expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClipboard := new(MockClipboard)
+			mockClipboard.On("WriteAll", "copied text").Return(tt.writeErr)
+
+			manager := NewFallbackClipboardManager(NewClipboardManager(mockClipboard))
+			manager.SetTempDir(t.TempDir())
+
+			result, err := manager.Copy("copied text")
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectFile, result.ToFile)
+
+			if tt.expectFile {
+				content, err := os.ReadFile(result.FilePath)
+				require.NoError(t, err, "Expected fallback file to exist")
+				assert.Equal(t, "copied text", string(content))
+			} else {
+				assert.Empty(t, result.FilePath)
+			}
+			mockClipboard.AssertExpectations(t)
+		})
+	}
+}
@@ -1,53 +1,169 @@
 package clipboard
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/remym/go-dwg-extractor/pkg/data"
 )
 
+// DefaultCSVDelimiter separates CSV cells unless another delimiter is set
+const DefaultCSVDelimiter = ','
+
 // ClipboardFormatter handles formatting of DXF entities for clipboard operations
-type ClipboardFormatter struct{}
+type ClipboardFormatter struct {
+	delimiter       rune // Separates CSV cells
+	omitEmptyLayers bool // FormatGroupedByLayer leaves out layers without entities
+	colorMode       ColorMode
+	layers          []data.LayerInfo // Colors are resolved against these in layer mode
+	jsonIndent      bool             // FormatAsJSON indents its output
+	gridSnap        float64          // Coordinates are rounded to multiples of this when positive
+}
 
 // NewClipboardFormatter creates a new clipboard formatter
 func NewClipboardFormatter() *ClipboardFormatter {
-	return &ClipboardFormatter{}
+	return &ClipboardFormatter{delimiter: DefaultCSVDelimiter, colorMode: ColorModeEntity, jsonIndent: true}
+}
+
+// SetDelimiter sets the rune separating CSV cells, such as ';' for locales that use a
+// decimal comma. Cells containing the delimiter are quoted. A zero rune, or one that cannot
+// separate cells such as a quote or line break, restores the comma.
+func (f *ClipboardFormatter) SetDelimiter(delimiter rune) {
+	if !validCSVDelimiter(delimiter) {
+		delimiter = DefaultCSVDelimiter
+	}
+	f.delimiter = delimiter
+}
+
+// validCSVDelimiter reports whether encoding/csv accepts delimiter
+func validCSVDelimiter(delimiter rune) bool {
+	return delimiter != 0 && delimiter != '"' && delimiter != '\r' && delimiter != '\n' &&
+		utf8.ValidRune(delimiter) && delimiter != utf8.RuneError
+}
+
+// Delimiter returns the rune separating CSV cells
+func (f *ClipboardFormatter) Delimiter() rune {
+	return f.delimiter
+}
+
+// SetOmitEmptyLayers sets whether FormatGroupedByLayer leaves out layers without entities
+func (f *ClipboardFormatter) SetOmitEmptyLayers(omit bool) {
+	f.omitEmptyLayers = omit
+}
+
+// SetJSONIndent sets whether FormatAsJSON indents its output, which is the default,
+// or writes it compactly on a single line
+func (f *ClipboardFormatter) SetJSONIndent(indent bool) {
+	f.jsonIndent = indent
+}
+
+// JSONIndent returns whether FormatAsJSON indents its output
+func (f *ClipboardFormatter) JSONIndent() bool {
+	return f.jsonIndent
+}
+
+// SetGridSnap sets the grid size coordinates are rounded to before formatting, e.g. 0.25
+// to snap them to the nearest quarter unit. A size of zero, the default, disables snapping.
+// Radii, lengths and areas derived from the snapped coordinates follow them.
+func (f *ClipboardFormatter) SetGridSnap(size float64) {
+	f.gridSnap = max(size, 0)
+}
+
+// GridSnap returns the grid size coordinates are rounded to, or zero when snapping is off
+func (f *ClipboardFormatter) GridSnap() float64 {
+	return f.gridSnap
+}
+
+// prepare returns the entities as they are formatted: with the colors the color mode shows
+// and coordinates snapped to the grid. The originals are left untouched.
+func (f *ClipboardFormatter) prepare(entities []data.Entity, layers []data.LayerInfo) []data.Entity {
+	entities = f.resolveColors(entities, layers)
+	if f.gridSnap <= 0 {
+		return entities
+	}
+
+	snapped := make([]data.Entity, len(entities))
+	for i, entity := range entities {
+		if entity != nil {
+			snapped[i] = data.SnapEntity(entity, f.gridSnap)
+		}
+	}
+	return snapped
+}
+
+// coord formats a coordinate to one decimal, or when snapping to the grid with as many
+// decimals as the snapped value needs, so 10.25 on a quarter-unit grid is not shown as 10.2
+func (f *ClipboardFormatter) coord(v float64) string {
+	if f.gridSnap <= 0 {
+		return strconv.FormatFloat(v, 'f', 1, 64)
+	}
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
 }
 
 // FormatEntityForClipboard formats a single entity for clipboard copying
 func (f *ClipboardFormatter) FormatEntityForClipboard(entity data.Entity) string {
+	return f.formatEntity(f.prepare([]data.Entity{entity}, f.layers)[0])
+}
+
+// formatEntity formats a single entity with the colors it has
+func (f *ClipboardFormatter) formatEntity(entity data.Entity) string {
 	if entity == nil {
 		return "Unknown Entity"
 	}
 
 	switch e := entity.(type) {
 	case *data.LineInfo:
-		return fmt.Sprintf("Line: (%.1f, %.1f) to (%.1f, %.1f), Layer: %s, Color: %d",
-			e.StartPoint.X, e.StartPoint.Y, e.EndPoint.X, e.EndPoint.Y, e.Layer, e.Color)
+		return fmt.Sprintf("Line: (%s, %s) to (%s, %s), Layer: %s, Color: %d",
+			f.coord(e.StartPoint.X), f.coord(e.StartPoint.Y), f.coord(e.EndPoint.X), f.coord(e.EndPoint.Y), e.Layer, e.Color)
 
 	case *data.CircleInfo:
-		return fmt.Sprintf("Circle: Center (%.1f, %.1f), Radius: %.1f, Layer: %s, Color: %d",
-			e.Center.X, e.Center.Y, e.Radius, e.Layer, e.Color)
+		return fmt.Sprintf("Circle: Center (%s, %s), Radius: %.1f, Layer: %s, Color: %d",
+			f.coord(e.Center.X), f.coord(e.Center.Y), e.Radius, e.Layer, e.Color)
 
 	case *data.TextInfo:
-		return fmt.Sprintf("Text: \"%s\", InsertionPoint: (%.1f, %.1f), Height: %.1f, Layer: %s",
-			e.Value, e.InsertionPoint.X, e.InsertionPoint.Y, e.Height, e.Layer)
+		text := fmt.Sprintf("Text: \"%s\", InsertionPoint: (%s, %s), Height: %.1f, Layer: %s",
+			e.Value, f.coord(e.InsertionPoint.X), f.coord(e.InsertionPoint.Y), e.Height, e.Layer)
+		// Rotation is only appended when set, so unrotated text keeps the documented format
+		if e.Rotation != 0 {
+			text += fmt.Sprintf(", Rotation: %.1f", e.Rotation)
+		}
+		return text
 
 	case *data.BlockInfo:
 		attributeStr := f.formatAttributes(e.Attributes)
 		if attributeStr != "" {
-			return fmt.Sprintf("Block: %s, InsertionPoint: (%.1f, %.1f), Rotation: %.1f, Scale: (%.1f, %.1f), Layer: %s, Attributes: [%s]",
-				e.Name, e.InsertionPoint.X, e.InsertionPoint.Y, e.Rotation, e.Scale.X, e.Scale.Y, e.Layer, attributeStr)
+			return fmt.Sprintf("Block: %s, InsertionPoint: (%s, %s), Rotation: %.1f, Scale: (%.1f, %.1f), Layer: %s, Attributes: [%s]",
+				e.Name, f.coord(e.InsertionPoint.X), f.coord(e.InsertionPoint.Y), e.Rotation, e.Scale.X, e.Scale.Y, e.Layer, attributeStr)
 		}
-		return fmt.Sprintf("Block: %s, InsertionPoint: (%.1f, %.1f), Rotation: %.1f, Scale: (%.1f, %.1f), Layer: %s",
-			e.Name, e.InsertionPoint.X, e.InsertionPoint.Y, e.Rotation, e.Scale.X, e.Scale.Y, e.Layer)
+		return fmt.Sprintf("Block: %s, InsertionPoint: (%s, %s), Rotation: %.1f, Scale: (%.1f, %.1f), Layer: %s",
+			e.Name, f.coord(e.InsertionPoint.X), f.coord(e.InsertionPoint.Y), e.Rotation, e.Scale.X, e.Scale.Y, e.Layer)
 
 	case *data.PolylineInfo:
 		return fmt.Sprintf("Polyline: %d points, Layer: %s, Color: %d, Closed: %v",
 			len(e.Points), e.Layer, e.Color, e.IsClosed)
 
+	case *data.DimensionInfo:
+		return fmt.Sprintf("Dimension: %s, Type: %s, Measurement: %.2f, Layer: %s",
+			e.DisplayText(), e.DimensionType, e.Measurement, e.Layer)
+
+	case *data.PointInfo:
+		return fmt.Sprintf("Point: (%s, %s, %s), Layer: %s, Color: %d",
+			f.coord(e.Location.X), f.coord(e.Location.Y), f.coord(e.Location.Z), e.Layer, e.Color)
+
+	case *data.HatchInfo:
+		return fmt.Sprintf("Hatch: %s, Solid: %v, Boundary Points: %d, Layer: %s, Color: %d",
+			e.PatternName, e.IsSolid, e.BoundaryPointCount, e.Layer, e.Color)
+
 	default:
 		return fmt.Sprintf("Entity: %T, Layer: %s", entity, entity.GetLayer())
 	}
@@ -60,16 +176,31 @@ func (f *ClipboardFormatter) FormatMultipleEntitiesForClipboard(entities []data.
 	}
 
 	result := make([]string, len(entities))
-	for i, entity := range entities {
-		result[i] = f.FormatEntityForClipboard(entity)
+	for i, entity := range f.prepare(entities, f.layers) {
+		result[i] = f.formatEntity(entity)
 	}
 
 	return result
 }
 
+// CSVOptions controls how FormatAsCSVOptions writes CSV
+type CSVOptions struct {
+	IncludeHeader bool // Start with the Type,Layer,Details header row
+}
+
 // FormatAsCSV formats entities as CSV for spreadsheet compatibility
 func (f *ClipboardFormatter) FormatAsCSV(entities []data.Entity) []string {
-	result := []string{"Type,Layer,Details"}
+	return f.FormatAsCSVOptions(entities, CSVOptions{IncludeHeader: true})
+}
+
+// FormatAsCSVOptions formats entities as CSV using the given options.
+// Leaving out the header is useful when appending rows to an existing spreadsheet.
+func (f *ClipboardFormatter) FormatAsCSVOptions(entities []data.Entity, opts CSVOptions) []string {
+	entities = f.prepare(entities, f.layers)
+	result := []string{}
+	if opts.IncludeHeader {
+		result = append(result, f.csvRow("Type", "Layer", "Details"))
+	}
 
 	if len(entities) == 0 {
 		return result
@@ -86,109 +217,213 @@ func (f *ClipboardFormatter) FormatAsCSV(entities []data.Entity) []string {
 		switch e := entity.(type) {
 		case *data.LineInfo:
 			entityType = "Line"
-			details = fmt.Sprintf("\"(%.1f,%.1f) to (%.1f,%.1f), Color: %d\"",
-				e.StartPoint.X, e.StartPoint.Y, e.EndPoint.X, e.EndPoint.Y, e.Color)
+			details = fmt.Sprintf("(%s,%s) to (%s,%s), Color: %d",
+				f.coord(e.StartPoint.X), f.coord(e.StartPoint.Y), f.coord(e.EndPoint.X), f.coord(e.EndPoint.Y), e.Color)
 
 		case *data.CircleInfo:
 			entityType = "Circle"
-			details = fmt.Sprintf("\"Center (%.1f,%.1f), Radius: %.1f, Color: %d\"",
-				e.Center.X, e.Center.Y, e.Radius, e.Color)
+			details = fmt.Sprintf("Center (%s,%s), Radius: %.1f, Color: %d",
+				f.coord(e.Center.X), f.coord(e.Center.Y), e.Radius, e.Color)
 
 		case *data.TextInfo:
 			entityType = "Text"
-			details = fmt.Sprintf("\"%s at (%.1f,%.1f), Height: %.1f\"",
-				strings.ReplaceAll(e.Value, "\"", "\"\""), e.InsertionPoint.X, e.InsertionPoint.Y, e.Height)
+			details = fmt.Sprintf("%s at (%s,%s), Height: %.1f",
+				e.Value, f.coord(e.InsertionPoint.X), f.coord(e.InsertionPoint.Y), e.Height)
 
 		case *data.BlockInfo:
 			entityType = "Block"
 			attributeStr := f.formatAttributes(e.Attributes)
 			if attributeStr != "" {
-				details = fmt.Sprintf("\"%s at (%.1f,%.1f), Rotation: %.1f, Attributes: %s\"",
-					e.Name, e.InsertionPoint.X, e.InsertionPoint.Y, e.Rotation, attributeStr)
+				details = fmt.Sprintf("%s at (%s,%s), Rotation: %.1f, Attributes: %s",
+					e.Name, f.coord(e.InsertionPoint.X), f.coord(e.InsertionPoint.Y), e.Rotation, attributeStr)
 			} else {
-				details = fmt.Sprintf("\"%s at (%.1f,%.1f), Rotation: %.1f\"",
-					e.Name, e.InsertionPoint.X, e.InsertionPoint.Y, e.Rotation)
+				details = fmt.Sprintf("%s at (%s,%s), Rotation: %.1f",
+					e.Name, f.coord(e.InsertionPoint.X), f.coord(e.InsertionPoint.Y), e.Rotation)
 			}
 
 		case *data.PolylineInfo:
 			entityType = "Polyline"
-			details = fmt.Sprintf("\"%d points, Color: %d, Closed: %v\"",
+			details = fmt.Sprintf("%d points, Color: %d, Closed: %v",
 				len(e.Points), e.Color, e.IsClosed)
 
+		case *data.DimensionInfo:
+			entityType = "Dimension"
+			details = fmt.Sprintf("%s, Type: %s, Measurement: %.2f",
+				e.DisplayText(), e.DimensionType, e.Measurement)
+
+		case *data.PointInfo:
+			entityType = "Point"
+			details = fmt.Sprintf("(%s,%s,%s), Color: %d",
+				f.coord(e.Location.X), f.coord(e.Location.Y), f.coord(e.Location.Z), e.Color)
+
+		case *data.HatchInfo:
+			entityType = "Hatch"
+			details = fmt.Sprintf("Pattern: %s, Solid: %v, Boundary Points: %d, Color: %d",
+				e.PatternName, e.IsSolid, e.BoundaryPointCount, e.Color)
+
 		default:
 			entityType = "Unknown"
-			details = fmt.Sprintf("\"%T\"", entity)
+			details = fmt.Sprintf("%T", entity)
 		}
 
-		csvLine := fmt.Sprintf("%s,%s,%s", entityType, layer, details)
-		result = append(result, csvLine)
+		result = append(result, f.csvRow(entityType, layer, details))
 	}
 
 	return result
 }
 
-// FormatAsJSON formats entities as JSON
-func (f *ClipboardFormatter) FormatAsJSON(entities []data.Entity) (string, error) {
-	// Create a simplified structure for JSON serialization
-	jsonEntities := make([]map[string]interface{}, 0, len(entities))
+// CSVColumns lists the column names accepted by FormatAsCSVWithColumns
+var CSVColumns = []string{"type", "handle", "layer", "color", "x", "y", "radius", "value"}
+
+// ValidateCSVColumns returns an error if columns is empty or names a column not in CSVColumns.
+// Names are matched case-insensitively.
+func ValidateCSVColumns(columns []string) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("no CSV columns given: choose from %s", strings.Join(CSVColumns, ", "))
+	}
+	for _, column := range columns {
+		if !slices.Contains(CSVColumns, strings.ToLower(strings.TrimSpace(column))) {
+			return fmt.Errorf("unknown CSV column %q: choose from %s", column, strings.Join(CSVColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// FormatAsCSVWithColumns formats entities as CSV with only the given columns, in order,
+// after a header row naming them. Cells for fields an entity lacks, such as the radius
+// of a line, are left empty. Unknown column names are skipped; check them first with
+// ValidateCSVColumns to report them instead.
+func (f *ClipboardFormatter) FormatAsCSVWithColumns(entities []data.Entity, columns []string) []string {
+	entities = f.prepare(entities, f.layers)
+	var chosen []string
+	for _, column := range columns {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if slices.Contains(CSVColumns, column) {
+			chosen = append(chosen, column)
+		}
+	}
+
+	header := make([]string, len(chosen))
+	for i, column := range chosen {
+		header[i] = csvColumnHeader(column)
+	}
+	result := []string{f.csvRow(header...)}
 
 	for _, entity := range entities {
 		if entity == nil {
 			continue
 		}
-
-		entityMap := map[string]interface{}{
-			"layer": entity.GetLayer(),
+		cells := make([]string, len(chosen))
+		for i, column := range chosen {
+			cells[i] = f.csvColumnValue(entity, column)
 		}
+		result = append(result, f.csvRow(cells...))
+	}
 
-		switch e := entity.(type) {
-		case *data.LineInfo:
-			entityMap["type"] = "Line"
-			entityMap["startPoint"] = map[string]float64{"x": e.StartPoint.X, "y": e.StartPoint.Y}
-			entityMap["endPoint"] = map[string]float64{"x": e.EndPoint.X, "y": e.EndPoint.Y}
-			entityMap["color"] = e.Color
+	return result
+}
 
-		case *data.CircleInfo:
-			entityMap["type"] = "Circle"
-			entityMap["center"] = map[string]float64{"x": e.Center.X, "y": e.Center.Y}
-			entityMap["radius"] = e.Radius
-			entityMap["color"] = e.Color
+// csvColumnHeader returns the header title for a column name, e.g. "radius" becomes "Radius"
+func csvColumnHeader(column string) string {
+	return strings.ToUpper(column[:1]) + column[1:]
+}
 
+// csvColumnValue returns an entity's value for a column, or "" if the entity has no such field
+func (f *ClipboardFormatter) csvColumnValue(entity data.Entity, column string) string {
+	switch column {
+	case "type":
+		return entityTypeName(entity)
+	case "handle":
+		return entity.GetHandle()
+	case "layer":
+		return entity.GetLayer()
+	case "color":
+		return strconv.Itoa(f.entityColor(entity))
+	case "x", "y":
+		if position, ok := entityPosition(entity); ok {
+			if column == "x" {
+				return formatCSVFloat(position.X)
+			}
+			return formatCSVFloat(position.Y)
+		}
+	case "radius":
+		if circle, ok := entity.(*data.CircleInfo); ok {
+			return formatCSVFloat(circle.Radius)
+		}
+	case "value":
+		switch e := entity.(type) {
 		case *data.TextInfo:
-			entityMap["type"] = "Text"
-			entityMap["value"] = e.Value
-			entityMap["insertionPoint"] = map[string]float64{"x": e.InsertionPoint.X, "y": e.InsertionPoint.Y}
-			entityMap["height"] = e.Height
+			return e.Value
+		case *data.DimensionInfo:
+			return e.DisplayText()
+		}
+	}
+	return ""
+}
 
-		case *data.BlockInfo:
-			entityMap["type"] = "Block"
-			entityMap["name"] = e.Name
-			entityMap["insertionPoint"] = map[string]float64{"x": e.InsertionPoint.X, "y": e.InsertionPoint.Y}
-			entityMap["rotation"] = e.Rotation
-			entityMap["scale"] = map[string]float64{"x": e.Scale.X, "y": e.Scale.Y}
-
-			if len(e.Attributes) > 0 {
-				attributes := make([]map[string]string, len(e.Attributes))
-				for i, attr := range e.Attributes {
-					attributes[i] = map[string]string{"tag": attr.Tag, "value": attr.Value}
-				}
-				entityMap["attributes"] = attributes
-			}
+// entityPosition returns the point that locates an entity: a line's start, a circle's center,
+// a polyline's first vertex, or the insertion, definition or location point of the others
+func entityPosition(entity data.Entity) (data.Point, bool) {
+	switch e := entity.(type) {
+	case *data.LineInfo:
+		return e.StartPoint, true
+	case *data.CircleInfo:
+		return e.Center, true
+	case *data.TextInfo:
+		return e.InsertionPoint, true
+	case *data.BlockInfo:
+		return e.InsertionPoint, true
+	case *data.PolylineInfo:
+		if len(e.Points) == 0 {
+			return data.Point{}, false
+		}
+		return e.Points[0], true
+	case *data.DimensionInfo:
+		return e.DefinitionPoint, true
+	case *data.PointInfo:
+		return e.Location, true
+	default:
+		return data.Point{}, false
+	}
+}
 
-		case *data.PolylineInfo:
-			entityMap["type"] = "Polyline"
-			entityMap["pointCount"] = len(e.Points)
-			entityMap["color"] = e.Color
-			entityMap["closed"] = e.IsClosed
+// formatCSVFloat formats a coordinate with as many digits as needed to round-trip it
+func formatCSVFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
 
-		default:
-			entityMap["type"] = "Unknown"
+// csvRow formats cells as one CSV record with encoding/csv, which quotes cells per RFC 4180
+// where needed. Line breaks inside a cell are kept in its quotes, so a record may span lines.
+func (f *ClipboardFormatter) csvRow(cells ...string) string {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	writer.Comma = f.delimiter
+	// Writing to a strings.Builder only fails for an invalid delimiter, which SetDelimiter prevents
+	_ = writer.Write(cells)
+	writer.Flush()
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// FormatAsJSON formats entities as a JSON array, indented unless SetJSONIndent turned it off
+func (f *ClipboardFormatter) FormatAsJSON(entities []data.Entity) (string, error) {
+	// Create a simplified structure for JSON serialization
+	jsonEntities := make([]interface{}, 0, len(entities))
+
+	for _, entity := range f.prepare(entities, f.layers) {
+		if entity == nil {
+			continue
 		}
 
-		jsonEntities = append(jsonEntities, entityMap)
+		jsonEntities = append(jsonEntities, entityJSON(entity))
 	}
 
-	jsonBytes, err := json.MarshalIndent(jsonEntities, "", "  ")
+	var jsonBytes []byte
+	var err error
+	if f.jsonIndent {
+		jsonBytes, err = json.MarshalIndent(jsonEntities, "", "  ")
+	} else {
+		jsonBytes, err = json.Marshal(jsonEntities)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal entities to JSON: %w", err)
 	}
@@ -196,6 +431,256 @@ func (f *ClipboardFormatter) FormatAsJSON(entities []data.Entity) (string, error
 	return string(jsonBytes), nil
 }
 
+// WriteJSONLines writes entities as JSON Lines (NDJSON), one object per line,
+// encoding each entity as it goes instead of building the whole result in memory.
+// Nil entities are skipped.
+func (f *ClipboardFormatter) WriteJSONLines(w io.Writer, entities []data.Entity) error {
+	encoder := json.NewEncoder(w)
+	for i, entity := range f.prepare(entities, f.layers) {
+		if entity == nil {
+			continue
+		}
+		if err := encoder.Encode(entityJSON(entity)); err != nil {
+			return fmt.Errorf("failed to write entity %d as JSON: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// entityJSON returns the typed value written as the JSON object of a single entity
+func entityJSON(entity data.Entity) interface{} {
+	layer := entity.GetLayer()
+
+	switch e := entity.(type) {
+	case *data.LineInfo:
+		return data.JSONLine{
+			Type:       "Line",
+			Layer:      layer,
+			StartPoint: data.NewJSONPoint2D(e.StartPoint),
+			EndPoint:   data.NewJSONPoint2D(e.EndPoint),
+			Length:     e.Length(),
+			Color:      e.Color,
+		}
+
+	case *data.CircleInfo:
+		return data.JSONCircle{
+			Type:   "Circle",
+			Layer:  layer,
+			Center: data.NewJSONPoint2D(e.Center),
+			Radius: e.Radius,
+			Color:  e.Color,
+		}
+
+	case *data.TextInfo:
+		return data.JSONText{
+			Type:            "Text",
+			Layer:           layer,
+			Value:           e.Value,
+			InsertionPoint:  data.NewJSONPoint2D(e.InsertionPoint),
+			Height:          e.Height,
+			Rotation:        e.Rotation,
+			HorizontalAlign: e.HorizontalAlign,
+			VerticalAlign:   e.VerticalAlign,
+		}
+
+	case *data.BlockInfo:
+		block := data.JSONBlock{
+			Type:           "Block",
+			Layer:          layer,
+			Name:           e.Name,
+			InsertionPoint: data.NewJSONPoint2D(e.InsertionPoint),
+			Rotation:       e.Rotation,
+			Scale:          data.NewJSONPoint2D(e.Scale),
+		}
+		for _, attr := range e.Attributes {
+			block.Attributes = append(block.Attributes, data.JSONAttribute{Tag: attr.Tag, Value: attr.Value})
+		}
+		return block
+
+	case *data.PolylineInfo:
+		return data.JSONPolyline{
+			Type:       "Polyline",
+			Layer:      layer,
+			PointCount: len(e.Points),
+			Points:     data.NewJSONPoints(e.Points),
+			Color:      e.Color,
+			Closed:     e.IsClosed,
+			Length:     e.Length(),
+			Area:       e.Area(),
+		}
+
+	case *data.DimensionInfo:
+		return data.JSONDimension{
+			Type:            "Dimension",
+			Layer:           layer,
+			DimensionType:   e.DimensionType,
+			Text:            e.DisplayText(),
+			TextOverride:    e.TextOverride,
+			Measurement:     e.Measurement,
+			DefinitionPoint: data.NewJSONPoint2D(e.DefinitionPoint),
+		}
+
+	case *data.PointInfo:
+		return data.JSONPointEntity{
+			Type:     "Point",
+			Layer:    layer,
+			Location: data.JSONPoint{X: e.Location.X, Y: e.Location.Y, Z: e.Location.Z},
+			Color:    e.Color,
+		}
+
+	case *data.HatchInfo:
+		return data.JSONHatch{
+			Type:               "Hatch",
+			Layer:              layer,
+			PatternName:        e.PatternName,
+			Solid:              e.IsSolid,
+			BoundaryPointCount: e.BoundaryPointCount,
+			Color:              e.Color,
+		}
+
+	default:
+		return data.JSONEntityHeader{Type: "Unknown", Layer: layer}
+	}
+}
+
+// summaryKinds lists the entity kinds counted in layer summaries, in display order
+var summaryKinds = []struct {
+	singular, plural string
+}{
+	{"line", "lines"},
+	{"circle", "circles"},
+	{"text", "texts"},
+	{"block", "blocks"},
+	{"polyline", "polylines"},
+	{"dimension", "dimensions"},
+	{"point", "points"},
+	{"hatch", "hatches"},
+	{"other", "others"},
+}
+
+// summaryKind returns the index in summaryKinds of the entity's kind
+func summaryKind(entity data.Entity) int {
+	switch entity.(type) {
+	case *data.LineInfo:
+		return 0
+	case *data.CircleInfo:
+		return 1
+	case *data.TextInfo:
+		return 2
+	case *data.BlockInfo:
+		return 3
+	case *data.PolylineInfo:
+		return 4
+	case *data.DimensionInfo:
+		return 5
+	case *data.PointInfo:
+		return 6
+	case *data.HatchInfo:
+		return 7
+	default:
+		return 8
+	}
+}
+
+// FormatLayerSummary formats one line per layer with its entity counts by kind and
+// its status, e.g. "Layer1: 5 entities (3 lines, 1 circle, 1 text) ON".
+func (f *ClipboardFormatter) FormatLayerSummary(d *data.ExtractedData) []string {
+	if d == nil {
+		return []string{}
+	}
+
+	result := make([]string, 0, len(d.Layers))
+	for _, layer := range d.Layers {
+		counts := make([]int, len(summaryKinds))
+		total := 0
+		for _, entity := range layer.Entities {
+			if entity == nil {
+				continue
+			}
+			counts[summaryKind(entity)]++
+			total++
+		}
+
+		line := fmt.Sprintf("%s: %d %s", layer.Name, total, pluralize(total, "entity", "entities"))
+
+		var parts []string
+		for i, count := range counts {
+			if count > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", count, pluralize(count, summaryKinds[i].singular, summaryKinds[i].plural)))
+			}
+		}
+		if len(parts) > 0 {
+			line += " (" + strings.Join(parts, ", ") + ")"
+		}
+
+		if layer.IsOn {
+			line += " ON"
+		} else {
+			line += " OFF"
+		}
+		if layer.IsFrozen {
+			line += " FROZEN"
+		}
+
+		result = append(result, line)
+	}
+
+	return result
+}
+
+// FormatGroupedByLayer formats the entities of every layer under a "== LayerName ==" header,
+// with a blank line between layers. Headers of layers that are off or frozen say so, e.g.
+// "== Walls (OFF, FROZEN) ==". Layers without entities are kept unless SetOmitEmptyLayers is set.
+func (f *ClipboardFormatter) FormatGroupedByLayer(d *data.ExtractedData) []string {
+	if d == nil {
+		return []string{}
+	}
+
+	result := []string{}
+	for _, layer := range d.Layers {
+		var lines []string
+		for _, entity := range f.prepare(layer.Entities, d.Layers) {
+			if entity != nil {
+				lines = append(lines, f.formatEntity(entity))
+			}
+		}
+		if len(lines) == 0 && f.omitEmptyLayers {
+			continue
+		}
+
+		if len(result) > 0 {
+			result = append(result, "")
+		}
+		result = append(result, layerHeader(layer))
+		result = append(result, lines...)
+	}
+
+	return result
+}
+
+// layerHeader returns the header line of a layer in FormatGroupedByLayer
+func layerHeader(layer data.LayerInfo) string {
+	var states []string
+	if !layer.IsOn {
+		states = append(states, "OFF")
+	}
+	if layer.IsFrozen {
+		states = append(states, "FROZEN")
+	}
+	if len(states) == 0 {
+		return fmt.Sprintf("== %s ==", layer.Name)
+	}
+	return fmt.Sprintf("== %s (%s) ==", layer.Name, strings.Join(states, ", "))
+}
+
+// pluralize returns singular for a count of one and plural otherwise
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
 // formatAttributes formats a list of attributes as "Tag:Value" pairs
 func (f *ClipboardFormatter) formatAttributes(attributes []data.AttributeInfo) string {
 	if len(attributes) == 0 {
@@ -1,10 +1,16 @@
 package clipboard
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/remym/go-dwg-extractor/pkg/data"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestFormatEntityForClipboard tests formatting individual entities
@@ -48,6 +54,20 @@ func TestFormatEntityForClipboard(t *testing.T) {
 			expectedFormat: "Text: \"Sample Text Content\", InsertionPoint: (5.0, 10.0), Height: 12.0, Layer: TextLayer",
 			expectedFields: []string{"Text:", "Sample Text Content", "InsertionPoint:", "5.0", "10.0", "Height: 12.0", "TextLayer"},
 		},
+		{
+			name: "Rotated TextInfo formatting",
+			entity: &data.TextInfo{
+				Value:           "Label",
+				InsertionPoint:  data.Point{X: 1.0, Y: 2.0},
+				Height:          2.5,
+				Rotation:        90.0,
+				HorizontalAlign: "Center",
+				VerticalAlign:   "Middle",
+				Layer:           "TextLayer",
+			},
+			expectedFormat: "Text: \"Label\", InsertionPoint: (1.0, 2.0), Height: 2.5, Layer: TextLayer, Rotation: 90.0",
+			expectedFields: []string{"Text:", "Label", "Rotation: 90.0"},
+		},
 		{
 			name: "BlockInfo formatting with attributes",
 			entity: &data.BlockInfo{
@@ -326,6 +346,197 @@ func TestClipboardFormatterEdgeCases(t *testing.T) {
 }
 
 // TestFormatAsCSV_ComprehensiveEdgeCases tests all edge cases for CSV formatting
+func TestFormatAsCSVOptions(t *testing.T) {
+	formatter := NewClipboardFormatter()
+	entities := []data.Entity{
+		&data.LineInfo{StartPoint: data.Point{X: 0, Y: 0}, EndPoint: data.Point{X: 1, Y: 1}, Layer: "Layer1", Color: 1},
+		&data.CircleInfo{Center: data.Point{X: 0, Y: 0}, Radius: 1, Layer: "Layer2", Color: 2},
+	}
+
+	withHeader := formatter.FormatAsCSVOptions(entities, CSVOptions{IncludeHeader: true})
+	withoutHeader := formatter.FormatAsCSVOptions(entities, CSVOptions{IncludeHeader: false})
+
+	assert.Equal(t, formatter.FormatAsCSV(entities), withHeader, "FormatAsCSV should include the header")
+	assert.Len(t, withoutHeader, len(withHeader)-1)
+	assert.Equal(t, withHeader[1:], withoutHeader)
+	assert.Empty(t, formatter.FormatAsCSVOptions(nil, CSVOptions{}))
+}
+
+func TestFormatAsCSV_Delimiter(t *testing.T) {
+	entities := []data.Entity{
+		&data.TextInfo{Value: "a,b;c", InsertionPoint: data.Point{X: 1, Y: 2}, Height: 2.5, Layer: "Walls;Doors"},
+		&data.LineInfo{StartPoint: data.Point{X: 0, Y: 0}, EndPoint: data.Point{X: 1, Y: 1}, Layer: "Grid,Main", Color: 1},
+	}
+	columns := []string{"type", "layer", "value"}
+
+	tests := []struct {
+		name        string
+		delimiter   rune
+		csv         []string
+		withColumns []string
+	}{
+		{
+			name:      "comma",
+			delimiter: ',',
+			csv: []string{
+				"Type,Layer,Details",
+				`Text,Walls;Doors,"a,b;c at (1.0,2.0), Height: 2.5"`,
+				`Line,"Grid,Main","(0.0,0.0) to (1.0,1.0), Color: 1"`,
+			},
+			withColumns: []string{
+				"Type,Layer,Value",
+				`Text,Walls;Doors,"a,b;c"`,
+				`Line,"Grid,Main",`,
+			},
+		},
+		{
+			name:      "semicolon",
+			delimiter: ';',
+			csv: []string{
+				"Type;Layer;Details",
+				`Text;"Walls;Doors";"a,b;c at (1.0,2.0), Height: 2.5"`,
+				`Line;Grid,Main;(0.0,0.0) to (1.0,1.0), Color: 1`,
+			},
+			withColumns: []string{
+				"Type;Layer;Value",
+				`Text;"Walls;Doors";"a,b;c"`,
+				`Line;Grid,Main;`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewClipboardFormatter()
+			formatter.SetDelimiter(tt.delimiter)
+			assert.Equal(t, tt.delimiter, formatter.Delimiter())
+
+			assert.Equal(t, tt.csv, formatter.FormatAsCSV(entities))
+			assert.Equal(t, tt.withColumns, formatter.FormatAsCSVWithColumns(entities, columns))
+		})
+	}
+
+	formatter := NewClipboardFormatter()
+	assert.Equal(t, DefaultCSVDelimiter, formatter.Delimiter(), "the delimiter defaults to a comma")
+	formatter.SetDelimiter(';')
+	formatter.SetDelimiter(0)
+	assert.Equal(t, DefaultCSVDelimiter, formatter.Delimiter(), "a zero delimiter restores the comma")
+	formatter.SetDelimiter('"')
+	assert.Equal(t, DefaultCSVDelimiter, formatter.Delimiter(), "a quote cannot separate cells")
+}
+
+func TestFormatAsCSV_EmbeddedLineBreaks(t *testing.T) {
+	entities := []data.Entity{
+		&data.TextInfo{Value: "First line\nSecond \"quoted\" line\r\nÜberschrift", InsertionPoint: data.Point{X: 1, Y: 2}, Height: 2.5, Layer: "Notes\nArchive"},
+		&data.BlockInfo{Name: `Door "A"`, Layer: "0", Attributes: []data.AttributeInfo{{Tag: "NOTE", Value: "two\nlines"}}},
+		&data.LineInfo{StartPoint: data.Point{X: 0, Y: 0}, EndPoint: data.Point{X: 1, Y: 1}, Layer: "Grid", Color: 1},
+	}
+
+	for _, delimiter := range []rune{',', ';'} {
+		t.Run(string(delimiter), func(t *testing.T) {
+			formatter := NewClipboardFormatter()
+			formatter.SetDelimiter(delimiter)
+
+			parse := func(rows []string) [][]string {
+				reader := csv.NewReader(strings.NewReader(strings.Join(rows, "\n")))
+				reader.Comma = delimiter
+				records, err := reader.ReadAll()
+				require.NoError(t, err, "the output should be valid CSV")
+				return records
+			}
+
+			rows := formatter.FormatAsCSV(entities)
+			records := parse(rows)
+			require.Len(t, records, 4, "embedded line breaks should not split rows")
+			assert.Equal(t, []string{"Type", "Layer", "Details"}, records[0])
+			assert.Equal(t, "Notes\nArchive", records[1][1])
+			// encoding/csv reads a quoted \r\n as \n
+			assert.Equal(t, "First line\nSecond \"quoted\" line\nÜberschrift at (1.0,2.0), Height: 2.5", records[1][2])
+			assert.Equal(t, `Door "A" at (0.0,0.0), Rotation: 0.0, Attributes: NOTE:two`+"\nlines", records[2][2])
+			assert.Equal(t, "Grid", records[3][1])
+			// Only cells holding the delimiter, a quote or a line break are quoted
+			if delimiter == ';' {
+				assert.Equal(t, "Line;Grid;(0.0,0.0) to (1.0,1.0), Color: 1", rows[len(rows)-1])
+			} else {
+				assert.Equal(t, `Line,Grid,"(0.0,0.0) to (1.0,1.0), Color: 1"`, rows[len(rows)-1])
+			}
+
+			records = parse(formatter.FormatAsCSVWithColumns(entities, []string{"type", "value", "layer"}))
+			require.Len(t, records, 4)
+			assert.Equal(t, []string{"Text", "First line\nSecond \"quoted\" line\nÜberschrift", "Notes\nArchive"}, records[1])
+			assert.Equal(t, []string{"Line", "", "Grid"}, records[3])
+		})
+	}
+}
+
+func TestFormatAsCSVWithColumns(t *testing.T) {
+	formatter := NewClipboardFormatter()
+	entities := []data.Entity{
+		&data.CircleInfo{Center: data.Point{X: 1.5, Y: -2}, Radius: 3, Layer: "Walls", Color: 5, Handle: "2A"},
+		&data.TextInfo{Value: `Room "A", east`, InsertionPoint: data.Point{X: 4, Y: 5}, Layer: "Notes", Color: 1, Handle: "2B"},
+		nil,
+		&data.HatchInfo{Layer: "Fill", Color: 2},
+	}
+
+	tests := []struct {
+		name     string
+		columns  []string
+		expected []string
+	}{
+		{
+			name:    "columns in the requested order",
+			columns: []string{"layer", "type", "radius"},
+			expected: []string{
+				"Layer,Type,Radius",
+				"Walls,Circle,3",
+				"Notes,Text,",
+				"Fill,Hatch,",
+			},
+		},
+		{
+			name:    "missing fields are empty and values are quoted",
+			columns: []string{"X", "y", "color", "value"},
+			expected: []string{
+				"X,Y,Color,Value",
+				"1.5,-2,5,",
+				`4,5,1,"Room ""A"", east"`,
+				",,2,",
+			},
+		},
+		{
+			name:    "handles come from every entity type",
+			columns: []string{"handle", "type"},
+			expected: []string{
+				"Handle,Type",
+				"2A,Circle",
+				"2B,Text",
+				",Hatch",
+			},
+		},
+		{
+			name:     "unknown columns are skipped",
+			columns:  []string{"type", "weight"},
+			expected: []string{"Type", "Circle", "Text", "Hatch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatter.FormatAsCSVWithColumns(entities, tt.columns))
+		})
+	}
+}
+
+func TestValidateCSVColumns(t *testing.T) {
+	assert.NoError(t, ValidateCSVColumns([]string{"type", "Layer", " x "}))
+
+	err := ValidateCSVColumns([]string{"type", "weight"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown CSV column "weight"`)
+
+	assert.Error(t, ValidateCSVColumns(nil))
+}
+
 func TestFormatAsCSV_ComprehensiveEdgeCases(t *testing.T) {
 	formatter := NewClipboardFormatter()
 
@@ -521,6 +732,52 @@ func TestFormatAsJSON_ComprehensiveEdgeCases(t *testing.T) {
 				assert.Contains(t, result, "\"y\": 2.5")
 				assert.Contains(t, result, "\"color\": 7")
 				assert.Contains(t, result, "\"layer\": \"LineLayer\"")
+				assert.Contains(t, result, "\"length\": 2.828")
+			},
+		},
+		{
+			name: "Dimension entity JSON",
+			entities: []data.Entity{
+				&data.DimensionInfo{
+					DimensionType:   "Aligned",
+					TextOverride:    "WIDTH",
+					Measurement:     42.5,
+					DefinitionPoint: data.Point{X: 1, Y: 2},
+					Layer:           "DimLayer",
+				},
+			},
+			wantErr: false,
+			checkContent: func(t *testing.T, result string) {
+				assert.Contains(t, result, "\"type\": \"Dimension\"")
+				assert.Contains(t, result, "\"dimensionType\": \"Aligned\"")
+				assert.Contains(t, result, "\"text\": \"WIDTH\"")
+				assert.Contains(t, result, "\"measurement\": 42.5")
+			},
+		},
+		{
+			name: "Point entity JSON",
+			entities: []data.Entity{
+				&data.PointInfo{Location: data.Point{X: 7.5, Y: -2}, Layer: "Survey", Color: 3},
+			},
+			wantErr: false,
+			checkContent: func(t *testing.T, result string) {
+				assert.Contains(t, result, "\"type\": \"Point\"")
+				assert.Contains(t, result, "\"x\": 7.5")
+				assert.Contains(t, result, "\"y\": -2")
+				assert.Contains(t, result, "\"layer\": \"Survey\"")
+			},
+		},
+		{
+			name: "Hatch entity JSON",
+			entities: []data.Entity{
+				&data.HatchInfo{PatternName: "SOLID", IsSolid: true, BoundaryPointCount: 6, Layer: "Fill"},
+			},
+			wantErr: false,
+			checkContent: func(t *testing.T, result string) {
+				assert.Contains(t, result, "\"type\": \"Hatch\"")
+				assert.Contains(t, result, "\"patternName\": \"SOLID\"")
+				assert.Contains(t, result, "\"solid\": true")
+				assert.Contains(t, result, "\"boundaryPointCount\": 6")
 			},
 		},
 		{
@@ -619,8 +876,11 @@ func TestFormatAsJSON_ComprehensiveEdgeCases(t *testing.T) {
 			checkContent: func(t *testing.T, result string) {
 				assert.Contains(t, result, "\"type\": \"Polyline\"")
 				assert.Contains(t, result, "\"pointCount\": 2")
+				assert.Contains(t, result, "\"points\": [")
 				assert.Contains(t, result, "\"closed\": false")
 				assert.Contains(t, result, "\"color\": 5")
+				assert.Contains(t, result, "\"length\": 1.414")
+				assert.Contains(t, result, "\"area\": 0")
 			},
 		},
 		{
@@ -677,6 +937,34 @@ func TestFormatEntityForClipboard_AllEntityTypes(t *testing.T) {
 			},
 			expectedFormat: "Polyline: 3 points, Layer: PolyLayer, Color: 4, Closed: true",
 		},
+		{
+			name: "DimensionInfo formatting",
+			entity: &data.DimensionInfo{
+				DimensionType: "Linear",
+				Measurement:   1250,
+				Layer:         "DimLayer",
+			},
+			expectedFormat: "Dimension: 1250.00, Type: Linear, Measurement: 1250.00, Layer: DimLayer",
+		},
+		{
+			name: "PointInfo formatting",
+			entity: &data.PointInfo{
+				Location: data.Point{X: 3, Y: 4, Z: 5},
+				Layer:    "Survey",
+				Color:    2,
+			},
+			expectedFormat: "Point: (3.0, 4.0, 5.0), Layer: Survey, Color: 2",
+		},
+		{
+			name: "HatchInfo formatting",
+			entity: &data.HatchInfo{
+				PatternName:        "ANSI31",
+				BoundaryPointCount: 4,
+				Layer:              "Fill",
+				Color:              8,
+			},
+			expectedFormat: "Hatch: ANSI31, Solid: false, Boundary Points: 4, Layer: Fill, Color: 8",
+		},
 		{
 			name:           "Unknown entity type",
 			entity:         &unknownEntity{layer: "TestLayer"},
@@ -692,6 +980,117 @@ func TestFormatEntityForClipboard_AllEntityTypes(t *testing.T) {
 	}
 }
 
+// TestFormatAsJSON_RoundTrip tests that FormatAsJSON output loads back with data.FromJSON
+func TestFormatAsJSON_RoundTrip(t *testing.T) {
+	entities := []data.Entity{
+		&data.LineInfo{StartPoint: data.Point{X: 1, Y: 2}, EndPoint: data.Point{X: 3, Y: 4}, Layer: "A", Color: 1},
+		&data.CircleInfo{Center: data.Point{X: 5, Y: 6}, Radius: 7, Layer: "B", Color: 2},
+		&data.TextInfo{Value: "Note", InsertionPoint: data.Point{X: 1, Y: 1}, Height: 2.5, Rotation: 30,
+			HorizontalAlign: "Right", VerticalAlign: "Top", Layer: "A"},
+		&data.BlockInfo{Name: "DOOR", InsertionPoint: data.Point{X: 2, Y: 3}, Rotation: 45, Scale: data.Point{X: 1, Y: 1}, Layer: "B",
+			Attributes: []data.AttributeInfo{{Tag: "W", Value: "900", Layer: "B"}}},
+		&data.PolylineInfo{Points: []data.Point{{X: 0, Y: 0}, {X: 3, Y: 4, Z: 1}}, Layer: "A", Color: 4, IsClosed: true},
+		&data.DimensionInfo{DimensionType: "Linear", TextOverride: "TYP.", Measurement: 12, DefinitionPoint: data.Point{X: 1}, Layer: "B"},
+		&data.PointInfo{Location: data.Point{X: 1, Y: 2, Z: 3}, Layer: "A", Color: 5},
+		&data.HatchInfo{PatternName: "ANSI31", BoundaryPointCount: 4, Layer: "B", Color: 6},
+	}
+
+	formatter := NewClipboardFormatter()
+	content, err := formatter.FormatAsJSON(entities)
+	assert.NoError(t, err)
+
+	result, err := data.FromJSON(strings.NewReader(content))
+	assert.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+	assert.Len(t, result.Layers, 2)
+
+	var loaded []data.Entity
+	for _, layer := range result.Layers {
+		loaded = append(loaded, layer.Entities...)
+	}
+	assert.ElementsMatch(t, entities, loaded)
+
+	// Compact output holds the same entities on a single line
+	formatter.SetJSONIndent(false)
+	assert.False(t, formatter.JSONIndent())
+	compact, err := formatter.FormatAsJSON(entities)
+	require.NoError(t, err)
+	assert.NotContains(t, compact, "\n")
+	assert.Contains(t, compact, `"type":"Line"`)
+	assert.Less(t, len(compact), len(content))
+
+	result, err = data.FromJSON(strings.NewReader(compact))
+	require.NoError(t, err)
+	loaded = nil
+	for _, layer := range result.Layers {
+		loaded = append(loaded, layer.Entities...)
+	}
+	assert.ElementsMatch(t, entities, loaded)
+}
+
+// TestFormatAsJSON_KeyOrder tests that entity objects are written with sorted keys
+func TestFormatAsJSON_KeyOrder(t *testing.T) {
+	formatter := NewClipboardFormatter()
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.WriteJSONLines(&buf, []data.Entity{
+		&data.LineInfo{EndPoint: data.Point{X: 3, Y: 4}, Layer: "Walls", Color: 1},
+		&data.BlockInfo{Name: "DOOR", Scale: data.Point{X: 1, Y: 1}, Layer: "Doors"},
+		&unknownEntity{layer: "Doors"},
+	}))
+	assert.Equal(t,
+		`{"color":1,"endPoint":{"x":3,"y":4},"layer":"Walls","length":5,"startPoint":{"x":0,"y":0},"type":"Line"}`+"\n"+
+			`{"insertionPoint":{"x":0,"y":0},"layer":"Doors","name":"DOOR","rotation":0,"scale":{"x":1,"y":1},"type":"Block"}`+"\n"+
+			`{"layer":"Doors","type":"Unknown"}`+"\n",
+		buf.String(), "blocks without attributes leave them out")
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	entities := []data.Entity{
+		&data.LineInfo{StartPoint: data.Point{X: 1, Y: 2}, EndPoint: data.Point{X: 4, Y: 6}, Layer: "A", Color: 1},
+		nil,
+		&data.TextInfo{Value: "Note", Layer: "B", Height: 2.5},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewClipboardFormatter()
+	require.NoError(t, formatter.WriteJSONLines(&buf, entities))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2, "Expected one line per non-nil entity")
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &line))
+	assert.Equal(t, "Line", line["type"])
+	assert.Equal(t, "A", line["layer"])
+	assert.Equal(t, 5.0, line["length"])
+
+	var text map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &text))
+	assert.Equal(t, "Text", text["type"])
+	assert.Equal(t, "Note", text["value"])
+
+	// Each line matches the object FormatAsJSON writes for the same entity
+	content, err := formatter.FormatAsJSON(entities)
+	require.NoError(t, err)
+	var all []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(content), &all))
+	assert.Equal(t, all, []map[string]interface{}{line, text})
+}
+
+func TestWriteJSONLines_WriteError(t *testing.T) {
+	formatter := NewClipboardFormatter()
+	err := formatter.WriteJSONLines(failingWriter{}, []data.Entity{&data.LineInfo{Layer: "A"}})
+	assert.ErrorContains(t, err, "failed to write entity 1 as JSON")
+}
+
+// failingWriter is an io.Writer that always fails
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
 // TestFormatAttributes_EdgeCases tests the formatAttributes helper function
 func TestFormatAttributes_EdgeCases(t *testing.T) {
 	formatter := NewClipboardFormatter()
@@ -753,3 +1152,110 @@ type unknownEntity struct {
 func (u *unknownEntity) GetLayer() string {
 	return u.layer
 }
+
+func (u *unknownEntity) GetHandle() string {
+	return ""
+}
+
+func (u *unknownEntity) GetColor() int {
+	return data.ColorByLayer
+}
+
+// TestFormatLayerSummary tests the per-layer inventory lines
+func TestFormatLayerSummary(t *testing.T) {
+	formatter := NewClipboardFormatter()
+	d := &data.ExtractedData{
+		Layers: []data.LayerInfo{
+			{Name: "Layer1", IsOn: true, Entities: []data.Entity{
+				&data.LineInfo{}, &data.LineInfo{}, &data.LineInfo{},
+				&data.CircleInfo{}, &data.TextInfo{},
+			}},
+			{Name: "Empty", IsOn: true},
+			{Name: "Hidden", IsOn: false, Entities: []data.Entity{&data.HatchInfo{}, &data.HatchInfo{}}},
+			{Name: "Locked", IsOn: true, IsFrozen: true, Entities: []data.Entity{&data.BlockInfo{}, &unknownEntity{}}},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"Layer1: 5 entities (3 lines, 1 circle, 1 text) ON",
+		"Empty: 0 entities ON",
+		"Hidden: 2 entities (2 hatches) OFF",
+		"Locked: 2 entities (1 block, 1 other) ON FROZEN",
+	}, formatter.FormatLayerSummary(d))
+
+	assert.Empty(t, formatter.FormatLayerSummary(nil))
+	assert.Empty(t, formatter.FormatLayerSummary(&data.ExtractedData{}))
+}
+
+func TestFormatGroupedByLayer(t *testing.T) {
+	formatter := NewClipboardFormatter()
+	d := &data.ExtractedData{
+		Layers: []data.LayerInfo{
+			{Name: "Walls", IsOn: true, Entities: []data.Entity{
+				&data.LineInfo{EndPoint: data.Point{X: 10}, Layer: "Walls", Color: 1},
+				nil,
+				&data.CircleInfo{Center: data.Point{X: 5, Y: 5}, Radius: 2, Layer: "Walls", Color: 1},
+			}},
+			{Name: "Empty", IsOn: true},
+			{Name: "Hidden", IsOn: false, Entities: []data.Entity{
+				&data.TextInfo{Value: "Note", Height: 2.5, Layer: "Hidden"},
+			}},
+			{Name: "Locked", IsOn: false, IsFrozen: true},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"== Walls ==",
+		"Line: (0.0, 0.0) to (10.0, 0.0), Layer: Walls, Color: 1",
+		"Circle: Center (5.0, 5.0), Radius: 2.0, Layer: Walls, Color: 1",
+		"",
+		"== Empty ==",
+		"",
+		"== Hidden (OFF) ==",
+		"Text: \"Note\", InsertionPoint: (0.0, 0.0), Height: 2.5, Layer: Hidden",
+		"",
+		"== Locked (OFF, FROZEN) ==",
+	}, formatter.FormatGroupedByLayer(d))
+
+	formatter.SetOmitEmptyLayers(true)
+	assert.Equal(t, []string{
+		"== Walls ==",
+		"Line: (0.0, 0.0) to (10.0, 0.0), Layer: Walls, Color: 1",
+		"Circle: Center (5.0, 5.0), Radius: 2.0, Layer: Walls, Color: 1",
+		"",
+		"== Hidden (OFF) ==",
+		"Text: \"Note\", InsertionPoint: (0.0, 0.0), Height: 2.5, Layer: Hidden",
+	}, formatter.FormatGroupedByLayer(d))
+
+	assert.Empty(t, formatter.FormatGroupedByLayer(nil))
+	assert.Empty(t, formatter.FormatGroupedByLayer(&data.ExtractedData{}))
+}
+
+// TestClipboardFormatter_GridSnap tests that coordinates are snapped in every format
+func TestClipboardFormatter_GridSnap(t *testing.T) {
+	line := &data.LineInfo{StartPoint: data.Point{X: 10.12, Y: 0.9}, EndPoint: data.Point{X: 10.13, Y: 5}, Layer: "0"}
+	entities := []data.Entity{line}
+
+	formatter := NewClipboardFormatter()
+	assert.Zero(t, formatter.GridSnap(), "snapping is off by default")
+	assert.Contains(t, formatter.FormatEntityForClipboard(line), "(10.1, 0.9) to (10.1, 5.0)")
+
+	formatter.SetGridSnap(0.25)
+	assert.Equal(t, 0.25, formatter.GridSnap())
+	assert.Contains(t, formatter.FormatEntityForClipboard(line), "(10.0, 1.0) to (10.25, 5.0)")
+	assert.Contains(t, formatter.FormatAsCSV(entities)[1], "(10.0,1.0) to (10.25,5.0)")
+
+	csv := formatter.FormatAsCSVWithColumns(entities, []string{"x", "y"})
+	assert.Equal(t, []string{"X,Y", "10,1"}, csv)
+
+	content, err := formatter.FormatAsJSON(entities)
+	require.NoError(t, err)
+	assert.Contains(t, content, `"x": 10.25`)
+	assert.Contains(t, content, `"x": 10,`)
+	assert.Equal(t, 10.12, line.StartPoint.X, "the entity is left untouched")
+
+	formatter.SetGridSnap(0)
+	content, err = formatter.FormatAsJSON(entities)
+	require.NoError(t, err)
+	assert.Contains(t, content, `"x": 10.12`)
+}
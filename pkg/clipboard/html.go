@@ -0,0 +1,115 @@
+package clipboard
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+)
+
+// htmlStyle is the inline stylesheet embedded in HTML reports so they stay self-contained
+const htmlStyle = `body { font-family: sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.2em; margin-top: 1.5em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #f0f0f0; }
+.summary td { border: none; padding: 2px 8px; }
+.empty { color: #888; font-style: italic; }`
+
+// FormatAsHTML formats entities as a self-contained HTML report grouped by layer
+func (f *ClipboardFormatter) FormatAsHTML(entities []data.Entity) (string, error) {
+	entities = f.prepare(entities, f.layers)
+
+	// Group entities by layer, keeping the order in which layers first appear
+	var layerOrder []string
+	byLayer := make(map[string][]data.Entity)
+	typeCounts := make(map[string]int)
+	var typeOrder []string
+	total := 0
+
+	for _, entity := range entities {
+		if entity == nil {
+			continue
+		}
+
+		layer := entity.GetLayer()
+		if _, exists := byLayer[layer]; !exists {
+			layerOrder = append(layerOrder, layer)
+		}
+		byLayer[layer] = append(byLayer[layer], entity)
+
+		entityType := entityTypeName(entity)
+		if _, exists := typeCounts[entityType]; !exists {
+			typeOrder = append(typeOrder, entityType)
+		}
+		typeCounts[entityType]++
+		total++
+	}
+
+	var report strings.Builder
+
+	report.WriteString("<!DOCTYPE html>\n")
+	report.WriteString("<html lang=\"en\">\n<head>\n")
+	report.WriteString("<meta charset=\"utf-8\">\n")
+	report.WriteString("<title>DWG Extractor Report</title>\n")
+	report.WriteString("<style>\n" + htmlStyle + "\n</style>\n")
+	report.WriteString("</head>\n<body>\n")
+	report.WriteString("<h1>DWG Extractor Report</h1>\n")
+
+	// Summary header
+	report.WriteString("<table class=\"summary\">\n")
+	report.WriteString(fmt.Sprintf("<tr><td>Entities</td><td>%d</td></tr>\n", total))
+	report.WriteString(fmt.Sprintf("<tr><td>Layers</td><td>%d</td></tr>\n", len(layerOrder)))
+	for _, entityType := range typeOrder {
+		report.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n",
+			html.EscapeString(entityType), typeCounts[entityType]))
+	}
+	report.WriteString("</table>\n")
+
+	if total == 0 {
+		report.WriteString("<p class=\"empty\">No entities found.</p>\n")
+	}
+
+	// One table per layer
+	for _, layer := range layerOrder {
+		layerEntities := byLayer[layer]
+		report.WriteString(fmt.Sprintf("<h2>Layer: %s (%d)</h2>\n", html.EscapeString(layer), len(layerEntities)))
+		report.WriteString("<table>\n<tr><th>Type</th><th>Details</th></tr>\n")
+		for _, entity := range layerEntities {
+			report.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(entityTypeName(entity)),
+				html.EscapeString(f.FormatEntityForClipboard(entity))))
+		}
+		report.WriteString("</table>\n")
+	}
+
+	report.WriteString("</body>\n</html>\n")
+
+	return report.String(), nil
+}
+
+// entityTypeName returns the display name for an entity's type
+func entityTypeName(entity data.Entity) string {
+	switch entity.(type) {
+	case *data.LineInfo:
+		return "Line"
+	case *data.CircleInfo:
+		return "Circle"
+	case *data.TextInfo:
+		return "Text"
+	case *data.BlockInfo:
+		return "Block"
+	case *data.PolylineInfo:
+		return "Polyline"
+	case *data.DimensionInfo:
+		return "Dimension"
+	case *data.PointInfo:
+		return "Point"
+	case *data.HatchInfo:
+		return "Hatch"
+	default:
+		return "Unknown"
+	}
+}
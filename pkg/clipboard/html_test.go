@@ -0,0 +1,108 @@
+package clipboard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFormatAsHTML tests HTML report generation
+func TestFormatAsHTML(t *testing.T) {
+	tests := []struct {
+		name             string
+		entities         []data.Entity
+		expectedContains []string
+		notContains      []string
+	}{
+		{
+			name: "Entities grouped by layer",
+			entities: []data.Entity{
+				&data.LineInfo{
+					StartPoint: data.Point{X: 0, Y: 0},
+					EndPoint:   data.Point{X: 10, Y: 10},
+					Layer:      "Walls",
+					Color:      1,
+				},
+				&data.CircleInfo{
+					Center: data.Point{X: 5, Y: 5},
+					Radius: 2.5,
+					Layer:  "Doors",
+					Color:  2,
+				},
+				&data.LineInfo{
+					StartPoint: data.Point{X: 1, Y: 1},
+					EndPoint:   data.Point{X: 2, Y: 2},
+					Layer:      "Walls",
+					Color:      1,
+				},
+			},
+			expectedContains: []string{
+				"<!DOCTYPE html>",
+				"<h2>Layer: Walls (2)</h2>",
+				"<h2>Layer: Doors (1)</h2>",
+				"<tr><td>Entities</td><td>3</td></tr>",
+				"<tr><td>Layers</td><td>2</td></tr>",
+				"<tr><td>Line</td><td>2</td></tr>",
+				"<tr><td>Circle</td><td>1</td></tr>",
+			},
+			notContains: []string{"No entities found."},
+		},
+		{
+			name: "User-controlled strings are escaped",
+			entities: []data.Entity{
+				&data.TextInfo{
+					Value:          "<script>alert('x')</script>",
+					InsertionPoint: data.Point{X: 1, Y: 1},
+					Height:         2.5,
+					Layer:          "A&B",
+				},
+				&data.BlockInfo{
+					Name:  "Door",
+					Layer: "A&B",
+					Attributes: []data.AttributeInfo{
+						{Tag: "ROOM", Value: "<101>"},
+					},
+				},
+			},
+			expectedContains: []string{
+				"Layer: A&amp;B",
+				"&lt;script&gt;",
+				"ROOM:&lt;101&gt;",
+			},
+			notContains: []string{"<script>", "<101>"},
+		},
+		{
+			name:             "Empty input produces valid document",
+			entities:         []data.Entity{},
+			expectedContains: []string{"<!DOCTYPE html>", "No entities found.", "</html>"},
+			notContains:      []string{"<h2>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewClipboardFormatter()
+			result, err := formatter.FormatAsHTML(tt.entities)
+			require.NoError(t, err, "Expected HTML generation to succeed")
+
+			for _, expected := range tt.expectedContains {
+				assert.Contains(t, result, expected, "Expected '%s' in report", expected)
+			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, result, unexpected, "Did not expect '%s' in report", unexpected)
+			}
+			assert.True(t, strings.HasSuffix(strings.TrimSpace(result), "</html>"), "Expected a complete document")
+		})
+	}
+}
+
+// TestFormatAsHTML_SkipsNilEntities tests that nil entities are ignored
+func TestFormatAsHTML_SkipsNilEntities(t *testing.T) {
+	formatter := NewClipboardFormatter()
+	result, err := formatter.FormatAsHTML([]data.Entity{nil})
+	require.NoError(t, err)
+	assert.Contains(t, result, "No entities found.")
+}
@@ -0,0 +1,68 @@
+package clipboard
+
+import (
+	"strings"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+)
+
+// scheduleBaseColumns are the columns FormatBlocksAsCSV writes before the attribute tags
+var scheduleBaseColumns = []string{"Name", "Layer"}
+
+// FormatBlocksAsCSV formats block insertions as a CSV schedule with one column per attribute
+// tag, in the order the tags first appear, after the block name and layer. Blocks without a tag
+// leave its cell empty; when a block repeats a tag, its first value is used. Tags named like a
+// base column, ignoring case, get an "Attr:" prefix so every header is unique. Nil blocks are skipped.
+func (f *ClipboardFormatter) FormatBlocksAsCSV(blocks []*data.BlockInfo) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, block := range blocks {
+		if block == nil {
+			continue
+		}
+		for _, attr := range block.Attributes {
+			if !seen[attr.Tag] {
+				seen[attr.Tag] = true
+				tags = append(tags, attr.Tag)
+			}
+		}
+	}
+
+	header := append([]string{}, scheduleBaseColumns...)
+	for _, tag := range tags {
+		header = append(header, scheduleTagHeader(tag))
+	}
+	result := []string{f.csvRow(header...)}
+
+	for _, block := range blocks {
+		if block == nil {
+			continue
+		}
+
+		values := make(map[string]string, len(block.Attributes))
+		for _, attr := range block.Attributes {
+			if _, ok := values[attr.Tag]; !ok {
+				values[attr.Tag] = attr.Value
+			}
+		}
+
+		cells := []string{block.Name, block.Layer}
+		for _, tag := range tags {
+			cells = append(cells, values[tag])
+		}
+		result = append(result, f.csvRow(cells...))
+	}
+
+	return result
+}
+
+// scheduleTagHeader returns the column header of an attribute tag, prefixed when it
+// would collide with a base column
+func scheduleTagHeader(tag string) string {
+	for _, column := range scheduleBaseColumns {
+		if strings.EqualFold(tag, column) {
+			return "Attr:" + tag
+		}
+	}
+	return tag
+}
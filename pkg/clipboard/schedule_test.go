@@ -0,0 +1,36 @@
+package clipboard
+
+import (
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatBlocksAsCSV(t *testing.T) {
+	blocks := []*data.BlockInfo{
+		{Name: "TITLE", Layer: "Border", Attributes: []data.AttributeInfo{
+			{Tag: "DWG_NO", Value: "A-101"},
+			{Tag: "NAME", Value: "Ground floor, east"},
+			{Tag: "DWG_NO", Value: "ignored duplicate"},
+		}},
+		nil,
+		{Name: "PUMP", Layer: "Equipment", Attributes: []data.AttributeInfo{
+			{Tag: "FLOW", Value: "12"},
+			{Tag: "DWG_NO", Value: "M-7"},
+			{Tag: "layer", Value: "L2"},
+		}},
+		{Name: "VALVE", Layer: "Equipment"},
+	}
+
+	formatter := NewClipboardFormatter()
+	assert.Equal(t, []string{
+		"Name,Layer,DWG_NO,Attr:NAME,FLOW,Attr:layer",
+		`TITLE,Border,A-101,"Ground floor, east",,`,
+		"PUMP,Equipment,M-7,,12,L2",
+		"VALVE,Equipment,,,,",
+	}, formatter.FormatBlocksAsCSV(blocks))
+
+	formatter.SetDelimiter(';')
+	assert.Equal(t, []string{"Name;Layer"}, formatter.FormatBlocksAsCSV(nil), "the header is written even without blocks")
+}
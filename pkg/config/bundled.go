@@ -103,7 +103,7 @@ func GetBundledConverterForPlatform(goos, goarch string) string {
 	return filepath.Join("assets", "oda_converter", goos, executableName)
 }
 
-// LoadConfigWithPriority loads configuration with priority order: env var > bundled > default
+// LoadConfigWithPriority loads configuration with priority order: env var > bundled > discovered > default
 func LoadConfigWithPriority() (*AppConfig, error) {
 	config := &AppConfig{}
 
@@ -119,7 +119,13 @@ func LoadConfigWithPriority() (*AppConfig, error) {
 		return config, nil
 	}
 
-	// Priority 3: Default path
+	// Priority 3: Converter installed in a usual location
+	if discoveredPath, found := DiscoverODAConverter(); found {
+		config.ODAConverterPath = discoveredPath
+		return config, nil
+	}
+
+	// Priority 4: Default path
 	config.ODAConverterPath = getDefaultODAConverterPath()
 	return config, nil
 }
@@ -168,8 +168,11 @@ func TestConfigWithBundledConverter(t *testing.T) {
 	}
 }
 
-// TestBundledConverterPriority tests the priority order: env var > bundled > default
+// TestBundledConverterPriority tests the priority order: env var > bundled > discovered > default
 func TestBundledConverterPriority(t *testing.T) {
+	// Keep converters installed on this machine out of the result
+	withSearchPatterns(t)
+
 	tests := []struct {
 		name          string
 		envVarSet     bool
@@ -23,7 +23,7 @@ func getDefaultODAConverterPath() string {
 	return "/usr/local/bin/ODAFileConverter"
 }
 
-// LoadConfig loads the application configuration with priority: env var > bundled > default
+// LoadConfig loads the application configuration with priority: env var > bundled > discovered > default
 func LoadConfig() (*AppConfig, error) {
 	// Priority 1: Environment variable
 	if envPath := os.Getenv("ODA_CONVERTER_PATH"); envPath != "" {
@@ -39,7 +39,14 @@ func LoadConfig() (*AppConfig, error) {
 		}, nil
 	}
 
-	// Priority 3: Default path
+	// Priority 3: Converter installed in a usual location
+	if discoveredPath, found := DiscoverODAConverter(); found {
+		return &AppConfig{
+			ODAConverterPath: discoveredPath,
+		}, nil
+	}
+
+	// Priority 4: Default path
 	return &AppConfig{
 		ODAConverterPath: DefaultODAConverterPath,
 	}, nil
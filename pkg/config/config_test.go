@@ -10,6 +10,9 @@ import (
 )
 
 func TestLoadConfig(t *testing.T) {
+	// Keep converters installed on this machine out of the result
+	withSearchPatterns(t)
+
 	// Create a temporary file for testing
 	tempFile, err := os.CreateTemp("", "test-converter-*.exe")
 	require.NoError(t, err, "Failed to create temp file")
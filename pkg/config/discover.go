@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+)
+
+// converterSearchPatterns are the glob patterns DiscoverODAConverter scans, in order;
+// a variable so tests can replace it
+var converterSearchPatterns = defaultConverterSearchPatterns(runtime.GOOS)
+
+// defaultConverterSearchPatterns returns where the ODA File Converter is usually installed on goos
+func defaultConverterSearchPatterns(goos string) []string {
+	switch goos {
+	case "windows":
+		var patterns []string
+		for _, env := range []string{"ProgramFiles", "ProgramFiles(x86)"} {
+			if dir := os.Getenv(env); dir != "" {
+				patterns = append(patterns, filepath.Join(dir, "ODA", "ODAFileConverter*", "ODAFileConverter.exe"))
+			}
+		}
+		return append(patterns,
+			`C:\Program Files\ODA\ODAFileConverter*\ODAFileConverter.exe`,
+			`C:\Program Files (x86)\ODA\ODAFileConverter*\ODAFileConverter.exe`,
+		)
+	case "darwin":
+		return []string{
+			"/Applications/ODAFileConverter*.app/Contents/MacOS/ODAFileConverter",
+			"/usr/local/bin/ODAFileConverter",
+			"/opt/homebrew/bin/ODAFileConverter",
+		}
+	default:
+		return []string{
+			"/usr/bin/ODAFileConverter",
+			"/usr/local/bin/ODAFileConverter",
+			"/opt/ODAFileConverter*/ODAFileConverter",
+			"/opt/ODA/ODAFileConverter*/ODAFileConverter",
+		}
+	}
+}
+
+// DiscoverODAConverter scans the usual install locations for an ODA File Converter and
+// returns the first executable one found. Where a pattern matches several versioned
+// directories, the highest version is tried first.
+func DiscoverODAConverter() (string, bool) {
+	seen := make(map[string]bool)
+	for _, pattern := range converterSearchPatterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			continue
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+
+		for _, match := range matches {
+			if seen[match] {
+				continue
+			}
+			seen[match] = true
+			if ValidateBundledConverter(match) {
+				return match, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withSearchPatterns replaces the discovery search patterns for the duration of the test
+func withSearchPatterns(t *testing.T, patterns ...string) {
+	t.Helper()
+	original := converterSearchPatterns
+	converterSearchPatterns = patterns
+	t.Cleanup(func() { converterSearchPatterns = original })
+}
+
+// writeConverter creates a stand-in converter executable at path
+func writeConverter(t *testing.T, path string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755))
+}
+
+func TestDiscoverODAConverter(t *testing.T) {
+	t.Run("nothing installed", func(t *testing.T) {
+		dir := t.TempDir()
+		withSearchPatterns(t, filepath.Join(dir, "ODA*", "ODAFileConverter"))
+
+		path, found := DiscoverODAConverter()
+		assert.False(t, found)
+		assert.Empty(t, path)
+	})
+
+	t.Run("first pattern with a match wins", func(t *testing.T) {
+		dir := t.TempDir()
+		usrBin := filepath.Join(dir, "usr", "bin", "ODAFileConverter")
+		opt := filepath.Join(dir, "opt", "ODAFileConverter_25.4", "ODAFileConverter")
+		writeConverter(t, usrBin)
+		writeConverter(t, opt)
+		withSearchPatterns(t, usrBin, filepath.Join(dir, "opt", "ODAFileConverter*", "ODAFileConverter"))
+
+		path, found := DiscoverODAConverter()
+		require.True(t, found)
+		assert.Equal(t, usrBin, path)
+	})
+
+	t.Run("highest version preferred", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConverter(t, filepath.Join(dir, "ODAFileConverter_24.1", "ODAFileConverter"))
+		newest := filepath.Join(dir, "ODAFileConverter_25.4", "ODAFileConverter")
+		writeConverter(t, newest)
+		withSearchPatterns(t, filepath.Join(dir, "ODAFileConverter*", "ODAFileConverter"))
+
+		path, found := DiscoverODAConverter()
+		require.True(t, found)
+		assert.Equal(t, newest, path)
+	})
+
+	t.Run("directories are skipped", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "ODAFileConverter"), 0o755))
+		withSearchPatterns(t, filepath.Join(dir, "ODAFileConverter"))
+
+		_, found := DiscoverODAConverter()
+		assert.False(t, found)
+	})
+}
+
+func TestLoadConfigWithPriority_Discovered(t *testing.T) {
+	t.Setenv("ODA_CONVERTER_PATH", "")
+	if _, exists := DetectBundledConverter(); exists {
+		t.Skip("bundled converter takes priority on this platform")
+	}
+
+	dir := t.TempDir()
+	installed := filepath.Join(dir, "ODAFileConverter")
+	writeConverter(t, installed)
+	withSearchPatterns(t, installed)
+
+	config, err := LoadConfigWithPriority()
+	require.NoError(t, err)
+	assert.Equal(t, installed, config.ODAConverterPath)
+}
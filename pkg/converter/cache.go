@@ -0,0 +1,128 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// cacheEntry records the DXF produced for a DWG file in a given state.
+type cacheEntry struct {
+	modTime time.Time
+	size    int64
+	dxfPath string
+}
+
+// cacheKey identifies a conversion by the absolute paths of the DWG file and the
+// directory its DXF file is written to.
+type cacheKey struct {
+	dwgPath   string
+	outputDir string
+}
+
+// ConversionCache remembers the DXF file produced for each DWG file and output
+// directory so that unchanged drawings are not converted again. An entry is valid
+// while the DWG file's modification time and size are unchanged and the DXF file
+// still exists. It is safe for concurrent use.
+type ConversionCache struct {
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+// NewConversionCache creates an empty conversion cache.
+func NewConversionCache() *ConversionCache {
+	return &ConversionCache{
+		entries: make(map[cacheKey]cacheEntry),
+	}
+}
+
+// Get returns the cached DXF path for the DWG file converted into outputDir if the
+// entry is still valid. Stale entries are removed.
+func (c *ConversionCache) Get(dwgPath, outputDir string) (string, bool) {
+	key, err := newCacheKey(dwgPath, outputDir)
+	if err != nil {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+
+	info, err := os.Stat(dwgPath)
+	if err != nil || !info.ModTime().Equal(entry.modTime) || info.Size() != entry.size {
+		delete(c.entries, key)
+		return "", false
+	}
+
+	if _, err := os.Stat(entry.dxfPath); err != nil {
+		delete(c.entries, key)
+		return "", false
+	}
+
+	return entry.dxfPath, true
+}
+
+// Put records the DXF path produced for the DWG file, in its current state, in outputDir.
+func (c *ConversionCache) Put(dwgPath, outputDir, dxfPath string) error {
+	key, err := newCacheKey(dwgPath, outputDir)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(dwgPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat DWG file: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		dxfPath: dxfPath,
+	}
+	return nil
+}
+
+// Invalidate removes the entries for the DWG file, whatever directory it was converted into.
+func (c *ConversionCache) Invalidate(dwgPath string) {
+	absPath, err := filepath.Abs(dwgPath)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.dwgPath == absPath {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *ConversionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// newCacheKey returns the key of the conversion of a DWG file into outputDir.
+func newCacheKey(dwgPath, outputDir string) (cacheKey, error) {
+	absPath, err := filepath.Abs(dwgPath)
+	if err != nil {
+		return cacheKey{}, fmt.Errorf("failed to get absolute path for DWG file: %w", err)
+	}
+	absDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return cacheKey{}, fmt.Errorf("failed to get absolute path for output directory: %w", err)
+	}
+	return cacheKey{dwgPath: absPath, outputDir: absDir}, nil
+}
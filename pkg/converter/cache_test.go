@@ -0,0 +1,135 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversionCache(t *testing.T) {
+	tempDir := t.TempDir()
+	dwgPath := filepath.Join(tempDir, "drawing.dwg")
+	dxfPath := filepath.Join(tempDir, "drawing.dxf")
+	require.NoError(t, os.WriteFile(dwgPath, []byte("dwg"), 0644))
+	require.NoError(t, os.WriteFile(dxfPath, []byte("dxf"), 0644))
+
+	cache := NewConversionCache()
+	_, ok := cache.Get(dwgPath, tempDir)
+	assert.False(t, ok, "Empty cache should miss")
+
+	require.NoError(t, cache.Put(dwgPath, tempDir, dxfPath))
+	got, ok := cache.Get(dwgPath, tempDir)
+	assert.True(t, ok)
+	assert.Equal(t, dxfPath, got)
+
+	// A new modification time invalidates the entry
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(dwgPath, later, later))
+	_, ok = cache.Get(dwgPath, tempDir)
+	assert.False(t, ok, "Changed mtime should miss")
+	assert.Equal(t, 0, cache.Len(), "Stale entry should be removed")
+
+	// A removed DXF file invalidates the entry
+	require.NoError(t, cache.Put(dwgPath, tempDir, dxfPath))
+	require.NoError(t, os.Remove(dxfPath))
+	_, ok = cache.Get(dwgPath, tempDir)
+	assert.False(t, ok, "Missing DXF should miss")
+
+	// Another output directory is a different conversion
+	require.NoError(t, os.WriteFile(dxfPath, []byte("dxf"), 0644))
+	require.NoError(t, cache.Put(dwgPath, tempDir, dxfPath))
+	_, ok = cache.Get(dwgPath, filepath.Join(tempDir, "other"))
+	assert.False(t, ok, "A different output directory should miss")
+
+	// Invalidate removes the DWG file's entries explicitly
+	cache.Invalidate(dwgPath)
+	assert.Equal(t, 0, cache.Len())
+
+	assert.Error(t, cache.Put(filepath.Join(tempDir, "missing.dwg"), tempDir, dxfPath))
+}
+
+func TestConversionCache_Concurrent(t *testing.T) {
+	tempDir := t.TempDir()
+	dxfPath := filepath.Join(tempDir, "out.dxf")
+	require.NoError(t, os.WriteFile(dxfPath, []byte("dxf"), 0644))
+
+	cache := NewConversionCache()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		dwgPath := filepath.Join(tempDir, fmt.Sprintf("drawing%d.dwg", i))
+		require.NoError(t, os.WriteFile(dwgPath, []byte("dwg"), 0644))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cache.Put(dwgPath, tempDir, dxfPath))
+			_, ok := cache.Get(dwgPath, tempDir)
+			assert.True(t, ok)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 20, cache.Len())
+}
+
+func TestDWGConverter_ConvertToDXF_UsesCache(t *testing.T) {
+	originalCommand := commandContext
+	defer func() { commandContext = originalCommand }()
+
+	tempDir := t.TempDir()
+	dwgPath := filepath.Join(tempDir, "test.dwg")
+	outputDir := filepath.Join(tempDir, "output")
+	require.NoError(t, os.WriteFile(dwgPath, []byte("test content"), 0644))
+
+	runs := 0
+	commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+		runs++
+		_ = os.WriteFile(filepath.Join(outputDir, "test.dxf"), []byte("DXF content"), 0644)
+		return exec.CommandContext(ctx, "echo", "mock command")
+	}
+
+	converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+	require.NoError(t, err)
+	converter.SetCache(NewConversionCache())
+
+	first, err := converter.ConvertToDXF(dwgPath, outputDir)
+	require.NoError(t, err)
+	second, err := converter.ConvertToDXF(dwgPath, outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, runs, "Unchanged DWG should not be converted again")
+
+	// Touching the DWG forces a new conversion
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(dwgPath, later, later))
+	_, err = converter.ConvertToDXF(dwgPath, outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, runs)
+
+	// Another output directory gets its own DXF file
+	otherDir := filepath.Join(tempDir, "other")
+	outputDir = otherDir
+	third, err := converter.ConvertToDXF(dwgPath, otherDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(otherDir, "test.dxf"), third)
+	assert.Equal(t, 3, runs, "A new output directory should be converted into")
+	outputDir = filepath.Join(tempDir, "output")
+
+	// Other DXF versions bypass the cache
+	_, err = converter.ConvertToDXFVersion(dwgPath, outputDir, "ACAD2000")
+	require.NoError(t, err)
+	assert.Equal(t, 4, runs)
+
+	// Without a cache every call converts
+	converter.SetCache(nil)
+	_, err = converter.ConvertToDXF(dwgPath, outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, 5, runs)
+}
@@ -5,13 +5,57 @@ package converter
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/remym/go-dwg-extractor/pkg/logging"
+)
+
+// ErrFormatUnsupported is returned when the converter reports it cannot produce the requested output format.
+var ErrFormatUnsupported = errors.New("output format not supported by the converter")
+
+// Output file types understood by the ODA File Converter
+const (
+	fileTypeDXF = "DXF"
+	fileTypePDF = "PDF"
 )
 
+// DefaultDXFVersion is the DXF version written when none is requested.
+const DefaultDXFVersion = "ACAD2018"
+
+// DefaultArgTemplate is the ODA File Converter argument layout used unless another is set:
+// InputFolder OutputFolder OutputVersion OutputFileType RecurseFolder AuditFile InputFilter.
+var DefaultArgTemplate = []string{"{input}", "{output}", "{version}", "{filetype}", "0", "0", "{filter}"}
+
+// SupportedDXFVersions lists the output versions accepted by the ODA File Converter, oldest first.
+var SupportedDXFVersions = []string{
+	"ACAD9", "ACAD10", "ACAD12", "ACAD13", "ACAD14",
+	"ACAD2000", "ACAD2004", "ACAD2007", "ACAD2010", "ACAD2013", "ACAD2018",
+}
+
+// ValidateDXFVersion returns an error if version is not one of SupportedDXFVersions.
+// Versions are matched case-insensitively.
+func ValidateDXFVersion(version string) error {
+	for _, supported := range SupportedDXFVersions {
+		if strings.EqualFold(version, supported) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown DXF version %q: supported versions are %s", version, strings.Join(SupportedDXFVersions, ", "))
+}
+
+// cancelWaitDelay is how long a cancelled conversion waits for the converter's output to
+// close after the converter is killed
+const cancelWaitDelay = time.Second
+
 // commandContext is a variable that holds the function to create commands
 // This is used to allow mocking in tests
 var commandContext = exec.CommandContext
@@ -21,28 +65,174 @@ type DWGConverter interface {
 	// ConvertToDXF converts a DWG file to DXF format.
 	// It returns the path to the converted DXF file or an error if the conversion fails.
 	ConvertToDXF(dwgPath, outputDir string) (string, error)
+
+	// ConvertToDXFContext converts a DWG file to DXF format like ConvertToDXF.
+	// Cancelling ctx stops the conversion and returns ctx's error.
+	ConvertToDXFContext(ctx context.Context, dwgPath, outputDir string) (string, error)
+
+	// ConvertToDXFVersion converts a DWG file to the given DXF version, such as ACAD2000.
+	// It returns an error for versions not listed in SupportedDXFVersions.
+	ConvertToDXFVersion(dwgPath, outputDir, version string) (string, error)
+
+	// ConvertToPDF converts a DWG file to a PDF rendering.
+	// It returns the path to the PDF file or an error if the conversion fails.
+	ConvertToPDF(dwgPath, outputDir string) (string, error)
+
+	// ConvertDirectoryConcurrent converts every DWG file in inputDir using up to workers
+	// concurrent conversions. It returns the paths of the DXF files produced and an
+	// error describing any files that failed.
+	ConvertDirectoryConcurrent(ctx context.Context, inputDir, outputDir string, workers int) ([]string, error)
+
+	// SetCache sets the cache consulted before converting. A nil cache disables caching.
+	SetCache(cache *ConversionCache)
+
+	// SetLogger sets the logger that receives converter command lines and timings.
+	// A nil logger disables logging.
+	SetLogger(logger logging.Logger)
+
+	// SetArgTemplate sets the arguments passed to the converter. The placeholders {input},
+	// {output}, {version}, {filetype} and {filter} are substituted at call time.
+	// An empty template restores DefaultArgTemplate.
+	SetArgTemplate(template []string)
 }
 
 // odaconverter implements the DWGConverter interface.
 type odaconverter struct {
-	converterPath string // Path to the ODA File Converter executable
+	converterPath string           // Path to the ODA File Converter executable
+	cache         *ConversionCache // Optional cache of previous conversions
+	logger        logging.Logger   // Receives command lines and timings; never nil
+	argTemplate   []string         // Converter arguments with placeholders; nil means DefaultArgTemplate
+}
+
+// Options adjust how NewDWGConverterWithOptions creates a converter.
+type Options struct {
+	// SkipValidation accepts a converter path that does not exist or is not executable,
+	// for test doubles that never run the converter.
+	SkipValidation bool
 }
 
 // NewDWGConverter creates a new instance of DWGConverter.
-// It returns an error if the converter path is empty.
+// It returns an error if the converter path is empty, does not exist or is not executable.
 func NewDWGConverter(converterPath string) (DWGConverter, error) {
+	return NewDWGConverterWithOptions(converterPath, Options{})
+}
+
+// NewDWGConverterWithOptions creates a new instance of DWGConverter adjusted by opts.
+// It returns an error if the converter path is empty, or fails ValidateConverterPath
+// unless opts.SkipValidation is set.
+func NewDWGConverterWithOptions(converterPath string, opts Options) (DWGConverter, error) {
 	if converterPath == "" {
 		return nil, fmt.Errorf("converter path cannot be empty")
 	}
+	if !opts.SkipValidation {
+		if err := ValidateConverterPath(converterPath); err != nil {
+			return nil, err
+		}
+	}
 
 	return &odaconverter{
 		converterPath: converterPath,
+		logger:        logging.Nop(),
 	}, nil
 }
 
+// ValidateConverterPath returns an error if converterPath is not an existing executable file.
+// Execute permission is not checked on Windows.
+func ValidateConverterPath(converterPath string) error {
+	info, err := os.Stat(converterPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("converter not found at %s", converterPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check converter at %s: %w", converterPath, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("converter at %s is a directory, not an executable", converterPath)
+	}
+	if runtime.GOOS != "windows" && info.Mode()&0111 == 0 {
+		return fmt.Errorf("converter at %s is not executable", converterPath)
+	}
+	return nil
+}
+
+// SetLogger sets the logger that receives converter command lines and timings.
+// A nil logger disables logging.
+func (c *odaconverter) SetLogger(logger logging.Logger) {
+	c.logger = logging.OrNop(logger)
+}
+
+// SetArgTemplate sets the arguments passed to the converter. The placeholders {input},
+// {output}, {version}, {filetype} and {filter} are substituted at call time.
+// An empty template restores DefaultArgTemplate.
+func (c *odaconverter) SetArgTemplate(template []string) {
+	if len(template) == 0 {
+		c.argTemplate = nil
+		return
+	}
+	c.argTemplate = append([]string(nil), template...)
+}
+
+// converterArgs substitutes the placeholders of the argument template
+func (c *odaconverter) converterArgs(inputDir, outputDir, version, fileType, inputFilter string) []string {
+	template := c.argTemplate
+	if template == nil {
+		template = DefaultArgTemplate
+	}
+
+	replacer := strings.NewReplacer(
+		"{input}", inputDir,
+		"{output}", outputDir,
+		"{version}", version,
+		"{filetype}", fileType,
+		"{filter}", inputFilter,
+	)
+	args := make([]string, len(template))
+	for i, arg := range template {
+		args[i] = replacer.Replace(arg)
+	}
+	return args
+}
+
+// SetCache sets the cache consulted before converting. A nil cache disables caching.
+func (c *odaconverter) SetCache(cache *ConversionCache) {
+	c.cache = cache
+}
+
 // ConvertToDXF converts the specified DWG file to DXF format using the ODA File Converter.
 // It returns the path to the converted DXF file or an error if the conversion fails.
+// When a cache is set, a DXF produced earlier for the unchanged DWG file is returned instead.
 func (c *odaconverter) ConvertToDXF(dwgPath, outputDir string) (string, error) {
+	return c.ConvertToDXFVersion(dwgPath, outputDir, DefaultDXFVersion)
+}
+
+// ConvertToDXFContext converts the specified DWG file to DXF format like ConvertToDXF.
+// Cancelling ctx stops the converter and returns ctx's error.
+func (c *odaconverter) ConvertToDXFContext(ctx context.Context, dwgPath, outputDir string) (string, error) {
+	return c.convertToDXF(ctx, dwgPath, outputDir, DefaultDXFVersion)
+}
+
+// ConvertToDXFVersion converts the specified DWG file to the given DXF version.
+// The cache only holds conversions to DefaultDXFVersion, so other versions always run the converter.
+func (c *odaconverter) ConvertToDXFVersion(dwgPath, outputDir, version string) (string, error) {
+	return c.convertToDXF(context.Background(), dwgPath, outputDir, version)
+}
+
+// convertToDXF checks the arguments of a single DWG to DXF conversion and runs it
+func (c *odaconverter) convertToDXF(ctx context.Context, dwgPath, outputDir, version string) (string, error) {
+	if dwgPath == "" {
+		return "", fmt.Errorf("DWG path cannot be empty")
+	}
+	if err := ValidateDXFVersion(version); err != nil {
+		return "", err
+	}
+
+	return c.convertFile(ctx, dwgPath, outputDir, "*.DWG", strings.ToUpper(version))
+}
+
+// ConvertToPDF converts the specified DWG file to PDF using the ODA File Converter.
+// It returns the path to the PDF file, or an error wrapping ErrFormatUnsupported
+// when the installed converter cannot write PDF. PDF conversions are not cached.
+func (c *odaconverter) ConvertToPDF(dwgPath, outputDir string) (string, error) {
 	if dwgPath == "" {
 		return "", fmt.Errorf("DWG path cannot be empty")
 	}
@@ -52,6 +242,123 @@ func (c *odaconverter) ConvertToDXF(dwgPath, outputDir string) (string, error) {
 		return "", fmt.Errorf("input file does not exist: %s", dwgPath)
 	}
 
+	return c.convert(context.Background(), dwgPath, outputDir, "*.DWG", DefaultDXFVersion, fileTypePDF)
+}
+
+// ConvertDirectoryConcurrent converts every DWG file in inputDir using up to workers
+// concurrent conversions, defaulting to the number of CPUs when workers is not positive.
+// Cancelling ctx stops new conversions from starting. It returns the paths of the DXF
+// files produced, in no particular order, and an error describing any files that failed.
+func (c *odaconverter) ConvertDirectoryConcurrent(ctx context.Context, inputDir, outputDir string, workers int) ([]string, error) {
+	dwgFiles, err := findDWGFiles(inputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	var (
+		mu       sync.Mutex
+		dxfPaths []string
+		errs     []error
+		wg       sync.WaitGroup
+	)
+
+	jobs := make(chan string)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for dwgPath := range jobs {
+				// Restrict each run to its own file so workers don't convert each other's drawings
+				dxfPath, err := c.convertFile(ctx, dwgPath, outputDir, filepath.Base(dwgPath), DefaultDXFVersion)
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(dwgPath), err))
+				} else {
+					dxfPaths = append(dxfPaths, dxfPath)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, dwgPath := range dwgFiles {
+		// Check first so a cancelled context never races with a ready worker
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case jobs <- dwgPath:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return dxfPaths, errors.Join(errs...)
+}
+
+// findDWGFiles returns the DWG files directly inside dir, sorted by name.
+func findDWGFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".dwg") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+	return files, nil
+}
+
+// convertFile converts a single DWG file to the given DXF version, consulting the cache when
+// one is set and the version is the default. inputFilter selects the files in the DWG file's
+// directory that the converter processes.
+func (c *odaconverter) convertFile(ctx context.Context, dwgPath, outputDir, inputFilter, version string) (string, error) {
+	// Check if the input file exists
+	if _, err := os.Stat(dwgPath); os.IsNotExist(err) {
+		return "", fmt.Errorf("input file does not exist: %s", dwgPath)
+	}
+
+	cache := c.cache
+	if version != DefaultDXFVersion {
+		cache = nil
+	}
+
+	if cache != nil {
+		if dxfPath, ok := cache.Get(dwgPath, outputDir); ok {
+			return dxfPath, nil
+		}
+	}
+
+	dxfPath, err := c.convert(ctx, dwgPath, outputDir, inputFilter, version, fileTypeDXF)
+	if err != nil {
+		return "", err
+	}
+
+	if cache != nil {
+		if err := cache.Put(dwgPath, outputDir, dxfPath); err != nil {
+			return "", fmt.Errorf("failed to cache conversion: %w", err)
+		}
+	}
+
+	return dxfPath, nil
+}
+
+// convert runs the ODA File Converter for the DWG file and locates the produced file of the given type.
+func (c *odaconverter) convert(ctx context.Context, dwgPath, outputDir, inputFilter, version, fileType string) (string, error) {
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create output directory: %w", err)
@@ -64,11 +371,12 @@ func (c *odaconverter) ConvertToDXF(dwgPath, outputDir string) (string, error) {
 		baseName = baseName[0 : len(baseName)-len(ext)]
 	}
 
-	// Generate DXF file path (same name as DWG but with .dxf extension)
-	dxfPath := filepath.Join(outputDir, baseName+".dxf")
+	// Generate the output file path (same name as DWG but with the output type's extension)
+	outExt := "." + strings.ToLower(fileType)
+	dxfPath := filepath.Join(outputDir, baseName+outExt)
 
 	// Create a context with timeout for the conversion
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
 	// Get absolute path for the input file directory
@@ -85,19 +393,18 @@ func (c *odaconverter) ConvertToDXF(dwgPath, outputDir string) (string, error) {
 	}
 
 	// Prepare the command to run the ODA File Converter
-	// Command format from ODA dialog: InputFolder OutputFolder OutputVersion OutputFileType RecurseFolder AuditFile [InputFilter]
+	// Default format from ODA dialog: InputFolder OutputFolder OutputVersion OutputFileType RecurseFolder AuditFile [InputFilter]
 	// Example: "C:\input" "C:\output" "ACAD2018" "DXF" "0" "0" "*.DWG"
-	cmd := commandContext(
-		ctx,
-		c.converterPath,
-		absInputDir,  // Input Folder (absolute path, no manual quotes)
-		absOutputDir, // Output Folder (absolute path, no manual quotes)
-		"ACAD2018",   // Output version
-		"DXF",        // Output File type
-		"0",          // Recurse Input Folder (0 = no)
-		"0",          // Audit each file (0 = no)
-		"*.DWG",      // Input files filter
-	)
+	// Folders are absolute paths, without manual quotes
+	args := c.converterArgs(absInputDir, absOutputDir, version, fileType, inputFilter)
+	cmd := commandContext(ctx, c.converterPath, args...)
+	// Kill the converter when ctx is cancelled, and stop waiting for its output soon after
+	// in case a process it started still holds the output open
+	cmd.Cancel = func() error {
+		return cmd.Process.Kill()
+	}
+	cmd.WaitDelay = cancelWaitDelay
+	c.logger.Debug("running ODA File Converter", "command", formatCommandLine(c.converterPath, args))
 
 	// Set up output buffers
 	var stdout, stderr bytes.Buffer
@@ -105,18 +412,35 @@ func (c *odaconverter) ConvertToDXF(dwgPath, outputDir string) (string, error) {
 	cmd.Stderr = &stderr
 
 	// Run the command
-	if err := cmd.Run(); err != nil {
+	start := time.Now()
+	runErr := cmd.Run()
+	duration := time.Since(start)
+	// Output mentioning an unsupported format only counts when no file came of it, since
+	// a successful run may still report objects it skipped
+	output := stdout.String() + stderr.String()
+	if runErr != nil && ctx.Err() == nil && unsupportedFormat(output) {
+		return "", c.unsupportedFormatError(fileType, dwgPath)
+	}
+	if runErr != nil && ctx.Err() != nil {
+		c.logger.Error("conversion stopped", "file", dwgPath, "duration", duration, "error", ctx.Err())
+		return "", fmt.Errorf("conversion of %s stopped: %w", dwgPath, ctx.Err())
+	}
+	if runErr != nil {
+		c.logger.Error("conversion failed", "file", dwgPath, "duration", duration, "error", runErr, "stderr", stderr.String())
 		// If the command failed, include stderr in the error message
-		return "", fmt.Errorf("failed to convert DWG to DXF: %w\n%s", err, stderr.String())
+		return "", fmt.Errorf("failed to convert DWG to %s: %w\n%s", fileType, runErr, stderr.String())
 	}
 
 	// Verify the output file was created
 	if _, err := os.Stat(dxfPath); os.IsNotExist(err) {
 		// If the expected DXF file doesn't exist, check for other possible names
 		// Sometimes the converter might use a different naming convention
-		files, err := filepath.Glob(filepath.Join(outputDir, "*.dxf"))
+		files, err := filepath.Glob(filepath.Join(outputDir, "*"+outExt))
 		if err != nil || len(files) == 0 {
-			return "", fmt.Errorf("conversion failed: no DXF file was generated")
+			if unsupportedFormat(output) {
+				return "", c.unsupportedFormatError(fileType, dwgPath)
+			}
+			return "", fmt.Errorf("conversion failed: no %s file was generated", fileType)
 		}
 
 		// Filter files to only include those that were likely created by this conversion
@@ -137,11 +461,11 @@ func (c *odaconverter) ConvertToDXF(dwgPath, outputDir string) (string, error) {
 		}
 
 		if len(recentFiles) == 0 {
-			return "", fmt.Errorf("conversion failed: no recently created DXF file was found")
+			return "", fmt.Errorf("conversion failed: no recently created %s file was found", fileType)
 		}
 
 		// Prefer files with the expected base name, but accept any recent file
-		expectedBase := baseName + ".dxf"
+		expectedBase := baseName + outExt
 		for _, file := range recentFiles {
 			if filepath.Base(file) == expectedBase {
 				dxfPath = file
@@ -150,10 +474,36 @@ func (c *odaconverter) ConvertToDXF(dwgPath, outputDir string) (string, error) {
 		}
 
 		// If no file with expected name found, use the first recent file
-		if dxfPath == filepath.Join(outputDir, baseName+".dxf") {
+		if dxfPath == filepath.Join(outputDir, baseName+outExt) {
 			dxfPath = recentFiles[0]
 		}
 	}
 
+	c.logger.Info("converted DWG", "file", dwgPath, "output", dxfPath, "duration", duration)
 	return dxfPath, nil
 }
+
+// formatCommandLine joins a command and its arguments into one line, quoting empty arguments
+// and those containing whitespace or quotes so the line can be pasted into a bug report
+func formatCommandLine(command string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	for _, arg := range append([]string{command}, args...) {
+		if arg == "" || strings.ContainsAny(arg, " \t\"") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// unsupportedFormatError logs and returns ErrFormatUnsupported for fileType
+func (c *odaconverter) unsupportedFormatError(fileType, dwgPath string) error {
+	c.logger.Error("converter does not support output format", "format", fileType, "file", dwgPath)
+	return fmt.Errorf("%w: %s", ErrFormatUnsupported, fileType)
+}
+
+// unsupportedFormat reports whether converter output says the requested format is not supported
+func unsupportedFormat(output string) bool {
+	output = strings.ToLower(output)
+	return strings.Contains(output, "unsupported") || strings.Contains(output, "not supported")
+}
@@ -2,10 +2,15 @@ package converter
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -164,7 +169,7 @@ func TestDWGConverter_ConvertToDXF(t *testing.T) {
 			}
 
 			// Create a new converter instance
-			converter, err := NewDWGConverter("path/to/odaconverter")
+			converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
 			require.NoError(t, err)
 			require.NotNil(t, converter)
 
@@ -197,16 +202,293 @@ func TestDWGConverter_ConvertToDXF(t *testing.T) {
 	}
 }
 
+func TestDWGConverter_ConvertToDXFVersion(t *testing.T) {
+	originalCommand := commandContext
+	defer func() { commandContext = originalCommand }()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+	tests := []struct {
+		name            string
+		version         string
+		expectedVersion string
+		errContains     string
+	}{
+		{name: "older version", version: "ACAD2000", expectedVersion: "ACAD2000"},
+		{name: "lowercase version is normalized", version: "acad2010", expectedVersion: "ACAD2010"},
+		{name: "default version", version: DefaultDXFVersion, expectedVersion: "ACAD2018"},
+		{name: "unknown version", version: "ACAD2099", errContains: `unknown DXF version "ACAD2099"`},
+		{name: "empty version", version: "", errContains: "unknown DXF version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			var gotVersion string
+			commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+				gotVersion = args[2]
+				_ = os.WriteFile(filepath.Join(outputDir, "test.dxf"), []byte("DXF content"), 0644)
+				return exec.CommandContext(ctx, "echo", "mock command")
+			}
+
+			converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+			require.NoError(t, err)
+
+			dxfPath, err := converter.ConvertToDXFVersion(testDWGPath, outputDir, tt.version)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Empty(t, gotVersion, "Converter should not run for an unknown version")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(outputDir, "test.dxf"), dxfPath)
+			assert.Equal(t, tt.expectedVersion, gotVersion)
+		})
+	}
+}
+
+func TestDWGConverter_ConvertToPDF(t *testing.T) {
+	originalCommand := commandContext
+	defer func() { commandContext = originalCommand }()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+	tests := []struct {
+		name        string
+		dwgPath     string
+		command     func(ctx context.Context, outputDir string, args []string) *exec.Cmd
+		expectError error
+		errContains string
+	}{
+		{
+			name:    "successful conversion",
+			dwgPath: testDWGPath,
+			command: func(ctx context.Context, outputDir string, args []string) *exec.Cmd {
+				assert.Equal(t, "PDF", args[3]) // File type
+				_ = os.WriteFile(filepath.Join(outputDir, "test.pdf"), []byte("%PDF"), 0644)
+				return exec.CommandContext(ctx, "echo", "mock command")
+			},
+		},
+		{
+			name:    "converter reports unsupported format",
+			dwgPath: testDWGPath,
+			command: func(ctx context.Context, outputDir string, args []string) *exec.Cmd {
+				return exec.CommandContext(ctx, "sh", "-c", "echo 'Output file type PDF is not supported' >&2; exit 1")
+			},
+			expectError: ErrFormatUnsupported,
+		},
+		{
+			name:    "converter reports unsupported format without exit code",
+			dwgPath: testDWGPath,
+			command: func(ctx context.Context, outputDir string, args []string) *exec.Cmd {
+				return exec.CommandContext(ctx, "sh", "-c", "echo 'Output file type PDF is not supported'")
+			},
+			expectError: ErrFormatUnsupported,
+		},
+		{
+			name:    "successful conversion mentioning an unsupported object",
+			dwgPath: testDWGPath,
+			command: func(ctx context.Context, outputDir string, args []string) *exec.Cmd {
+				_ = os.WriteFile(filepath.Join(outputDir, "test.pdf"), []byte("%PDF"), 0644)
+				return exec.CommandContext(ctx, "sh", "-c", "echo 'Skipped unsupported object: PROXY'")
+			},
+		},
+		{
+			name:    "no PDF file generated",
+			dwgPath: testDWGPath,
+			command: func(ctx context.Context, outputDir string, args []string) *exec.Cmd {
+				return exec.CommandContext(ctx, "echo", "mock command")
+			},
+			errContains: "conversion failed: no PDF file was generated",
+		},
+		{
+			name:        "empty dwg path",
+			dwgPath:     "",
+			errContains: "DWG path cannot be empty",
+		},
+		{
+			name:        "nonexistent dwg file",
+			dwgPath:     filepath.Join(tempDir, "nonexistent.dwg"),
+			errContains: "input file does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+				require.NotNil(t, tt.command, "converter should not be run")
+				return tt.command(ctx, outputDir, args)
+			}
+
+			converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+			require.NoError(t, err)
+
+			pdfPath, err := converter.ConvertToPDF(tt.dwgPath, outputDir)
+
+			switch {
+			case tt.expectError != nil:
+				assert.ErrorIs(t, err, tt.expectError)
+			case tt.errContains != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(outputDir, "test.pdf"), pdfPath)
+			}
+		})
+	}
+}
+
+// recordingLogger collects log messages by level
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages map[string][]string
+}
+
+func (l *recordingLogger) record(level, msg string, args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.messages == nil {
+		l.messages = make(map[string][]string)
+	}
+	l.messages[level] = append(l.messages[level], fmt.Sprint(msg, args))
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.record("debug", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...any)  { l.record("info", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...any)  { l.record("warn", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...any) { l.record("error", msg, args) }
+
+func TestDWGConverter_SetLogger(t *testing.T) {
+	originalCommand := commandContext
+	defer func() { commandContext = originalCommand }()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	outputDir := filepath.Join(tempDir, "out put")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+	commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+		_ = os.WriteFile(filepath.Join(outputDir, "test.dxf"), []byte("DXF content"), 0644)
+		return exec.CommandContext(ctx, "echo", "mock command")
+	}
+
+	converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+	require.NoError(t, err)
+
+	// The default logger discards messages without panicking
+	_, err = converter.ConvertToDXF(testDWGPath, outputDir)
+	require.NoError(t, err)
+
+	logger := &recordingLogger{}
+	converter.SetLogger(logger)
+	_, err = converter.ConvertToDXF(testDWGPath, outputDir)
+	require.NoError(t, err)
+
+	require.Len(t, logger.messages["debug"], 1)
+	assert.Contains(t, logger.messages["debug"][0], "path/to/odaconverter")
+	assert.Contains(t, logger.messages["debug"][0], `"`+outputDir+`" ACAD2018 DXF 0 0 *.DWG`)
+	require.Len(t, logger.messages["info"], 1)
+	assert.Contains(t, logger.messages["info"][0], "duration")
+
+	// Failures are logged at Error
+	commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "false")
+	}
+	_, err = converter.ConvertToDXF(testDWGPath, outputDir)
+	require.Error(t, err)
+	assert.Len(t, logger.messages["error"], 1)
+
+	converter.SetLogger(nil)
+	assert.NotPanics(t, func() {
+		_, _ = converter.ConvertToDXF(testDWGPath, outputDir)
+	})
+}
+
+func TestDWGConverter_SetArgTemplate(t *testing.T) {
+	originalCommand := commandContext
+	defer func() { commandContext = originalCommand }()
+
+	tempDir := t.TempDir()
+	testDWGPath := filepath.Join(tempDir, "test.dwg")
+	outputDir := filepath.Join(tempDir, "output")
+	require.NoError(t, os.WriteFile(testDWGPath, []byte("test content"), 0644))
+
+	var gotArgs []string
+	commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+		gotArgs = args
+		_ = os.WriteFile(filepath.Join(outputDir, "test.dxf"), []byte("DXF content"), 0644)
+		return exec.CommandContext(ctx, "echo", "mock command")
+	}
+
+	converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		template []string
+		expected []string
+	}{
+		{
+			name:     "default layout",
+			template: nil,
+			expected: []string{tempDir, outputDir, "ACAD2018", "DXF", "0", "0", "*.DWG"},
+		},
+		{
+			name:     "reordered with flags",
+			template: []string{"--type={filetype}", "--version", "{version}", "{filter}", "{input}", "{output}", "1"},
+			expected: []string{"--type=DXF", "--version", "ACAD2018", "*.DWG", tempDir, outputDir, "1"},
+		},
+		{
+			name:     "empty template restores the default",
+			template: []string{},
+			expected: []string{tempDir, outputDir, "ACAD2018", "DXF", "0", "0", "*.DWG"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter.SetArgTemplate(tt.template)
+			_, err := converter.ConvertToDXF(testDWGPath, outputDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, gotArgs)
+		})
+	}
+
+	// The converter keeps its own copy of the template
+	template := []string{"{input}"}
+	converter.SetArgTemplate(template)
+	template[0] = "changed"
+	_, err = converter.ConvertToDXF(testDWGPath, outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{tempDir}, gotArgs)
+}
+
 func TestNewDWGConverter(t *testing.T) {
+	tempDir := t.TempDir()
+	executable := filepath.Join(tempDir, "ODAFileConverter")
+	require.NoError(t, os.WriteFile(executable, []byte("#!/bin/sh\n"), 0755))
+	plainFile := filepath.Join(tempDir, "notes.txt")
+	require.NoError(t, os.WriteFile(plainFile, []byte("text"), 0644))
+	missing := filepath.Join(tempDir, "missing")
+
 	tests := []struct {
 		name          string
 		converterPath string
+		opts          Options
+		unixOnly      bool // Execute permission is not checked on Windows
 		expectError   bool
 		errContains   string
 	}{
 		{
 			name:          "valid converter path",
-			converterPath: "path/to/odaconverter",
+			converterPath: executable,
 			expectError:   false,
 		},
 		{
@@ -215,11 +497,46 @@ func TestNewDWGConverter(t *testing.T) {
 			expectError:   true,
 			errContains:   "converter path cannot be empty",
 		},
+		{
+			name:          "missing converter",
+			converterPath: missing,
+			expectError:   true,
+			errContains:   "converter not found at " + missing,
+		},
+		{
+			name:          "directory instead of converter",
+			converterPath: tempDir,
+			expectError:   true,
+			errContains:   "is a directory",
+		},
+		{
+			name:          "missing converter with validation skipped",
+			converterPath: "path/to/odaconverter",
+			opts:          Options{SkipValidation: true},
+			expectError:   false,
+		},
+		{
+			name:          "empty converter path with validation skipped",
+			converterPath: "",
+			opts:          Options{SkipValidation: true},
+			expectError:   true,
+			errContains:   "converter path cannot be empty",
+		},
+		{
+			name:          "converter without execute permission",
+			converterPath: plainFile,
+			unixOnly:      true,
+			expectError:   true,
+			errContains:   "converter at " + plainFile + " is not executable",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			converter, err := NewDWGConverter(tt.converterPath)
+			if tt.unixOnly && runtime.GOOS == "windows" {
+				t.Skip("execute permission is not checked on Windows")
+			}
+			converter, err := NewDWGConverterWithOptions(tt.converterPath, tt.opts)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -233,3 +550,131 @@ func TestNewDWGConverter(t *testing.T) {
 		})
 	}
 }
+
+func TestDWGConverter_ConvertDirectoryConcurrent(t *testing.T) {
+	originalCommand := commandContext
+	defer func() { commandContext = originalCommand }()
+
+	inputDir := t.TempDir()
+	outputDir := t.TempDir()
+	for _, name := range []string{"a.dwg", "b.DWG", "c.dwg", "broken.dwg", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(inputDir, name), []byte("content"), 0644))
+	}
+
+	var mu sync.Mutex
+	var filters []string
+	commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+		filter := args[6]
+		mu.Lock()
+		filters = append(filters, filter)
+		mu.Unlock()
+
+		if filter == "broken.dwg" {
+			return exec.CommandContext(ctx, "false")
+		}
+		base := strings.TrimSuffix(filter, filepath.Ext(filter))
+		_ = os.WriteFile(filepath.Join(outputDir, base+".dxf"), []byte("DXF content"), 0644)
+		return exec.CommandContext(ctx, "echo", "mock command")
+	}
+
+	converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+	require.NoError(t, err)
+
+	dxfPaths, err := converter.ConvertDirectoryConcurrent(context.Background(), inputDir, outputDir, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken.dwg")
+	assert.ElementsMatch(t, []string{
+		filepath.Join(outputDir, "a.dxf"),
+		filepath.Join(outputDir, "b.dxf"),
+		filepath.Join(outputDir, "c.dxf"),
+	}, dxfPaths)
+	assert.ElementsMatch(t, []string{"a.dwg", "b.DWG", "c.dwg", "broken.dwg"}, filters, "Each file should be converted on its own")
+}
+
+func TestDWGConverter_ConvertDirectoryConcurrent_Cancelled(t *testing.T) {
+	originalCommand := commandContext
+	defer func() { commandContext = originalCommand }()
+
+	inputDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(inputDir, "a.dwg"), []byte("content"), 0644))
+
+	runs := 0
+	commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+		runs++
+		return exec.CommandContext(ctx, "echo", "mock command")
+	}
+
+	converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dxfPaths, err := converter.ConvertDirectoryConcurrent(ctx, inputDir, t.TempDir(), 2)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, dxfPaths)
+	assert.Equal(t, 0, runs, "No conversion should start after cancellation")
+}
+
+func TestDWGConverter_ConvertDirectoryConcurrent_MissingDir(t *testing.T) {
+	converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+	require.NoError(t, err)
+
+	_, err = converter.ConvertDirectoryConcurrent(context.Background(), filepath.Join(t.TempDir(), "missing"), t.TempDir(), 1)
+	assert.ErrorContains(t, err, "failed to read input directory")
+}
+
+func TestDWGConverter_ConvertToDXFContext_Cancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the sleep command")
+	}
+	originalCommand := commandContext
+	defer func() { commandContext = originalCommand }()
+	commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "sleep", "10")
+	}
+
+	tempDir := t.TempDir()
+	dwgPath := filepath.Join(tempDir, "large.dwg")
+	require.NoError(t, os.WriteFile(dwgPath, []byte("content"), 0644))
+
+	converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = converter.ConvertToDXFContext(ctx, dwgPath, filepath.Join(tempDir, "output"))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second, "cancelling should stop the converter")
+}
+
+func TestDWGConverter_ConvertToDXFContext_CancelWithChildProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the sh and sleep commands")
+	}
+	originalCommand := commandContext
+	defer func() { commandContext = originalCommand }()
+	// The sleep started by the shell keeps the output open after the shell is killed
+	commandContext = func(ctx context.Context, command string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "sh", "-c", "sleep 10; true")
+	}
+
+	tempDir := t.TempDir()
+	dwgPath := filepath.Join(tempDir, "large.dwg")
+	require.NoError(t, os.WriteFile(dwgPath, []byte("content"), 0644))
+
+	converter, err := NewDWGConverterWithOptions("path/to/odaconverter", Options{SkipValidation: true})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = converter.ConvertToDXFContext(ctx, dwgPath, filepath.Join(tempDir, "output"))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second, "cancelling should not wait for the converter's children")
+}
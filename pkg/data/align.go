@@ -0,0 +1,35 @@
+package data
+
+import "fmt"
+
+// horizontalAlignNames are the labels of the TEXT group 72 codes, indexed by code
+var horizontalAlignNames = []string{"Left", "Center", "Right", "Aligned", "Middle", "Fit"}
+
+// verticalAlignNames are the labels of the TEXT group 73 codes, indexed by code
+var verticalAlignNames = []string{"Baseline", "Bottom", "Middle", "Top"}
+
+// HorizontalAlignName returns the name of a TEXT horizontal justification code (group 72)
+func HorizontalAlignName(code int) string {
+	if code >= 0 && code < len(horizontalAlignNames) {
+		return horizontalAlignNames[code]
+	}
+	return fmt.Sprintf("Unknown alignment (%d)", code)
+}
+
+// VerticalAlignName returns the name of a TEXT vertical justification code (group 73)
+func VerticalAlignName(code int) string {
+	if code >= 0 && code < len(verticalAlignNames) {
+		return verticalAlignNames[code]
+	}
+	return fmt.Sprintf("Unknown alignment (%d)", code)
+}
+
+// AttachmentAlign returns the horizontal and vertical alignment of an MTEXT attachment
+// point code (group 71), which numbers the cells of a 3x3 grid from top left to bottom right
+func AttachmentAlign(code int) (horizontal, vertical string) {
+	if code < 1 || code > 9 {
+		unknown := fmt.Sprintf("Unknown alignment (%d)", code)
+		return unknown, unknown
+	}
+	return []string{"Left", "Center", "Right"}[(code-1)%3], []string{"Top", "Middle", "Bottom"}[(code-1)/3]
+}
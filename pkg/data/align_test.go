@@ -0,0 +1,38 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlignNames(t *testing.T) {
+	assert.Equal(t, "Left", HorizontalAlignName(0))
+	assert.Equal(t, "Fit", HorizontalAlignName(5))
+	assert.Equal(t, "Unknown alignment (6)", HorizontalAlignName(6))
+	assert.Equal(t, "Baseline", VerticalAlignName(0))
+	assert.Equal(t, "Top", VerticalAlignName(3))
+	assert.Equal(t, "Unknown alignment (-1)", VerticalAlignName(-1))
+}
+
+func TestAttachmentAlign(t *testing.T) {
+	tests := []struct {
+		code       int
+		horizontal string
+		vertical   string
+	}{
+		{1, "Left", "Top"},
+		{2, "Center", "Top"},
+		{6, "Right", "Middle"},
+		{7, "Left", "Bottom"},
+		{9, "Right", "Bottom"},
+		{0, "Unknown alignment (0)", "Unknown alignment (0)"},
+		{10, "Unknown alignment (10)", "Unknown alignment (10)"},
+	}
+
+	for _, tt := range tests {
+		horizontal, vertical := AttachmentAlign(tt.code)
+		assert.Equal(t, tt.horizontal, horizontal, "code %d", tt.code)
+		assert.Equal(t, tt.vertical, vertical, "code %d", tt.code)
+	}
+}
@@ -0,0 +1,96 @@
+package data
+
+import "math"
+
+// BoundingBox is an axis-aligned box enclosing geometry
+type BoundingBox struct {
+	Min Point
+	Max Point
+}
+
+// pointBounds returns the box enclosing a single point
+func pointBounds(p Point) BoundingBox {
+	return BoundingBox{Min: p, Max: p}
+}
+
+// Union returns the smallest box enclosing both b and o
+func (b BoundingBox) Union(o BoundingBox) BoundingBox {
+	return BoundingBox{
+		Min: Point{X: math.Min(b.Min.X, o.Min.X), Y: math.Min(b.Min.Y, o.Min.Y), Z: math.Min(b.Min.Z, o.Min.Z)},
+		Max: Point{X: math.Max(b.Max.X, o.Max.X), Y: math.Max(b.Max.Y, o.Max.Y), Z: math.Max(b.Max.Z, o.Max.Z)},
+	}
+}
+
+// Near reports whether the corners of b and o differ by at most epsilon on every axis
+func (b BoundingBox) Near(o BoundingBox, epsilon float64) bool {
+	return pointsNear(b.Min, o.Min, epsilon) && pointsNear(b.Max, o.Max, epsilon)
+}
+
+// Intersects reports whether b and o overlap or touch in the XY plane
+func (b BoundingBox) Intersects(o BoundingBox) bool {
+	return b.Min.X <= o.Max.X && o.Min.X <= b.Max.X &&
+		b.Min.Y <= o.Max.Y && o.Min.Y <= b.Max.Y
+}
+
+// ComputeBounds returns the box enclosing an entity's geometry. Texts, blocks and dimensions
+// are bounded by their insertion or definition point. It returns false for entities without
+// geometry, such as hatches, polylines without points and unknown types.
+func ComputeBounds(entity Entity) (BoundingBox, bool) {
+	switch e := entity.(type) {
+	case *LineInfo:
+		return pointBounds(e.StartPoint).Union(pointBounds(e.EndPoint)), true
+	case *CircleInfo:
+		r := math.Abs(e.Radius)
+		return BoundingBox{
+			Min: Point{X: e.Center.X - r, Y: e.Center.Y - r, Z: e.Center.Z},
+			Max: Point{X: e.Center.X + r, Y: e.Center.Y + r, Z: e.Center.Z},
+		}, true
+	case *TextInfo:
+		return pointBounds(e.InsertionPoint), true
+	case *BlockInfo:
+		return pointBounds(e.InsertionPoint), true
+	case *PolylineInfo:
+		if len(e.Points) == 0 {
+			return BoundingBox{}, false
+		}
+		bounds := pointBounds(e.Points[0])
+		for _, p := range e.Points[1:] {
+			bounds = bounds.Union(pointBounds(p))
+		}
+		return bounds, true
+	case *DimensionInfo:
+		return pointBounds(e.DefinitionPoint), true
+	case *PointInfo:
+		return pointBounds(e.Location), true
+	default:
+		return BoundingBox{}, false
+	}
+}
+
+// Extents returns the drawing extents stored in the header when there are any, and
+// otherwise the extents computed from the entities by ComputedExtents.
+func (d *ExtractedData) Extents() (BoundingBox, bool) {
+	if d != nil && d.HeaderExtents != nil {
+		return *d.HeaderExtents, true
+	}
+	return d.ComputedExtents()
+}
+
+// ComputedExtents returns the box enclosing every entity of every layer of d that has bounds,
+// including layers that are off or frozen. It returns false when there is no such entity.
+func (d *ExtractedData) ComputedExtents() (BoundingBox, bool) {
+	var extents BoundingBox
+	found := false
+	d.ForEachEntity(func(_ *LayerInfo, entity Entity) {
+		bounds, ok := ComputeBounds(entity)
+		if !ok {
+			return
+		}
+		if found {
+			extents = extents.Union(bounds)
+		} else {
+			extents, found = bounds, true
+		}
+	})
+	return extents, found
+}
@@ -0,0 +1,121 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		entity   Entity
+		expected BoundingBox
+		ok       bool
+	}{
+		{
+			name:     "line",
+			entity:   &LineInfo{StartPoint: Point{X: 5, Y: 1}, EndPoint: Point{X: 2, Y: 4}},
+			expected: BoundingBox{Min: Point{X: 2, Y: 1}, Max: Point{X: 5, Y: 4}},
+			ok:       true,
+		},
+		{
+			name:     "circle",
+			entity:   &CircleInfo{Center: Point{X: 3, Y: 3}, Radius: 2},
+			expected: BoundingBox{Min: Point{X: 1, Y: 1}, Max: Point{X: 5, Y: 5}},
+			ok:       true,
+		},
+		{
+			name:     "polyline",
+			entity:   &PolylineInfo{Points: []Point{{X: 0, Y: 2}, {X: 4, Y: -1}, {X: 1, Y: 6}}},
+			expected: BoundingBox{Min: Point{X: 0, Y: -1}, Max: Point{X: 4, Y: 6}},
+			ok:       true,
+		},
+		{
+			name:     "text",
+			entity:   &TextInfo{InsertionPoint: Point{X: 7, Y: 8}},
+			expected: BoundingBox{Min: Point{X: 7, Y: 8}, Max: Point{X: 7, Y: 8}},
+			ok:       true,
+		},
+		{name: "polyline without points", entity: &PolylineInfo{}},
+		{name: "hatch", entity: &HatchInfo{PatternName: "SOLID"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounds, ok := ComputeBounds(tt.entity)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, bounds)
+		})
+	}
+}
+
+func TestBoundingBox_Intersects(t *testing.T) {
+	box := BoundingBox{Min: Point{X: 0, Y: 0}, Max: Point{X: 10, Y: 10}}
+	tests := []struct {
+		name     string
+		other    BoundingBox
+		expected bool
+	}{
+		{"inside", BoundingBox{Min: Point{X: 2, Y: 2}, Max: Point{X: 3, Y: 3}}, true},
+		{"overlapping", BoundingBox{Min: Point{X: 8, Y: -5}, Max: Point{X: 12, Y: 5}}, true},
+		{"enclosing", BoundingBox{Min: Point{X: -1, Y: -1}, Max: Point{X: 11, Y: 11}}, true},
+		{"touching an edge", BoundingBox{Min: Point{X: 10, Y: 4}, Max: Point{X: 12, Y: 6}}, true},
+		{"a point inside", BoundingBox{Min: Point{X: 5, Y: 5}, Max: Point{X: 5, Y: 5}}, true},
+		{"left of it", BoundingBox{Min: Point{X: -5, Y: 2}, Max: Point{X: -1, Y: 3}}, false},
+		{"above it", BoundingBox{Min: Point{X: 2, Y: 11}, Max: Point{X: 3, Y: 12}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, box.Intersects(tt.other))
+			assert.Equal(t, tt.expected, tt.other.Intersects(box))
+		})
+	}
+}
+
+func TestExtractedData_Extents(t *testing.T) {
+	d := &ExtractedData{Layers: []LayerInfo{
+		{Name: "Walls", IsOn: true, Entities: []Entity{
+			&LineInfo{StartPoint: Point{X: 10, Y: 20}, EndPoint: Point{X: 30, Y: 25}},
+			&HatchInfo{PatternName: "SOLID"},
+		}},
+		{Name: "Hidden", IsOn: false, Entities: []Entity{
+			&CircleInfo{Center: Point{X: 12, Y: 40}, Radius: 5},
+			&TextInfo{InsertionPoint: Point{X: 50, Y: 22}},
+		}},
+	}}
+
+	extents, ok := d.Extents()
+	assert.True(t, ok)
+	assert.Equal(t, BoundingBox{Min: Point{X: 7, Y: 20}, Max: Point{X: 50, Y: 45}}, extents,
+		"layers that are off count too")
+
+	hatchOnly := &ExtractedData{Layers: []LayerInfo{{Name: "0", Entities: []Entity{&HatchInfo{}}}}}
+	_, ok = hatchOnly.Extents()
+	assert.False(t, ok, "hatches have no bounds")
+
+	var empty *ExtractedData
+	_, ok = empty.Extents()
+	assert.False(t, ok)
+
+	header := BoundingBox{Min: Point{X: -5, Y: -5}, Max: Point{X: 100, Y: 100}}
+	d.HeaderExtents = &header
+	extents, ok = d.Extents()
+	assert.True(t, ok)
+	assert.Equal(t, header, extents, "header extents are preferred")
+	computed, ok := d.ComputedExtents()
+	assert.True(t, ok)
+	assert.Equal(t, BoundingBox{Min: Point{X: 7, Y: 20}, Max: Point{X: 50, Y: 45}}, computed)
+
+	hatchOnly.HeaderExtents = &header
+	extents, ok = hatchOnly.Extents()
+	assert.True(t, ok, "header extents do not need entities")
+	assert.Equal(t, header, extents)
+}
+
+func TestBoundingBox_Near(t *testing.T) {
+	box := BoundingBox{Min: Point{X: 0, Y: 0}, Max: Point{X: 10, Y: 10}}
+	assert.True(t, box.Near(BoundingBox{Min: Point{X: 0.0005}, Max: Point{X: 10, Y: 9.9995}}, 0.001))
+	assert.False(t, box.Near(BoundingBox{Min: Point{X: 0}, Max: Point{X: 10, Y: 12}}, 0.001))
+}
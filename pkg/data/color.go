@@ -0,0 +1,139 @@
+package data
+
+// Special AutoCAD Color Index (ACI) values. Colors 1-255 are explicit; a negative
+// layer color means the layer is off and its absolute value is the color.
+const (
+	ColorByBlock = 0   // Entity takes the color of the block insertion containing it
+	ColorByLayer = 256 // Entity takes the color of its layer
+)
+
+// ResolveEntityColor returns the ACI color an entity is drawn with. ByLayer (256) and
+// ByBlock (0) resolve to the layer's color; explicit colors are returned unchanged. ByBlock resolves to the layer because
+// entities are resolved outside of any block insertion.
+func ResolveEntityColor(e Entity, layer LayerInfo) int {
+	if color := e.GetColor(); color != ColorByBlock && color != ColorByLayer {
+		return color
+	}
+
+	if layer.Color < 0 {
+		return -layer.Color
+	}
+	return layer.Color
+}
+
+// ResolveColors returns the entities with ByLayer and ByBlock colors replaced by the
+// color of the named layer they are on. Entities whose color changes are copied, so
+// the originals are left untouched; entities on unknown layers are returned as they are.
+func ResolveColors(entities []Entity, layers []LayerInfo) []Entity {
+	layerByName := make(map[string]LayerInfo, len(layers))
+	for _, layer := range layers {
+		layerByName[layer.Name] = layer
+	}
+
+	resolved := make([]Entity, len(entities))
+	for i, entity := range entities {
+		resolved[i] = entity
+		if entity == nil {
+			continue
+		}
+
+		layer, known := layerByName[entity.GetLayer()]
+		if !known {
+			continue
+		}
+		if actual := ResolveEntityColor(entity, layer); actual != entity.GetColor() {
+			if clone := cloneEntity(entity); setEntityColor(clone, actual) {
+				resolved[i] = clone
+			}
+		}
+	}
+	return resolved
+}
+
+// setEntityColor sets the color of an entity, reporting false for entity types it does not know
+func setEntityColor(entity Entity, color int) bool {
+	switch e := entity.(type) {
+	case *LineInfo:
+		e.Color = color
+	case *CircleInfo:
+		e.Color = color
+	case *TextInfo:
+		e.Color = color
+	case *BlockInfo:
+		e.Color = color
+	case *PolylineInfo:
+		e.Color = color
+	case *DimensionInfo:
+		e.Color = color
+	case *PointInfo:
+		e.Color = color
+	case *HatchInfo:
+		e.Color = color
+	default:
+		return false
+	}
+	return true
+}
+
+// RGB is a 24-bit color.
+type RGB struct {
+	R, G, B uint8
+}
+
+// aciFixedColors are the RGB values of ACI colors 1-9 and 250-255, which do not follow the hue wheel
+var aciFixedColors = map[int]RGB{
+	1: {255, 0, 0}, 2: {255, 255, 0}, 3: {0, 255, 0}, 4: {0, 255, 255}, 5: {0, 0, 255},
+	6: {255, 0, 255}, 7: {255, 255, 255}, 8: {128, 128, 128}, 9: {192, 192, 192},
+	250: {51, 51, 51}, 251: {80, 80, 80}, 252: {105, 105, 105},
+	253: {130, 130, 130}, 254: {190, 190, 190}, 255: {255, 255, 255},
+}
+
+// aciShades are the brightness values of the five shades of each ACI hue
+var aciShades = []float64{255, 204, 153, 127, 76}
+
+// ACIToRGB returns the RGB value AutoCAD displays an ACI color with. It returns false for
+// ByBlock, ByLayer and other values outside 1-255; callers pass the absolute value of a
+// negative layer color.
+func ACIToRGB(aci int) (RGB, bool) {
+	if rgb, ok := aciFixedColors[aci]; ok {
+		return rgb, true
+	}
+	if aci < 10 || aci > 249 {
+		return RGB{}, false
+	}
+
+	// Colors 10-249 step through 24 hues 15 degrees apart, ten colors each: five shades,
+	// each followed by a half-saturated version of itself
+	hue := float64(aci/10-1) * 15
+	value := aciShades[aci%10/2]
+	saturation := 1.0
+	if aci%2 == 1 {
+		saturation = 0.5
+	}
+	return hsvToRGB(hue, saturation, value), true
+}
+
+// hsvToRGB converts a hue in degrees, a saturation between 0 and 1 and a value between 0 and 255
+func hsvToRGB(hue, saturation, value float64) RGB {
+	sector := int(hue / 60)
+	fraction := hue/60 - float64(sector)
+	p := uint8(value * (1 - saturation))
+	q := uint8(value * (1 - saturation*fraction))
+	t := uint8(value * (1 - saturation*(1-fraction)))
+	v := uint8(value)
+
+	switch sector {
+	case 0:
+		return RGB{v, t, p}
+	case 1:
+		return RGB{q, v, p}
+	case 2:
+		return RGB{p, v, t}
+	case 3:
+		return RGB{p, q, v}
+	case 4:
+		return RGB{t, p, v}
+	default:
+		return RGB{v, p, q}
+	}
+}
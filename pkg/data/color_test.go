@@ -0,0 +1,86 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACIToRGB(t *testing.T) {
+	tests := []struct {
+		aci      int
+		expected RGB
+	}{
+		{1, RGB{255, 0, 0}},
+		{7, RGB{255, 255, 255}},
+		{9, RGB{192, 192, 192}},
+		{10, RGB{255, 0, 0}},
+		{11, RGB{255, 127, 127}},
+		{12, RGB{204, 0, 0}},
+		{19, RGB{76, 38, 38}},
+		{21, RGB{255, 159, 127}},
+		{30, RGB{255, 127, 0}},
+		{140, RGB{0, 191, 255}},
+		{250, RGB{51, 51, 51}},
+	}
+
+	for _, tt := range tests {
+		rgb, ok := ACIToRGB(tt.aci)
+		assert.True(t, ok, "ACI %d", tt.aci)
+		assert.Equal(t, tt.expected, rgb, "ACI %d", tt.aci)
+	}
+
+	for _, aci := range []int{ColorByBlock, ColorByLayer, -1} {
+		_, ok := ACIToRGB(aci)
+		assert.False(t, ok, "ACI %d has no RGB value", aci)
+	}
+}
+
+func TestResolveEntityColor(t *testing.T) {
+	walls := LayerInfo{Name: "Walls", Color: 3}
+
+	tests := []struct {
+		name     string
+		entity   Entity
+		layer    LayerInfo
+		expected int
+	}{
+		{"ByLayer takes the layer color", &LineInfo{Layer: "Walls", Color: ColorByLayer}, walls, 3},
+		{"ByBlock outside a block takes the layer color", &CircleInfo{Layer: "Walls", Color: ColorByBlock}, walls, 3},
+		{"explicit color passes through", &LineInfo{Layer: "Walls", Color: 1}, walls, 1},
+		{"explicit color on a hatch passes through", &HatchInfo{Layer: "Walls", Color: 250}, walls, 250},
+		{"entities without a color use the layer color", &TextInfo{Layer: "Walls"}, walls, 3},
+		{"layers that are off keep their color", &PointInfo{Layer: "Walls", Color: ColorByLayer}, LayerInfo{Name: "Walls", Color: -5}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ResolveEntityColor(tt.entity, tt.layer))
+		})
+	}
+}
+
+func TestResolveColors(t *testing.T) {
+	layers := []LayerInfo{{Name: "Walls", Color: 3}}
+	byLayer := &LineInfo{Layer: "Walls", Color: ColorByLayer}
+	explicit := &CircleInfo{Layer: "Walls", Color: 1}
+	unknownLayer := &PointInfo{Layer: "Missing", Color: ColorByLayer}
+	text := &TextInfo{Layer: "Walls", Value: "A", Color: ColorByBlock}
+
+	resolved := ResolveColors([]Entity{byLayer, explicit, unknownLayer, text, nil}, layers)
+	require.Len(t, resolved, 5)
+
+	line, ok := resolved[0].(*LineInfo)
+	require.True(t, ok)
+	assert.Equal(t, 3, line.Color)
+	assert.Equal(t, ColorByLayer, byLayer.Color, "the original entity is not modified")
+
+	assert.Same(t, explicit, resolved[1])
+	assert.Same(t, unknownLayer, resolved[2])
+	resolvedText, ok := resolved[3].(*TextInfo)
+	require.True(t, ok)
+	assert.Equal(t, 3, resolvedText.Color, "text colors resolve like any other entity's")
+	assert.Equal(t, ColorByBlock, text.Color)
+	assert.Nil(t, resolved[4])
+}
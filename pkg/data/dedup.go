@@ -0,0 +1,133 @@
+package data
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultDedupEpsilon is the coordinate tolerance used by Deduplicate.
+const DefaultDedupEpsilon = 1e-6
+
+// Deduplicate returns entities with geometrically identical duplicates removed,
+// keeping the first occurrence of each. Coordinates are compared within
+// DefaultDedupEpsilon. Nil entities are dropped.
+func Deduplicate(entities []Entity) []Entity {
+	return DeduplicateWithEpsilon(entities, DefaultDedupEpsilon)
+}
+
+// DeduplicateWithEpsilon is like Deduplicate but compares coordinates within epsilon.
+func DeduplicateWithEpsilon(entities []Entity, epsilon float64) []Entity {
+	indices := UniqueIndices(entities, epsilon)
+	unique := make([]Entity, len(indices))
+	for i, index := range indices {
+		unique[i] = entities[index]
+	}
+	return unique
+}
+
+// UniqueIndices returns the indices of the first occurrence of each distinct
+// entity, in order. Entities are duplicates when they have the same type, layer
+// and color and the same geometry within epsilon. Nil entities are skipped.
+func UniqueIndices(entities []Entity, epsilon float64) []int {
+	var indices []int
+	// Kept entities grouped by type, layer and color, so each entity is only compared with likely matches
+	kept := make(map[string][]Entity)
+
+	for i, entity := range entities {
+		if entity == nil {
+			continue
+		}
+
+		key := entityKey(entity)
+		duplicate := false
+		for _, other := range kept[key] {
+			if entitiesEqual(entity, other, epsilon) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+
+		kept[key] = append(kept[key], entity)
+		indices = append(indices, i)
+	}
+
+	return indices
+}
+
+// entityKey groups entities that can be equal: those of the same type, layer and color
+func entityKey(entity Entity) string {
+	return fmt.Sprintf("%T|%s|%d", entity, entity.GetLayer(), entity.GetColor())
+}
+
+// entitiesEqual reports whether two entities with the same entityKey are geometrically identical.
+// Hatch boundaries are not kept, so hatches and unknown types are never considered equal.
+func entitiesEqual(a, b Entity, epsilon float64) bool {
+	switch x := a.(type) {
+	case *LineInfo:
+		y := b.(*LineInfo)
+		// A line is the same whichever end it was drawn from
+		return (pointsNear(x.StartPoint, y.StartPoint, epsilon) && pointsNear(x.EndPoint, y.EndPoint, epsilon)) ||
+			(pointsNear(x.StartPoint, y.EndPoint, epsilon) && pointsNear(x.EndPoint, y.StartPoint, epsilon))
+
+	case *CircleInfo:
+		y := b.(*CircleInfo)
+		return pointsNear(x.Center, y.Center, epsilon) && near(x.Radius, y.Radius, epsilon)
+
+	case *TextInfo:
+		y := b.(*TextInfo)
+		return x.Value == y.Value && x.Style == y.Style &&
+			pointsNear(x.InsertionPoint, y.InsertionPoint, epsilon) &&
+			near(x.Height, y.Height, epsilon) && near(x.Rotation, y.Rotation, epsilon)
+
+	case *BlockInfo:
+		y := b.(*BlockInfo)
+		if x.Name != y.Name || len(x.Attributes) != len(y.Attributes) {
+			return false
+		}
+		for i := range x.Attributes {
+			if x.Attributes[i].Tag != y.Attributes[i].Tag || x.Attributes[i].Value != y.Attributes[i].Value {
+				return false
+			}
+		}
+		return pointsNear(x.InsertionPoint, y.InsertionPoint, epsilon) &&
+			pointsNear(x.Scale, y.Scale, epsilon) && near(x.Rotation, y.Rotation, epsilon)
+
+	case *PolylineInfo:
+		y := b.(*PolylineInfo)
+		if x.IsClosed != y.IsClosed || len(x.Points) != len(y.Points) {
+			return false
+		}
+		// Like lines, a polyline traced in reverse covers the same path
+		forward, reverse := true, true
+		for i := range x.Points {
+			forward = forward && pointsNear(x.Points[i], y.Points[i], epsilon)
+			reverse = reverse && pointsNear(x.Points[i], y.Points[len(y.Points)-1-i], epsilon)
+		}
+		return forward || reverse
+
+	case *DimensionInfo:
+		y := b.(*DimensionInfo)
+		return x.DimensionType == y.DimensionType && x.TextOverride == y.TextOverride &&
+			near(x.Measurement, y.Measurement, epsilon) && pointsNear(x.DefinitionPoint, y.DefinitionPoint, epsilon)
+
+	case *PointInfo:
+		y := b.(*PointInfo)
+		return pointsNear(x.Location, y.Location, epsilon)
+
+	default:
+		return false
+	}
+}
+
+// pointsNear reports whether two points match within epsilon on every axis
+func pointsNear(a, b Point, epsilon float64) bool {
+	return near(a.X, b.X, epsilon) && near(a.Y, b.Y, epsilon) && near(a.Z, b.Z, epsilon)
+}
+
+// near reports whether two values differ by at most epsilon
+func near(a, b, epsilon float64) bool {
+	return math.Abs(a-b) <= epsilon
+}
@@ -0,0 +1,103 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicate(t *testing.T) {
+	line := &LineInfo{StartPoint: Point{X: 0, Y: 0}, EndPoint: Point{X: 10, Y: 5}, Layer: "A", Color: 1}
+	circle := &CircleInfo{Center: Point{X: 1, Y: 1}, Radius: 2, Layer: "A", Color: 1}
+	polyline := &PolylineInfo{Points: []Point{{X: 0}, {X: 1}, {X: 1, Y: 1}}, Layer: "A"}
+
+	tests := []struct {
+		name     string
+		entities []Entity
+		expected []Entity
+	}{
+		{
+			name:     "exact duplicate line removed",
+			entities: []Entity{line, &LineInfo{StartPoint: Point{X: 0, Y: 0}, EndPoint: Point{X: 10, Y: 5}, Layer: "A", Color: 1}},
+			expected: []Entity{line},
+		},
+		{
+			name:     "line with swapped end points is a duplicate",
+			entities: []Entity{line, &LineInfo{StartPoint: Point{X: 10, Y: 5}, EndPoint: Point{X: 0, Y: 0}, Layer: "A", Color: 1}},
+			expected: []Entity{line},
+		},
+		{
+			name:     "coordinates within epsilon are a duplicate",
+			entities: []Entity{circle, &CircleInfo{Center: Point{X: 1 + 1e-9, Y: 1}, Radius: 2, Layer: "A", Color: 1}},
+			expected: []Entity{circle},
+		},
+		{
+			name:     "reversed polyline is a duplicate",
+			entities: []Entity{polyline, &PolylineInfo{Points: []Point{{X: 1, Y: 1}, {X: 1}, {X: 0}}, Layer: "A"}},
+			expected: []Entity{polyline},
+		},
+		{
+			name: "different layer, color or type are kept",
+			entities: []Entity{
+				line,
+				&LineInfo{StartPoint: Point{X: 0, Y: 0}, EndPoint: Point{X: 10, Y: 5}, Layer: "B", Color: 1},
+				&LineInfo{StartPoint: Point{X: 0, Y: 0}, EndPoint: Point{X: 10, Y: 5}, Layer: "A", Color: 2},
+				circle,
+			},
+			expected: []Entity{
+				line,
+				&LineInfo{StartPoint: Point{X: 0, Y: 0}, EndPoint: Point{X: 10, Y: 5}, Layer: "B", Color: 1},
+				&LineInfo{StartPoint: Point{X: 0, Y: 0}, EndPoint: Point{X: 10, Y: 5}, Layer: "A", Color: 2},
+				circle,
+			},
+		},
+		{
+			name: "recolored text, insert and dimension are kept",
+			entities: []Entity{
+				&TextInfo{Value: "A1", Layer: "A", Color: 1},
+				&TextInfo{Value: "A1", Layer: "A", Color: 2},
+				&BlockInfo{Name: "DOOR", Layer: "A", Color: 1},
+				&BlockInfo{Name: "DOOR", Layer: "A", Color: 2},
+				&DimensionInfo{DimensionType: "Linear", Layer: "A", Color: 1},
+				&DimensionInfo{DimensionType: "Linear", Layer: "A", Color: 2},
+			},
+			expected: []Entity{
+				&TextInfo{Value: "A1", Layer: "A", Color: 1},
+				&TextInfo{Value: "A1", Layer: "A", Color: 2},
+				&BlockInfo{Name: "DOOR", Layer: "A", Color: 1},
+				&BlockInfo{Name: "DOOR", Layer: "A", Color: 2},
+				&DimensionInfo{DimensionType: "Linear", Layer: "A", Color: 1},
+				&DimensionInfo{DimensionType: "Linear", Layer: "A", Color: 2},
+			},
+		},
+		{
+			name:     "hatches are never merged",
+			entities: []Entity{&HatchInfo{PatternName: "SOLID", Layer: "A"}, &HatchInfo{PatternName: "SOLID", Layer: "A"}},
+			expected: []Entity{&HatchInfo{PatternName: "SOLID", Layer: "A"}, &HatchInfo{PatternName: "SOLID", Layer: "A"}},
+		},
+		{
+			name:     "nil entities dropped",
+			entities: []Entity{nil, line, nil},
+			expected: []Entity{line},
+		},
+		{
+			name:     "empty input",
+			entities: nil,
+			expected: []Entity{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Deduplicate(tt.entities))
+		})
+	}
+}
+
+func TestDeduplicateWithEpsilon(t *testing.T) {
+	a := &PointInfo{Location: Point{X: 1, Y: 1}, Layer: "A"}
+	b := &PointInfo{Location: Point{X: 1.01, Y: 1}, Layer: "A"}
+
+	assert.Len(t, Deduplicate([]Entity{a, b}), 2, "Default epsilon should keep points 0.01 apart")
+	assert.Equal(t, []Entity{a}, DeduplicateWithEpsilon([]Entity{a, b}, 0.05))
+	assert.Equal(t, []int{0, 2}, UniqueIndices([]Entity{a, b, &PointInfo{Location: Point{X: 5}, Layer: "A"}}, 0.05))
+}
@@ -0,0 +1,142 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+)
+
+// LayerChange describes how the state of a layer present in both drawings changed
+type LayerChange struct {
+	Name    string
+	Changes []string // Human-readable changes, such as "turned off" or "color 1 -> 3"
+}
+
+// DiffResult lists the differences between two drawings
+type DiffResult struct {
+	AddedLayers     []string
+	RemovedLayers   []string
+	ChangedLayers   []LayerChange
+	AddedEntities   []Entity
+	RemovedEntities []Entity
+}
+
+// IsEmpty reports whether the drawings had no differences
+func (r DiffResult) IsEmpty() bool {
+	return len(r.AddedLayers) == 0 && len(r.RemovedLayers) == 0 && len(r.ChangedLayers) == 0 &&
+		len(r.AddedEntities) == 0 && len(r.RemovedEntities) == 0
+}
+
+// Diff compares drawing a with its revision b. Entities are matched by type, layer and
+// geometry within DefaultDedupEpsilon, so an entity that moved shows up as removed and added.
+func Diff(a, b *ExtractedData) DiffResult {
+	return DiffWithEpsilon(a, b, DefaultDedupEpsilon)
+}
+
+// DiffWithEpsilon is like Diff but compares coordinates within epsilon.
+// A nil drawing is treated as empty.
+func DiffWithEpsilon(a, b *ExtractedData, epsilon float64) DiffResult {
+	if a == nil {
+		a = &ExtractedData{}
+	}
+	if b == nil {
+		b = &ExtractedData{}
+	}
+
+	var result DiffResult
+
+	before := make(map[string]LayerInfo, len(a.Layers))
+	for _, layer := range a.Layers {
+		before[layer.Name] = layer
+	}
+	after := make(map[string]bool, len(b.Layers))
+	for _, layer := range b.Layers {
+		after[layer.Name] = true
+		old, ok := before[layer.Name]
+		if !ok {
+			result.AddedLayers = append(result.AddedLayers, layer.Name)
+			continue
+		}
+		if changes := layerChanges(old, layer); len(changes) > 0 {
+			result.ChangedLayers = append(result.ChangedLayers, LayerChange{Name: layer.Name, Changes: changes})
+		}
+	}
+	for _, layer := range a.Layers {
+		if !after[layer.Name] {
+			result.RemovedLayers = append(result.RemovedLayers, layer.Name)
+		}
+	}
+
+	result.AddedEntities, result.RemovedEntities = diffEntities(a.AllEntities(), b.AllEntities(), epsilon)
+	return result
+}
+
+// layerChanges describes the state changes between two versions of a layer
+func layerChanges(old, current LayerInfo) []string {
+	var changes []string
+	if old.IsOn != current.IsOn {
+		changes = append(changes, map[bool]string{true: "turned on", false: "turned off"}[current.IsOn])
+	}
+	if old.IsFrozen != current.IsFrozen {
+		changes = append(changes, map[bool]string{true: "frozen", false: "thawed"}[current.IsFrozen])
+	}
+	if old.Color != current.Color {
+		changes = append(changes, fmt.Sprintf("color %d -> %d", old.Color, current.Color))
+	}
+	if old.LineType != current.LineType {
+		changes = append(changes, fmt.Sprintf("line type %s -> %s", old.LineType, current.LineType))
+	}
+	return changes
+}
+
+// diffEntities matches each entity of after with an unmatched equal entity of before,
+// returning the entities of after left unmatched as added and those of before as removed
+func diffEntities(before, after []Entity, epsilon float64) (added, removed []Entity) {
+	// Unmatched entities of before grouped by type, layer and color, like UniqueIndices
+	unmatched := make(map[string][]Entity)
+	for _, entity := range before {
+		if entity == nil {
+			continue
+		}
+		key := entityKey(entity)
+		unmatched[key] = append(unmatched[key], entity)
+	}
+
+	for _, entity := range after {
+		if entity == nil {
+			continue
+		}
+		key := entityKey(entity)
+		candidates := unmatched[key]
+		index := slices.IndexFunc(candidates, func(other Entity) bool {
+			return diffEqual(entity, other, epsilon)
+		})
+		if index < 0 {
+			added = append(added, entity)
+			continue
+		}
+		unmatched[key] = slices.Delete(candidates, index, index+1)
+	}
+
+	// Report removals in the order they appear in before
+	for _, entity := range before {
+		if entity == nil {
+			continue
+		}
+		key := entityKey(entity)
+		if slices.Contains(unmatched[key], entity) {
+			removed = append(removed, entity)
+		}
+	}
+	return added, removed
+}
+
+// diffEqual reports whether two entities with the same entityKey are the same.
+// Unlike deduplication, hatches are compared on what is kept of them.
+func diffEqual(a, b Entity, epsilon float64) bool {
+	if x, ok := a.(*HatchInfo); ok {
+		y := b.(*HatchInfo)
+		return x.PatternName == y.PatternName && x.IsSolid == y.IsSolid &&
+			x.BoundaryPointCount == y.BoundaryPointCount
+	}
+	return entitiesEqual(a, b, epsilon)
+}
@@ -0,0 +1,99 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff_Layers(t *testing.T) {
+	a := &ExtractedData{Layers: []LayerInfo{
+		{Name: "0", IsOn: true, Color: 7, LineType: "CONTINUOUS"},
+		{Name: "Walls", IsOn: true, Color: 1, LineType: "CONTINUOUS"},
+		{Name: "Old", IsOn: true},
+	}}
+	b := &ExtractedData{Layers: []LayerInfo{
+		{Name: "0", IsOn: true, Color: 7, LineType: "CONTINUOUS"},
+		{Name: "Walls", IsOn: false, IsFrozen: true, Color: 3, LineType: "DASHED"},
+		{Name: "New", IsOn: true},
+	}}
+
+	result := Diff(a, b)
+	assert.Equal(t, []string{"New"}, result.AddedLayers)
+	assert.Equal(t, []string{"Old"}, result.RemovedLayers)
+	require.Len(t, result.ChangedLayers, 1)
+	assert.Equal(t, LayerChange{
+		Name:    "Walls",
+		Changes: []string{"turned off", "frozen", "color 1 -> 3", "line type CONTINUOUS -> DASHED"},
+	}, result.ChangedLayers[0])
+	assert.False(t, result.IsEmpty())
+}
+
+func TestDiff_Entities(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after *ExtractedData
+		added         int
+		removed       int
+	}{
+		{
+			name:   "identical drawings",
+			before: &ExtractedData{Lines: []LineInfo{{Layer: "0", EndPoint: Point{X: 1}}}},
+			after:  &ExtractedData{Lines: []LineInfo{{Layer: "0", EndPoint: Point{X: 1}}}},
+		},
+		{
+			name:   "geometry within epsilon matches",
+			before: &ExtractedData{Circles: []CircleInfo{{Layer: "0", Radius: 1}}},
+			after:  &ExtractedData{Circles: []CircleInfo{{Layer: "0", Radius: 1 + 1e-9}}},
+		},
+		{
+			name:    "moved entity is removed and added",
+			before:  &ExtractedData{Points: []PointInfo{{Layer: "0", Location: Point{X: 1}}}},
+			after:   &ExtractedData{Points: []PointInfo{{Layer: "0", Location: Point{X: 2}}}},
+			added:   1,
+			removed: 1,
+		},
+		{
+			name:    "entity moved to another layer",
+			before:  &ExtractedData{Lines: []LineInfo{{Layer: "A"}}},
+			after:   &ExtractedData{Lines: []LineInfo{{Layer: "B"}}},
+			added:   1,
+			removed: 1,
+		},
+		{
+			name:    "recolored text is removed and added",
+			before:  &ExtractedData{Texts: []TextInfo{{Layer: "0", Value: "A", Color: 1}}},
+			after:   &ExtractedData{Texts: []TextInfo{{Layer: "0", Value: "A", Color: 2}}},
+			added:   1,
+			removed: 1,
+		},
+		{
+			name:   "duplicates are matched one to one",
+			before: &ExtractedData{Lines: []LineInfo{{Layer: "0"}}},
+			after:  &ExtractedData{Lines: []LineInfo{{Layer: "0"}, {Layer: "0"}}},
+			added:  1,
+		},
+		{
+			name:   "unchanged hatches match",
+			before: &ExtractedData{Hatches: []HatchInfo{{Layer: "0", PatternName: "ANSI31", BoundaryPointCount: 4}}},
+			after:  &ExtractedData{Hatches: []HatchInfo{{Layer: "0", PatternName: "ANSI31", BoundaryPointCount: 4}}},
+		},
+		{
+			name:    "nil drawing is empty",
+			before:  nil,
+			after:   &ExtractedData{Texts: []TextInfo{{Layer: "0", Value: "A"}}},
+			added:   1,
+			removed: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Diff(tt.before, tt.after)
+			assert.Len(t, result.AddedEntities, tt.added)
+			assert.Len(t, result.RemovedEntities, tt.removed)
+			assert.Equal(t, tt.added == 0 && tt.removed == 0, result.IsEmpty())
+		})
+	}
+}
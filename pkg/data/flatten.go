@@ -0,0 +1,299 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// maxBlockNesting limits how deeply FlattenBlocks expands blocks inserted inside block definitions,
+// so a definition that inserts itself cannot recurse forever
+const maxBlockNesting = 16
+
+// blockTransform maps block definition coordinates to drawing coordinates:
+// the base point is moved to the origin, then scaled, rotated and moved to the insertion point.
+type blockTransform struct {
+	base     Point
+	scale    Point
+	rotation float64 // Degrees, counterclockwise
+	offset   Point
+}
+
+// newBlockTransform returns the transform of a block insertion of the given definition
+func newBlockTransform(block *BlockInfo, def *BlockDefinition) blockTransform {
+	return blockTransform{
+		base:     def.BasePoint,
+		scale:    block.Scale,
+		rotation: block.Rotation,
+		offset:   block.InsertionPoint,
+	}
+}
+
+// apply transforms a point from block definition to drawing coordinates
+func (t blockTransform) apply(p Point) Point {
+	x := (p.X - t.base.X) * t.scale.X
+	y := (p.Y - t.base.Y) * t.scale.Y
+	z := (p.Z - t.base.Z) * t.scale.Z
+	sin, cos := math.Sincos(t.rotation * math.Pi / 180)
+	return Point{
+		X: t.offset.X + x*cos - y*sin,
+		Y: t.offset.Y + x*sin + y*cos,
+		Z: t.offset.Z + z,
+	}
+}
+
+// lengthScale returns the factor applied to lengths such as radii and text heights.
+// Non-uniform scales are approximated by the X scale.
+func (t blockTransform) lengthScale() float64 {
+	return math.Abs(t.scale.X)
+}
+
+// FlattenBlocks returns a copy of d in which every block insertion with a known definition is
+// replaced by the definition's entities, transformed by the insertion point, rotation and scale
+// and placed on the insertion's layer. Attribute values become text entities at their positions.
+// Blocks inserted inside definitions are expanded too. Insertions without a definition are kept
+// as they are and reported in the copy's warnings. d itself is not modified.
+func (d *ExtractedData) FlattenBlocks() *ExtractedData {
+	if d == nil {
+		return nil
+	}
+
+	flat := &ExtractedData{
+		DXFVersion:       d.DXFVersion,
+		Units:            d.Units,
+		HeaderExtents:    d.HeaderExtents,
+		BlockDefinitions: d.BlockDefinitions,
+		LineTypes:        d.LineTypes,
+		Warnings:         slices.Clone(d.Warnings),
+	}
+
+	if d.BlockDefinitions == nil && len(d.Blocks) > 0 {
+		flat.Warnings = append(flat.Warnings,
+			fmt.Sprintf("Block definitions were not parsed; %d block insertions were left unflattened", len(d.Blocks)))
+	}
+
+	undefined := make(map[string]bool)
+	for _, entity := range d.AllEntities() {
+		block, ok := entity.(*BlockInfo)
+		if !ok {
+			flat.addEntity(cloneEntity(entity))
+			continue
+		}
+		if d.BlockDefinitions == nil {
+			flat.addEntity(cloneEntity(block))
+			continue
+		}
+		flat.flattenBlock(block, block.Layer, 0, undefined)
+	}
+
+	// Rebuild layer membership from the new entities, which now live in the typed slices
+	layerIndex := make(map[string]int, len(d.Layers))
+	flat.Layers = make([]LayerInfo, len(d.Layers))
+	for i, layer := range d.Layers {
+		layer.Entities = nil
+		flat.Layers[i] = layer
+		layerIndex[layer.Name] = i
+	}
+	for _, entity := range flat.AllEntities() {
+		if i, ok := layerIndex[entity.GetLayer()]; ok {
+			flat.Layers[i].Entities = append(flat.Layers[i].Entities, entity)
+		}
+	}
+
+	return flat
+}
+
+// flattenBlock adds the transformed entities of a block insertion to d, placing them on layer.
+// Insertions of undefined blocks are added unchanged and warned about once per block name.
+func (d *ExtractedData) flattenBlock(block *BlockInfo, layer string, depth int, undefined map[string]bool) {
+	def, ok := d.BlockDefinitions[block.Name]
+	switch {
+	case !ok:
+		if !undefined[block.Name] {
+			undefined[block.Name] = true
+			d.Warnings = append(d.Warnings, fmt.Sprintf("Block %s has no definition; its insertions were left unflattened", block.Name))
+		}
+	case depth >= maxBlockNesting:
+		d.Warnings = append(d.Warnings, fmt.Sprintf("Block %s is nested more than %d levels deep; left unflattened", block.Name, maxBlockNesting))
+		ok = false
+	}
+	if !ok {
+		kept := cloneEntity(block).(*BlockInfo)
+		kept.Layer = layer
+		d.addEntity(kept)
+		return
+	}
+
+	// Attribute positions are already in drawing coordinates
+	for _, attr := range block.Attributes {
+		d.addEntity(&TextInfo{Value: attr.Value, Layer: layer, InsertionPoint: attr.Position})
+	}
+
+	t := newBlockTransform(block, def)
+	for _, entity := range def.Entities {
+		if nested, ok := entity.(*BlockInfo); ok {
+			// Every insertion of the definition would share its entities' handles
+			inner := cloneEntity(nested).(*BlockInfo)
+			inner.Handle = ""
+			inner.InsertionPoint = t.apply(nested.InsertionPoint)
+			inner.Rotation += t.rotation
+			inner.Scale = Point{X: nested.Scale.X * t.scale.X, Y: nested.Scale.Y * t.scale.Y, Z: nested.Scale.Z * t.scale.Z}
+			for i := range inner.Attributes {
+				inner.Attributes[i].Position = t.apply(nested.Attributes[i].Position)
+			}
+			d.flattenBlock(inner, layer, depth+1, undefined)
+			continue
+		}
+		if transformed := transformEntity(entity, t); transformed != nil {
+			setEntityLayer(transformed, layer)
+			// The handle belongs to the definition's entity, not to this copy of it
+			setEntityHandle(transformed, "")
+			d.addEntity(transformed)
+		}
+	}
+}
+
+// transformEntity returns a copy of entity in drawing coordinates, or nil for unknown types
+func transformEntity(entity Entity, t blockTransform) Entity {
+	switch e := cloneEntity(entity).(type) {
+	case *LineInfo:
+		e.StartPoint = t.apply(e.StartPoint)
+		e.EndPoint = t.apply(e.EndPoint)
+		return e
+	case *CircleInfo:
+		e.Center = t.apply(e.Center)
+		e.Radius *= t.lengthScale()
+		return e
+	case *TextInfo:
+		e.InsertionPoint = t.apply(e.InsertionPoint)
+		e.Height *= math.Abs(t.scale.Y)
+		e.Rotation += t.rotation
+		return e
+	case *PolylineInfo:
+		for i := range e.Points {
+			e.Points[i] = t.apply(e.Points[i])
+		}
+		return e
+	case *DimensionInfo:
+		e.DefinitionPoint = t.apply(e.DefinitionPoint)
+		e.Measurement *= t.lengthScale()
+		return e
+	case *PointInfo:
+		e.Location = t.apply(e.Location)
+		return e
+	case *HatchInfo:
+		// Only the hatch summary is kept, so there is no geometry to move
+		return e
+	default:
+		return nil
+	}
+}
+
+// cloneEntity returns a copy of entity that shares no slices with it.
+// Unknown types are returned unchanged.
+func cloneEntity(entity Entity) Entity {
+	switch e := entity.(type) {
+	case *LineInfo:
+		c := *e
+		c.RawCodes = slices.Clone(e.RawCodes)
+		return &c
+	case *CircleInfo:
+		c := *e
+		c.RawCodes = slices.Clone(e.RawCodes)
+		return &c
+	case *TextInfo:
+		c := *e
+		c.RawCodes = slices.Clone(e.RawCodes)
+		return &c
+	case *BlockInfo:
+		c := *e
+		c.Attributes = slices.Clone(e.Attributes)
+		c.RawCodes = slices.Clone(e.RawCodes)
+		return &c
+	case *PolylineInfo:
+		c := *e
+		c.Points = slices.Clone(e.Points)
+		c.RawCodes = slices.Clone(e.RawCodes)
+		return &c
+	case *DimensionInfo:
+		c := *e
+		c.RawCodes = slices.Clone(e.RawCodes)
+		return &c
+	case *PointInfo:
+		c := *e
+		c.RawCodes = slices.Clone(e.RawCodes)
+		return &c
+	case *HatchInfo:
+		c := *e
+		c.RawCodes = slices.Clone(e.RawCodes)
+		return &c
+	default:
+		return entity
+	}
+}
+
+// setEntityLayer moves an entity built by cloneEntity onto layer
+func setEntityLayer(entity Entity, layer string) {
+	switch e := entity.(type) {
+	case *LineInfo:
+		e.Layer = layer
+	case *CircleInfo:
+		e.Layer = layer
+	case *TextInfo:
+		e.Layer = layer
+	case *BlockInfo:
+		e.Layer = layer
+	case *PolylineInfo:
+		e.Layer = layer
+	case *DimensionInfo:
+		e.Layer = layer
+	case *PointInfo:
+		e.Layer = layer
+	case *HatchInfo:
+		e.Layer = layer
+	}
+}
+
+// setEntityHandle sets the handle of an entity built by cloneEntity
+func setEntityHandle(entity Entity, handle string) {
+	switch e := entity.(type) {
+	case *LineInfo:
+		e.Handle = handle
+	case *CircleInfo:
+		e.Handle = handle
+	case *TextInfo:
+		e.Handle = handle
+	case *BlockInfo:
+		e.Handle = handle
+	case *PolylineInfo:
+		e.Handle = handle
+	case *DimensionInfo:
+		e.Handle = handle
+	case *PointInfo:
+		e.Handle = handle
+	case *HatchInfo:
+		e.Handle = handle
+	}
+}
+
+// addEntity appends a copy of entity to the matching typed slice of d
+func (d *ExtractedData) addEntity(entity Entity) {
+	switch e := entity.(type) {
+	case *LineInfo:
+		d.Lines = append(d.Lines, *e)
+	case *CircleInfo:
+		d.Circles = append(d.Circles, *e)
+	case *TextInfo:
+		d.Texts = append(d.Texts, *e)
+	case *BlockInfo:
+		d.Blocks = append(d.Blocks, *e)
+	case *PolylineInfo:
+		d.Polylines = append(d.Polylines, *e)
+	case *DimensionInfo:
+		d.Dimensions = append(d.Dimensions, *e)
+	case *PointInfo:
+		d.Points = append(d.Points, *e)
+	case *HatchInfo:
+		d.Hatches = append(d.Hatches, *e)
+	}
+}
@@ -0,0 +1,188 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertPointNear checks that two points match within a small tolerance
+func assertPointNear(t *testing.T, expected, actual Point) {
+	t.Helper()
+	assert.InDelta(t, expected.X, actual.X, 1e-9, "X of %v", actual)
+	assert.InDelta(t, expected.Y, actual.Y, 1e-9, "Y of %v", actual)
+	assert.InDelta(t, expected.Z, actual.Z, 1e-9, "Z of %v", actual)
+}
+
+// doorDefinitions returns a DOOR block made of a line, circle and text, and a FRAME block inserting a DOOR
+func doorDefinitions() map[string]*BlockDefinition {
+	return map[string]*BlockDefinition{
+		"DOOR": {
+			Name: "DOOR",
+			Entities: []Entity{
+				&LineInfo{StartPoint: Point{X: 0, Y: 0}, EndPoint: Point{X: 1, Y: 0}, Layer: "0", Color: 3},
+				&CircleInfo{Center: Point{X: 1, Y: 0}, Radius: 0.5, Layer: "0"},
+				&TextInfo{Value: "D", InsertionPoint: Point{X: 0, Y: 1}, Height: 1, Layer: "0"},
+			},
+		},
+		"FRAME": {
+			Name:      "FRAME",
+			BasePoint: Point{X: 1, Y: 1},
+			Entities: []Entity{
+				&BlockInfo{Name: "DOOR", InsertionPoint: Point{X: 2, Y: 1}, Scale: Point{X: 1, Y: 1, Z: 1}},
+			},
+		},
+	}
+}
+
+func TestFlattenBlocks(t *testing.T) {
+	d := &ExtractedData{
+		Layers: []LayerInfo{{Name: "Doors", IsOn: true}, {Name: "Walls", IsOn: true}},
+		Lines:  []LineInfo{{StartPoint: Point{X: 0, Y: 0}, EndPoint: Point{X: 5, Y: 0}, Layer: "Walls"}},
+		Blocks: []BlockInfo{{
+			Name:           "DOOR",
+			Layer:          "Doors",
+			InsertionPoint: Point{X: 10, Y: 5},
+			Rotation:       90,
+			Scale:          Point{X: 2, Y: 2, Z: 1},
+			Attributes:     []AttributeInfo{{Tag: "NUM", Value: "D1", Position: Point{X: 10, Y: 6}}},
+		}},
+		BlockDefinitions: doorDefinitions(),
+	}
+
+	flat := d.FlattenBlocks()
+	require.NotNil(t, flat)
+
+	assert.Empty(t, flat.Blocks, "Expected the insertion to be replaced")
+	// Entities are added in AllEntities order, so the block's geometry comes first
+	require.Len(t, flat.Lines, 2)
+	assert.Equal(t, "Walls", flat.Lines[1].Layer)
+
+	// (0,0)-(1,0) scaled by 2, rotated 90° and moved to (10,5)
+	door := flat.Lines[0]
+	assert.Equal(t, "Doors", door.Layer)
+	assert.Equal(t, 3, door.Color)
+	assertPointNear(t, Point{X: 10, Y: 5}, door.StartPoint)
+	assertPointNear(t, Point{X: 10, Y: 7}, door.EndPoint)
+
+	require.Len(t, flat.Circles, 1)
+	assertPointNear(t, Point{X: 10, Y: 7}, flat.Circles[0].Center)
+	assert.InDelta(t, 1.0, flat.Circles[0].Radius, 1e-9)
+
+	require.Len(t, flat.Texts, 2)
+	assert.Equal(t, "D1", flat.Texts[0].Value, "Expected the attribute value as text")
+	assertPointNear(t, Point{X: 10, Y: 6}, flat.Texts[0].InsertionPoint)
+	assertPointNear(t, Point{X: 8, Y: 5}, flat.Texts[1].InsertionPoint)
+	assert.InDelta(t, 2.0, flat.Texts[1].Height, 1e-9)
+	assert.InDelta(t, 90.0, flat.Texts[1].Rotation, 1e-9)
+
+	// Layers list the flattened entities
+	assert.Len(t, flat.Layers[0].Entities, 4)
+	assert.Len(t, flat.Layers[1].Entities, 1)
+	assert.Empty(t, flat.Warnings)
+
+	// The original is untouched
+	assert.Len(t, d.Blocks, 1)
+	assert.Len(t, d.Lines, 1)
+	assert.Empty(t, d.Texts)
+	assertPointNear(t, Point{X: 1, Y: 0}, d.BlockDefinitions["DOOR"].Entities[0].(*LineInfo).EndPoint)
+}
+
+func TestFlattenBlocks_KeepsHeaderExtents(t *testing.T) {
+	extents := &BoundingBox{Min: Point{X: -1, Y: -1}, Max: Point{X: 20, Y: 10}}
+	d := &ExtractedData{HeaderExtents: extents, BlockDefinitions: doorDefinitions()}
+
+	flat := d.FlattenBlocks()
+	require.NotNil(t, flat)
+	assert.Same(t, extents, flat.HeaderExtents)
+	box, ok := flat.Extents()
+	assert.True(t, ok)
+	assert.Equal(t, *extents, box)
+}
+
+func TestFlattenBlocks_Nested(t *testing.T) {
+	d := &ExtractedData{
+		Layers:           []LayerInfo{{Name: "0"}},
+		Blocks:           []BlockInfo{{Name: "FRAME", Layer: "0", InsertionPoint: Point{X: 1, Y: 1}, Scale: Point{X: 1, Y: 1, Z: 1}}},
+		BlockDefinitions: doorDefinitions(),
+	}
+
+	flat := d.FlattenBlocks()
+	require.Len(t, flat.Lines, 1)
+	// FRAME's base point (1,1) maps to its insertion (1,1), so DOOR lands at (2,1)
+	assertPointNear(t, Point{X: 2, Y: 1}, flat.Lines[0].StartPoint)
+	assertPointNear(t, Point{X: 3, Y: 1}, flat.Lines[0].EndPoint)
+	assert.Empty(t, flat.Blocks)
+}
+
+func TestFlattenBlocks_Handles(t *testing.T) {
+	definitions := doorDefinitions()
+	definitions["DOOR"].Entities[0].(*LineInfo).Handle = "1F"
+	d := &ExtractedData{
+		Layers: []LayerInfo{{Name: "0"}},
+		Lines:  []LineInfo{{Layer: "0", Handle: "2A"}},
+		Blocks: []BlockInfo{
+			{Name: "DOOR", Layer: "0", Handle: "2B", Scale: Point{X: 1, Y: 1, Z: 1}},
+			{Name: "DOOR", Layer: "0", Handle: "2C", Scale: Point{X: 1, Y: 1, Z: 1}},
+		},
+		BlockDefinitions: definitions,
+	}
+
+	flat := d.FlattenBlocks()
+	require.Len(t, flat.Lines, 3)
+	var handles []string
+	for _, line := range flat.Lines {
+		handles = append(handles, line.Handle)
+	}
+	assert.ElementsMatch(t, []string{"2A", "", ""}, handles,
+		"copies of a definition's entities do not share its handle")
+}
+
+func TestFlattenBlocks_Unflattened(t *testing.T) {
+	selfInserting := map[string]*BlockDefinition{
+		"LOOP": {Name: "LOOP", Entities: []Entity{&BlockInfo{Name: "LOOP", Scale: Point{X: 1, Y: 1, Z: 1}}}},
+	}
+
+	tests := []struct {
+		name            string
+		definitions     map[string]*BlockDefinition
+		blockName       string
+		expectedWarning string
+	}{
+		{
+			name:            "definitions not parsed",
+			blockName:       "DOOR",
+			expectedWarning: "Block definitions were not parsed; 2 block insertions were left unflattened",
+		},
+		{
+			name:            "undefined block",
+			definitions:     doorDefinitions(),
+			blockName:       "WINDOW",
+			expectedWarning: "Block WINDOW has no definition; its insertions were left unflattened",
+		},
+		{
+			name:            "self-inserting block",
+			definitions:     selfInserting,
+			blockName:       "LOOP",
+			expectedWarning: "Block LOOP is nested more than 16 levels deep; left unflattened",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &ExtractedData{
+				Layers:           []LayerInfo{{Name: "0"}},
+				Blocks:           []BlockInfo{{Name: tt.blockName, Layer: "0"}, {Name: tt.blockName, Layer: "0"}},
+				BlockDefinitions: tt.definitions,
+			}
+
+			flat := d.FlattenBlocks()
+			assert.Len(t, flat.Blocks, 2, "Expected insertions to be kept")
+			assert.Contains(t, flat.Warnings, tt.expectedWarning)
+			assert.Empty(t, d.Warnings, "Expected the original warnings to be untouched")
+		})
+	}
+
+	assert.Nil(t, (*ExtractedData)(nil).FlattenBlocks())
+}
@@ -0,0 +1,273 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The JSON export writes one object per entity, using the struct of its type below.
+// Fields are declared in alphabetical order of their keys, so objects are written with
+// sorted keys, and zero values are written rather than omitted.
+
+// JSONPoint is a 3D point in the JSON export.
+type JSONPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// JSONPoint2D is a point in the JSON export whose Z coordinate is left out.
+type JSONPoint2D struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// NewJSONPoint2D returns the X and Y coordinates of a point.
+func NewJSONPoint2D(p Point) JSONPoint2D {
+	return JSONPoint2D{X: p.X, Y: p.Y}
+}
+
+// NewJSONPoints returns the points as JSON points, as an empty rather than nil slice when
+// there are none so that the export writes [] rather than null.
+func NewJSONPoints(points []Point) []JSONPoint {
+	result := make([]JSONPoint, len(points))
+	for i, p := range points {
+		result[i] = JSONPoint{X: p.X, Y: p.Y, Z: p.Z}
+	}
+	return result
+}
+
+func (p JSONPoint2D) point() Point {
+	return Point{X: p.X, Y: p.Y}
+}
+
+func (p JSONPoint) point() Point {
+	return Point{X: p.X, Y: p.Y, Z: p.Z}
+}
+
+// JSONAttribute is a block attribute in the JSON export.
+type JSONAttribute struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// JSONEntityHeader holds the fields every entity in the JSON export has. It is also
+// written on its own, with type "Unknown", for entities of other types.
+type JSONEntityHeader struct {
+	Layer string `json:"layer"`
+	Type  string `json:"type"`
+}
+
+// JSONLine is a line in the JSON export.
+type JSONLine struct {
+	Color      int         `json:"color"`
+	EndPoint   JSONPoint2D `json:"endPoint"`
+	Layer      string      `json:"layer"`
+	Length     float64     `json:"length"`
+	StartPoint JSONPoint2D `json:"startPoint"`
+	Type       string      `json:"type"`
+}
+
+// JSONCircle is a circle in the JSON export.
+type JSONCircle struct {
+	Center JSONPoint2D `json:"center"`
+	Color  int         `json:"color"`
+	Layer  string      `json:"layer"`
+	Radius float64     `json:"radius"`
+	Type   string      `json:"type"`
+}
+
+// JSONText is a text entity in the JSON export.
+type JSONText struct {
+	Height          float64     `json:"height"`
+	HorizontalAlign string      `json:"horizontalAlign"`
+	InsertionPoint  JSONPoint2D `json:"insertionPoint"`
+	Layer           string      `json:"layer"`
+	Rotation        float64     `json:"rotation"`
+	Type            string      `json:"type"`
+	Value           string      `json:"value"`
+	VerticalAlign   string      `json:"verticalAlign"`
+}
+
+// JSONBlock is a block insertion in the JSON export. Attributes are left out when there are none.
+type JSONBlock struct {
+	Attributes     []JSONAttribute `json:"attributes,omitempty"`
+	InsertionPoint JSONPoint2D     `json:"insertionPoint"`
+	Layer          string          `json:"layer"`
+	Name           string          `json:"name"`
+	Rotation       float64         `json:"rotation"`
+	Scale          JSONPoint2D     `json:"scale"`
+	Type           string          `json:"type"`
+}
+
+// JSONPolyline is a polyline in the JSON export, with its vertices and their count.
+type JSONPolyline struct {
+	Area       float64     `json:"area"`
+	Closed     bool        `json:"closed"`
+	Color      int         `json:"color"`
+	Layer      string      `json:"layer"`
+	Length     float64     `json:"length"`
+	PointCount int         `json:"pointCount"`
+	Points     []JSONPoint `json:"points"`
+	Type       string      `json:"type"`
+}
+
+// JSONDimension is a dimension in the JSON export.
+type JSONDimension struct {
+	DefinitionPoint JSONPoint2D `json:"definitionPoint"`
+	DimensionType   string      `json:"dimensionType"`
+	Layer           string      `json:"layer"`
+	Measurement     float64     `json:"measurement"`
+	Text            string      `json:"text"`
+	TextOverride    string      `json:"textOverride"`
+	Type            string      `json:"type"`
+}
+
+// JSONPointEntity is a point entity in the JSON export.
+type JSONPointEntity struct {
+	Color    int       `json:"color"`
+	Layer    string    `json:"layer"`
+	Location JSONPoint `json:"location"`
+	Type     string    `json:"type"`
+}
+
+// JSONHatch is a hatch in the JSON export.
+type JSONHatch struct {
+	BoundaryPointCount int    `json:"boundaryPointCount"`
+	Color              int    `json:"color"`
+	Layer              string `json:"layer"`
+	PatternName        string `json:"patternName"`
+	Solid              bool   `json:"solid"`
+	Type               string `json:"type"`
+}
+
+// FromJSON reconstructs extracted data from the JSON array written by the
+// clipboard formatter's FormatAsJSON. Layers are created in order of first
+// appearance. Entities with an unknown type are skipped and reported in
+// Warnings.
+func FromJSON(r io.Reader) (*ExtractedData, error) {
+	var objects []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	result := &ExtractedData{}
+	layerIndex := make(map[string]int)
+
+	for i, object := range objects {
+		var header JSONEntityHeader
+		if err := json.Unmarshal(object, &header); err != nil {
+			return nil, fmt.Errorf("failed to decode entity %d: %w", i+1, err)
+		}
+
+		var entity Entity
+		var err error
+		switch header.Type {
+		case "Line":
+			var e JSONLine
+			err = json.Unmarshal(object, &e)
+			line := LineInfo{StartPoint: e.StartPoint.point(), EndPoint: e.EndPoint.point(), Layer: e.Layer, Color: e.Color}
+			result.Lines = append(result.Lines, line)
+			entity = &line
+
+		case "Circle":
+			var e JSONCircle
+			err = json.Unmarshal(object, &e)
+			circle := CircleInfo{Center: e.Center.point(), Radius: e.Radius, Layer: e.Layer, Color: e.Color}
+			result.Circles = append(result.Circles, circle)
+			entity = &circle
+
+		case "Text":
+			var e JSONText
+			err = json.Unmarshal(object, &e)
+			text := TextInfo{
+				Value:           e.Value,
+				Layer:           e.Layer,
+				InsertionPoint:  e.InsertionPoint.point(),
+				Height:          e.Height,
+				Rotation:        e.Rotation,
+				HorizontalAlign: e.HorizontalAlign,
+				VerticalAlign:   e.VerticalAlign,
+			}
+			result.Texts = append(result.Texts, text)
+			entity = &text
+
+		case "Block":
+			var e JSONBlock
+			err = json.Unmarshal(object, &e)
+			block := BlockInfo{
+				Name:           e.Name,
+				Layer:          e.Layer,
+				InsertionPoint: e.InsertionPoint.point(),
+				Rotation:       e.Rotation,
+				Scale:          e.Scale.point(),
+			}
+			for _, attr := range e.Attributes {
+				block.Attributes = append(block.Attributes, AttributeInfo{Tag: attr.Tag, Value: attr.Value, Layer: e.Layer})
+			}
+			result.Blocks = append(result.Blocks, block)
+			entity = &block
+
+		case "Polyline":
+			var e JSONPolyline
+			err = json.Unmarshal(object, &e)
+			polyline := PolylineInfo{Layer: e.Layer, Color: e.Color, IsClosed: e.Closed}
+			for _, p := range e.Points {
+				polyline.Points = append(polyline.Points, p.point())
+			}
+			result.Polylines = append(result.Polylines, polyline)
+			entity = &polyline
+
+		case "Dimension":
+			var e JSONDimension
+			err = json.Unmarshal(object, &e)
+			dimension := DimensionInfo{
+				DimensionType:   e.DimensionType,
+				TextOverride:    e.TextOverride,
+				Measurement:     e.Measurement,
+				DefinitionPoint: e.DefinitionPoint.point(),
+				Layer:           e.Layer,
+			}
+			result.Dimensions = append(result.Dimensions, dimension)
+			entity = &dimension
+
+		case "Point":
+			var e JSONPointEntity
+			err = json.Unmarshal(object, &e)
+			point := PointInfo{Location: e.Location.point(), Layer: e.Layer, Color: e.Color}
+			result.Points = append(result.Points, point)
+			entity = &point
+
+		case "Hatch":
+			var e JSONHatch
+			err = json.Unmarshal(object, &e)
+			hatch := HatchInfo{
+				PatternName:        e.PatternName,
+				IsSolid:            e.Solid,
+				BoundaryPointCount: e.BoundaryPointCount,
+				Layer:              e.Layer,
+				Color:              e.Color,
+			}
+			result.Hatches = append(result.Hatches, hatch)
+			entity = &hatch
+
+		default:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Skipped entity %d with unknown type %q", i+1, header.Type))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode entity %d: %w", i+1, err)
+		}
+
+		li, ok := layerIndex[header.Layer]
+		if !ok {
+			li = len(result.Layers)
+			layerIndex[header.Layer] = li
+			result.Layers = append(result.Layers, LayerInfo{Name: header.Layer, IsOn: true})
+		}
+		result.Layers[li].Entities = append(result.Layers[li].Entities, entity)
+	}
+
+	return result, nil
+}
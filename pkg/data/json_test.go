@@ -0,0 +1,79 @@
+package data
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromJSON(t *testing.T) {
+	input := `[
+  {"type": "Line", "layer": "Walls", "startPoint": {"x": 0, "y": 0}, "endPoint": {"x": 10, "y": 5}, "color": 1},
+  {"type": "Circle", "layer": "Holes", "center": {"x": 5, "y": 5}, "radius": 2.5, "color": 3},
+  {"type": "Spline", "layer": "Walls"},
+  {"type": "Block", "layer": "Walls", "name": "DOOR", "insertionPoint": {"x": 1, "y": 2}, "rotation": 90,
+   "scale": {"x": 1, "y": 1}, "attributes": [{"tag": "WIDTH", "value": "900"}]},
+  {"type": "Hatch", "layer": "Holes", "patternName": "SOLID", "solid": true, "boundaryPointCount": 4, "color": 7},
+  {"type": "Text", "layer": "Holes", "value": "A1", "insertionPoint": {"x": 3, "y": 4}, "height": 2.5, "rotation": 45,
+   "horizontalAlign": "Center", "verticalAlign": "Middle"}
+]`
+
+	result, err := FromJSON(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, result.Layers, 2)
+	assert.Equal(t, "Walls", result.Layers[0].Name)
+	assert.True(t, result.Layers[0].IsOn)
+	assert.Len(t, result.Layers[0].Entities, 2)
+	assert.Equal(t, "Holes", result.Layers[1].Name)
+	assert.Len(t, result.Layers[1].Entities, 3)
+
+	require.Len(t, result.Lines, 1)
+	assert.Equal(t, Point{X: 10, Y: 5}, result.Lines[0].EndPoint)
+	require.Len(t, result.Circles, 1)
+	assert.Equal(t, 2.5, result.Circles[0].Radius)
+	require.Len(t, result.Blocks, 1)
+	assert.Equal(t, []AttributeInfo{{Tag: "WIDTH", Value: "900", Layer: "Walls"}}, result.Blocks[0].Attributes)
+	require.Len(t, result.Hatches, 1)
+	assert.True(t, result.Hatches[0].IsSolid)
+	require.Len(t, result.Texts, 1)
+	assert.Equal(t, TextInfo{
+		Value: "A1", Layer: "Holes", InsertionPoint: Point{X: 3, Y: 4}, Height: 2.5,
+		Rotation: 45, HorizontalAlign: "Center", VerticalAlign: "Middle",
+	}, result.Texts[0])
+
+	assert.Equal(t, []string{`Skipped entity 3 with unknown type "Spline"`}, result.Warnings)
+}
+
+func TestFromJSON_PolylinePoints(t *testing.T) {
+	input := `[{"type": "Polyline", "layer": "Walls", "closed": true, "pointCount": 2,
+  "points": [{"x": 0, "y": 0, "z": 0}, {"x": 3, "y": 4, "z": 1}]}]`
+
+	result, err := FromJSON(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, result.Polylines, 1)
+	assert.True(t, result.Polylines[0].IsClosed)
+	assert.Equal(t, []Point{{X: 0, Y: 0, Z: 0}, {X: 3, Y: 4, Z: 1}}, result.Polylines[0].Points)
+}
+
+func TestFromJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid JSON", "{not json"},
+		{"object instead of array", `{"type": "Line"}`},
+		{"empty input", ""},
+		{"entity that is not an object", `[1]`},
+		{"field of the wrong type", `[{"type": "Line", "color": "red"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromJSON(strings.NewReader(tt.input))
+			assert.Error(t, err)
+		})
+	}
+}
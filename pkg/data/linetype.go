@@ -0,0 +1,44 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LineType returns the line type with the given name, ignoring case as CAD programs do,
+// or nil when d has no such line type.
+func (d *ExtractedData) LineType(name string) *LineTypeInfo {
+	if d == nil {
+		return nil
+	}
+	if lineType, ok := d.LineTypes[name]; ok {
+		return lineType
+	}
+	for key, lineType := range d.LineTypes {
+		if strings.EqualFold(key, name) {
+			return lineType
+		}
+	}
+	return nil
+}
+
+// PatternString describes the dash pattern, e.g. "dash 0.50, gap 0.25, dot, gap 0.25",
+// or "solid" when the line type has no pattern.
+func (l *LineTypeInfo) PatternString() string {
+	if len(l.Pattern) == 0 {
+		return "solid"
+	}
+
+	parts := make([]string, len(l.Pattern))
+	for i, length := range l.Pattern {
+		switch {
+		case length > 0:
+			parts[i] = fmt.Sprintf("dash %.2f", length)
+		case length < 0:
+			parts[i] = fmt.Sprintf("gap %.2f", -length)
+		default:
+			parts[i] = "dot"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,25 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractedData_LineType(t *testing.T) {
+	dashed := &LineTypeInfo{Name: "DASHED", Pattern: []float64{0.5, -0.25}}
+	continuous := &LineTypeInfo{Name: "Continuous"}
+	d := &ExtractedData{LineTypes: map[string]*LineTypeInfo{"DASHED": dashed, "Continuous": continuous}}
+
+	assert.Same(t, dashed, d.LineType("DASHED"))
+	assert.Same(t, continuous, d.LineType("CONTINUOUS"), "names are matched ignoring case")
+	assert.Nil(t, d.LineType("HIDDEN"))
+	assert.Nil(t, (&ExtractedData{}).LineType("DASHED"))
+	assert.Nil(t, (*ExtractedData)(nil).LineType("DASHED"))
+}
+
+func TestLineTypeInfo_PatternString(t *testing.T) {
+	assert.Equal(t, "solid", (&LineTypeInfo{Name: "CONTINUOUS"}).PatternString())
+	assert.Equal(t, "dash 0.50, gap 0.25, dot, gap 0.25",
+		(&LineTypeInfo{Pattern: []float64{0.5, -0.25, 0, -0.25}}).PatternString())
+}
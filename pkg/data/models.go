@@ -1,6 +1,10 @@
 package data
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"strings"
+)
 
 // Point defines a 2D or 3D point.
 type Point struct {
@@ -12,9 +16,33 @@ func (p Point) String() string {
 	return fmt.Sprintf("(%.2f, %.2f, %.2f)", p.X, p.Y, p.Z)
 }
 
+// DistanceTo returns the 3D distance between p and q.
+func (p Point) DistanceTo(q Point) float64 {
+	return math.Sqrt((q.X-p.X)*(q.X-p.X) + (q.Y-p.Y)*(q.Y-p.Y) + (q.Z-p.Z)*(q.Z-p.Z))
+}
+
+// AngleTo returns the angle in radians from p to q in the XY plane, measured
+// counter-clockwise from the positive X axis. It returns 0 when the points coincide.
+func (p Point) AngleTo(q Point) float64 {
+	return math.Atan2(q.Y-p.Y, q.X-p.X)
+}
+
 // Entity is the interface that all DXF entities must implement.
 type Entity interface {
 	GetLayer() string
+	GetHandle() string // The DXF handle (group code 5); empty when the entity has none
+	GetColor() int     // The ACI color number, which may be ColorByLayer or ColorByBlock
+}
+
+// GroupCode is a raw DXF group code and its value.
+type GroupCode struct {
+	Code  int
+	Value string
+}
+
+// RawCodeHolder is implemented by entities that keep the raw group codes they were parsed from.
+type RawCodeHolder interface {
+	GetRawCodes() []GroupCode
 }
 
 // LayerInfo holds information about a DXF layer.
@@ -41,14 +69,47 @@ func (b BlockInfo) GetLayer() string {
 	return b.Layer
 }
 
+// GetRawCodes implements the RawCodeHolder interface for BlockInfo.
+func (b BlockInfo) GetRawCodes() []GroupCode {
+	return b.RawCodes
+}
+
+// GetHandle implements the Entity interface for BlockInfo.
+func (b BlockInfo) GetHandle() string {
+	return b.Handle
+}
+
+// GetColor implements the Entity interface for BlockInfo.
+func (b BlockInfo) GetColor() int {
+	return b.Color
+}
+
 // BlockInfo holds information about a block instance (Insert entity).
 type BlockInfo struct {
 	Name           string
 	Layer          string
+	Color          int
 	InsertionPoint Point
 	Rotation       float64
 	Scale          Point
 	Attributes     []AttributeInfo
+	Handle         string      // DXF handle (group code 5), unique within the drawing
+	RawCodes       []GroupCode // Raw group codes, kept only when the parser is asked to
+}
+
+// BlockDefinition holds the entities of a block defined in the BLOCKS section.
+// Entity coordinates are relative to the definition, with BasePoint mapping to an insertion's InsertionPoint.
+type BlockDefinition struct {
+	Name      string
+	BasePoint Point
+	Entities  []Entity
+}
+
+// LineTypeInfo holds a line type defined in the LTYPE table.
+type LineTypeInfo struct {
+	Name        string
+	Description string
+	Pattern     []float64 // Dash lengths: positive for dashes, negative for gaps, 0 for dots; empty for a solid line
 }
 
 // GetLayer implements the Entity interface for TextInfo.
@@ -56,14 +117,34 @@ func (t TextInfo) GetLayer() string {
 	return t.Layer
 }
 
+// GetRawCodes implements the RawCodeHolder interface for TextInfo.
+func (t TextInfo) GetRawCodes() []GroupCode {
+	return t.RawCodes
+}
+
+// GetHandle implements the Entity interface for TextInfo.
+func (t TextInfo) GetHandle() string {
+	return t.Handle
+}
+
+// GetColor implements the Entity interface for TextInfo.
+func (t TextInfo) GetColor() int {
+	return t.Color
+}
+
 // TextInfo holds information about a Text entity.
 type TextInfo struct {
-	Value          string
-	Layer          string
-	InsertionPoint Point
-	Height         float64
-	Rotation       float64
-	Style          string
+	Value           string
+	Layer           string
+	Color           int
+	InsertionPoint  Point
+	Height          float64
+	Rotation        float64 // Degrees counter-clockwise from the X axis
+	HorizontalAlign string  // As named by HorizontalAlignName; empty when unknown
+	VerticalAlign   string  // As named by VerticalAlignName; empty when unknown
+	Style           string
+	Handle          string      // DXF handle (group code 5), unique within the drawing
+	RawCodes        []GroupCode // Raw group codes, kept only when the parser is asked to
 }
 
 // GetLayer implements the Entity interface for LineInfo.
@@ -71,12 +152,34 @@ func (l LineInfo) GetLayer() string {
 	return l.Layer
 }
 
+// GetRawCodes implements the RawCodeHolder interface for LineInfo.
+func (l LineInfo) GetRawCodes() []GroupCode {
+	return l.RawCodes
+}
+
+// GetHandle implements the Entity interface for LineInfo.
+func (l LineInfo) GetHandle() string {
+	return l.Handle
+}
+
+// GetColor implements the Entity interface for LineInfo.
+func (l LineInfo) GetColor() int {
+	return l.Color
+}
+
+// Length returns the distance between the line's start and end points.
+func (l *LineInfo) Length() float64 {
+	return l.StartPoint.DistanceTo(l.EndPoint)
+}
+
 // LineInfo holds information about a Line entity.
 type LineInfo struct {
 	StartPoint Point
 	EndPoint   Point
 	Layer      string
 	Color      int
+	Handle     string      // DXF handle (group code 5), unique within the drawing
+	RawCodes   []GroupCode // Raw group codes, kept only when the parser is asked to
 }
 
 // GetLayer implements the Entity interface for CircleInfo.
@@ -84,12 +187,29 @@ func (c CircleInfo) GetLayer() string {
 	return c.Layer
 }
 
+// GetRawCodes implements the RawCodeHolder interface for CircleInfo.
+func (c CircleInfo) GetRawCodes() []GroupCode {
+	return c.RawCodes
+}
+
+// GetHandle implements the Entity interface for CircleInfo.
+func (c CircleInfo) GetHandle() string {
+	return c.Handle
+}
+
+// GetColor implements the Entity interface for CircleInfo.
+func (c CircleInfo) GetColor() int {
+	return c.Color
+}
+
 // CircleInfo holds information about a Circle entity.
 type CircleInfo struct {
 	Center     Point
 	Radius     float64
 	Layer      string
 	Color      int
+	Handle     string      // DXF handle (group code 5), unique within the drawing
+	RawCodes   []GroupCode // Raw group codes, kept only when the parser is asked to
 }
 
 // GetLayer implements the Entity interface for PolylineInfo.
@@ -97,21 +217,292 @@ func (p PolylineInfo) GetLayer() string {
 	return p.Layer
 }
 
+// GetRawCodes implements the RawCodeHolder interface for PolylineInfo.
+func (p PolylineInfo) GetRawCodes() []GroupCode {
+	return p.RawCodes
+}
+
+// GetHandle implements the Entity interface for PolylineInfo.
+func (p PolylineInfo) GetHandle() string {
+	return p.Handle
+}
+
+// GetColor implements the Entity interface for PolylineInfo.
+func (p PolylineInfo) GetColor() int {
+	return p.Color
+}
+
 // PolylineInfo holds information about a Polyline entity.
 type PolylineInfo struct {
 	Points     []Point
 	Layer      string
 	Color      int
 	IsClosed   bool
+	Handle     string      // DXF handle (group code 5), unique within the drawing
+	RawCodes   []GroupCode // Raw group codes, kept only when the parser is asked to
+}
+
+// Length returns the total length of the polyline's segments, including the
+// closing segment when the polyline is closed.
+func (p *PolylineInfo) Length() float64 {
+	if len(p.Points) < 2 {
+		return 0
+	}
+
+	length := 0.0
+	for i := 1; i < len(p.Points); i++ {
+		length += p.Points[i-1].DistanceTo(p.Points[i])
+	}
+	if p.IsClosed {
+		length += p.Points[len(p.Points)-1].DistanceTo(p.Points[0])
+	}
+	return length
+}
+
+// Area returns the area enclosed by a closed polyline using the shoelace formula.
+// It returns 0 for open polylines and polylines with fewer than 3 points.
+func (p *PolylineInfo) Area() float64 {
+	if !p.IsClosed || len(p.Points) < 3 {
+		return 0
+	}
+
+	sum := 0.0
+	for i := range p.Points {
+		next := p.Points[(i+1)%len(p.Points)]
+		sum += p.Points[i].X*next.Y - next.X*p.Points[i].Y
+	}
+	return math.Abs(sum) / 2
+}
+
+// GetLayer implements the Entity interface for DimensionInfo.
+func (d DimensionInfo) GetLayer() string {
+	return d.Layer
+}
+
+// GetRawCodes implements the RawCodeHolder interface for DimensionInfo.
+func (d DimensionInfo) GetRawCodes() []GroupCode {
+	return d.RawCodes
+}
+
+// GetHandle implements the Entity interface for DimensionInfo.
+func (d DimensionInfo) GetHandle() string {
+	return d.Handle
+}
+
+// GetColor implements the Entity interface for DimensionInfo.
+func (d DimensionInfo) GetColor() int {
+	return d.Color
+}
+
+// DimensionInfo holds information about a Dimension entity.
+type DimensionInfo struct {
+	DimensionType   string
+	TextOverride    string
+	Measurement     float64
+	DefinitionPoint Point
+	Layer           string
+	Color           int
+	Handle          string      // DXF handle (group code 5), unique within the drawing
+	RawCodes        []GroupCode // Raw group codes, kept only when the parser is asked to
+}
+
+// DisplayText returns the text shown for the dimension: the override when set,
+// otherwise the measured value. An override of "<>" stands for the measurement.
+func (d DimensionInfo) DisplayText() string {
+	if d.TextOverride != "" && d.TextOverride != "<>" {
+		return d.TextOverride
+	}
+	return fmt.Sprintf("%.2f", d.Measurement)
+}
+
+// GetLayer implements the Entity interface for PointInfo.
+func (p PointInfo) GetLayer() string {
+	return p.Layer
+}
+
+// GetRawCodes implements the RawCodeHolder interface for PointInfo.
+func (p PointInfo) GetRawCodes() []GroupCode {
+	return p.RawCodes
+}
+
+// GetHandle implements the Entity interface for PointInfo.
+func (p PointInfo) GetHandle() string {
+	return p.Handle
+}
+
+// GetColor implements the Entity interface for PointInfo.
+func (p PointInfo) GetColor() int {
+	return p.Color
+}
+
+// PointInfo holds information about a Point entity.
+type PointInfo struct {
+	Location Point
+	Layer    string
+	Color    int
+	Handle   string      // DXF handle (group code 5), unique within the drawing
+	RawCodes []GroupCode // Raw group codes, kept only when the parser is asked to
+}
+
+// GetLayer implements the Entity interface for HatchInfo.
+func (h HatchInfo) GetLayer() string {
+	return h.Layer
+}
+
+// GetRawCodes implements the RawCodeHolder interface for HatchInfo.
+func (h HatchInfo) GetRawCodes() []GroupCode {
+	return h.RawCodes
+}
+
+// GetHandle implements the Entity interface for HatchInfo.
+func (h HatchInfo) GetHandle() string {
+	return h.Handle
+}
+
+// GetColor implements the Entity interface for HatchInfo.
+func (h HatchInfo) GetColor() int {
+	return h.Color
+}
+
+// HatchInfo holds summary information about a Hatch entity.
+type HatchInfo struct {
+	PatternName        string
+	IsSolid            bool
+	BoundaryPointCount int
+	Layer              string
+	Color              int
+	Handle             string      // DXF handle (group code 5), unique within the drawing
+	RawCodes           []GroupCode // Raw group codes, kept only when the parser is asked to
 }
 
 // ExtractedData holds all data parsed from the DXF.
 type ExtractedData struct {
-	DXFVersion string
-	Layers     []LayerInfo
-	Blocks     []BlockInfo
-	Texts      []TextInfo
-	Lines      []LineInfo
-	Circles    []CircleInfo
-	Polylines  []PolylineInfo
+	DXFVersion       string
+	Units            string // Drawing units from $INSUNITS, as named by UnitName
+	Layers           []LayerInfo
+	Blocks           []BlockInfo
+	Texts            []TextInfo
+	Lines            []LineInfo
+	Circles          []CircleInfo
+	Polylines        []PolylineInfo
+	Dimensions       []DimensionInfo
+	Points           []PointInfo
+	Hatches          []HatchInfo
+	BlockDefinitions map[string]*BlockDefinition // Block definitions by name; nil when not parsed
+	LineTypes        map[string]*LineTypeInfo    // Line types by name; nil when not parsed
+	HeaderExtents    *BoundingBox                // Extents stored in $EXTMIN and $EXTMAX; nil when the header has none
+	Warnings         []string                    // Non-fatal problems found while parsing
+}
+
+// ForEachEntity calls fn for every entity of every layer, in layer order.
+// It does nothing when d is nil.
+func (d *ExtractedData) ForEachEntity(fn func(layer *LayerInfo, e Entity)) {
+	d.forEachEntity(false, fn)
+}
+
+// ForEachVisibleEntity is like ForEachEntity but skips layers that are off or frozen.
+func (d *ExtractedData) ForEachVisibleEntity(fn func(layer *LayerInfo, e Entity)) {
+	d.forEachEntity(true, fn)
+}
+
+// forEachEntity calls fn for the entities of each layer, skipping hidden layers when visibleOnly is set
+func (d *ExtractedData) forEachEntity(visibleOnly bool, fn func(layer *LayerInfo, e Entity)) {
+	if d == nil {
+		return
+	}
+	for i := range d.Layers {
+		layer := &d.Layers[i]
+		if visibleOnly && (!layer.IsOn || layer.IsFrozen) {
+			continue
+		}
+		for _, entity := range layer.Entities {
+			fn(layer, entity)
+		}
+	}
+}
+
+// AllEntities returns every parsed entity as a flat list, in the order
+// blocks, texts, lines, circles, polylines, dimensions, points, hatches.
+func (d *ExtractedData) AllEntities() []Entity {
+	if d == nil {
+		return nil
+	}
+
+	entities := make([]Entity, 0, len(d.Blocks)+len(d.Texts)+len(d.Lines)+len(d.Circles)+len(d.Polylines)+len(d.Dimensions)+len(d.Points)+len(d.Hatches))
+	for i := range d.Blocks {
+		entities = append(entities, &d.Blocks[i])
+	}
+	for i := range d.Texts {
+		entities = append(entities, &d.Texts[i])
+	}
+	for i := range d.Lines {
+		entities = append(entities, &d.Lines[i])
+	}
+	for i := range d.Circles {
+		entities = append(entities, &d.Circles[i])
+	}
+	for i := range d.Polylines {
+		entities = append(entities, &d.Polylines[i])
+	}
+	for i := range d.Dimensions {
+		entities = append(entities, &d.Dimensions[i])
+	}
+	for i := range d.Points {
+		entities = append(entities, &d.Points[i])
+	}
+	for i := range d.Hatches {
+		entities = append(entities, &d.Hatches[i])
+	}
+	return entities
+}
+
+// ValidateLayerConsistency returns a message for each entity whose own layer differs from
+// the layer it is stored under, and for each layer name that entities reference but the
+// layer table does not define. An empty result means the data is consistent.
+func (d *ExtractedData) ValidateLayerConsistency() []string {
+	if d == nil {
+		return nil
+	}
+
+	var messages []string
+	defined := make(map[string]bool, len(d.Layers))
+	for _, layer := range d.Layers {
+		defined[layer.Name] = true
+		for i, entity := range layer.Entities {
+			if entity != nil && entity.GetLayer() != layer.Name {
+				messages = append(messages, fmt.Sprintf("%s %d on layer %s reports layer %q",
+					entityKindName(entity), i+1, layer.Name, entity.GetLayer()))
+			}
+		}
+	}
+
+	// Count references to undefined layers, in the order they first appear
+	var missing []string
+	missingCounts := make(map[string]int)
+	for _, entity := range d.AllEntities() {
+		name := entity.GetLayer()
+		if defined[name] {
+			continue
+		}
+		if missingCounts[name] == 0 {
+			missing = append(missing, name)
+		}
+		missingCounts[name]++
+	}
+	for _, name := range missing {
+		if missingCounts[name] == 1 {
+			messages = append(messages, fmt.Sprintf("1 entity references nonexistent layer %q", name))
+		} else {
+			messages = append(messages, fmt.Sprintf("%d entities reference nonexistent layer %q", missingCounts[name], name))
+		}
+	}
+
+	return messages
+}
+
+// entityKindName returns a short type name for an entity, e.g. "Line" for *LineInfo
+func entityKindName(entity Entity) string {
+	name := fmt.Sprintf("%T", entity)
+	name = name[strings.LastIndex(name, ".")+1:]
+	return strings.TrimSuffix(name, "Info")
 }
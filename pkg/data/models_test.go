@@ -1,6 +1,7 @@
 package data
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,6 +77,9 @@ func TestGetLayerImplementations(t *testing.T) {
 		{"TextInfo", TextInfo{Layer: "Layer3"}, "Layer3"},
 		{"BlockInfo", BlockInfo{Layer: "Layer4"}, "Layer4"},
 		{"LineInfo", LineInfo{Layer: "Layer5"}, "Layer5"},
+		{"DimensionInfo", DimensionInfo{Layer: "Layer6"}, "Layer6"},
+		{"PointInfo", PointInfo{Layer: "Layer7"}, "Layer7"},
+		{"HatchInfo", HatchInfo{Layer: "Layer8"}, "Layer8"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -86,6 +90,29 @@ func TestGetLayerImplementations(t *testing.T) {
 	}
 }
 
+func TestGetColorAndHandleImplementations(t *testing.T) {
+	tests := []struct {
+		name      string
+		entity    Entity
+		wantColor int
+	}{
+		{"PolylineInfo", PolylineInfo{Color: 1, Handle: "A1"}, 1},
+		{"CircleInfo", CircleInfo{Color: 2, Handle: "A1"}, 2},
+		{"TextInfo", TextInfo{Color: 3, Handle: "A1"}, 3},
+		{"BlockInfo", BlockInfo{Color: ColorByLayer, Handle: "A1"}, ColorByLayer},
+		{"LineInfo", LineInfo{Color: ColorByBlock, Handle: "A1"}, ColorByBlock},
+		{"DimensionInfo", DimensionInfo{Color: 4, Handle: "A1"}, 4},
+		{"PointInfo", PointInfo{Color: 7, Handle: "A1"}, 7},
+		{"HatchInfo", HatchInfo{Color: 8, Handle: "A1"}, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantColor, tt.entity.GetColor())
+			assert.Equal(t, "A1", tt.entity.GetHandle())
+		})
+	}
+}
+
 func TestNewExtractedData(t *testing.T) {
 	data := &ExtractedData{
 		DXFVersion: "AC1018",
@@ -96,3 +123,201 @@ func TestNewExtractedData(t *testing.T) {
 	assert.Empty(t, data.Blocks)
 	assert.Empty(t, data.Texts)
 }
+
+func TestExtractedData_AllEntities(t *testing.T) {
+	d := &ExtractedData{
+		Blocks:    []BlockInfo{{Name: "B", Layer: "0"}},
+		Texts:     []TextInfo{{Value: "T", Layer: "0"}},
+		Lines:     []LineInfo{{Layer: "1"}, {Layer: "2"}},
+		Circles:   []CircleInfo{{Layer: "3"}},
+		Polylines: []PolylineInfo{{Layer: "4"}},
+		Points:    []PointInfo{{Layer: "5"}},
+	}
+
+	entities := d.AllEntities()
+	assert.Len(t, entities, 7)
+	assert.IsType(t, &BlockInfo{}, entities[0])
+	assert.IsType(t, &PolylineInfo{}, entities[5])
+	assert.IsType(t, &PointInfo{}, entities[6])
+
+	var nilData *ExtractedData
+	assert.Empty(t, nilData.AllEntities())
+}
+
+func TestExtractedData_ForEachEntity(t *testing.T) {
+	line := &LineInfo{Layer: "Walls"}
+	circle := &CircleInfo{Layer: "Hidden"}
+	text := &TextInfo{Layer: "Frozen"}
+	d := &ExtractedData{
+		Layers: []LayerInfo{
+			{Name: "Walls", IsOn: true, Entities: []Entity{line}},
+			{Name: "Empty", IsOn: true},
+			{Name: "Hidden", IsOn: false, Entities: []Entity{circle}},
+			{Name: "Frozen", IsOn: true, IsFrozen: true, Entities: []Entity{text}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		data           *ExtractedData
+		visibleOnly    bool
+		expectedLayers []string
+		expected       []Entity
+	}{
+		{"all layers in order", d, false, []string{"Walls", "Hidden", "Frozen"}, []Entity{line, circle, text}},
+		{"visible layers only", d, true, []string{"Walls"}, []Entity{line}},
+		{"nil data", nil, false, nil, nil},
+		{"nil data visible only", nil, true, nil, nil},
+		{"empty layers", &ExtractedData{Layers: []LayerInfo{{Name: "Empty", IsOn: true}}}, false, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var layers []string
+			var entities []Entity
+			visit := func(layer *LayerInfo, e Entity) {
+				layers = append(layers, layer.Name)
+				entities = append(entities, e)
+			}
+			if tt.visibleOnly {
+				tt.data.ForEachVisibleEntity(visit)
+			} else {
+				tt.data.ForEachEntity(visit)
+			}
+			assert.Equal(t, tt.expectedLayers, layers)
+			assert.Equal(t, tt.expected, entities)
+		})
+	}
+}
+
+func TestDimensionInfo_DisplayText(t *testing.T) {
+	tests := []struct {
+		name string
+		dim  DimensionInfo
+		want string
+	}{
+		{"no override shows measurement", DimensionInfo{Measurement: 12.345}, "12.35"},
+		{"override replaces measurement", DimensionInfo{Measurement: 10, TextOverride: "TYP."}, "TYP."},
+		{"placeholder override shows measurement", DimensionInfo{Measurement: 3, TextOverride: "<>"}, "3.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.dim.DisplayText())
+		})
+	}
+}
+
+func TestPolylineInfo_LengthAndArea(t *testing.T) {
+	square := []Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}}
+	tests := []struct {
+		name       string
+		polyline   PolylineInfo
+		wantLength float64
+		wantArea   float64
+	}{
+		{"no points", PolylineInfo{IsClosed: true}, 0, 0},
+		{"single point", PolylineInfo{Points: []Point{{X: 1, Y: 1}}, IsClosed: true}, 0, 0},
+		{"open square", PolylineInfo{Points: square}, 12, 0},
+		{"closed square", PolylineInfo{Points: square, IsClosed: true}, 16, 16},
+		{"closed two points", PolylineInfo{Points: []Point{{X: 0, Y: 0}, {X: 3, Y: 4}}, IsClosed: true}, 10, 0},
+		{"clockwise triangle", PolylineInfo{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 4, Y: 0}}, IsClosed: true}, 12, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.wantLength, tt.polyline.Length(), 1e-9)
+			assert.InDelta(t, tt.wantArea, tt.polyline.Area(), 1e-9)
+		})
+	}
+}
+
+func TestPoint_DistanceTo(t *testing.T) {
+	tests := []struct {
+		name string
+		p, q Point
+		want float64
+	}{
+		{"same point", Point{X: 1, Y: 2, Z: 3}, Point{X: 1, Y: 2, Z: 3}, 0},
+		{"2D", Point{}, Point{X: 3, Y: 4}, 5},
+		{"3D", Point{X: 1, Y: 1, Z: 1}, Point{X: 3, Y: 3, Z: 2}, 3},
+		{"negative coordinates", Point{X: -1, Y: -1}, Point{X: 2, Y: 3}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.p.DistanceTo(tt.q), 1e-9)
+			assert.InDelta(t, tt.want, tt.q.DistanceTo(tt.p), 1e-9, "Distance should be symmetric")
+		})
+	}
+}
+
+func TestPoint_AngleTo(t *testing.T) {
+	tests := []struct {
+		name string
+		q    Point
+		want float64
+	}{
+		{"same point", Point{}, 0},
+		{"positive X", Point{X: 2}, 0},
+		{"positive Y", Point{Y: 2}, math.Pi / 2},
+		{"negative X", Point{X: -2}, math.Pi},
+		{"negative Y", Point{Y: -2}, -math.Pi / 2},
+		{"diagonal", Point{X: 1, Y: 1}, math.Pi / 4},
+		{"ignores Z", Point{X: 1, Z: 5}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, Point{}.AngleTo(tt.q), 1e-9)
+		})
+	}
+}
+
+func TestLineInfo_Length(t *testing.T) {
+	assert.InDelta(t, 5, (&LineInfo{StartPoint: Point{X: 1, Y: 1}, EndPoint: Point{X: 4, Y: 5}}).Length(), 1e-9)
+	assert.Zero(t, (&LineInfo{StartPoint: Point{X: 2, Y: 2}, EndPoint: Point{X: 2, Y: 2}}).Length(), "Zero-length line")
+}
+
+func TestExtractedData_ValidateLayerConsistency(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     *ExtractedData
+		expected []string
+	}{
+		{
+			name: "consistent data",
+			data: &ExtractedData{
+				Layers: []LayerInfo{{Name: "Walls", Entities: []Entity{&LineInfo{Layer: "Walls"}}}},
+				Lines:  []LineInfo{{Layer: "Walls"}},
+			},
+		},
+		{
+			name: "entity stored under another layer",
+			data: &ExtractedData{
+				Layers: []LayerInfo{
+					{Name: "Walls", Entities: []Entity{&LineInfo{Layer: "Walls"}, &CircleInfo{Layer: "Doors"}}},
+					{Name: "Doors"},
+				},
+			},
+			expected: []string{`Circle 2 on layer Walls reports layer "Doors"`},
+		},
+		{
+			name: "entities referencing undefined layers",
+			data: &ExtractedData{
+				Layers: []LayerInfo{{Name: "0"}},
+				Texts:  []TextInfo{{Layer: "Ghost"}},
+				Lines:  []LineInfo{{Layer: "Ghost"}, {Layer: "Old"}, {Layer: "0"}},
+			},
+			expected: []string{
+				`2 entities reference nonexistent layer "Ghost"`,
+				`1 entity references nonexistent layer "Old"`,
+			},
+		},
+		{
+			name: "nil data",
+			data: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.data.ValidateLayerConsistency())
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package data
+
+import "math"
+
+// TranslateEntity returns a copy of entity moved by dx and dy. Every position moves,
+// including text, block and attribute insertion points; radii, lengths and counts are
+// unchanged. Unknown types are returned as they are.
+func TranslateEntity(entity Entity, dx, dy float64) Entity {
+	return mapEntityPoints(entity, func(p Point) Point {
+		return Point{X: p.X + dx, Y: p.Y + dy, Z: p.Z}
+	})
+}
+
+// SnapEntity returns a copy of entity with every coordinate of its positions rounded to
+// the nearest multiple of size, like TranslateEntity moves them. Radii, lengths and counts
+// are unchanged. A size of zero or less returns the entity as it is.
+func SnapEntity(entity Entity, size float64) Entity {
+	if size <= 0 {
+		return entity
+	}
+	return mapEntityPoints(entity, func(p Point) Point {
+		return Point{X: snap(p.X, size), Y: snap(p.Y, size), Z: snap(p.Z, size)}
+	})
+}
+
+// snap rounds v to the nearest multiple of size
+func snap(v, size float64) float64 {
+	steps := math.Round(v / size)
+	// Dividing by a whole inverse keeps multiples of sizes such as 0.1 exact as decimals,
+	// and adding zero turns a rounded -0 into 0
+	if inverse := 1 / size; inverse == math.Round(inverse) {
+		return steps/inverse + 0
+	}
+	return steps*size + 0
+}
+
+// mapEntityPoints returns a copy of entity with fn applied to each of its positions
+func mapEntityPoints(entity Entity, fn func(Point) Point) Entity {
+	switch e := cloneEntity(entity).(type) {
+	case *LineInfo:
+		e.StartPoint = fn(e.StartPoint)
+		e.EndPoint = fn(e.EndPoint)
+		return e
+	case *CircleInfo:
+		e.Center = fn(e.Center)
+		return e
+	case *TextInfo:
+		e.InsertionPoint = fn(e.InsertionPoint)
+		return e
+	case *BlockInfo:
+		e.InsertionPoint = fn(e.InsertionPoint)
+		for i := range e.Attributes {
+			e.Attributes[i].Position = fn(e.Attributes[i].Position)
+		}
+		return e
+	case *PolylineInfo:
+		for i := range e.Points {
+			e.Points[i] = fn(e.Points[i])
+		}
+		return e
+	case *DimensionInfo:
+		e.DefinitionPoint = fn(e.DefinitionPoint)
+		return e
+	case *PointInfo:
+		e.Location = fn(e.Location)
+		return e
+	default:
+		// Hatches keep only a summary, so there is no geometry to move
+		return e
+	}
+}
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateEntity(t *testing.T) {
+	tests := []struct {
+		name     string
+		entity   Entity
+		expected Entity
+	}{
+		{
+			name:     "line",
+			entity:   &LineInfo{StartPoint: Point{X: 10, Y: 20, Z: 1}, EndPoint: Point{X: 15, Y: 25}, Layer: "Walls"},
+			expected: &LineInfo{StartPoint: Point{X: 0, Y: 15, Z: 1}, EndPoint: Point{X: 5, Y: 20}, Layer: "Walls"},
+		},
+		{
+			name:     "circle keeps its radius",
+			entity:   &CircleInfo{Center: Point{X: 12, Y: 8}, Radius: 3},
+			expected: &CircleInfo{Center: Point{X: 2, Y: 3}, Radius: 3},
+		},
+		{
+			name:     "text",
+			entity:   &TextInfo{Value: "A", InsertionPoint: Point{X: 10, Y: 5}, Height: 2.5},
+			expected: &TextInfo{Value: "A", InsertionPoint: Point{X: 0, Y: 0}, Height: 2.5},
+		},
+		{
+			name: "block and its attributes",
+			entity: &BlockInfo{Name: "DOOR", InsertionPoint: Point{X: 20, Y: 10}, Scale: Point{X: 1, Y: 1, Z: 1},
+				Attributes: []AttributeInfo{{Tag: "NUM", Value: "D1", Position: Point{X: 21, Y: 11}}}},
+			expected: &BlockInfo{Name: "DOOR", InsertionPoint: Point{X: 10, Y: 5}, Scale: Point{X: 1, Y: 1, Z: 1},
+				Attributes: []AttributeInfo{{Tag: "NUM", Value: "D1", Position: Point{X: 11, Y: 6}}}},
+		},
+		{
+			name:     "polyline",
+			entity:   &PolylineInfo{Points: []Point{{X: 10, Y: 5}, {X: 20, Y: 5}}, IsClosed: true},
+			expected: &PolylineInfo{Points: []Point{{X: 0, Y: 0}, {X: 10, Y: 0}}, IsClosed: true},
+		},
+		{
+			name:     "dimension keeps its measurement",
+			entity:   &DimensionInfo{DefinitionPoint: Point{X: 10, Y: 10}, Measurement: 42},
+			expected: &DimensionInfo{DefinitionPoint: Point{X: 0, Y: 5}, Measurement: 42},
+		},
+		{
+			name:     "point",
+			entity:   &PointInfo{Location: Point{X: 11, Y: 6, Z: 2}},
+			expected: &PointInfo{Location: Point{X: 1, Y: 1, Z: 2}},
+		},
+		{
+			name:     "hatch is unchanged",
+			entity:   &HatchInfo{PatternName: "ANSI31", BoundaryPointCount: 4},
+			expected: &HatchInfo{PatternName: "ANSI31", BoundaryPointCount: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TranslateEntity(tt.entity, -10, -5))
+		})
+	}
+}
+
+func TestTranslateEntity_LeavesOriginalUnchanged(t *testing.T) {
+	polyline := &PolylineInfo{Points: []Point{{X: 1, Y: 1}}}
+	block := &BlockInfo{Attributes: []AttributeInfo{{Position: Point{X: 1, Y: 1}}}}
+
+	moved, ok := TranslateEntity(polyline, 5, 5).(*PolylineInfo)
+	require.True(t, ok)
+	assert.Equal(t, Point{X: 6, Y: 6}, moved.Points[0])
+	assert.Equal(t, Point{X: 1, Y: 1}, polyline.Points[0])
+
+	TranslateEntity(block, 5, 5)
+	assert.Equal(t, Point{X: 1, Y: 1}, block.Attributes[0].Position)
+}
+
+func TestSnapEntity(t *testing.T) {
+	tests := []struct {
+		name     string
+		entity   Entity
+		size     float64
+		expected Entity
+	}{
+		{
+			name:     "line rounds to the nearest multiple",
+			entity:   &LineInfo{StartPoint: Point{X: 10.12, Y: 10.13, Z: -0.1}, EndPoint: Point{X: 2.6, Y: -3.4}},
+			size:     0.25,
+			expected: &LineInfo{StartPoint: Point{X: 10, Y: 10.25, Z: 0}, EndPoint: Point{X: 2.5, Y: -3.5}},
+		},
+		{
+			name:     "decimal grids stay exact",
+			entity:   &PointInfo{Location: Point{X: 0.29, Y: 0.71}},
+			size:     0.1,
+			expected: &PointInfo{Location: Point{X: 0.3, Y: 0.7}},
+		},
+		{
+			name:     "circle keeps its radius",
+			entity:   &CircleInfo{Center: Point{X: 4.4, Y: 5.6}, Radius: 1.3},
+			size:     1,
+			expected: &CircleInfo{Center: Point{X: 4, Y: 6}, Radius: 1.3},
+		},
+		{
+			name:     "zero size leaves the entity as it is",
+			entity:   &TextInfo{InsertionPoint: Point{X: 10.12}},
+			size:     0,
+			expected: &TextInfo{InsertionPoint: Point{X: 10.12}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SnapEntity(tt.entity, tt.size))
+		})
+	}
+
+	line := &LineInfo{EndPoint: Point{X: 1.1}}
+	SnapEntity(line, 1)
+	assert.Equal(t, 1.1, line.EndPoint.X, "the original is left untouched")
+}
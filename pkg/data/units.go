@@ -0,0 +1,41 @@
+package data
+
+import "fmt"
+
+// unitNames are the labels of the $INSUNITS codes, indexed by code
+var unitNames = []string{
+	"Unitless",
+	"Inches",
+	"Feet",
+	"Miles",
+	"Millimeters",
+	"Centimeters",
+	"Meters",
+	"Kilometers",
+	"Microinches",
+	"Mils",
+	"Yards",
+	"Angstroms",
+	"Nanometers",
+	"Microns",
+	"Decimeters",
+	"Decameters",
+	"Hectometers",
+	"Gigameters",
+	"Astronomical units",
+	"Light years",
+	"Parsecs",
+	"US survey feet",
+	"US survey inches",
+	"US survey yards",
+	"US survey miles",
+}
+
+// UnitName returns the label of a $INSUNITS code, such as "Millimeters" for 4.
+// Codes outside the DXF reference are labelled with their number.
+func UnitName(code int) string {
+	if code < 0 || code >= len(unitNames) {
+		return fmt.Sprintf("Unknown units (%d)", code)
+	}
+	return unitNames[code]
+}
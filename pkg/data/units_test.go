@@ -0,0 +1,30 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitName(t *testing.T) {
+	tests := []struct {
+		code     int
+		expected string
+	}{
+		{0, "Unitless"},
+		{1, "Inches"},
+		{2, "Feet"},
+		{4, "Millimeters"},
+		{6, "Meters"},
+		{21, "US survey feet"},
+		{24, "US survey miles"},
+		{25, "Unknown units (25)"},
+		{-1, "Unknown units (-1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, UnitName(tt.code))
+		})
+	}
+}
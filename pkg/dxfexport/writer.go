@@ -0,0 +1,242 @@
+// Package dxfexport writes extracted entities back out as DXF group codes.
+package dxfexport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+)
+
+// maxTextChunk is the longest string a single DXF group value may hold
+const maxTextChunk = 250
+
+// Writer writes entities as DXF group code pairs.
+// Geometry is written in the R12 style, with polylines as POLYLINE, VERTEX and SEQEND,
+// so that the widest range of CAD tools can read it.
+type Writer struct {
+	w   *bufio.Writer
+	err error // First write error; later writes are skipped
+}
+
+// NewWriter creates a writer that writes to w. Call Flush when done.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// WriteEntities writes entities as a complete DXF ENTITIES section. Nil entities are skipped.
+func WriteEntities(w io.Writer, entities []data.Entity) error {
+	writer := NewWriter(w)
+	writer.group(0, "SECTION")
+	writer.group(2, "ENTITIES")
+	for i, entity := range entities {
+		if entity == nil {
+			continue
+		}
+		if err := writer.WriteEntity(entity); err != nil {
+			return fmt.Errorf("failed to write entity %d: %w", i+1, err)
+		}
+	}
+	writer.group(0, "ENDSEC")
+	return writer.Flush()
+}
+
+// WriteEntity writes the group codes of a single entity.
+// It returns an error for entity types it cannot write.
+func (w *Writer) WriteEntity(entity data.Entity) error {
+	switch e := entity.(type) {
+	case *data.LineInfo:
+		w.start("LINE", e.Layer, e.Color)
+		w.point(10, e.StartPoint)
+		w.point(11, e.EndPoint)
+	case *data.CircleInfo:
+		w.start("CIRCLE", e.Layer, e.Color)
+		w.point(10, e.Center)
+		w.float(40, e.Radius)
+	case *data.TextInfo:
+		w.writeText(e)
+	case *data.PolylineInfo:
+		w.writePolyline(e)
+	case *data.BlockInfo:
+		w.writeInsert(e)
+	case *data.DimensionInfo:
+		w.start("DIMENSION", e.Layer, e.Color)
+		w.point(10, e.DefinitionPoint)
+		w.int(70, dimensionTypeFlags(e.DimensionType))
+		if e.TextOverride != "" {
+			w.group(1, e.TextOverride)
+		}
+		w.float(42, e.Measurement)
+	case *data.PointInfo:
+		w.start("POINT", e.Layer, e.Color)
+		w.point(10, e.Location)
+	case *data.HatchInfo:
+		// Only the hatch summary is kept, so the hatch is written without boundary paths
+		w.start("HATCH", e.Layer, e.Color)
+		w.point(10, data.Point{})
+		w.group(2, e.PatternName)
+		w.int(70, boolFlag(e.IsSolid))
+		w.int(71, 0)
+		w.int(91, 0)
+		w.int(75, 0)
+		w.int(76, 1)
+	default:
+		return fmt.Errorf("unsupported entity type %T", entity)
+	}
+	return w.err
+}
+
+// Flush writes any buffered output and returns the first error met while writing
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.w.Flush(); err != nil {
+		return fmt.Errorf("failed to write DXF: %w", err)
+	}
+	return nil
+}
+
+// writeText writes a TEXT entity, or an MTEXT entity when the value spans several lines
+func (w *Writer) writeText(e *data.TextInfo) {
+	value := strings.ReplaceAll(e.Value, "\r\n", "\n")
+	if !strings.Contains(value, "\n") {
+		w.start("TEXT", e.Layer, e.Color)
+		w.point(10, e.InsertionPoint)
+		w.float(40, e.Height)
+		w.group(1, value)
+		w.float(50, e.Rotation)
+		if e.Style != "" {
+			w.group(7, e.Style)
+		}
+		return
+	}
+
+	// MTEXT marks line breaks with \P and splits long values into chunks of 250 characters,
+	// all but the last written with group 3
+	value = strings.ReplaceAll(value, "\n", `\P`)
+	w.start("MTEXT", e.Layer, e.Color)
+	w.point(10, e.InsertionPoint)
+	w.float(40, e.Height)
+	for len(value) > maxTextChunk {
+		// Split between characters, not inside a multi-byte one
+		n := maxTextChunk
+		for n > 0 && !utf8.RuneStart(value[n]) {
+			n--
+		}
+		w.group(3, value[:n])
+		value = value[n:]
+	}
+	w.group(1, value)
+	w.float(50, e.Rotation)
+	if e.Style != "" {
+		w.group(7, e.Style)
+	}
+}
+
+// writePolyline writes a POLYLINE entity followed by its VERTEX entities and a SEQEND
+func (w *Writer) writePolyline(e *data.PolylineInfo) {
+	w.start("POLYLINE", e.Layer, e.Color)
+	w.int(66, 1)
+	w.point(10, data.Point{})
+	w.int(70, boolFlag(e.IsClosed))
+	for _, p := range e.Points {
+		w.start("VERTEX", e.Layer, data.ColorByLayer)
+		w.point(10, p)
+	}
+	w.start("SEQEND", e.Layer, data.ColorByLayer)
+}
+
+// writeInsert writes an INSERT entity, followed by its ATTRIB entities and a SEQEND when it has attributes
+func (w *Writer) writeInsert(e *data.BlockInfo) {
+	w.start("INSERT", e.Layer, e.Color)
+	if len(e.Attributes) > 0 {
+		w.int(66, 1)
+	}
+	w.group(2, e.Name)
+	w.point(10, e.InsertionPoint)
+	w.float(41, e.Scale.X)
+	w.float(42, e.Scale.Y)
+	w.float(43, e.Scale.Z)
+	w.float(50, e.Rotation)
+	if len(e.Attributes) == 0 {
+		return
+	}
+
+	for _, attribute := range e.Attributes {
+		layer := attribute.Layer
+		if layer == "" {
+			layer = e.Layer
+		}
+		w.start("ATTRIB", layer, data.ColorByLayer)
+		w.point(10, attribute.Position)
+		w.float(40, 1)
+		w.group(1, attribute.Value)
+		w.group(2, attribute.Tag)
+		w.int(70, 0)
+	}
+	w.start("SEQEND", e.Layer, data.ColorByLayer)
+}
+
+// start begins an entity of the given kind on a layer. A ByLayer color is left out,
+// as it is the DXF default; ByBlock and explicit colors are written.
+func (w *Writer) start(kind, layer string, color int) {
+	w.group(0, kind)
+	w.group(8, layer)
+	if color != data.ColorByLayer {
+		w.int(62, color)
+	}
+}
+
+// point writes a point as the X, Y and Z groups that follow from code
+func (w *Writer) point(code int, p data.Point) {
+	w.float(code, p.X)
+	w.float(code+10, p.Y)
+	w.float(code+20, p.Z)
+}
+
+// float writes a floating-point group value
+func (w *Writer) float(code int, value float64) {
+	w.group(code, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// int writes an integer group value
+func (w *Writer) int(code int, value int) {
+	w.group(code, strconv.Itoa(value))
+}
+
+// group writes a group code and its value on two lines, with the code right-aligned
+// in three columns as DXF writers conventionally do
+func (w *Writer) group(code int, value string) {
+	if w.err != nil {
+		return
+	}
+	if _, err := fmt.Fprintf(w.w, "%3d\n%s\n", code, value); err != nil {
+		w.err = fmt.Errorf("failed to write DXF: %w", err)
+	}
+}
+
+// boolFlag returns 1 for true and 0 for false
+func boolFlag(set bool) int {
+	if set {
+		return 1
+	}
+	return 0
+}
+
+// dimensionTypes are the DIMENSION type names, indexed by the type stored in group 70
+var dimensionTypes = []string{"Linear", "Aligned", "Angular", "Diameter", "Radius", "Angular3Point", "Ordinate"}
+
+// dimensionTypeFlags returns the group 70 type of a dimension type name, or 0 (linear) if unknown
+func dimensionTypeFlags(name string) int {
+	for i, dimensionType := range dimensionTypes {
+		if dimensionType == name {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package dxfexport
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/remym/go-dwg-extractor/pkg/dxfparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseEntities parses the entities of a DXF stream
+func parseEntities(t *testing.T, content string) []data.Entity {
+	t.Helper()
+	var entities []data.Entity
+	err := dxfparser.NewParser().ParseDXFStream(strings.NewReader(content), func(entity data.Entity) error {
+		entities = append(entities, entity)
+		return nil
+	})
+	require.NoError(t, err)
+	return entities
+}
+
+func TestWriteEntities_RoundTrip(t *testing.T) {
+	entities := []data.Entity{
+		&data.LineInfo{StartPoint: data.Point{X: 0, Y: 0}, EndPoint: data.Point{X: 10.5, Y: -2, Z: 1}, Layer: "Walls", Color: 1},
+		&data.CircleInfo{Center: data.Point{X: 5, Y: 5}, Radius: 2.25, Layer: "Walls", Color: 3},
+		&data.TextInfo{Value: "Room 101", InsertionPoint: data.Point{X: 1, Y: 2}, Height: 0.5, Rotation: 90,
+			HorizontalAlign: "Left", VerticalAlign: "Baseline", Style: "ROMANS", Layer: "Notes"},
+		&data.PolylineInfo{Points: []data.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 3, Z: 2}}, IsClosed: true, Layer: "Walls", Color: 5},
+		&data.BlockInfo{
+			Name:           "DOOR",
+			Layer:          "Doors",
+			InsertionPoint: data.Point{X: 3, Y: 4},
+			Scale:          data.Point{X: 2, Y: 2, Z: 1},
+			Rotation:       45,
+			Attributes:     []data.AttributeInfo{{Tag: "NUM", Value: "D1", Position: data.Point{X: 3, Y: 5}, Layer: "Doors"}},
+		},
+		&data.DimensionInfo{DimensionType: "Aligned", TextOverride: "5 m", Measurement: 5, DefinitionPoint: data.Point{X: 1, Y: 1}, Layer: "Dims"},
+		&data.PointInfo{Location: data.Point{X: 7, Y: 8, Z: 9}, Layer: "Marks", Color: 2},
+		&data.HatchInfo{PatternName: "SOLID", IsSolid: true, Layer: "Fill", Color: 4},
+	}
+
+	var builder strings.Builder
+	require.NoError(t, WriteEntities(&builder, entities))
+	content := builder.String()
+	assert.True(t, strings.HasPrefix(content, "  0\nSECTION\n  2\nENTITIES\n"), content)
+	assert.True(t, strings.HasSuffix(content, "  0\nENDSEC\n"), content)
+
+	assert.Equal(t, entities, parseEntities(t, content))
+}
+
+func TestWriteEntities_MultilineText(t *testing.T) {
+	long := strings.Repeat("é", 200) // 400 bytes, more than one chunk
+	text := &data.TextInfo{Value: "first\r\nsecond\n" + long, Height: 1, Layer: "Notes"}
+
+	var builder strings.Builder
+	require.NoError(t, WriteEntities(&builder, []data.Entity{text}))
+	content := builder.String()
+	assert.Contains(t, content, "\nMTEXT\n")
+	assert.Contains(t, content, "  3\nfirst\\Psecond\\P")
+
+	parsed := parseEntities(t, content)
+	require.Len(t, parsed, 1)
+	assert.Equal(t, `first\Psecond\P`+long, parsed[0].(*data.TextInfo).Value,
+		"chunks split between characters join back up")
+}
+
+func TestWriteEntities_SkipsNil(t *testing.T) {
+	var builder strings.Builder
+	require.NoError(t, WriteEntities(&builder, []data.Entity{nil, &data.PointInfo{Layer: "0"}, nil}))
+	assert.Len(t, parseEntities(t, builder.String()), 1)
+}
+
+// unknownEntity is an entity type the writer does not know
+type unknownEntity struct{}
+
+func (unknownEntity) GetLayer() string  { return "0" }
+func (unknownEntity) GetHandle() string { return "" }
+func (unknownEntity) GetColor() int     { return data.ColorByLayer }
+
+func TestWriteEntities_Errors(t *testing.T) {
+	var builder strings.Builder
+	err := WriteEntities(&builder, []data.Entity{&data.PointInfo{}, unknownEntity{}})
+	assert.ErrorContains(t, err, "failed to write entity 2: unsupported entity type dxfexport.unknownEntity")
+
+	err = WriteEntities(failingWriter{}, []data.Entity{&data.PointInfo{}})
+	assert.ErrorContains(t, err, "failed to write DXF: disk full")
+}
+
+// failingWriter is an io.Writer that always fails
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
@@ -0,0 +1,67 @@
+package dxfparser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dwgMagic is how every DWG file starts, followed by the rest of its version code
+const dwgMagic = "AC10"
+
+// maxSniffPairs is how many leading group code pairs IsDXF reads looking for the first section
+const maxSniffPairs = 64
+
+// IsDXF reports whether the file at path is an ASCII DXF file, either by its .dxf
+// extension or, failing that, because its content starts like one: an optional run
+// of 999 comments followed by a 0/SECTION pair. It returns an error when the file
+// cannot be read.
+func IsDXF(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".dxf") {
+		return true, nil
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxLineLength)
+	for i := 0; i < maxSniffPairs; i++ {
+		if !scanner.Scan() {
+			break
+		}
+		// A UTF-8 byte order mark may lead the file
+		code := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if !scanner.Scan() {
+			break
+		}
+		value := strings.TrimSpace(scanner.Text())
+
+		switch code {
+		case "999":
+			// Comments may precede the first section
+			continue
+		case "0":
+			return value == "SECTION", nil
+		default:
+			return false, nil
+		}
+	}
+
+	// Binary content such as a DWG file can hold lines too long for the scanner
+	if err := scanner.Err(); err != nil && err != bufio.ErrTooLong {
+		return false, fmt.Errorf("failed to read input file: %w", err)
+	}
+	return false, nil
+}
+
+// IsDWGContent reports whether content starts with the DWG version magic, e.g. AC1032
+func IsDWGContent(content []byte) bool {
+	return bytes.HasPrefix(content, []byte(dwgMagic))
+}
@@ -0,0 +1,52 @@
+package dxfparser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDXF(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		expected bool
+	}{
+		{"dxf extension", "drawing.dxf", "anything", true},
+		{"upper case extension", "DRAWING.DXF", "", true},
+		{"dxf content without extension", "drawing.dat", "  0\r\nSECTION\r\n  2\r\nHEADER\r\n", true},
+		{"leading comments", "drawing", "999\nexported by a tool\n0\nSECTION\n", true},
+		{"byte order mark", "drawing", "\ufeff0\nSECTION\n", true},
+		{"dwg content", "drawing.dwg", "AC1032\x00\x00\x00\x00\x00\x01", false},
+		{"other group code first", "drawing.txt", "2\nSECTION\n", false},
+		{"zero code not followed by a section", "drawing.txt", "0\nEOF\n", false},
+		{"empty file", "empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			isDXF, err := IsDXF(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, isDXF)
+		})
+	}
+}
+
+func TestIsDWGContent(t *testing.T) {
+	assert.True(t, IsDWGContent([]byte("AC1032\x00\x00\x00")))
+	assert.False(t, IsDWGContent([]byte("0\nSECTION\n")))
+	assert.False(t, IsDWGContent(nil))
+}
+
+func TestIsDXF_MissingFile(t *testing.T) {
+	_, err := IsDXF(filepath.Join(t.TempDir(), "missing.dxf"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to open input file")
+}
@@ -0,0 +1,84 @@
+package dxfparser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+)
+
+// parseLineTypes reads the entries of the LTYPE table, keyed by name.
+// It returns nil when the file has no LTYPE table.
+func parseLineTypes(lines []string) map[string]*data.LineTypeInfo {
+	var lineTypes map[string]*data.LineTypeInfo
+	var current *data.LineTypeInfo
+	inTable := false
+	afterTable := false // The previous pair opened a table, whose name comes next
+
+	for i := 0; i+1 < len(lines); i += 2 {
+		code := strings.TrimSpace(lines[i])
+		value := strings.TrimSpace(lines[i+1])
+
+		if code != "0" {
+			if !inTable {
+				if afterTable && code == "2" && value == "LTYPE" {
+					inTable = true
+					lineTypes = make(map[string]*data.LineTypeInfo)
+				}
+				afterTable = false
+				continue
+			}
+
+			if current != nil {
+				switch code {
+				case "2": // Line type name
+					current.Name = value
+				case "3": // Description, often an ASCII sketch of the pattern
+					current.Description = value
+				case "49": // Dash, gap or dot length
+					current.Pattern = append(current.Pattern, parseFloat(value))
+				}
+			}
+			continue
+		}
+
+		// A 0 code ends the entry we were reading
+		afterTable = value == "TABLE"
+		if !inTable {
+			continue
+		}
+		if current != nil && current.Name != "" {
+			lineTypes[current.Name] = current
+		}
+		current = nil
+
+		switch value {
+		case "LTYPE":
+			current = &data.LineTypeInfo{}
+		case "ENDTAB":
+			return lineTypes
+		}
+	}
+
+	// The table was cut off; keep the entry being read
+	if current != nil && current.Name != "" {
+		lineTypes[current.Name] = current
+	}
+	return lineTypes
+}
+
+// undefinedLineTypeWarnings returns a warning for each layer whose line type is not
+// in the LTYPE table. Nothing is reported when the table was not parsed.
+func undefinedLineTypeWarnings(result *data.ExtractedData) []string {
+	if result.LineTypes == nil {
+		return nil
+	}
+
+	var warnings []string
+	for _, layer := range result.Layers {
+		if result.LineType(layer.LineType) == nil {
+			warnings = append(warnings, fmt.Sprintf("Layer %s references undefined line type %q", layer.Name, layer.LineType))
+		}
+	}
+	return warnings
+}
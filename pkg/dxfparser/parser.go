@@ -1,11 +1,18 @@
 package dxfparser
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/remym/go-dwg-extractor/pkg/logging"
 )
 
 // ParserInterface defines the contract for DXF parsing
@@ -17,9 +24,19 @@ type ParserInterface interface {
 
 // Parser handles the parsing of DXF files.
 type Parser struct {
-	// Add any parser configuration or state here
+	keepRawCodes bool            // Retain the raw group codes of each entity
+	logger       logging.Logger  // Receives parse warnings; nil means no logging
+	entityFilter map[string]bool // Entity types to parse, by EntityTypes name; nil parses all
+	strict       bool            // Fail on truncated input instead of returning partial data
 }
 
+// ErrTruncated is returned in strict mode when a DXF section or file ends unexpectedly
+var ErrTruncated = errors.New("DXF input is truncated")
+
+// ErrDWGInput is returned by ParseDXFReader when the input is a DWG drawing, which must be
+// converted from a file before it can be parsed
+var ErrDWGInput = errors.New("input is a DWG drawing; only DXF can be read from a stream, pass the DWG file path instead")
+
 // NewParser creates a new instance of the DXF parser.
 func NewParser() *Parser {
 	return &Parser{}
@@ -28,6 +45,150 @@ func NewParser() *Parser {
 // Ensure Parser implements ParserInterface
 var _ ParserInterface = (*Parser)(nil)
 
+// SetLogger sets the logger that receives parse warnings. A nil logger disables logging.
+func (p *Parser) SetLogger(logger logging.Logger) {
+	p.logger = logger
+}
+
+// EntityTypes lists the entity type names accepted by SetEntityFilter
+var EntityTypes = []string{"line", "circle", "text", "polyline", "block", "dimension", "point", "hatch"}
+
+// entityTypeOf returns the EntityTypes name for a DXF entity kind, or "" if the kind is not parsed
+func entityTypeOf(kind string) string {
+	switch kind {
+	case "LINE":
+		return "line"
+	case "CIRCLE":
+		return "circle"
+	case "TEXT", "MTEXT":
+		return "text"
+	case "LWPOLYLINE", "POLYLINE":
+		return "polyline"
+	case "INSERT":
+		return "block"
+	case "DIMENSION":
+		return "dimension"
+	case "POINT":
+		return "point"
+	case "HATCH":
+		return "hatch"
+	default:
+		return ""
+	}
+}
+
+// ValidateEntityTypes returns an error if any name is not in EntityTypes.
+// Names are matched case-insensitively.
+func ValidateEntityTypes(types []string) error {
+	for _, name := range types {
+		if !slices.Contains(EntityTypes, strings.ToLower(strings.TrimSpace(name))) {
+			return fmt.Errorf("unknown entity type %q: choose from %s", name, strings.Join(EntityTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// SetEntityFilter restricts parsing to the given entity types, named as in EntityTypes
+// (e.g. "text" covers TEXT and MTEXT, "block" covers INSERT). Other entities are skipped
+// without being built. A nil or empty filter parses every type.
+func (p *Parser) SetEntityFilter(types []string) {
+	if len(types) == 0 {
+		p.entityFilter = nil
+		return
+	}
+	p.entityFilter = make(map[string]bool, len(types))
+	for _, name := range types {
+		p.entityFilter[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+}
+
+// allowsKind reports whether the entity filter lets entities of the DXF kind be parsed.
+// Unrecognized kinds are allowed through so they are still reported as unrecognized.
+func (p *Parser) allowsKind(kind string) bool {
+	entityType := entityTypeOf(kind)
+	return p.entityFilter == nil || entityType == "" || p.entityFilter[entityType]
+}
+
+// SetStrict sets whether a section cut off before its ENDSEC or a missing EOF marker is an
+// error. By default ParseDXF returns whatever was parsed, with a warning for each such issue.
+func (p *Parser) SetStrict(strict bool) {
+	p.strict = strict
+}
+
+// truncated reports an unexpected end of input: an ErrTruncated error in strict mode,
+// otherwise a warning added to result
+func (p *Parser) truncated(result *data.ExtractedData, issue string) error {
+	if p.strict {
+		return fmt.Errorf("%w: %s", ErrTruncated, issue)
+	}
+	result.Warnings = append(result.Warnings, issue)
+	return nil
+}
+
+// SetKeepRawCodes sets whether ParseDXF retains the raw group codes of each entity in its RawCodes field.
+func (p *Parser) SetKeepRawCodes(keep bool) {
+	p.keepRawCodes = keep
+}
+
+// acadVersions maps $ACADVER codes to release names, oldest first
+var acadVersions = []struct {
+	code string
+	name string
+}{
+	{"AC1009", "R12"},
+	{"AC1012", "R13"},
+	{"AC1014", "R14"},
+	{"AC1015", "R2000"},
+	{"AC1018", "R2004"},
+	{"AC1021", "R2007"},
+	{"AC1024", "R2010"},
+	{"AC1027", "R2013"},
+	{"AC1032", "R2018"},
+}
+
+const (
+	// oldestSupportedVersion is the oldest $ACADVER the parser is tested against
+	oldestSupportedVersion = "AC1015"
+	// newestSupportedVersion is the newest $ACADVER the parser is tested against
+	newestSupportedVersion = "AC1032"
+)
+
+// SupportedVersions returns the DXF release names the parser is tested against, oldest first
+func (p *Parser) SupportedVersions() []string {
+	var versions []string
+	for _, v := range acadVersions {
+		if v.code >= oldestSupportedVersion && v.code <= newestSupportedVersion {
+			versions = append(versions, v.name)
+		}
+	}
+	return versions
+}
+
+// versionName returns the release name for an $ACADVER code, or the code itself if unknown
+func versionName(code string) string {
+	for _, v := range acadVersions {
+		if v.code == code {
+			return v.name
+		}
+	}
+	return code
+}
+
+// versionWarning returns a warning for $ACADVER codes outside the tested range, or "" if supported.
+// Codes share the ACxxxx format, so they compare in release order.
+func versionWarning(code string) string {
+	switch {
+	case code < oldestSupportedVersion:
+		return fmt.Sprintf("DXF version %s (%s) is older than %s; some data may not be parsed correctly",
+			versionName(code), code, versionName(oldestSupportedVersion))
+	case code > newestSupportedVersion:
+		return fmt.Sprintf("DXF version %s (%s) is newer than the latest tested version %s; some data may not be parsed correctly",
+			versionName(code), code, versionName(newestSupportedVersion))
+	default:
+		return ""
+	}
+}
+
 // ParseDXF parses a DXF file and returns the extracted data.
 // This is a simplified implementation that extracts basic information.
 func (p *Parser) ParseDXF(filePath string) (*data.ExtractedData, error) {
@@ -36,10 +197,29 @@ func (p *Parser) ParseDXF(filePath string) (*data.ExtractedData, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read DXF file: %w", err)
 	}
+	return p.parseContent(content, filePath)
+}
 
+// ParseDXFReader parses DXF content read from r, such as standard input, and returns the
+// extracted data. DWG content is rejected with ErrDWGInput since it cannot be converted
+// without a file.
+func (p *Parser) ParseDXFReader(r io.Reader) (*data.ExtractedData, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DXF input: %w", err)
+	}
+	if IsDWGContent(content) {
+		return nil, ErrDWGInput
+	}
+	return p.parseContent(content, "-")
+}
+
+// parseContent parses the DXF content read from source, the name warnings are logged with
+func (p *Parser) parseContent(content []byte, source string) (*data.ExtractedData, error) {
 	// Create a new ExtractedData instance
 	result := &data.ExtractedData{
-		DXFVersion: "R12", // Default version
+		DXFVersion: "R12",            // Default version
+		Units:      data.UnitName(0), // Drawings without $INSUNITS are unitless
 	}
 
 	// Convert content to string for parsing
@@ -47,22 +227,28 @@ func (p *Parser) ParseDXF(filePath string) (*data.ExtractedData, error) {
 	lines := strings.Split(dxfContent, "\n")
 
 	// Parse DXF version
-	for i, line := range lines {
-		if strings.TrimSpace(line) == "$ACADVER" && i+2 < len(lines) {
-			version := strings.TrimSpace(lines[i+2])
-			if version == "AC1015" {
-				result.DXFVersion = "R2000"
-			} else if version == "AC1021" {
-				result.DXFVersion = "R2007"
-			} else if version == "AC1024" {
-				result.DXFVersion = "R2010"
-			} else {
-				result.DXFVersion = version
-			}
-			break
+	if version, ok := headerValue(lines, "$ACADVER"); ok {
+		result.DXFVersion = versionName(version)
+
+		// Unsupported versions are still parsed so they can be inspected
+		if warning := versionWarning(version); warning != "" {
+			result.Warnings = append(result.Warnings, warning)
 		}
 	}
 
+	// Parse drawing units
+	if units, ok := headerValue(lines, "$INSUNITS"); ok {
+		result.Units = data.UnitName(parseInt(units))
+	}
+
+	// Parse the stored drawing extents. AutoCAD writes a minimum above the maximum for
+	// empty drawings, which is treated as no extents.
+	extMin, hasMin := headerPoint(lines, "$EXTMIN")
+	extMax, hasMax := headerPoint(lines, "$EXTMAX")
+	if hasMin && hasMax && extMin.X <= extMax.X && extMin.Y <= extMax.Y {
+		result.HeaderExtents = &data.BoundingBox{Min: extMin, Max: extMax}
+	}
+
 	// Parse layers from TABLES section
 	inLayerTable := false
 	var layers []data.LayerInfo
@@ -82,8 +268,8 @@ func (p *Parser) ParseDXF(filePath string) (*data.ExtractedData, error) {
 			continue
 		}
 
-		// Parse layer entries
-		if inLayerTable && line == "LAYER" {
+		// Parse layer entries (a "0" code precedes each entry; the table name follows a "2")
+		if inLayerTable && line == "LAYER" && i > 0 && strings.TrimSpace(lines[i-1]) == "0" {
 			layer := data.LayerInfo{
 				IsOn:     true,         // Default
 				IsFrozen: false,        // Default
@@ -92,6 +278,7 @@ func (p *Parser) ParseDXF(filePath string) (*data.ExtractedData, error) {
 			}
 
 			// Parse layer properties
+		properties:
 			for j := i + 1; j < len(lines) && j < i+20; j++ { // Look ahead max 20 lines
 				code := strings.TrimSpace(lines[j])
 				if j+1 >= len(lines) {
@@ -112,10 +299,8 @@ func (p *Parser) ParseDXF(filePath string) (*data.ExtractedData, error) {
 					layer.IsOn = (flags & 2) == 0     // Bit 1: frozen in new viewports (inverted for IsOn)
 				case "6": // Line type
 					layer.LineType = value
-				case "0": // Next entity
-					if value == "LAYER" || value == "ENDTAB" {
-						break
-					}
+				case "0": // Next entry or end of table
+					break properties
 				}
 				j++ // Skip the value line
 			}
@@ -127,45 +312,850 @@ func (p *Parser) ParseDXF(filePath string) (*data.ExtractedData, error) {
 	}
 
 	result.Layers = layers
+	result.LineTypes = parseLineTypes(lines)
+	result.Warnings = append(result.Warnings, undefinedLineTypeWarnings(result)...)
 
-	// Parse entities (simplified)
-	lineCount := 0
-	circleCount := 0
-	textCount := 0
+	// Parse entities from the ENTITIES section
+	layerIndex := make(map[string]int, len(result.Layers))
+	for i, layer := range result.Layers {
+		layerIndex[layer.Name] = i
+	}
 
-	for i := 0; i < len(lines); i++ {
-		line := strings.TrimSpace(lines[i])
-		if line == "LINE" {
-			lineCount++
-		} else if line == "CIRCLE" {
-			circleCount++
-		} else if line == "TEXT" {
-			textCount++
+	// Unrecognized and filtered out entity types, counted in the order they first appear
+	var unknownKinds, filteredKinds []string
+	unknownCounts := make(map[string]int)
+	filteredCounts := make(map[string]int)
+
+	skippedCodes, entitiesTruncated, err := p.parseEntities(bytes.NewReader(content), func(entity data.Entity) error {
+		switch e := entity.(type) {
+		case *data.LineInfo:
+			result.Lines = append(result.Lines, *e)
+		case *data.CircleInfo:
+			result.Circles = append(result.Circles, *e)
+		case *data.TextInfo:
+			result.Texts = append(result.Texts, *e)
+		case *data.PolylineInfo:
+			result.Polylines = append(result.Polylines, *e)
+		case *data.BlockInfo:
+			result.Blocks = append(result.Blocks, *e)
+		case *data.DimensionInfo:
+			result.Dimensions = append(result.Dimensions, *e)
+		case *data.PointInfo:
+			result.Points = append(result.Points, *e)
+		case *data.HatchInfo:
+			result.Hatches = append(result.Hatches, *e)
+		}
+		addToLayer(result, layerIndex, entity)
+		return nil
+	}, func(kind string) {
+		if _, seen := unknownCounts[kind]; !seen {
+			unknownKinds = append(unknownKinds, kind)
+		}
+		unknownCounts[kind]++
+	}, func(kind string) {
+		if _, seen := filteredCounts[kind]; !seen {
+			filteredKinds = append(filteredKinds, kind)
+		}
+		filteredCounts[kind]++
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DXF entities: %w", err)
+	}
+	if entitiesTruncated {
+		if err := p.truncated(result, "ENTITIES section ends without ENDSEC; the file may be truncated"); err != nil {
+			return nil, fmt.Errorf("failed to parse DXF entities: %w", err)
+		}
+	}
+	if skippedCodes > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("Skipped %d malformed group codes in the ENTITIES section", skippedCodes))
+	}
+
+	for _, kind := range unknownKinds {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("Skipped %d unrecognized %s entities", unknownCounts[kind], kind))
+	}
+
+	for _, kind := range filteredKinds {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("Skipped %d %s entities excluded by the entity filter", filteredCounts[kind], kind))
+	}
+
+	var blocksTruncated bool
+	result.BlockDefinitions, blocksTruncated, err = p.parseBlocks(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DXF blocks: %w", err)
+	}
+	if blocksTruncated {
+		if err := p.truncated(result, "BLOCKS section ends without ENDSEC; the file may be truncated"); err != nil {
+			return nil, fmt.Errorf("failed to parse DXF blocks: %w", err)
+		}
+	}
+	if !hasEOFMarker(lines) {
+		if err := p.truncated(result, "DXF file has no EOF marker; the file may be truncated"); err != nil {
+			return nil, fmt.Errorf("failed to parse DXF file: %w", err)
 		}
 	}
+	result.Warnings = append(result.Warnings, undefinedBlockWarnings(result)...)
 
-	// Create sample entities for display
-	for i := 0; i < lineCount; i++ {
-		result.Lines = append(result.Lines, data.LineInfo{
-			StartPoint: data.Point{X: 0, Y: 0, Z: 0},
-			EndPoint:   data.Point{X: 100, Y: 100, Z: 0},
-			Layer:      "WALLS",
-			Color:      1,
-		})
+	for _, layer := range result.Layers {
+		if len(layer.Entities) == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Layer %s has no entities", layer.Name))
+		}
 	}
 
-	for i := 0; i < circleCount; i++ {
-		result.Circles = append(result.Circles, data.CircleInfo{
-			Center: data.Point{X: 50, Y: 50, Z: 0},
-			Radius: 25,
-			Layer:  "DOORS",
-			Color:  2,
-		})
+	logger := logging.OrNop(p.logger)
+	for _, warning := range result.Warnings {
+		logger.Warn(warning, "file", source)
 	}
 
 	return result, nil
 }
 
+// groupCode is a single DXF group code/value pair
+type groupCode struct {
+	code  int
+	value string
+}
+
+// rawEntity holds the group codes of a single entity read from a section
+type rawEntity struct {
+	kind  string
+	codes []groupCode
+}
+
+// rawCodes returns the group codes of the given entities, each led by its 0/type code,
+// or nil when raw codes are not being kept
+func (p *Parser) rawCodes(entities []rawEntity) []data.GroupCode {
+	if !p.keepRawCodes {
+		return nil
+	}
+
+	var codes []data.GroupCode
+	for _, entity := range entities {
+		codes = append(codes, data.GroupCode{Code: 0, Value: entity.kind})
+		for _, gc := range entity.codes {
+			codes = append(codes, data.GroupCode{Code: gc.code, Value: gc.value})
+		}
+	}
+	return codes
+}
+
+// ParseDXFStream parses the entities of a DXF stream, calling handler for each
+// entity as soon as it is parsed so that entities need not be held in memory.
+// Unrecognized entity types are skipped. An error returned by the handler stops
+// parsing and is returned unchanged. In strict mode an ENTITIES section cut off
+// before its ENDSEC is an ErrTruncated error.
+func (p *Parser) ParseDXFStream(r io.Reader, handler func(data.Entity) error) error {
+	_, truncated, err := p.parseEntities(r, handler, nil, nil)
+	if err == nil && truncated && p.strict {
+		return fmt.Errorf("%w: ENTITIES section ends without ENDSEC", ErrTruncated)
+	}
+	return err
+}
+
+// parseEntities reads the ENTITIES section of r, calling handler for each recognized
+// entity, unknown, if set, with the kind of each unrecognized one and filtered, if set,
+// with the kind of each one left out by the entity filter. It returns the number of
+// malformed group codes skipped inside the section and whether the input ended before
+// the section did.
+func (p *Parser) parseEntities(r io.Reader, handler func(data.Entity) error, unknown, filtered func(kind string)) (int, bool, error) {
+	reader := newEntityReader(r)
+	for {
+		raw, err := reader.next()
+		if err != nil {
+			return reader.skipped, reader.truncated, err
+		}
+		if raw == nil {
+			return reader.skipped, reader.truncated, nil
+		}
+
+		if !p.allowsKind(raw.kind) {
+			// Consume the vertices or attributes of a skipped polyline or block as well
+			if member, ok := sequenceMembers[raw.kind]; ok {
+				if _, err := reader.readSequence(member); err != nil {
+					return reader.skipped, reader.truncated, err
+				}
+			}
+			if filtered != nil {
+				filtered(raw.kind)
+			}
+			continue
+		}
+
+		entity, err := p.buildEntity(reader, raw)
+		if err != nil {
+			return reader.skipped, reader.truncated, err
+		}
+		if entity == nil {
+			if unknown != nil {
+				unknown(raw.kind)
+			}
+			continue
+		}
+
+		if err := handler(entity); err != nil {
+			return reader.skipped, reader.truncated, err
+		}
+	}
+}
+
+// buildEntity converts raw into an entity, consuming the vertices or attributes that
+// follow a polyline or block insertion. It returns nil for unrecognized entity types.
+func (p *Parser) buildEntity(reader *entityReader, raw *rawEntity) (data.Entity, error) {
+	group := []rawEntity{*raw}
+	var entity data.Entity
+	switch raw.kind {
+	case "LINE":
+		line := parseLine(raw.codes)
+		line.RawCodes = p.rawCodes(group)
+		entity = line
+	case "CIRCLE":
+		circle := parseCircle(raw.codes)
+		circle.RawCodes = p.rawCodes(group)
+		entity = circle
+	case "TEXT", "MTEXT":
+		text := parseText(raw.kind, raw.codes)
+		text.RawCodes = p.rawCodes(group)
+		entity = text
+	case "LWPOLYLINE":
+		polyline := parseLWPolyline(raw.codes)
+		polyline.RawCodes = p.rawCodes(group)
+		entity = polyline
+	case "POLYLINE":
+		// Vertices follow as separate VERTEX entities up to SEQEND
+		polyline := parsePolylineHeader(raw.codes)
+		sequence, err := reader.readSequence(sequenceMembers[raw.kind])
+		if err != nil {
+			return nil, err
+		}
+		for _, vertex := range sequence {
+			if vertex.kind == "VERTEX" {
+				polyline.Points = append(polyline.Points, parsePointCodes(vertex.codes))
+			}
+		}
+		polyline.RawCodes = p.rawCodes(append(group, sequence...))
+		entity = polyline
+	case "INSERT":
+		// Attributes follow as separate ATTRIB entities up to SEQEND
+		block := parseInsert(raw.codes)
+		sequence, err := reader.readSequence(sequenceMembers[raw.kind])
+		if err != nil {
+			return nil, err
+		}
+		for _, attrib := range sequence {
+			if attrib.kind == "ATTRIB" {
+				block.Attributes = append(block.Attributes, parseAttribute(attrib.codes))
+			}
+		}
+		block.RawCodes = p.rawCodes(append(group, sequence...))
+		entity = block
+	case "DIMENSION":
+		dimension := parseDimension(raw.codes)
+		dimension.RawCodes = p.rawCodes(group)
+		entity = dimension
+	case "POINT":
+		point := parsePoint(raw.codes)
+		point.RawCodes = p.rawCodes(group)
+		entity = point
+	case "HATCH":
+		hatch := parseHatch(raw.codes)
+		hatch.RawCodes = p.rawCodes(group)
+		entity = hatch
+	}
+
+	return entity, nil
+}
+
+// parseBlocks reads the block definitions of the BLOCKS section of r, keyed by name,
+// and whether the input ended before the section did. It returns nil when r has no
+// BLOCKS section. A block cut off before its ENDBLK is left out.
+func (p *Parser) parseBlocks(r io.Reader) (map[string]*data.BlockDefinition, bool, error) {
+	reader := newSectionReader(r, "BLOCKS")
+	var definitions map[string]*data.BlockDefinition
+	var current *data.BlockDefinition
+	for {
+		raw, err := reader.next()
+		if err != nil {
+			return nil, false, err
+		}
+		if raw == nil {
+			if reader.inSection && definitions == nil {
+				definitions = make(map[string]*data.BlockDefinition)
+			}
+			return definitions, reader.truncated, nil
+		}
+
+		switch raw.kind {
+		case "BLOCK":
+			current = &data.BlockDefinition{BasePoint: parsePointCodes(raw.codes)}
+			for _, gc := range raw.codes {
+				if gc.code == 2 {
+					current.Name = gc.value
+				}
+			}
+		case "ENDBLK":
+			if current != nil && current.Name != "" {
+				if definitions == nil {
+					definitions = make(map[string]*data.BlockDefinition)
+				}
+				definitions[current.Name] = current
+			}
+			current = nil
+		default:
+			entity, err := p.buildEntity(reader, raw)
+			if err != nil {
+				return nil, false, err
+			}
+			if entity != nil && current != nil {
+				current.Entities = append(current.Entities, entity)
+			}
+		}
+	}
+}
+
+// undefinedBlockWarnings returns a warning for each block name that is inserted
+// but not defined, in the order the names first appear. Nothing is reported when
+// block definitions were not parsed.
+func undefinedBlockWarnings(result *data.ExtractedData) []string {
+	if result.BlockDefinitions == nil {
+		return nil
+	}
+
+	var names []string
+	counts := make(map[string]int)
+	for _, block := range result.Blocks {
+		if _, ok := result.BlockDefinitions[block.Name]; ok {
+			continue
+		}
+		if _, seen := counts[block.Name]; !seen {
+			names = append(names, block.Name)
+		}
+		counts[block.Name]++
+	}
+
+	var warnings []string
+	for _, name := range names {
+		if counts[name] == 1 {
+			warnings = append(warnings, fmt.Sprintf("1 insertion references undefined block %q", name))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%d insertions reference undefined block %q", counts[name], name))
+		}
+	}
+	return warnings
+}
+
+// sequenceMembers maps entity kinds followed by a SEQEND-terminated sequence to the kind of its members
+var sequenceMembers = map[string]string{
+	"POLYLINE": "VERTEX",
+	"INSERT":   "ATTRIB",
+}
+
+// maxLineLength is the longest DXF line the entity reader accepts
+const maxLineLength = 1 << 20
+
+// entityReader reads the entities of a single DXF section one at a time
+type entityReader struct {
+	scanner   *bufio.Scanner
+	section   string // Name of the section to read, such as ENTITIES or BLOCKS
+	inSection bool
+	done      bool
+	current   *rawEntity // Entity whose group codes are being collected
+	peeked    *rawEntity // Entity read ahead by peek
+	skipped   int        // Malformed group codes skipped inside the section
+	truncated bool       // The input ended inside the section, before its ENDSEC
+}
+
+// newEntityReader creates an entity reader for the ENTITIES section of a DXF stream
+func newEntityReader(r io.Reader) *entityReader {
+	return newSectionReader(r, "ENTITIES")
+}
+
+// newSectionReader creates an entity reader for the named section of a DXF stream
+func newSectionReader(r io.Reader, section string) *entityReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLength)
+	return &entityReader{scanner: scanner, section: section}
+}
+
+// next returns the next entity, or nil when the section or the input ends. An entity
+// cut off by the end of the input is dropped, as its group codes may be incomplete.
+func (er *entityReader) next() (*rawEntity, error) {
+	if er.peeked != nil {
+		entity := er.peeked
+		er.peeked = nil
+		return entity, nil
+	}
+	return er.read()
+}
+
+// peek returns the next entity without consuming it
+func (er *entityReader) peek() (*rawEntity, error) {
+	if er.peeked == nil {
+		entity, err := er.read()
+		if err != nil {
+			return nil, err
+		}
+		er.peeked = entity
+	}
+	return er.peeked, nil
+}
+
+// readSequence consumes the entities of the given kind that follow, plus the SEQEND closing them
+func (er *entityReader) readSequence(kind string) ([]rawEntity, error) {
+	var sequence []rawEntity
+	for {
+		entity, err := er.peek()
+		if err != nil {
+			return nil, err
+		}
+		if entity == nil || (entity.kind != kind && entity.kind != "SEQEND") {
+			return sequence, nil
+		}
+		er.peeked = nil
+		sequence = append(sequence, *entity)
+		if entity.kind == "SEQEND" {
+			return sequence, nil
+		}
+	}
+}
+
+// read collects group code pairs until an entity is complete
+func (er *entityReader) read() (*rawEntity, error) {
+	for !er.done {
+		if !er.scan() {
+			break
+		}
+		codeLine := er.scanner.Text()
+		if !er.scan() {
+			break
+		}
+		value := strings.TrimSpace(er.scanner.Text())
+
+		code, err := strconv.Atoi(strings.TrimSpace(codeLine))
+		if err != nil {
+			if er.inSection {
+				er.skipped++
+			}
+			continue
+		}
+
+		if !er.inSection {
+			// Look for the start of the section
+			if code == 2 && value == er.section {
+				er.inSection = true
+			}
+			continue
+		}
+
+		if code == 0 {
+			// A 0 code ends the entity we were reading
+			entity := er.current
+			er.current = nil
+			if value == "ENDSEC" {
+				er.done = true
+			} else {
+				er.current = &rawEntity{kind: value}
+			}
+			if entity != nil {
+				return entity, nil
+			}
+			continue
+		}
+
+		if er.current != nil {
+			er.current.codes = append(er.current.codes, groupCode{code: code, value: value})
+		}
+	}
+
+	if err := er.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read DXF stream: %w", err)
+	}
+
+	er.current = nil
+	return nil, nil
+}
+
+// scan advances to the next line, marking the reader done when the input ends
+func (er *entityReader) scan() bool {
+	if er.scanner.Scan() {
+		return true
+	}
+	er.done = true
+	// Only ENDSEC ends a section cleanly
+	er.truncated = er.inSection
+	return false
+}
+
+// parseLine builds a LineInfo from the group codes of a LINE entity. Like every entity
+// parser, it leaves the color ByLayer when the entity has no group 62, as DXF does.
+func parseLine(codes []groupCode) *data.LineInfo {
+	line := &data.LineInfo{Color: data.ColorByLayer}
+	for _, gc := range codes {
+		switch gc.code {
+		case 5: // Handle
+			line.Handle = gc.value
+		case 8: // Layer name
+			line.Layer = gc.value
+		case 62: // Color number
+			line.Color = parseInt(gc.value)
+		case 10: // Start point X
+			line.StartPoint.X = parseFloat(gc.value)
+		case 20: // Start point Y
+			line.StartPoint.Y = parseFloat(gc.value)
+		case 30: // Start point Z
+			line.StartPoint.Z = parseFloat(gc.value)
+		case 11: // End point X
+			line.EndPoint.X = parseFloat(gc.value)
+		case 21: // End point Y
+			line.EndPoint.Y = parseFloat(gc.value)
+		case 31: // End point Z
+			line.EndPoint.Z = parseFloat(gc.value)
+		}
+	}
+	return line
+}
+
+// parseCircle builds a CircleInfo from the group codes of a CIRCLE entity
+func parseCircle(codes []groupCode) *data.CircleInfo {
+	circle := &data.CircleInfo{Color: data.ColorByLayer}
+	for _, gc := range codes {
+		switch gc.code {
+		case 5: // Handle
+			circle.Handle = gc.value
+		case 8: // Layer name
+			circle.Layer = gc.value
+		case 62: // Color number
+			circle.Color = parseInt(gc.value)
+		case 10: // Center X
+			circle.Center.X = parseFloat(gc.value)
+		case 20: // Center Y
+			circle.Center.Y = parseFloat(gc.value)
+		case 30: // Center Z
+			circle.Center.Z = parseFloat(gc.value)
+		case 40: // Radius
+			circle.Radius = parseFloat(gc.value)
+		}
+	}
+	return circle
+}
+
+// parseText builds a TextInfo from the group codes of a TEXT or MTEXT entity
+func parseText(kind string, codes []groupCode) *data.TextInfo {
+	text := &data.TextInfo{Color: data.ColorByLayer}
+	var chunks strings.Builder
+	// TEXT justification defaults to left on the baseline, MTEXT attachment to top left
+	horizontal, vertical, attachment := 0, 0, 1
+	for _, gc := range codes {
+		switch gc.code {
+		case 5: // Handle
+			text.Handle = gc.value
+		case 8: // Layer name
+			text.Layer = gc.value
+		case 62: // Color number
+			text.Color = parseInt(gc.value)
+		case 3: // MTEXT continuation chunk, precedes the final group 1
+			chunks.WriteString(gc.value)
+		case 1: // Text value
+			text.Value = chunks.String() + gc.value
+		case 7: // Text style
+			text.Style = gc.value
+		case 10: // Insertion point X
+			text.InsertionPoint.X = parseFloat(gc.value)
+		case 20: // Insertion point Y
+			text.InsertionPoint.Y = parseFloat(gc.value)
+		case 30: // Insertion point Z
+			text.InsertionPoint.Z = parseFloat(gc.value)
+		case 40: // Text height
+			text.Height = parseFloat(gc.value)
+		case 50: // Rotation angle
+			text.Rotation = parseFloat(gc.value)
+		case 71: // MTEXT attachment point; TEXT generation flags
+			attachment = parseInt(gc.value)
+		case 72: // TEXT horizontal justification; MTEXT drawing direction
+			horizontal = parseInt(gc.value)
+		case 73: // TEXT vertical justification; MTEXT line spacing style
+			vertical = parseInt(gc.value)
+		}
+	}
+
+	if kind == "MTEXT" {
+		text.HorizontalAlign, text.VerticalAlign = data.AttachmentAlign(attachment)
+	} else {
+		text.HorizontalAlign = data.HorizontalAlignName(horizontal)
+		text.VerticalAlign = data.VerticalAlignName(vertical)
+	}
+	return text
+}
+
+// parseLWPolyline builds a PolylineInfo from the group codes of an LWPOLYLINE entity
+func parseLWPolyline(codes []groupCode) *data.PolylineInfo {
+	polyline := &data.PolylineInfo{Color: data.ColorByLayer}
+	for _, gc := range codes {
+		switch gc.code {
+		case 5: // Handle
+			polyline.Handle = gc.value
+		case 8: // Layer name
+			polyline.Layer = gc.value
+		case 62: // Color number
+			polyline.Color = parseInt(gc.value)
+		case 70: // Polyline flags, bit 0 means closed
+			polyline.IsClosed = parseInt(gc.value)&1 != 0
+		case 10: // Vertex X starts a new vertex
+			polyline.Points = append(polyline.Points, data.Point{X: parseFloat(gc.value)})
+		case 20: // Vertex Y
+			if n := len(polyline.Points); n > 0 {
+				polyline.Points[n-1].Y = parseFloat(gc.value)
+			}
+		}
+	}
+	return polyline
+}
+
+// parsePolylineHeader builds a PolylineInfo from the group codes of a POLYLINE entity, without vertices
+func parsePolylineHeader(codes []groupCode) *data.PolylineInfo {
+	polyline := &data.PolylineInfo{Color: data.ColorByLayer}
+	for _, gc := range codes {
+		switch gc.code {
+		case 5: // Handle
+			polyline.Handle = gc.value
+		case 8: // Layer name
+			polyline.Layer = gc.value
+		case 62: // Color number
+			polyline.Color = parseInt(gc.value)
+		case 70: // Polyline flags, bit 0 means closed
+			polyline.IsClosed = parseInt(gc.value)&1 != 0
+		}
+	}
+	return polyline
+}
+
+// parsePointCodes reads the 10/20/30 location from a list of group codes
+func parsePointCodes(codes []groupCode) data.Point {
+	var point data.Point
+	for _, gc := range codes {
+		switch gc.code {
+		case 10:
+			point.X = parseFloat(gc.value)
+		case 20:
+			point.Y = parseFloat(gc.value)
+		case 30:
+			point.Z = parseFloat(gc.value)
+		}
+	}
+	return point
+}
+
+// parseInsert builds a BlockInfo from the group codes of an INSERT entity, without attributes
+func parseInsert(codes []groupCode) *data.BlockInfo {
+	block := &data.BlockInfo{Color: data.ColorByLayer, Scale: data.Point{X: 1, Y: 1, Z: 1}}
+	for _, gc := range codes {
+		switch gc.code {
+		case 5: // Handle
+			block.Handle = gc.value
+		case 2: // Block name
+			block.Name = gc.value
+		case 8: // Layer name
+			block.Layer = gc.value
+		case 62: // Color number
+			block.Color = parseInt(gc.value)
+		case 10: // Insertion point X
+			block.InsertionPoint.X = parseFloat(gc.value)
+		case 20: // Insertion point Y
+			block.InsertionPoint.Y = parseFloat(gc.value)
+		case 30: // Insertion point Z
+			block.InsertionPoint.Z = parseFloat(gc.value)
+		case 41: // X scale factor
+			block.Scale.X = parseFloat(gc.value)
+		case 42: // Y scale factor
+			block.Scale.Y = parseFloat(gc.value)
+		case 43: // Z scale factor
+			block.Scale.Z = parseFloat(gc.value)
+		case 50: // Rotation angle
+			block.Rotation = parseFloat(gc.value)
+		}
+	}
+	return block
+}
+
+// parseAttribute builds an AttributeInfo from the group codes of an ATTRIB entity
+func parseAttribute(codes []groupCode) data.AttributeInfo {
+	attribute := data.AttributeInfo{Position: parsePointCodes(codes)}
+	for _, gc := range codes {
+		switch gc.code {
+		case 2: // Attribute tag
+			attribute.Tag = gc.value
+		case 1: // Attribute value
+			attribute.Value = gc.value
+		case 8: // Layer name
+			attribute.Layer = gc.value
+		}
+	}
+	return attribute
+}
+
+// parseDimension builds a DimensionInfo from the group codes of a DIMENSION entity
+func parseDimension(codes []groupCode) *data.DimensionInfo {
+	dimension := &data.DimensionInfo{Color: data.ColorByLayer}
+	for _, gc := range codes {
+		switch gc.code {
+		case 5: // Handle
+			dimension.Handle = gc.value
+		case 8: // Layer name
+			dimension.Layer = gc.value
+		case 62: // Color number
+			dimension.Color = parseInt(gc.value)
+		case 1: // Text override
+			dimension.TextOverride = gc.value
+		case 10: // Definition point X
+			dimension.DefinitionPoint.X = parseFloat(gc.value)
+		case 20: // Definition point Y
+			dimension.DefinitionPoint.Y = parseFloat(gc.value)
+		case 30: // Definition point Z
+			dimension.DefinitionPoint.Z = parseFloat(gc.value)
+		case 42: // Actual measurement
+			dimension.Measurement = parseFloat(gc.value)
+		case 70: // Dimension type flags
+			dimension.DimensionType = dimensionTypeName(parseInt(gc.value))
+		}
+	}
+	return dimension
+}
+
+// parsePoint builds a PointInfo from the group codes of a POINT entity
+func parsePoint(codes []groupCode) *data.PointInfo {
+	point := &data.PointInfo{Color: data.ColorByLayer}
+	for _, gc := range codes {
+		switch gc.code {
+		case 5: // Handle
+			point.Handle = gc.value
+		case 8: // Layer name
+			point.Layer = gc.value
+		case 10: // Location X
+			point.Location.X = parseFloat(gc.value)
+		case 20: // Location Y
+			point.Location.Y = parseFloat(gc.value)
+		case 30: // Location Z
+			point.Location.Z = parseFloat(gc.value)
+		case 62: // Color number
+			point.Color = parseInt(gc.value)
+		}
+	}
+	return point
+}
+
+// parseHatch builds a HatchInfo summary from the group codes of a HATCH entity.
+// Boundary geometry is not kept; only the points inside the boundary path data are counted.
+func parseHatch(codes []groupCode) *data.HatchInfo {
+	hatch := &data.HatchInfo{Color: data.ColorByLayer}
+	inBoundary := false
+	for _, gc := range codes {
+		switch gc.code {
+		case 5: // Handle
+			hatch.Handle = gc.value
+		case 8: // Layer name
+			hatch.Layer = gc.value
+		case 2: // Pattern name
+			hatch.PatternName = gc.value
+		case 62: // Color number
+			hatch.Color = parseInt(gc.value)
+		case 70: // Solid fill flag
+			hatch.IsSolid = parseInt(gc.value) == 1
+		case 91: // Number of boundary paths, boundary data follows
+			inBoundary = true
+		case 75: // Hatch style, boundary data has ended
+			inBoundary = false
+		case 10: // Boundary vertex or edge point
+			if inBoundary {
+				hatch.BoundaryPointCount++
+			}
+		}
+	}
+	return hatch
+}
+
+// dimensionTypeName maps the DIMENSION type flags (group 70) to a readable name
+func dimensionTypeName(flags int) string {
+	// The lower three bits hold the dimension type; higher bits are flags
+	switch flags & 7 {
+	case 0:
+		return "Linear"
+	case 1:
+		return "Aligned"
+	case 2:
+		return "Angular"
+	case 3:
+		return "Diameter"
+	case 4:
+		return "Radius"
+	case 5:
+		return "Angular3Point"
+	case 6:
+		return "Ordinate"
+	default:
+		return "Unknown"
+	}
+}
+
+// addToLayer attaches an entity to the layer it references, if that layer exists
+func addToLayer(result *data.ExtractedData, layerIndex map[string]int, entity data.Entity) {
+	if i, ok := layerIndex[entity.GetLayer()]; ok {
+		result.Layers[i].Entities = append(result.Layers[i].Entities, entity)
+	}
+}
+
+// headerValue returns the value of a header variable, which follows the variable name
+// and its group code, or false if the variable is not set
+func headerValue(lines []string, name string) (string, bool) {
+	for i, line := range lines {
+		if strings.TrimSpace(line) == name && i+2 < len(lines) {
+			return strings.TrimSpace(lines[i+2]), true
+		}
+	}
+	return "", false
+}
+
+// headerPoint returns the value of a point header variable, whose 10/20/30 coordinates
+// follow the variable name, or false if the variable is not set or has no X and Y
+func headerPoint(lines []string, name string) (data.Point, bool) {
+	for i, line := range lines {
+		if strings.TrimSpace(line) != name {
+			continue
+		}
+		var codes []groupCode
+		for j := i + 1; j+1 < len(lines); j += 2 {
+			code, err := strconv.Atoi(strings.TrimSpace(lines[j]))
+			if err != nil || (code != 10 && code != 20 && code != 30) {
+				break
+			}
+			codes = append(codes, groupCode{code: code, value: strings.TrimSpace(lines[j+1])})
+		}
+		if len(codes) < 2 {
+			return data.Point{}, false
+		}
+		return parsePointCodes(codes), true
+	}
+	return data.Point{}, false
+}
+
+// hasEOFMarker reports whether the last group of lines is the 0/EOF pair ending a DXF file
+func hasEOFMarker(lines []string) bool {
+	last := len(lines) - 1
+	for last >= 0 && strings.TrimSpace(lines[last]) == "" {
+		last--
+	}
+	return last > 0 && strings.TrimSpace(lines[last]) == "EOF" && strings.TrimSpace(lines[last-1]) == "0"
+}
+
+// parseFloat safely converts a string to float64, returning 0 on error
+func parseFloat(s string) float64 {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
 // parseInt safely converts a string to int, returning 0 on error
 func parseInt(s string) int {
 	var result int
@@ -1,9 +1,15 @@
 package dxfparser
 
 import (
+	"bytes"
+	"errors"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/remym/go-dwg-extractor/pkg/data"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -86,3 +92,1397 @@ EOF`
 	assert.Equal(t, "0", result.Layers[0].Name, "Expected default layer name")
 	assert.Equal(t, 7, result.Layers[0].Color, "Expected default layer color")
 }
+
+func TestParseDXF_WithDimensions(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+TABLES
+0
+TABLE
+2
+LAYER
+0
+LAYER
+2
+DIMS
+70
+0
+62
+3
+6
+CONTINUOUS
+0
+ENDTAB
+0
+ENDSEC
+0
+SECTION
+2
+ENTITIES
+0
+DIMENSION
+8
+DIMS
+10
+12.5
+20
+-4.0
+30
+0.0
+42
+250.0
+70
+32
+0
+DIMENSION
+8
+DIMS
+1
+ROOM WIDTH
+42
+1200.0
+70
+33
+0
+ENDSEC
+0
+EOF`
+
+	tmpFile, err := os.CreateTemp("", "test-*.dxf")
+	require.NoError(t, err, "Failed to create temp file")
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString(dxfContent)
+	require.NoError(t, err, "Failed to write test DXF content")
+	tmpFile.Close()
+
+	p := NewParser()
+	result, err := p.ParseDXF(tmpFile.Name())
+	require.NoError(t, err, "Unexpected error parsing DXF with dimensions")
+
+	require.Len(t, result.Dimensions, 2, "Expected two dimensions")
+
+	first := result.Dimensions[0]
+	assert.Equal(t, "DIMS", first.Layer)
+	assert.Equal(t, "Linear", first.DimensionType)
+	assert.Equal(t, 250.0, first.Measurement)
+	assert.Equal(t, 12.5, first.DefinitionPoint.X)
+	assert.Equal(t, -4.0, first.DefinitionPoint.Y)
+	assert.Equal(t, "250.00", first.DisplayText(), "Expected measurement when no override is set")
+
+	second := result.Dimensions[1]
+	assert.Equal(t, "Aligned", second.DimensionType)
+	assert.Equal(t, "ROOM WIDTH", second.TextOverride)
+	assert.Equal(t, "ROOM WIDTH", second.DisplayText())
+
+	// Dimensions should be attached to their layer
+	require.Len(t, result.Layers, 1)
+	assert.Len(t, result.Layers[0].Entities, 2, "Expected dimensions on the DIMS layer")
+}
+
+func TestParseDXF_WithPoints(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+TABLES
+0
+TABLE
+2
+LAYER
+0
+LAYER
+2
+SURVEY
+70
+0
+62
+5
+0
+ENDTAB
+0
+ENDSEC
+0
+SECTION
+2
+ENTITIES
+0
+POINT
+8
+SURVEY
+10
+100.25
+20
+200.5
+30
+3.0
+62
+1
+0
+POINT
+8
+MISSING
+10
+1.0
+20
+2.0
+0
+ENDSEC
+0
+EOF`
+
+	tmpFile, err := os.CreateTemp("", "test-*.dxf")
+	require.NoError(t, err, "Failed to create temp file")
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString(dxfContent)
+	require.NoError(t, err, "Failed to write test DXF content")
+	tmpFile.Close()
+
+	p := NewParser()
+	result, err := p.ParseDXF(tmpFile.Name())
+	require.NoError(t, err, "Unexpected error parsing DXF with points")
+
+	require.Len(t, result.Points, 2, "Expected two points")
+	first := result.Points[0]
+	assert.Equal(t, "SURVEY", first.Layer)
+	assert.Equal(t, data.Point{X: 100.25, Y: 200.5, Z: 3.0}, first.Location)
+	assert.Equal(t, 1, first.Color)
+
+	// Only the point on an existing layer is attached to it
+	require.Len(t, result.Layers, 1)
+	require.Len(t, result.Layers[0].Entities, 1)
+	assert.IsType(t, &data.PointInfo{}, result.Layers[0].Entities[0])
+}
+
+func TestParseDXF_WithHatches(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+TABLES
+0
+TABLE
+2
+LAYER
+0
+LAYER
+2
+FILL
+70
+0
+0
+ENDTAB
+0
+ENDSEC
+0
+SECTION
+2
+ENTITIES
+0
+HATCH
+8
+FILL
+10
+0.0
+20
+0.0
+30
+0.0
+2
+SOLID
+70
+1
+91
+1
+92
+2
+72
+0
+73
+1
+93
+4
+10
+0.0
+20
+0.0
+10
+10.0
+20
+0.0
+10
+10.0
+20
+10.0
+10
+0.0
+20
+10.0
+97
+0
+75
+0
+76
+1
+98
+1
+10
+5.0
+20
+5.0
+0
+HATCH
+8
+FILL
+62
+3
+2
+ANSI31
+70
+0
+91
+0
+75
+0
+0
+ENDSEC
+0
+EOF`
+
+	tmpFile, err := os.CreateTemp("", "test-*.dxf")
+	require.NoError(t, err, "Failed to create temp file")
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString(dxfContent)
+	require.NoError(t, err, "Failed to write test DXF content")
+	tmpFile.Close()
+
+	p := NewParser()
+	result, err := p.ParseDXF(tmpFile.Name())
+	require.NoError(t, err, "Unexpected error parsing DXF with hatches")
+
+	require.Len(t, result.Hatches, 2, "Expected two hatches")
+
+	solid := result.Hatches[0]
+	assert.Equal(t, "FILL", solid.Layer)
+	assert.Equal(t, "SOLID", solid.PatternName)
+	assert.True(t, solid.IsSolid)
+	assert.Equal(t, 4, solid.BoundaryPointCount, "Elevation and seed points should not be counted")
+
+	pattern := result.Hatches[1]
+	assert.Equal(t, "ANSI31", pattern.PatternName)
+	assert.False(t, pattern.IsSolid)
+	assert.Equal(t, 0, pattern.BoundaryPointCount)
+	assert.Equal(t, 3, pattern.Color)
+
+	require.Len(t, result.Layers, 1)
+	assert.Len(t, result.Layers[0].Entities, 2, "Expected hatches on the FILL layer")
+}
+
+func TestParseDXF_DefaultColorByLayer(t *testing.T) {
+	dxfContent := "0\nSECTION\n2\nENTITIES\n" +
+		"0\nLINE\n8\n0\n10\n0\n20\n0\n11\n1\n21\n1\n" +
+		"0\nLINE\n8\n0\n62\n0\n10\n0\n20\n0\n11\n1\n21\n1\n" +
+		"0\nCIRCLE\n8\n0\n10\n0\n20\n0\n40\n1\n" +
+		"0\nLWPOLYLINE\n8\n0\n10\n0\n20\n0\n10\n1\n20\n1\n" +
+		"0\nPOLYLINE\n8\n0\n0\nVERTEX\n8\n0\n10\n0\n20\n0\n0\nSEQEND\n" +
+		"0\nPOINT\n8\n0\n10\n0\n20\n0\n" +
+		"0\nHATCH\n8\n0\n2\nSOLID\n70\n1\n" +
+		"0\nENDSEC\n0\nEOF"
+
+	result, err := NewParser().ParseDXFReader(strings.NewReader(dxfContent))
+	require.NoError(t, err)
+
+	require.Len(t, result.Lines, 2)
+	assert.Equal(t, data.ColorByLayer, result.Lines[0].Color, "Entities without group 62 should be ByLayer")
+	assert.Equal(t, data.ColorByBlock, result.Lines[1].Color, "An explicit 0 should stay ByBlock")
+	require.Len(t, result.Circles, 1)
+	assert.Equal(t, data.ColorByLayer, result.Circles[0].Color)
+	require.Len(t, result.Polylines, 2)
+	assert.Equal(t, data.ColorByLayer, result.Polylines[0].Color)
+	assert.Equal(t, data.ColorByLayer, result.Polylines[1].Color)
+	require.Len(t, result.Points, 1)
+	assert.Equal(t, data.ColorByLayer, result.Points[0].Color)
+	require.Len(t, result.Hatches, 1)
+	assert.Equal(t, data.ColorByLayer, result.Hatches[0].Color)
+}
+
+func TestParseDXF_TextBlockDimensionColors(t *testing.T) {
+	dxfContent := "0\nSECTION\n2\nENTITIES\n" +
+		"0\nTEXT\n8\n0\n62\n1\n1\nA\n" +
+		"0\nMTEXT\n8\n0\n1\nB\n" +
+		"0\nINSERT\n8\n0\n62\n0\n2\nDOOR\n" +
+		"0\nDIMENSION\n8\n0\n62\n5\n" +
+		"0\nENDSEC\n0\nEOF"
+
+	result, err := NewParser().ParseDXFReader(strings.NewReader(dxfContent))
+	require.NoError(t, err)
+
+	require.Len(t, result.Texts, 2)
+	assert.Equal(t, 1, result.Texts[0].Color)
+	assert.Equal(t, data.ColorByLayer, result.Texts[1].Color)
+	require.Len(t, result.Blocks, 1)
+	assert.Equal(t, data.ColorByBlock, result.Blocks[0].Color)
+	require.Len(t, result.Dimensions, 1)
+	assert.Equal(t, 5, result.Dimensions[0].Color)
+}
+
+func TestParser_SupportedVersions(t *testing.T) {
+	p := NewParser()
+	versions := p.SupportedVersions()
+
+	assert.Equal(t, "R2000", versions[0], "Expected R2000 to be the oldest supported version")
+	assert.Contains(t, versions, "R2018")
+	assert.NotContains(t, versions, "R14")
+}
+
+func TestParseDXF_VersionWarnings(t *testing.T) {
+	tests := []struct {
+		name            string
+		acadVer         string
+		expectedVersion string
+		expectedWarning string
+	}{
+		{name: "R2000 is supported", acadVer: "AC1015", expectedVersion: "R2000"},
+		{name: "R2018 is supported", acadVer: "AC1032", expectedVersion: "R2018"},
+		{name: "R14 is too old", acadVer: "AC1014", expectedVersion: "R14", expectedWarning: "older than R2000"},
+		{name: "R12 is too old", acadVer: "AC1009", expectedVersion: "R12", expectedWarning: "older than R2000"},
+		{name: "unknown newer version", acadVer: "AC1040", expectedVersion: "AC1040", expectedWarning: "newer than the latest tested version R2018"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dxfContent := "0\nSECTION\n2\nHEADER\n9\n$ACADVER\n1\n" + tt.acadVer + "\n0\nENDSEC\n0\nEOF"
+
+			tmpFile, err := os.CreateTemp("", "test-*.dxf")
+			require.NoError(t, err, "Failed to create temp file")
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(dxfContent)
+			require.NoError(t, err, "Failed to write test DXF content")
+			tmpFile.Close()
+
+			result, err := NewParser().ParseDXF(tmpFile.Name())
+			require.NoError(t, err, "Unsupported versions should still parse")
+			assert.Equal(t, tt.expectedVersion, result.DXFVersion)
+
+			if tt.expectedWarning == "" {
+				assert.Empty(t, result.Warnings)
+			} else {
+				require.Len(t, result.Warnings, 1)
+				assert.Contains(t, result.Warnings[0], tt.expectedWarning)
+			}
+		})
+	}
+}
+
+func TestParseDXF_Units(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "millimeters", header: "9\n$INSUNITS\n70\n4\n", expected: "Millimeters"},
+		{name: "inches", header: "9\n$INSUNITS\n70\n     1\n", expected: "Inches"},
+		{name: "missing header", header: "", expected: "Unitless"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dxfContent := "0\nSECTION\n2\nHEADER\n9\n$ACADVER\n1\nAC1015\n" + tt.header + "0\nENDSEC\n0\nEOF"
+			path := filepath.Join(t.TempDir(), "units.dxf")
+			require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+			result, err := NewParser().ParseDXF(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Units)
+		})
+	}
+}
+
+func TestParseDXF_HeaderExtents(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected *data.BoundingBox
+	}{
+		{
+			name:   "stored extents",
+			header: "9\n$EXTMIN\n10\n-1.5\n20\n2\n30\n0\n9\n$EXTMAX\n10\n100\n20\n50.25\n30\n3\n",
+			expected: &data.BoundingBox{
+				Min: data.Point{X: -1.5, Y: 2},
+				Max: data.Point{X: 100, Y: 50.25, Z: 3},
+			},
+		},
+		{name: "missing header", header: ""},
+		{name: "only a minimum", header: "9\n$EXTMIN\n10\n0\n20\n0\n"},
+		{
+			name:   "empty drawing",
+			header: "9\n$EXTMIN\n10\n1e+20\n20\n1e+20\n30\n1e+20\n9\n$EXTMAX\n10\n-1e+20\n20\n-1e+20\n30\n-1e+20\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dxfContent := "0\nSECTION\n2\nHEADER\n9\n$ACADVER\n1\nAC1015\n" + tt.header + "0\nENDSEC\n0\nEOF"
+			path := filepath.Join(t.TempDir(), "extents.dxf")
+			require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+			result, err := NewParser().ParseDXF(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.HeaderExtents)
+		})
+	}
+}
+
+func TestParseDXFReader(t *testing.T) {
+	dxfContent := "0\nSECTION\n2\nHEADER\n9\n$ACADVER\n1\nAC1015\n0\nENDSEC\n" +
+		"0\nSECTION\n2\nENTITIES\n0\nLINE\n8\nWalls\n10\n0\n20\n0\n11\n3\n21\n4\n0\nENDSEC\n0\nEOF"
+
+	result, err := NewParser().ParseDXFReader(strings.NewReader(dxfContent))
+	require.NoError(t, err)
+	assert.Equal(t, "R2000", result.DXFVersion)
+	require.Len(t, result.Lines, 1)
+	assert.Equal(t, data.Point{X: 3, Y: 4}, result.Lines[0].EndPoint)
+}
+
+func TestParseDXFReader_RejectsDWG(t *testing.T) {
+	_, err := NewParser().ParseDXFReader(strings.NewReader("AC1032\x00\x00\x00\x00\x00\x01"))
+	assert.ErrorIs(t, err, ErrDWGInput)
+}
+
+func TestParseDXF_LogsWarnings(t *testing.T) {
+	dxfContent := "0\nSECTION\n2\nHEADER\n9\n$ACADVER\n1\nAC1009\n0\nENDSEC\n0\nEOF"
+	path := filepath.Join(t.TempDir(), "old.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+	var buf bytes.Buffer
+	p := NewParser()
+	p.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	result, err := p.ParseDXF(path)
+	require.NoError(t, err)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, buf.String(), "level=WARN")
+	assert.Contains(t, buf.String(), "older than R2000")
+
+	// A nil logger falls back to discarding messages
+	p.SetLogger(nil)
+	assert.NotPanics(t, func() {
+		_, err = p.ParseDXF(path)
+	})
+	assert.NoError(t, err)
+}
+
+func TestParseDXF_WithGeometryEntities(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+TABLES
+0
+TABLE
+2
+LAYER
+0
+LAYER
+2
+WALLS
+70
+0
+0
+LAYER
+2
+EMPTY
+70
+0
+0
+ENDTAB
+0
+ENDSEC
+0
+SECTION
+2
+ENTITIES
+0
+LINE
+8
+WALLS
+62
+1
+10
+1.0
+20
+2.0
+30
+0.0
+11
+4.0
+21
+6.0
+31
+0.0
+0
+CIRCLE
+8
+WALLS
+10
+5.0
+20
+5.0
+40
+2.5
+0
+TEXT
+8
+WALLS
+1
+Room 101
+10
+3.0
+20
+4.0
+40
+0.25
+50
+90.0
+7
+STANDARD
+0
+MTEXT
+8
+WALLS
+3
+Long-
+1
+note
+0
+LWPOLYLINE
+8
+WALLS
+90
+3
+70
+1
+10
+0.0
+20
+0.0
+10
+10.0
+20
+0.0
+10
+10.0
+20
+10.0
+0
+POLYLINE
+8
+WALLS
+66
+1
+0
+VERTEX
+8
+WALLS
+10
+1.0
+20
+1.0
+0
+VERTEX
+8
+WALLS
+10
+2.0
+20
+2.0
+0
+SEQEND
+0
+INSERT
+8
+WALLS
+2
+DOOR
+10
+7.0
+20
+8.0
+41
+2.0
+50
+45.0
+66
+1
+0
+ATTRIB
+8
+WALLS
+2
+WIDTH
+1
+900
+0
+SEQEND
+0
+SPLINE
+8
+WALLS
+0
+SPLINE
+8
+WALLS
+xx
+bad
+0
+ENDSEC
+0
+EOF`
+
+	tmpFile, err := os.CreateTemp("", "test-*.dxf")
+	require.NoError(t, err, "Failed to create temp file")
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString(dxfContent)
+	require.NoError(t, err, "Failed to write test DXF content")
+	tmpFile.Close()
+
+	result, err := NewParser().ParseDXF(tmpFile.Name())
+	require.NoError(t, err, "Unexpected error parsing DXF entities")
+
+	require.Len(t, result.Lines, 1)
+	assert.Equal(t, data.LineInfo{StartPoint: data.Point{X: 1, Y: 2}, EndPoint: data.Point{X: 4, Y: 6}, Layer: "WALLS", Color: 1}, result.Lines[0])
+
+	require.Len(t, result.Circles, 1)
+	assert.Equal(t, 2.5, result.Circles[0].Radius)
+	assert.Equal(t, data.Point{X: 5, Y: 5}, result.Circles[0].Center)
+
+	require.Len(t, result.Texts, 2)
+	assert.Equal(t, "Room 101", result.Texts[0].Value)
+	assert.Equal(t, 0.25, result.Texts[0].Height)
+	assert.Equal(t, 90.0, result.Texts[0].Rotation)
+	assert.Equal(t, "STANDARD", result.Texts[0].Style)
+	assert.Equal(t, "Long-note", result.Texts[1].Value, "Expected MTEXT chunks to be joined")
+
+	require.Len(t, result.Polylines, 2)
+	assert.True(t, result.Polylines[0].IsClosed)
+	assert.Equal(t, []data.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}, result.Polylines[0].Points)
+	assert.Equal(t, []data.Point{{X: 1, Y: 1}, {X: 2, Y: 2}}, result.Polylines[1].Points, "Expected VERTEX entities on the POLYLINE")
+
+	require.Len(t, result.Blocks, 1)
+	block := result.Blocks[0]
+	assert.Equal(t, "DOOR", block.Name)
+	assert.Equal(t, data.Point{X: 7, Y: 8}, block.InsertionPoint)
+	assert.Equal(t, data.Point{X: 2, Y: 1, Z: 1}, block.Scale, "Expected unset scale factors to default to 1")
+	assert.Equal(t, 45.0, block.Rotation)
+	require.Len(t, block.Attributes, 1)
+	assert.Equal(t, "WIDTH", block.Attributes[0].Tag)
+	assert.Equal(t, "900", block.Attributes[0].Value)
+
+	// Seven entities land on WALLS; VERTEX/ATTRIB/SEQEND are folded into their parents
+	require.Len(t, result.Layers, 2)
+	assert.Len(t, result.Layers[0].Entities, 7)
+
+	assert.Equal(t, []string{
+		"Skipped 1 malformed group codes in the ENTITIES section",
+		"Skipped 2 unrecognized SPLINE entities",
+		"Layer EMPTY has no entities",
+	}, result.Warnings)
+}
+
+func TestParseDXF_KeepRawCodes(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+ENTITIES
+0
+LINE
+8
+0
+10
+1.0
+20
+2.0
+0
+POLYLINE
+8
+0
+0
+VERTEX
+10
+3.0
+0
+SEQEND
+0
+ENDSEC
+0
+EOF`
+
+	tmpFile, err := os.CreateTemp("", "test-*.dxf")
+	require.NoError(t, err, "Failed to create temp file")
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString(dxfContent)
+	require.NoError(t, err, "Failed to write test DXF content")
+	tmpFile.Close()
+
+	// Raw codes are not kept by default
+	result, err := NewParser().ParseDXF(tmpFile.Name())
+	require.NoError(t, err)
+	require.Len(t, result.Lines, 1)
+	assert.Nil(t, result.Lines[0].RawCodes)
+
+	parser := NewParser()
+	parser.SetKeepRawCodes(true)
+	result, err = parser.ParseDXF(tmpFile.Name())
+	require.NoError(t, err)
+
+	require.Len(t, result.Lines, 1)
+	assert.Equal(t, []data.GroupCode{
+		{Code: 0, Value: "LINE"},
+		{Code: 8, Value: "0"},
+		{Code: 10, Value: "1.0"},
+		{Code: 20, Value: "2.0"},
+	}, result.Lines[0].RawCodes)
+
+	require.Len(t, result.Polylines, 1)
+	assert.Equal(t, []data.GroupCode{
+		{Code: 0, Value: "POLYLINE"},
+		{Code: 8, Value: "0"},
+		{Code: 0, Value: "VERTEX"},
+		{Code: 10, Value: "3.0"},
+		{Code: 0, Value: "SEQEND"},
+	}, result.Polylines[0].RawCodes, "Expected VERTEX and SEQEND codes with the POLYLINE")
+}
+
+func TestParseDXF_EntityFilter(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+ENTITIES
+0
+LINE
+8
+0
+0
+TEXT
+8
+0
+1
+Hello
+0
+INSERT
+8
+0
+2
+DOOR
+0
+ATTRIB
+2
+TAG
+1
+D1
+0
+SEQEND
+0
+POLYLINE
+8
+0
+0
+VERTEX
+10
+3.0
+0
+SEQEND
+0
+MTEXT
+8
+0
+1
+World
+0
+LINE
+8
+0
+0
+ENDSEC
+0
+EOF`
+	path := filepath.Join(t.TempDir(), "filter.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+	tests := []struct {
+		name             string
+		filter           []string
+		expectTexts      int
+		expectLines      int
+		expectBlocks     int
+		expectPolylines  int
+		expectedWarnings []string
+	}{
+		{
+			name:            "no filter parses everything",
+			expectTexts:     2,
+			expectLines:     2,
+			expectBlocks:    1,
+			expectPolylines: 1,
+		},
+		{
+			name:        "text only",
+			filter:      []string{"TEXT"},
+			expectTexts: 2,
+			expectedWarnings: []string{
+				"Skipped 2 LINE entities excluded by the entity filter",
+				"Skipped 1 INSERT entities excluded by the entity filter",
+				"Skipped 1 POLYLINE entities excluded by the entity filter",
+			},
+		},
+		{
+			name:            "blocks and polylines keep their sequences",
+			filter:          []string{"block", "polyline"},
+			expectBlocks:    1,
+			expectPolylines: 1,
+			expectedWarnings: []string{
+				"Skipped 2 LINE entities excluded by the entity filter",
+				"Skipped 1 TEXT entities excluded by the entity filter",
+				"Skipped 1 MTEXT entities excluded by the entity filter",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser()
+			p.SetEntityFilter(tt.filter)
+			result, err := p.ParseDXF(path)
+			require.NoError(t, err)
+
+			assert.Len(t, result.Texts, tt.expectTexts)
+			assert.Len(t, result.Lines, tt.expectLines)
+			assert.Len(t, result.Blocks, tt.expectBlocks)
+			assert.Len(t, result.Polylines, tt.expectPolylines)
+			if tt.expectBlocks > 0 {
+				assert.Len(t, result.Blocks[0].Attributes, 1)
+			}
+			assert.Equal(t, tt.expectedWarnings, result.Warnings, "Skipped sequences should not be reported as unrecognized")
+		})
+	}
+
+	// Clearing the filter parses every type again
+	p := NewParser()
+	p.SetEntityFilter([]string{"line"})
+	p.SetEntityFilter(nil)
+	result, err := p.ParseDXF(path)
+	require.NoError(t, err)
+	assert.Len(t, result.Texts, 2)
+}
+
+func TestParseDXF_BlockDefinitions(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+BLOCKS
+0
+BLOCK
+8
+0
+2
+DOOR
+10
+1.0
+20
+2.0
+30
+0.0
+0
+LINE
+8
+0
+10
+0.0
+20
+0.0
+11
+1.0
+21
+0.0
+0
+INSERT
+8
+0
+2
+HINGE
+0
+ENDBLK
+8
+0
+0
+BLOCK
+8
+0
+2
+HINGE
+0
+CIRCLE
+8
+0
+10
+0.5
+20
+0.5
+40
+0.25
+0
+ENDBLK
+0
+ENDSEC
+0
+SECTION
+2
+ENTITIES
+0
+INSERT
+8
+0
+2
+DOOR
+0
+INSERT
+8
+0
+2
+WINDOW
+0
+INSERT
+8
+0
+2
+WINDOW
+0
+ENDSEC
+0
+EOF`
+	path := filepath.Join(t.TempDir(), "blocks.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+	result, err := NewParser().ParseDXF(path)
+	require.NoError(t, err)
+
+	require.Len(t, result.BlockDefinitions, 2)
+	door := result.BlockDefinitions["DOOR"]
+	require.NotNil(t, door)
+	assert.Equal(t, "DOOR", door.Name)
+	assert.Equal(t, data.Point{X: 1, Y: 2}, door.BasePoint)
+	require.Len(t, door.Entities, 2)
+	assert.IsType(t, &data.LineInfo{}, door.Entities[0])
+	nested, ok := door.Entities[1].(*data.BlockInfo)
+	require.True(t, ok)
+	assert.Equal(t, "HINGE", nested.Name)
+
+	hinge := result.BlockDefinitions["HINGE"]
+	require.NotNil(t, hinge)
+	require.Len(t, hinge.Entities, 1)
+	assert.Equal(t, 0.25, hinge.Entities[0].(*data.CircleInfo).Radius)
+
+	// Definition entities are not part of the drawing itself
+	assert.Empty(t, result.Lines)
+	assert.Empty(t, result.Circles)
+	assert.Len(t, result.Blocks, 3)
+
+	assert.Contains(t, result.Warnings, `2 insertions reference undefined block "WINDOW"`)
+	for _, warning := range result.Warnings {
+		assert.NotContains(t, warning, "DOOR")
+	}
+}
+
+func TestParseDXF_NoBlocksSection(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+ENTITIES
+0
+INSERT
+8
+0
+2
+DOOR
+0
+ENDSEC
+0
+EOF`
+	path := filepath.Join(t.TempDir(), "noblocks.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+	result, err := NewParser().ParseDXF(path)
+	require.NoError(t, err)
+
+	// Without a BLOCKS section there is nothing to check insertions against
+	assert.Nil(t, result.BlockDefinitions)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestParseDXF_LineTypes(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+TABLES
+0
+TABLE
+2
+LTYPE
+70
+2
+0
+LTYPE
+2
+Continuous
+70
+0
+3
+Solid line
+72
+65
+73
+0
+40
+0.0
+0
+LTYPE
+2
+DASHDOT
+70
+0
+3
+Dash dot __ . __ . __
+72
+65
+73
+4
+40
+1.0
+49
+0.5
+74
+0
+49
+-0.25
+74
+0
+49
+0.0
+74
+0
+49
+-0.25
+74
+0
+0
+ENDTAB
+0
+TABLE
+2
+LAYER
+0
+LAYER
+2
+Walls
+70
+0
+0
+LAYER
+2
+Hidden
+70
+0
+6
+HIDDEN
+0
+LAYER
+2
+Center
+70
+0
+6
+DASHDOT
+0
+ENDTAB
+0
+ENDSEC
+0
+EOF`
+	path := filepath.Join(t.TempDir(), "linetypes.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+	result, err := NewParser().ParseDXF(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]*data.LineTypeInfo{
+		"Continuous": {Name: "Continuous", Description: "Solid line"},
+		"DASHDOT":    {Name: "DASHDOT", Description: "Dash dot __ . __ . __", Pattern: []float64{0.5, -0.25, 0, -0.25}},
+	}, result.LineTypes)
+
+	// Walls uses the default CONTINUOUS, matched ignoring case
+	assert.Contains(t, result.Warnings, `Layer Hidden references undefined line type "HIDDEN"`)
+	assert.NotContains(t, result.Warnings, `Layer Walls references undefined line type "CONTINUOUS"`)
+}
+
+func TestParseDXF_TextAlignment(t *testing.T) {
+	dxfContent := "0\nSECTION\n2\nENTITIES\n" +
+		"0\nTEXT\n8\n0\n1\nPlain\n" +
+		"0\nTEXT\n8\n0\n1\nCentered\n50\n30.0\n72\n1\n73\n2\n" +
+		// 72 is the drawing direction of an MTEXT, not its alignment
+		"0\nMTEXT\n8\n0\n1\nNote\n71\n9\n72\n1\n" +
+		"0\nMTEXT\n8\n0\n1\nDefault\n" +
+		"0\nENDSEC\n0\nEOF"
+	path := filepath.Join(t.TempDir(), "text.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+	result, err := NewParser().ParseDXF(path)
+	require.NoError(t, err)
+	require.Len(t, result.Texts, 4)
+
+	alignments := make([][3]any, len(result.Texts))
+	for i, text := range result.Texts {
+		alignments[i] = [3]any{text.Rotation, text.HorizontalAlign, text.VerticalAlign}
+	}
+	assert.Equal(t, [][3]any{
+		{0.0, "Left", "Baseline"},
+		{30.0, "Center", "Middle"},
+		{0.0, "Right", "Bottom"},
+		{0.0, "Left", "Top"},
+	}, alignments)
+}
+
+func TestParseDXF_Truncated(t *testing.T) {
+	// Cut off in the middle of the third entity, with no ENDSEC or EOF marker
+	dxfContent := `0
+SECTION
+2
+TABLES
+0
+TABLE
+2
+LAYER
+0
+LAYER
+2
+Walls
+70
+0
+0
+ENDTAB
+0
+ENDSEC
+0
+SECTION
+2
+ENTITIES
+0
+LINE
+8
+Walls
+10
+1.0
+20
+2.0
+11
+3.0
+21
+4.0
+0
+CIRCLE
+8
+Walls
+10
+5.0
+20
+5.0
+40
+2.5
+0
+LINE
+8
+Walls
+10
+9.0
+20
+`
+	path := filepath.Join(t.TempDir(), "truncated.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+	result, err := NewParser().ParseDXF(path)
+	require.NoError(t, err)
+
+	// The entities read before the cut survive; the one cut off is dropped
+	require.Len(t, result.Lines, 1)
+	assert.Equal(t, data.Point{X: 3, Y: 4}, result.Lines[0].EndPoint)
+	require.Len(t, result.Circles, 1)
+	assert.Equal(t, 2.5, result.Circles[0].Radius)
+	require.Len(t, result.Layers, 1)
+	assert.Len(t, result.Layers[0].Entities, 2)
+	assert.Equal(t, []string{
+		"ENTITIES section ends without ENDSEC; the file may be truncated",
+		"DXF file has no EOF marker; the file may be truncated",
+	}, result.Warnings)
+
+	p := NewParser()
+	p.SetStrict(true)
+	result, err = p.ParseDXF(path)
+	assert.ErrorIs(t, err, ErrTruncated)
+	assert.Contains(t, err.Error(), "ENTITIES section ends without ENDSEC")
+	assert.Nil(t, result)
+}
+
+func TestParseDXF_TruncatedBlocks(t *testing.T) {
+	dxfContent := "0\nSECTION\n2\nBLOCKS\n" +
+		"0\nBLOCK\n2\nDOOR\n0\nLINE\n8\n0\n0\nENDBLK\n" +
+		"0\nBLOCK\n2\nWINDOW\n0\nLINE\n8\n0\n"
+	path := filepath.Join(t.TempDir(), "blocks.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+	result, err := NewParser().ParseDXF(path)
+	require.NoError(t, err)
+	assert.Contains(t, result.BlockDefinitions, "DOOR")
+	assert.NotContains(t, result.BlockDefinitions, "WINDOW", "a block cut off before ENDBLK is left out")
+	assert.Contains(t, result.Warnings, "BLOCKS section ends without ENDSEC; the file may be truncated")
+
+	// A complete file without its EOF marker only fails in strict mode
+	complete := filepath.Join(t.TempDir(), "noeof.dxf")
+	require.NoError(t, os.WriteFile(complete, []byte("0\nSECTION\n2\nENTITIES\n0\nPOINT\n8\n0\n0\nENDSEC\n"), 0644))
+	result, err = NewParser().ParseDXF(complete)
+	require.NoError(t, err)
+	assert.Len(t, result.Points, 1)
+	assert.Equal(t, []string{"DXF file has no EOF marker; the file may be truncated"}, result.Warnings)
+
+	p := NewParser()
+	p.SetStrict(true)
+	_, err = p.ParseDXF(complete)
+	assert.ErrorIs(t, err, ErrTruncated)
+}
+
+func TestValidateEntityTypes(t *testing.T) {
+	assert.NoError(t, ValidateEntityTypes([]string{"line", " Text ", "block"}))
+	assert.NoError(t, ValidateEntityTypes(nil))
+
+	err := ValidateEntityTypes([]string{"line", "spline"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown entity type "spline"`)
+}
+
+func TestParseDXFStream(t *testing.T) {
+	dxfContent := `0
+SECTION
+2
+ENTITIES
+0
+LINE
+8
+Walls
+10
+1.0
+0
+SPLINE
+8
+Walls
+0
+INSERT
+8
+Doors
+2
+DOOR
+0
+ATTRIB
+2
+WIDTH
+1
+900
+0
+SEQEND
+0
+CIRCLE
+8
+Walls
+40
+2.5
+0
+ENDSEC
+0
+EOF`
+
+	var entities []data.Entity
+	err := NewParser().ParseDXFStream(strings.NewReader(dxfContent), func(entity data.Entity) error {
+		entities = append(entities, entity)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Unrecognized entities are skipped and sequences are folded into their owner
+	require.Len(t, entities, 3)
+	assert.Equal(t, 1.0, entities[0].(*data.LineInfo).StartPoint.X)
+	block := entities[1].(*data.BlockInfo)
+	assert.Equal(t, "DOOR", block.Name)
+	assert.Equal(t, []data.AttributeInfo{{Tag: "WIDTH", Value: "900"}}, block.Attributes)
+	assert.Equal(t, 2.5, entities[2].(*data.CircleInfo).Radius)
+}
+
+func TestParseDXFStream_HandlerError(t *testing.T) {
+	dxfContent := "0\nSECTION\n2\nENTITIES\n0\nLINE\n8\n0\n0\nCIRCLE\n8\n0\n0\nPOINT\n8\n0\n0\nENDSEC\n"
+
+	stop := errors.New("stop")
+	calls := 0
+	err := NewParser().ParseDXFStream(strings.NewReader(dxfContent), func(entity data.Entity) error {
+		calls++
+		if calls == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, calls, "Parsing should stop at the handler error")
+}
+
+func TestParseDXFStream_Strict(t *testing.T) {
+	dxfContent := "0\nSECTION\n2\nENTITIES\n0\nPOINT\n8\n0\n0\nLINE\n8\n"
+
+	p := NewParser()
+	calls := 0
+	handler := func(data.Entity) error {
+		calls++
+		return nil
+	}
+	require.NoError(t, p.ParseDXFStream(strings.NewReader(dxfContent), handler))
+	assert.Equal(t, 1, calls, "The entity cut off should not reach the handler")
+
+	p.SetStrict(true)
+	err := p.ParseDXFStream(strings.NewReader(dxfContent), handler)
+	assert.ErrorIs(t, err, ErrTruncated)
+	complete := "0\nSECTION\n2\nENTITIES\n0\nPOINT\n8\n0\n0\nENDSEC\n"
+	assert.NoError(t, p.ParseDXFStream(strings.NewReader(complete), handler))
+}
+
+func TestParseDXF_Handles(t *testing.T) {
+	dxfContent := "0\nSECTION\n2\nENTITIES\n" +
+		"0\nLINE\n5\n2A\n8\n0\n10\n0\n20\n0\n11\n1\n21\n1\n" +
+		"0\nCIRCLE\n5\n2B\n8\n0\n40\n1\n" +
+		"0\nTEXT\n5\n2C\n8\n0\n1\nNote\n" +
+		"0\nLWPOLYLINE\n5\n2D\n8\n0\n10\n0\n20\n0\n" +
+		"0\nINSERT\n5\n2E\n8\n0\n2\nDOOR\n" +
+		"0\nDIMENSION\n5\n2F\n8\n0\n" +
+		"0\nPOINT\n5\n30\n8\n0\n" +
+		"0\nHATCH\n5\n31\n8\n0\n2\nSOLID\n" +
+		"0\nPOINT\n8\n0\n" +
+		"0\nENDSEC\n0\nEOF"
+	path := filepath.Join(t.TempDir(), "handles.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(dxfContent), 0644))
+
+	result, err := NewParser().ParseDXF(path)
+	require.NoError(t, err)
+
+	var handles []string
+	for _, entity := range result.AllEntities() {
+		handles = append(handles, entity.GetHandle())
+	}
+	assert.ElementsMatch(t, []string{"2A", "2B", "2C", "2D", "2E", "2F", "30", "31", ""}, handles,
+		"entities without group code 5 have no handle")
+}
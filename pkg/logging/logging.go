@@ -0,0 +1,32 @@
+// Package logging defines the leveled logger used by the converter and parser.
+package logging
+
+// Logger receives leveled, structured log messages. args are alternating
+// key/value pairs, so a *slog.Logger satisfies this interface.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// nopLogger discards every message
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...any) {}
+func (nopLogger) Info(msg string, args ...any)  {}
+func (nopLogger) Warn(msg string, args ...any)  {}
+func (nopLogger) Error(msg string, args ...any) {}
+
+// Nop returns a Logger that discards all output.
+func Nop() Logger {
+	return nopLogger{}
+}
+
+// OrNop returns logger, or a no-op Logger if logger is nil.
+func OrNop(logger Logger) Logger {
+	if logger == nil {
+		return Nop()
+	}
+	return logger
+}
@@ -0,0 +1,29 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrNop(t *testing.T) {
+	t.Run("nil logger is replaced with a no-op logger", func(t *testing.T) {
+		logger := OrNop(nil)
+		assert.NotNil(t, logger)
+		assert.NotPanics(t, func() {
+			logger.Debug("debug", "key", 1)
+			logger.Info("info")
+			logger.Warn("warn")
+			logger.Error("error")
+		})
+	})
+
+	t.Run("non-nil logger is kept", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := OrNop(slog.New(slog.NewTextHandler(&buf, nil)))
+		logger.Info("converted", "file", "test.dwg")
+		assert.Contains(t, buf.String(), "msg=converted file=test.dwg")
+	})
+}
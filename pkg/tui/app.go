@@ -1,18 +1,31 @@
 package tui
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/remym/go-dwg-extractor/pkg/data"
 	"github.com/rivo/tview"
 )
 
+// noTerminalMessage explains why the TUI cannot start without a terminal
+const noTerminalMessage = "no interactive terminal available; use the extract command for headless output"
+
+// screenInitTimeout bounds how long Run waits for the terminal to initialize
+const screenInitTimeout = 5 * time.Second
+
+// newScreen creates the terminal screen; a variable so tests can simulate a missing terminal
+var newScreen = tcell.NewScreen
+
 // App represents the main TUI application
 type App struct {
-	app       *tview.Application
-	pages     *tview.Pages
-	dxfView   *DXFView
-	statusBar *tview.TextView
-	testMode  bool // Indicates if app is running in test mode
+	app          *tview.Application
+	pages        *tview.Pages
+	dxfView      *DXFView
+	screen       tcell.Screen // Screen to draw to; nil means the terminal
+	mouseEnabled bool
+	testMode     bool // Indicates if app is running in test mode
 }
 
 // NewApp creates a new TUI application
@@ -34,7 +47,6 @@ func NewApp() *App {
 
 	// Set the root
 	app.SetRoot(pages, true).
-		EnableMouse(true).
 		SetFocus(pages)
 
 	return tuiApp
@@ -47,6 +59,13 @@ func (a *App) SetTestMode(enabled bool) {
 	a.testMode = enabled
 }
 
+// SetMouseEnabled enables or disables mouse support for the application and its lists
+func (a *App) SetMouseEnabled(enabled bool) {
+	a.mouseEnabled = enabled
+	a.app.EnableMouse(enabled)
+	a.dxfView.SetMouseEnabled(enabled)
+}
+
 // UpdateDXFData updates the DXF view with new data
 func (a *App) UpdateDXFData(data *data.ExtractedData) {
 	if a.testMode {
@@ -54,9 +73,47 @@ func (a *App) UpdateDXFData(data *data.ExtractedData) {
 		a.dxfView.Update(data)
 	} else {
 		// In normal mode, queue the update for the event loop
-		a.app.QueueUpdateDraw(func() {
-			a.dxfView.Update(data)
-		})
+		a.dxfView.UpdateAsync(data)
+	}
+}
+
+// ShowDrawing shows the drawing read from sourcePath. The path Ctrl+R reloads, Shift+Y
+// copies and the session is saved under only changes once the drawing is shown, so a
+// failed or cancelled open leaves them with the drawing still on screen.
+func (a *App) ShowDrawing(sourcePath string, drawing *data.ExtractedData) {
+	a.queue(func() {
+		a.dxfView.SetSourcePath(sourcePath)
+		a.dxfView.Update(drawing)
+	})
+}
+
+// ShowSampleData shows sample in place of a drawing, such as when the conversion of the
+// drawing given at startup is cancelled
+func (a *App) ShowSampleData(sample *data.ExtractedData) {
+	a.queue(func() {
+		a.dxfView.SetSourcePath("")
+		a.dxfView.Update(sample)
+	})
+}
+
+// HasData reports whether a drawing or sample data is shown. It is safe to call from any goroutine.
+func (a *App) HasData() bool {
+	return a.dxfView.Data() != nil
+}
+
+// ShowDiff replaces the main view with a side-by-side comparison of drawing before and its revision after
+func (a *App) ShowDiff(beforeName, afterName string, before, after *data.ExtractedData) {
+	show := func() {
+		diffView := NewDiffView(a.app, beforeName, afterName, before, after)
+		a.pages.AddAndSwitchToPage("diff", diffView.GetLayout(), true)
+		a.app.SetFocus(diffView.beforeList)
+	}
+	if a.testMode {
+		// In test mode, update directly without queuing
+		show()
+	} else {
+		// In normal mode, queue the update for the event loop
+		a.app.QueueUpdateDraw(show)
 	}
 }
 
@@ -75,16 +132,9 @@ func (a *App) setupLayout() {
 		SetText("DWG Extractor")
 	header.SetBorder(true)
 
-	// Add a status bar and store reference for updates
-	a.statusBar = tview.NewTextView().
-		SetTextAlign(tview.AlignLeft).
-		SetText("Press Ctrl+C or Esc to exit")
-	a.statusBar.SetBorder(true)
-
-	// Add components to the flex layout
+	// Messages go to the view's status bar, shown below its pages
 	flex.AddItem(header, 3, 1, false).
-		AddItem(a.dxfView.GetLayout(), 0, 1, true).
-		AddItem(a.statusBar, 3, 1, false)
+		AddItem(a.dxfView.GetLayout(), 0, 1, true)
 
 	// Add the layout to the pages
 	a.pages.AddPage("main", flex, true, true)
@@ -96,6 +146,11 @@ func (a *App) Run() error {
 	a.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
 		case tcell.KeyCtrlC, tcell.KeyEsc:
+			// Esc closes the overlay shown, or cancels its task, rather than quitting
+			if event.Key() == tcell.KeyEsc && a.dxfView.handlesEsc() {
+				return event
+			}
+			a.dxfView.GetQuitManager().AttemptQuit("force")
 			a.Stop()
 			return nil
 		}
@@ -107,6 +162,16 @@ func (a *App) Run() error {
 		return nil
 	}
 
+	// Initialize the screen first so a missing terminal fails fast with a clear error
+	screen, err := a.initScreen()
+	if err != nil {
+		return err
+	}
+	a.app.SetScreen(initializedScreen{screen})
+	if a.mouseEnabled {
+		screen.EnableMouse()
+	}
+
 	// Run the application
 	if err := a.app.Run(); err != nil {
 		return err
@@ -114,6 +179,73 @@ func (a *App) Run() error {
 	return nil
 }
 
+// SetScreen makes the application draw to screen, such as a tcell.SimulationScreen,
+// instead of the terminal. It must be called before Run.
+func (a *App) SetScreen(screen tcell.Screen) {
+	a.screen = screen
+}
+
+// initScreen creates and initializes the screen Run draws to. It returns a SystemError when
+// there is no usable terminal or it does not initialize within screenInitTimeout.
+func (a *App) initScreen() (tcell.Screen, error) {
+	screen := a.screen
+	if screen == nil {
+		var err error
+		if screen, err = newScreen(); err != nil {
+			return nil, NewSystemError(noTerminalMessage, err)
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- screen.Init()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, NewSystemError(noTerminalMessage, err)
+		}
+		return screen, nil
+	case <-time.After(screenInitTimeout):
+		return nil, NewSystemError(noTerminalMessage,
+			fmt.Errorf("terminal did not initialize within %s", screenInitTimeout))
+	}
+}
+
+// initializedScreen is a screen that Run already initialized, which tview would otherwise
+// initialize a second time
+type initializedScreen struct {
+	tcell.Screen
+}
+
+// Init does nothing, as the screen is initialized already
+func (initializedScreen) Init() error {
+	return nil
+}
+
+// SetSession saves the view state of the drawing at sourcePath in manager on quit
+// and restores it when the drawing is loaded
+func (a *App) SetSession(manager *SessionManager, sourcePath string) {
+	a.dxfView.SetSession(manager, sourcePath)
+}
+
+// SetOpenHandler sets the function Ctrl+O calls with the path of the drawing picked
+func (a *App) SetOpenHandler(handler func(path string)) {
+	a.dxfView.SetOpenHandler(handler)
+}
+
+// SetReloadHandler sets the function Ctrl+R calls with the path of the drawing shown, or
+// "" for sample data, to read it again
+func (a *App) SetReloadHandler(handler func(path string)) {
+	a.dxfView.SetReloadHandler(handler)
+}
+
+// SetSourcePath sets the path of the drawing shown; empty means sample data
+func (a *App) SetSourcePath(sourcePath string) {
+	a.dxfView.SetSourcePath(sourcePath)
+}
+
 // Stop gracefully shuts down the TUI application
 func (a *App) Stop() {
 	a.app.Stop()
@@ -129,28 +261,39 @@ func (a *App) GetLayout() *tview.Pages {
 	return a.pages
 }
 
-// ShowStatus updates the status bar with a status message
-func (a *App) ShowStatus(message string) {
+// ShowLoading shows msg with a spinner above the view until HideLoading.
+// Pressing Esc calls cancel, unless it is nil.
+func (a *App) ShowLoading(msg string, cancel func()) {
+	a.queue(func() {
+		a.dxfView.ShowLoading(msg)
+		a.dxfView.SetLoadingCancel(cancel)
+	})
+}
+
+// HideLoading removes the loading spinner
+func (a *App) HideLoading() {
+	a.queue(a.dxfView.HideLoading)
+}
+
+// queue runs update in the event loop, or directly in test mode
+func (a *App) queue(update func()) {
 	if a.testMode {
-		// In test mode, update directly without queuing
-		a.statusBar.SetText("[yellow]" + message + "[-]")
-	} else {
-		// In normal mode, queue the update for the event loop
-		a.app.QueueUpdateDraw(func() {
-			a.statusBar.SetText("[yellow]" + message + "[-]")
-		})
+		update()
+		return
 	}
+	a.app.QueueUpdateDraw(update)
 }
 
-// ShowError updates the status bar with an error message
+// ShowStatus shows a status message in the view's status bar
+func (a *App) ShowStatus(message string) {
+	a.queue(func() {
+		a.dxfView.GetStatusBar().SetStatus(message, StatusInfo)
+	})
+}
+
+// ShowError shows an error message in the view's status bar
 func (a *App) ShowError(message string) {
-	if a.testMode {
-		// In test mode, update directly without queuing
-		a.statusBar.SetText("[red]Error: " + message + "[-]")
-	} else {
-		// In normal mode, queue the update for the event loop
-		a.app.QueueUpdateDraw(func() {
-			a.statusBar.SetText("[red]Error: " + message + "[-]")
-		})
-	}
+	a.queue(func() {
+		a.dxfView.GetStatusBar().SetStatus(message, StatusError)
+	})
 }
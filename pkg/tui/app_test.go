@@ -2,10 +2,13 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,6 +40,61 @@ func TestApp_UpdateDXFData(t *testing.T) {
 	// If we reach here without hanging, the test passes
 }
 
+func TestApp_ShowStatus(t *testing.T) {
+	app := NewApp()
+	app.SetTestMode(true)
+	defer app.Stop()
+
+	app.ShowStatus("Converting: plan.dwg")
+	message, level := app.dxfView.GetStatusBar().Message()
+	assert.Equal(t, "Converting: plan.dwg", message)
+	assert.Equal(t, StatusInfo, level)
+
+	app.ShowError("Conversion failed")
+	message, level = app.dxfView.GetStatusBar().Message()
+	assert.Equal(t, "Conversion failed", message)
+	assert.Equal(t, StatusError, level)
+}
+
+func TestApp_ShowDrawing(t *testing.T) {
+	app := NewApp()
+	app.SetTestMode(true)
+	defer app.Stop()
+
+	drawing := &data.ExtractedData{Layers: []data.LayerInfo{{Name: "Walls"}}}
+	app.ShowDrawing("plan.dwg", drawing)
+	assert.Same(t, drawing, app.dxfView.Data())
+	assert.Equal(t, "plan.dwg", app.dxfView.sourcePath)
+}
+
+func TestApp_ShowSampleData(t *testing.T) {
+	app := NewApp()
+	app.SetTestMode(true)
+	defer app.Stop()
+
+	assert.False(t, app.HasData())
+	app.SetSourcePath("plan.dwg")
+
+	sample := &data.ExtractedData{DXFVersion: "Sample", Layers: []data.LayerInfo{{Name: "0"}}}
+	app.ShowSampleData(sample)
+	assert.True(t, app.HasData())
+	assert.Same(t, sample, app.dxfView.Data())
+	assert.Empty(t, app.dxfView.sourcePath, "sample data has no source path")
+}
+
+func TestApp_ShowDiff(t *testing.T) {
+	app := NewApp()
+	app.SetTestMode(true) // Enable test mode to prevent hanging
+	defer app.Stop()
+
+	before := &data.ExtractedData{Layers: []data.LayerInfo{{Name: "0"}}}
+	after := &data.ExtractedData{Layers: []data.LayerInfo{{Name: "0"}, {Name: "New"}}}
+	app.ShowDiff("rev1.dwg", "rev2.dwg", before, after)
+
+	name, _ := app.GetLayout().GetFrontPage()
+	require.Equal(t, "diff", name)
+}
+
 func TestApp_SetupLayout(t *testing.T) {
 	app := NewApp()
 	app.SetTestMode(true) // Enable test mode to prevent hanging
@@ -60,6 +118,10 @@ func TestApp_Run_Stop(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			app := NewApp()
 			app.SetTestMode(tc.testMode)
+			if !tc.testMode {
+				// Draw to a simulated terminal, as tests have none
+				app.SetScreen(tcell.NewSimulationScreen(""))
+			}
 			done := make(chan struct{})
 
 			// Use a very short timeout for the actual event loop test
@@ -94,6 +156,119 @@ func TestApp_Run_Stop(t *testing.T) {
 	}
 }
 
+// failingScreen is a simulated screen whose terminal cannot be initialized
+type failingScreen struct {
+	tcell.SimulationScreen
+}
+
+func (failingScreen) Init() error {
+	return errors.New("open /dev/tty: no such device or address")
+}
+
+func TestApp_Run_NoTerminal(t *testing.T) {
+	t.Run("screen cannot be created", func(t *testing.T) {
+		original := newScreen
+		newScreen = func() (tcell.Screen, error) {
+			return nil, errors.New("terminal not cursor addressable")
+		}
+		defer func() { newScreen = original }()
+
+		err := NewApp().Run()
+		var systemErr *SystemError
+		require.ErrorAs(t, err, &systemErr)
+		assert.Equal(t, noTerminalMessage, err.Error())
+		assert.ErrorContains(t, errors.Unwrap(err), "cursor addressable")
+	})
+
+	t.Run("screen cannot be initialized", func(t *testing.T) {
+		app := NewApp()
+		app.SetScreen(failingScreen{tcell.NewSimulationScreen("")})
+
+		err := app.Run()
+		var systemErr *SystemError
+		require.ErrorAs(t, err, &systemErr)
+		assert.Contains(t, err.Error(), "use the extract command")
+	})
+}
+
+// TestApp_RunEscClosesOverlays tests that Esc in a running app closes the overlay shown
+// rather than quitting, and quits once nothing is left to close
+func TestApp_RunEscClosesOverlays(t *testing.T) {
+	tests := []struct {
+		name   string
+		open   func(view *DXFView)
+		isOpen func(view *DXFView) bool
+	}{
+		{
+			name:   "goto prompt",
+			open:   func(view *DXFView) { view.showGotoPrompt() },
+			isOpen: func(view *DXFView) bool { return view.pages.HasPage("goto") },
+		},
+		{
+			name: "block definition",
+			open: func(view *DXFView) {
+				view.data.BlockDefinitions = map[string]*data.BlockDefinition{
+					"DOOR": {Name: "DOOR", Entities: []data.Entity{&data.LineInfo{Layer: "0"}}},
+				}
+				view.ShowBlockDefinition("DOOR")
+			},
+			isOpen: func(view *DXFView) bool { return view.pages.HasPage("block") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := NewApp()
+			screen := tcell.NewSimulationScreen("")
+			app.SetScreen(screen)
+			view := app.dxfView
+			view.Update(createTestData())
+
+			done := make(chan error, 1)
+			go func() { done <- app.Run() }()
+			// Wait for the event loop to handle updates
+			app.app.QueueUpdate(func() {})
+
+			// inLoop runs f on the event loop and waits for it
+			inLoop := func(f func()) {
+				ran := make(chan struct{})
+				app.app.QueueUpdate(func() {
+					f()
+					close(ran)
+				})
+				<-ran
+			}
+
+			var open bool
+			inLoop(func() {
+				tt.open(view)
+				open = tt.isOpen(view)
+			})
+			require.True(t, open)
+
+			screen.InjectKey(tcell.KeyEsc, 0, tcell.ModNone)
+			assert.Eventually(t, func() bool {
+				inLoop(func() { open = tt.isOpen(view) })
+				return !open
+			}, 2*time.Second, 10*time.Millisecond, "Esc should close the overlay")
+			select {
+			case err := <-done:
+				t.Fatalf("Esc quit the app: %v", err)
+			default:
+			}
+
+			screen.InjectKey(tcell.KeyEsc, 0, tcell.ModNone)
+			select {
+			case err := <-done:
+				assert.NoError(t, err)
+			case <-time.After(2 * time.Second):
+				app.Stop()
+				t.Fatal("Esc with no overlay shown should quit")
+			}
+		})
+	}
+}
+
 func TestApp_App_GetLayout(t *testing.T) {
 	app := NewApp()
 	app.SetTestMode(true) // Enable test mode to prevent hanging
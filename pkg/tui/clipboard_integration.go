@@ -2,6 +2,8 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -10,6 +12,28 @@ import (
 	"github.com/remym/go-dwg-extractor/pkg/data"
 )
 
+// sampleDataPath is copied as the file path when the view shows sample data
+const sampleDataPath = "(sample data)"
+
+// formatNames are the names copy confirmations give the copy formats
+var formatNames = map[string]string{
+	"text":     "text",
+	"csv":      "CSV",
+	"json":     "JSON",
+	"dxf":      "DXF",
+	"schedule": "attribute schedule",
+	"summary":  "layer summary",
+}
+
+// formatName returns the name of a copy format shown to users. Unknown formats are
+// copied as text.
+func formatName(format string) string {
+	if name, ok := formatNames[format]; ok {
+		return name
+	}
+	return formatNames["text"]
+}
+
 // ClipboardManager interface for clipboard operations
 type ClipboardManager interface {
 	CopyToClipboard(text string) error
@@ -22,16 +46,21 @@ type ClipboardHandler struct {
 	formatter       *clipboard.ClipboardFormatter
 	selectedIndices []int
 	format          string
+	csvHeader       bool // CSV output starts with a header row
+	fallback        *clipboard.FallbackClipboardManager
 }
 
 // NewClipboardHandler creates a new clipboard handler for the TUI
 func NewClipboardHandler(view *DXFView, clipboardMgr ClipboardManager) *ClipboardHandler {
+	formatter := clipboard.NewClipboardFormatter()
+
 	return &ClipboardHandler{
 		view:            view,
 		clipboardMgr:    clipboardMgr,
-		formatter:       clipboard.NewClipboardFormatter(),
+		formatter:       formatter,
 		selectedIndices: make([]int, 0),
 		format:          "text", // Default format
+		csvHeader:       true,
 	}
 }
 
@@ -75,11 +104,66 @@ func (ch *ClipboardHandler) ClearSelection() {
 	ch.selectedIndices = ch.selectedIndices[:0]
 }
 
-// SetFormat sets the clipboard format (text, csv, json)
+// SetFormat sets the clipboard format (text, csv, json, dxf, schedule, summary)
 func (ch *ClipboardHandler) SetFormat(format string) {
 	ch.format = format
 }
 
+// SetCSVHeader sets whether CSV output starts with the Type,Layer,Details header row
+func (ch *ClipboardHandler) SetCSVHeader(include bool) {
+	ch.csvHeader = include
+}
+
+// CSVHeader returns whether CSV output starts with a header row
+func (ch *ClipboardHandler) CSVHeader() bool {
+	return ch.csvHeader
+}
+
+// SetCSVDelimiter sets the rune separating cells of copied CSV; a zero rune restores the comma
+func (ch *ClipboardHandler) SetCSVDelimiter(delimiter rune) {
+	ch.formatter.SetDelimiter(delimiter)
+}
+
+// CSVDelimiter returns the rune separating cells of copied CSV
+func (ch *ClipboardHandler) CSVDelimiter() rune {
+	return ch.formatter.Delimiter()
+}
+
+// SetColorMode sets whether copies show each entity's own color or resolve ByLayer and
+// ByBlock colors to the color of its layer, which is the default
+func (ch *ClipboardHandler) SetColorMode(mode clipboard.ColorMode) {
+	ch.formatter.SetColorMode(mode)
+}
+
+// SetJSONIndent sets whether copied JSON is indented, which is the default, or compact on one line
+func (ch *ClipboardHandler) SetJSONIndent(indent bool) {
+	ch.formatter.SetJSONIndent(indent)
+}
+
+// JSONIndent returns whether copied JSON is indented
+func (ch *ClipboardHandler) JSONIndent() bool {
+	return ch.formatter.JSONIndent()
+}
+
+// SetGridSnap sets the grid size copied coordinates are rounded to; zero disables snapping
+func (ch *ClipboardHandler) SetGridSnap(size float64) {
+	ch.formatter.SetGridSnap(size)
+}
+
+// ColorMode returns which color copied entities show
+func (ch *ClipboardHandler) ColorMode() clipboard.ColorMode {
+	return ch.formatter.ColorMode()
+}
+
+// SetFallbackToFile enables writing copied content to a temp file when no system clipboard is available
+func (ch *ClipboardHandler) SetFallbackToFile(enabled bool) {
+	if !enabled {
+		ch.fallback = nil
+		return
+	}
+	ch.fallback = clipboard.NewFallbackClipboardManager(ch.clipboardMgr)
+}
+
 // CopySelectedItems copies the selected items to clipboard
 func (ch *ClipboardHandler) CopySelectedItems() error {
 	if ch.view.data == nil {
@@ -98,32 +182,199 @@ func (ch *ClipboardHandler) CopySelectedItems() error {
 	}
 
 	// Format according to the selected format
-	var content string
-	var err error
+	content, err := ch.formatEntities(entities)
+	if err != nil {
+		return err
+	}
+
+	return ch.copyContent(content, len(entities), ch.format)
+}
+
+// CopyLayer copies all entities of the named layer to clipboard using the current format
+func (ch *ClipboardHandler) CopyLayer(layerName string) error {
+	if ch.view.data == nil {
+		return fmt.Errorf("no data available")
+	}
+
+	found := slices.ContainsFunc(ch.view.data.Layers, func(layer data.LayerInfo) bool {
+		return layer.Name == layerName
+	})
+	if !found {
+		return fmt.Errorf("layer not found: %s", layerName)
+	}
+
+	var entities []data.Entity
+	ch.view.data.ForEachEntity(func(layer *data.LayerInfo, entity data.Entity) {
+		if layer.Name == layerName {
+			entities = append(entities, entity)
+		}
+	})
+
+	// Don't put an empty string on the clipboard
+	if len(entities) == 0 {
+		ch.view.statusHandler.ShowMessage(fmt.Sprintf("Layer %s has no entities to copy", layerName))
+		return nil
+	}
+
+	content, err := ch.formatEntities(entities)
+	if err != nil {
+		return err
+	}
+
+	return ch.copyContent(content, len(entities), ch.format)
+}
+
+// CopyLayerSummary copies the per-layer entity counts of the whole drawing to clipboard
+func (ch *ClipboardHandler) CopyLayerSummary() error {
+	if ch.view.data == nil {
+		return fmt.Errorf("no data available")
+	}
+
+	lines := ch.formatter.FormatLayerSummary(ch.view.data)
+	if len(lines) == 0 {
+		ch.view.statusHandler.ShowMessage("No layers to summarize")
+		return nil
+	}
+
+	return ch.copyContent(strings.Join(lines, "\n"), len(lines), "summary")
+}
+
+// CopyGroupedByLayer copies every entity of the drawing to clipboard, grouped under a header per layer
+func (ch *ClipboardHandler) CopyGroupedByLayer() error {
+	if ch.view.data == nil {
+		return fmt.Errorf("no data available")
+	}
 
-	switch ch.format {
+	count := 0
+	for _, layer := range ch.view.data.Layers {
+		count += len(layer.Entities)
+	}
+
+	lines := ch.formatter.FormatGroupedByLayer(ch.view.data)
+	if len(lines) == 0 {
+		ch.view.statusHandler.ShowMessage("No layers to copy")
+		return nil
+	}
+
+	return ch.copyContent(strings.Join(lines, "\n"), count, "text")
+}
+
+// SetOmitEmptyLayers sets whether copies grouped by layer leave out layers without entities
+func (ch *ClipboardHandler) SetOmitEmptyLayers(omit bool) {
+	ch.formatter.SetOmitEmptyLayers(omit)
+}
+
+// formatEntities formats entities according to the selected format
+func (ch *ClipboardHandler) formatEntities(entities []data.Entity) (string, error) {
+	return ch.formatEntitiesAs(ch.format, entities)
+}
+
+// formatEntitiesAs formats entities in the given format.
+// The summary format always describes every layer of the drawing.
+func (ch *ClipboardHandler) formatEntitiesAs(format string, entities []data.Entity) (string, error) {
+	if ch.view.data != nil {
+		ch.formatter.SetLayers(ch.view.data.Layers)
+	}
+
+	switch format {
+	case "summary":
+		lines := ch.formatter.FormatLayerSummary(ch.view.data)
+		return strings.Join(lines, "\n"), nil
 	case "csv":
-		lines := ch.formatter.FormatAsCSV(entities)
-		content = strings.Join(lines, "\n")
+		lines := ch.formatter.FormatAsCSVOptions(entities, clipboard.CSVOptions{IncludeHeader: ch.csvHeader})
+		return strings.Join(lines, "\n"), nil
 	case "json":
-		content, err = ch.formatter.FormatAsJSON(entities)
+		content, err := ch.formatter.FormatAsJSON(entities)
 		if err != nil {
-			return fmt.Errorf("failed to format as JSON: %w", err)
+			return "", fmt.Errorf("failed to format as JSON: %w", err)
+		}
+		return content, nil
+	case "dxf":
+		return ch.formatter.FormatAsDXFSnippet(entities)
+	case "schedule":
+		// One row per block insertion, one column per attribute tag
+		var blocks []*data.BlockInfo
+		for _, entity := range entities {
+			if block, ok := entity.(*data.BlockInfo); ok {
+				blocks = append(blocks, block)
+			}
 		}
+		return strings.Join(ch.formatter.FormatBlocksAsCSV(blocks), "\n"), nil
 	default: // "text" or any other format defaults to text
 		lines := ch.formatter.FormatMultipleEntitiesForClipboard(entities)
-		content = strings.Join(lines, "\n")
+		return strings.Join(lines, "\n"), nil
+	}
+}
+
+// CopyLayerName copies the name of a layer to clipboard
+func (ch *ClipboardHandler) CopyLayerName(layerName string) error {
+	return ch.copyValue(layerName, "layer name")
+}
+
+// CopyFilePath copies the path of the current drawing to clipboard,
+// or "(sample data)" when the view shows sample data
+func (ch *ClipboardHandler) CopyFilePath() error {
+	path := ch.view.sourcePath
+	if path == "" {
+		path = sampleDataPath
+	} else if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+	return ch.copyValue(path, "file path")
+}
+
+// copyContent copies content formatted in format to clipboard and reports where it went
+func (ch *ClipboardHandler) copyContent(content string, itemCount int, format string) error {
+	filePath, copied, err := ch.write(content)
+	if err != nil || !copied {
+		return err
+	}
+
+	if filePath != "" {
+		ch.view.statusHandler.ShowCopiedToFile(itemCount, filePath)
+		return nil
+	}
+	ch.view.statusHandler.ShowCopiedAs(itemCount, format)
+	return nil
+}
+
+// copyValue copies a single value to clipboard and confirms it by what it is
+func (ch *ClipboardHandler) copyValue(value, what string) error {
+	filePath, copied, err := ch.write(value)
+	if err != nil || !copied {
+		return err
 	}
 
-	// Copy to clipboard
-	if ch.clipboardMgr != nil {
-		err = ch.clipboardMgr.CopyToClipboard(content)
+	if filePath != "" {
+		ch.view.statusHandler.ShowCopiedToFile(1, filePath)
+		return nil
+	}
+	ch.view.statusHandler.ShowMessage(fmt.Sprintf("Copied %s: %s", what, value))
+	return nil
+}
+
+// write puts content on the clipboard, or in a temp file whose path it returns when the
+// fallback is enabled and no clipboard is available. It reports false without a clipboard manager.
+func (ch *ClipboardHandler) write(content string) (string, bool, error) {
+	if ch.fallback != nil {
+		result, err := ch.fallback.Copy(content)
 		if err != nil {
-			return fmt.Errorf("failed to copy to clipboard: %w", err)
+			return "", false, fmt.Errorf("failed to copy to clipboard: %w", err)
+		}
+		if result.ToFile {
+			return result.FilePath, true, nil
 		}
+		return "", true, nil
 	}
 
-	return nil
+	if ch.clipboardMgr == nil {
+		return "", false, nil
+	}
+
+	if err := ch.clipboardMgr.CopyToClipboard(content); err != nil {
+		return "", false, fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return "", true, nil
 }
 
 // getSelectedEntities retrieves entities based on selected indices
@@ -132,9 +383,9 @@ func (ch *ClipboardHandler) getSelectedEntities() []data.Entity {
 
 	// Collect all entities from all layers
 	allEntities := make([]data.Entity, 0)
-	for _, layer := range ch.view.data.Layers {
-		allEntities = append(allEntities, layer.Entities...)
-	}
+	ch.view.data.ForEachEntity(func(_ *data.LayerInfo, entity data.Entity) {
+		allEntities = append(allEntities, entity)
+	})
 
 	// Get entities for selected indices
 	for _, index := range ch.selectedIndices {
@@ -163,17 +414,47 @@ func NewStatusMessageHandler(view *DXFView) *StatusMessageHandler {
 // ShowCopySuccess shows a success message for clipboard copy
 func (sh *StatusMessageHandler) ShowCopySuccess(itemCount int) {
 	if itemCount == 1 {
-		sh.currentMessage = "1 item copied to clipboard"
+		sh.show("1 item copied to clipboard", StatusInfo)
 	} else {
-		sh.currentMessage = fmt.Sprintf("%d items copied to clipboard", itemCount)
+		sh.show(fmt.Sprintf("%d items copied to clipboard", itemCount), StatusInfo)
 	}
-	sh.messageTime = time.Now()
+}
+
+// ShowCopiedAs shows how many items were copied to clipboard and in which format
+func (sh *StatusMessageHandler) ShowCopiedAs(itemCount int, format string) {
+	if itemCount == 1 {
+		sh.show(fmt.Sprintf("1 item copied as %s", formatName(format)), StatusInfo)
+	} else {
+		sh.show(fmt.Sprintf("%d items copied as %s", itemCount, formatName(format)), StatusInfo)
+	}
+}
+
+// ShowCopiedToFile shows where content was written when no clipboard was available
+func (sh *StatusMessageHandler) ShowCopiedToFile(itemCount int, path string) {
+	if itemCount == 1 {
+		sh.show(fmt.Sprintf("No clipboard available: 1 item written to %s", path), StatusWarn)
+	} else {
+		sh.show(fmt.Sprintf("No clipboard available: %d items written to %s", itemCount, path), StatusWarn)
+	}
+}
+
+// ShowMessage shows a general status message
+func (sh *StatusMessageHandler) ShowMessage(message string) {
+	sh.show(message, StatusInfo)
 }
 
 // ShowCopyError shows an error message for clipboard copy failure
 func (sh *StatusMessageHandler) ShowCopyError(errorMsg string) {
-	sh.currentMessage = fmt.Sprintf("Failed to copy: %s", errorMsg)
+	sh.show(fmt.Sprintf("Failed to copy: %s", errorMsg), StatusError)
+}
+
+// show records the message and shows it in the view's status bar
+func (sh *StatusMessageHandler) show(message string, level StatusLevel) {
+	sh.currentMessage = message
 	sh.messageTime = time.Now()
+	if sh.view != nil && sh.view.statusBar != nil {
+		sh.view.statusBar.SetStatus(message, level)
+	}
 }
 
 // GetCurrentMessage returns the current status message
@@ -188,4 +469,7 @@ func (sh *StatusMessageHandler) GetCurrentMessage() string {
 // ClearMessage clears the current message
 func (sh *StatusMessageHandler) ClearMessage() {
 	sh.currentMessage = ""
+	if sh.view != nil && sh.view.statusBar != nil {
+		sh.view.statusBar.Clear()
+	}
 }
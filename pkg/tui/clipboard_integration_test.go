@@ -1,12 +1,18 @@
 package tui
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/clipboard"
 	"github.com/remym/go-dwg-extractor/pkg/data"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockClipboardManager is a mock for testing clipboard integration
@@ -315,6 +321,16 @@ func TestClipboardIntegration_FormatOptions(t *testing.T) {
 			format:         "json",
 			expectedFormat: "[",
 		},
+		{
+			name:           "DXF format",
+			format:         "dxf",
+			expectedFormat: "  0\nSECTION\n  2\nENTITIES\n",
+		},
+		{
+			name:           "Block schedule format",
+			format:         "schedule",
+			expectedFormat: "Name,Layer",
+		},
 	}
 
 	for _, tt := range tests {
@@ -343,3 +359,433 @@ func TestClipboardIntegration_FormatOptions(t *testing.T) {
 		})
 	}
 }
+
+// TestClipboardIntegration_CSVHeader tests leaving the header row out of copied CSV
+func TestClipboardIntegration_CSVHeader(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+
+	var copied string
+	mockClipboard := new(MockClipboardManager)
+	mockClipboard.On("CopyToClipboard", mock.Anything).Run(func(args mock.Arguments) {
+		copied = args.String(0)
+	}).Return(nil)
+
+	handler := NewClipboardHandler(view, mockClipboard)
+	view.clipboardHandler = handler
+	handler.AddToSelection(0)
+	handler.SetFormat("csv")
+	assert.True(t, handler.CSVHeader(), "Expected the header to be included by default")
+
+	require.NoError(t, handler.CopySelectedItems())
+	withHeader := strings.Split(copied, "\n")
+	assert.Equal(t, "Type,Layer,Details", withHeader[0])
+
+	// 'h' in the entity list turns the header off
+	view.showLayerDetails(0)
+	view.entityList.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'h', tcell.ModNone))
+	assert.False(t, handler.CSVHeader())
+
+	require.NoError(t, handler.CopySelectedItems())
+	withoutHeader := strings.Split(copied, "\n")
+	assert.Len(t, withoutHeader, len(withHeader)-1)
+	assert.NotContains(t, copied, "Type,Layer,Details")
+}
+
+// TestClipboardIntegration_ResolvesLayerColors tests that copied ByLayer colors take the layer color in layer mode
+func TestClipboardIntegration_ResolvesLayerColors(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	line := &data.LineInfo{Layer: "Walls", Color: data.ColorByLayer, EndPoint: data.Point{X: 1}}
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "Walls", IsOn: true, Color: 3, Entities: []data.Entity{line}}},
+	})
+
+	var copied string
+	mockClipboard := new(MockClipboardManager)
+	mockClipboard.On("CopyToClipboard", mock.Anything).Run(func(args mock.Arguments) {
+		copied = args.String(0)
+	}).Return(nil)
+
+	handler := NewClipboardHandler(view, mockClipboard)
+	view.clipboardHandler = handler
+	assert.Equal(t, clipboard.ColorModeEntity, handler.ColorMode())
+	require.NoError(t, handler.CopyLayer("Walls"))
+	assert.Contains(t, copied, "Color: 256", "entity mode copies the entity's own color")
+
+	handler.SetColorMode(clipboard.ColorModeLayer)
+	require.NoError(t, handler.CopyLayer("Walls"))
+	assert.Contains(t, copied, "Color: 3")
+	assert.Equal(t, data.ColorByLayer, line.Color, "Copying should not modify the entity")
+
+	// The details pane shows the resolved color alongside its origin
+	view.showLayerDetails(0)
+	view.showEntityAt(1)
+	assert.Contains(t, view.textView.GetText(true), "Color: 3 (ByLayer)")
+}
+
+// TestClipboardIntegration_CopyLayer tests copying every entity of a layer
+func TestClipboardIntegration_CopyLayer(t *testing.T) {
+	tests := []struct {
+		name            string
+		layerName       string
+		format          string
+		expectCopy      bool
+		expectedContent []string
+		expectedError   bool
+		expectedMessage string
+	}{
+		{
+			name:            "Copies all layer entities as text",
+			layerName:       "Layer1",
+			format:          "text",
+			expectCopy:      true,
+			expectedContent: []string{"Line:", "Circle:"},
+			expectedMessage: "3 items copied as text",
+		},
+		{
+			name:            "Copies all layer entities as CSV",
+			layerName:       "Layer1",
+			format:          "csv",
+			expectCopy:      true,
+			expectedContent: []string{"Type,Layer,Details", "Circle"},
+			expectedMessage: "3 items copied as CSV",
+		},
+		{
+			name:            "Empty layer shows status message",
+			layerName:       "Layer2",
+			format:          "text",
+			expectCopy:      false,
+			expectedMessage: "Layer Layer2 has no entities to copy",
+		},
+		{
+			name:          "Unknown layer returns error",
+			layerName:     "Missing",
+			format:        "text",
+			expectCopy:    false,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := SetupTestApp(t)
+			view := NewDXFView(app)
+			view.Update(createTestDataWithMultipleItems())
+
+			mockClipboard := new(MockClipboardManager)
+			if tt.expectCopy {
+				mockClipboard.On("CopyToClipboard", mock.MatchedBy(func(content string) bool {
+					for _, expected := range tt.expectedContent {
+						if !assert.Contains(t, content, expected) {
+							return false
+						}
+					}
+					return true
+				})).Return(nil)
+			}
+
+			clipboardHandler := NewClipboardHandler(view, mockClipboard)
+			clipboardHandler.SetFormat(tt.format)
+
+			err := clipboardHandler.CopyLayer(tt.layerName)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.expectedMessage != "" {
+				assert.Equal(t, tt.expectedMessage, view.statusHandler.GetCurrentMessage())
+			}
+
+			mockClipboard.AssertExpectations(t)
+			if !tt.expectCopy {
+				mockClipboard.AssertNotCalled(t, "CopyToClipboard", mock.Anything)
+			}
+		})
+	}
+}
+
+// TestClipboardIntegration_ShiftCCopiesFocusedLayer tests the Shift+C binding in the layers list
+func TestClipboardIntegration_ShiftCCopiesFocusedLayer(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+
+	mockClipboard := new(MockClipboardManager)
+	mockClipboard.On("CopyToClipboard", mock.AnythingOfType("string")).Return(nil)
+	view.clipboardHandler = NewClipboardHandler(view, mockClipboard)
+
+	view.layers.SetCurrentItem(0)
+	capture := view.layers.GetInputCapture()
+	assert.NotNil(t, capture, "layers list should have an input capture")
+
+	result := capture(tcell.NewEventKey(tcell.KeyRune, 'C', tcell.ModShift))
+	assert.Nil(t, result, "Shift+C should be consumed")
+
+	mockClipboard.AssertNumberOfCalls(t, "CopyToClipboard", 1)
+}
+
+func TestClipboardIntegration_CopyErrorUsesErrorHandler(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+
+	mockClipboard := new(MockClipboardManager)
+	mockClipboard.On("CopyToClipboard", mock.AnythingOfType("string")).Return(errors.New("clipboard locked"))
+	view.clipboardHandler = NewClipboardHandler(view, mockClipboard)
+
+	view.layers.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'S', tcell.ModShift))
+	assert.True(t, view.errorHandler.IsErrorVisible())
+	assert.Contains(t, view.errorHandler.GetDisplayedError(), "clipboard locked")
+	message, level := view.GetStatusBar().Message()
+	assert.Contains(t, message, "Failed to copy")
+	assert.Equal(t, StatusError, level)
+}
+
+// TestClipboardIntegration_ShiftSCopiesLayerSummary tests the Shift+S binding in the layers list
+func TestClipboardIntegration_ShiftSCopiesLayerSummary(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+
+	expected := "Layer1: 3 entities (2 lines, 1 circle) ON\n" +
+		"Layer2: 0 entities ON\n" +
+		"Layer3: 0 entities OFF"
+	mockClipboard := new(MockClipboardManager)
+	mockClipboard.On("CopyToClipboard", expected).Return(nil)
+	view.clipboardHandler = NewClipboardHandler(view, mockClipboard)
+
+	result := view.layers.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'S', tcell.ModShift))
+	assert.Nil(t, result, "Shift+S should be consumed")
+	mockClipboard.AssertExpectations(t)
+	message, _ := view.GetStatusBar().Message()
+	assert.Equal(t, "3 items copied as layer summary", message)
+
+	// The summary format ignores the selection and describes the whole drawing
+	mockClipboard.On("CopyToClipboard", expected).Return(nil)
+	view.clipboardHandler.SetFormat("summary")
+	view.clipboardHandler.AddToSelection(0)
+	require.NoError(t, view.clipboardHandler.CopySelectedItems())
+	mockClipboard.AssertNumberOfCalls(t, "CopyToClipboard", 2)
+}
+
+func TestClipboardIntegration_ShiftGCopiesGroupedByLayer(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	testData := createTestDataWithMultipleItems()
+	testData.Layers[2].Entities = []data.Entity{
+		&data.LineInfo{EndPoint: data.Point{X: 1}, Layer: "Layer3", Color: data.ColorByLayer},
+	}
+	view.Update(testData)
+
+	mockClipboard := new(MockClipboardManager)
+	mockClipboard.On("CopyToClipboard", "== Layer1 ==\n"+
+		"Line: (0.0, 0.0) to (10.0, 10.0), Layer: Layer1, Color: 1\n"+
+		"Line: (10.0, 10.0) to (20.0, 20.0), Layer: Layer1, Color: 1\n"+
+		"Circle: Center (5.0, 5.0), Radius: 2.5, Layer: Layer1, Color: 1\n"+
+		"\n"+
+		"== Layer3 (OFF) ==\n"+
+		"Line: (0.0, 0.0) to (1.0, 0.0), Layer: Layer3, Color: 3").Return(nil)
+	view.clipboardHandler = NewClipboardHandler(view, mockClipboard)
+	view.clipboardHandler.SetOmitEmptyLayers(true)
+	view.clipboardHandler.SetColorMode(clipboard.ColorModeLayer)
+
+	result := view.layers.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'G', tcell.ModShift))
+	assert.Nil(t, result, "Shift+G should be consumed")
+	mockClipboard.AssertExpectations(t)
+
+	message, _ := view.GetStatusBar().Message()
+	assert.Equal(t, "4 items copied as text", message)
+	assert.Equal(t, data.ColorByLayer, testData.Layers[2].Entities[0].(*data.LineInfo).Color,
+		"resolving colors leaves the drawing untouched")
+
+	empty := NewDXFView(app)
+	assert.EqualError(t, empty.clipboardHandler.CopyGroupedByLayer(), "no data available")
+}
+
+// TestClipboardIntegration_FallbackToFile tests the temp file fallback when no clipboard is available
+func TestClipboardIntegration_FallbackToFile(t *testing.T) {
+	tests := []struct {
+		name            string
+		fallback        bool
+		copyErr         error
+		expectedError   bool
+		expectedMessage string
+	}{
+		{
+			name:            "Clipboard available reports clipboard",
+			fallback:        true,
+			copyErr:         nil,
+			expectedMessage: "1 item copied as text",
+		},
+		{
+			name:            "No clipboard reports file",
+			fallback:        true,
+			copyErr:         errors.New("No clipboard utilities available"),
+			expectedMessage: "No clipboard available: 1 item written to ",
+		},
+		{
+			name:          "No clipboard without fallback returns error",
+			fallback:      false,
+			copyErr:       errors.New("No clipboard utilities available"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := SetupTestApp(t)
+			view := NewDXFView(app)
+			view.Update(createTestDataWithMultipleItems())
+
+			mockClipboard := new(MockClipboardManager)
+			mockClipboard.On("CopyToClipboard", mock.AnythingOfType("string")).Return(tt.copyErr)
+
+			clipboardHandler := NewClipboardHandler(view, mockClipboard)
+			clipboardHandler.SetFallbackToFile(tt.fallback)
+			clipboardHandler.AddToSelection(0)
+
+			err := clipboardHandler.CopySelectedItems()
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			message := view.statusHandler.GetCurrentMessage()
+			assert.Contains(t, message, tt.expectedMessage)
+
+			// Clean up the fallback file and check it holds the copied entity
+			if idx := strings.Index(message, "written to "); idx >= 0 {
+				path := message[idx+len("written to "):]
+				defer os.Remove(path)
+				content, err := os.ReadFile(path)
+				require.NoError(t, err)
+				assert.Contains(t, string(content), "Line:")
+			}
+		})
+	}
+}
+
+// TestClipboardIntegration_QuickCopies tests the y and Shift+Y bindings in the layers list
+func TestClipboardIntegration_QuickCopies(t *testing.T) {
+	drawing, err := filepath.Abs("drawing.dwg")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		sourcePath string
+		key        rune
+		expected   string
+		message    string
+	}{
+		{
+			name:     "y copies the focused layer name",
+			key:      'y',
+			expected: "Layer2",
+			message:  "Copied layer name: Layer2",
+		},
+		{
+			name:       "Shift+Y copies the drawing path",
+			sourcePath: "drawing.dwg",
+			key:        'Y',
+			expected:   drawing,
+			message:    "Copied file path: " + drawing,
+		},
+		{
+			name:     "Shift+Y with sample data",
+			key:      'Y',
+			expected: "(sample data)",
+			message:  "Copied file path: (sample data)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := SetupTestApp(t)
+			view := NewDXFView(app)
+			view.SetSourcePath(tt.sourcePath)
+			view.Update(createTestDataWithMultipleItems())
+
+			mockClipboard := new(MockClipboardManager)
+			mockClipboard.On("CopyToClipboard", tt.expected).Return(nil)
+			view.clipboardHandler = NewClipboardHandler(view, mockClipboard)
+
+			view.layers.SetCurrentItem(1)
+			result := view.layers.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, tt.key, tcell.ModNone))
+			assert.Nil(t, result, "the key should be consumed")
+
+			mockClipboard.AssertExpectations(t)
+			assert.Equal(t, tt.message, view.statusHandler.GetCurrentMessage())
+			message, level := view.GetStatusBar().Message()
+			assert.Equal(t, tt.message, message)
+			assert.Equal(t, StatusInfo, level)
+		})
+	}
+}
+
+// TestClipboardIntegration_CSVDelimiter tests switching copied CSV to semicolons
+func TestClipboardIntegration_CSVDelimiter(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+
+	var copied string
+	mockClipboard := new(MockClipboardManager)
+	mockClipboard.On("CopyToClipboard", mock.Anything).Run(func(args mock.Arguments) {
+		copied = args.String(0)
+	}).Return(nil)
+
+	handler := NewClipboardHandler(view, mockClipboard)
+	view.clipboardHandler = handler
+	handler.AddToSelection(0)
+	handler.SetFormat("csv")
+	assert.Equal(t, ',', handler.CSVDelimiter())
+
+	// ';' in the entity list switches to semicolons, and back
+	view.showLayerDetails(0)
+	view.entityList.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, ';', tcell.ModNone))
+	assert.Equal(t, ';', handler.CSVDelimiter())
+	assert.Equal(t, "CSV delimiter: semicolon", view.statusHandler.GetCurrentMessage())
+
+	require.NoError(t, handler.CopySelectedItems())
+	assert.Equal(t, "Type;Layer;Details", strings.Split(copied, "\n")[0])
+
+	view.entityList.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, ';', tcell.ModNone))
+	assert.Equal(t, ',', handler.CSVDelimiter())
+}
+
+// TestClipboardIntegration_JSONIndent tests switching copied JSON to compact output
+func TestClipboardIntegration_JSONIndent(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+
+	var copied string
+	mockClipboard := new(MockClipboardManager)
+	mockClipboard.On("CopyToClipboard", mock.Anything).Run(func(args mock.Arguments) {
+		copied = args.String(0)
+	}).Return(nil)
+
+	handler := NewClipboardHandler(view, mockClipboard)
+	view.clipboardHandler = handler
+	handler.AddToSelection(0)
+	handler.SetFormat("json")
+	assert.True(t, handler.JSONIndent())
+
+	// Shift+J in the entity list switches to compact JSON, and back
+	view.showLayerDetails(0)
+	view.entityList.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'J', tcell.ModNone))
+	assert.False(t, handler.JSONIndent())
+	assert.Equal(t, "Copied JSON is compact", view.statusHandler.GetCurrentMessage())
+
+	require.NoError(t, handler.CopySelectedItems())
+	assert.NotContains(t, copied, "\n")
+	assert.Contains(t, copied, `"type":"`)
+
+	view.entityList.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'J', tcell.ModNone))
+	assert.True(t, handler.JSONIndent())
+}
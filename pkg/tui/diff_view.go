@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/clipboard"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/rivo/tview"
+)
+
+// DiffView shows the layers of two drawings side by side, marking added, removed and
+// changed layers, with the entity changes of the selected layer below them
+type DiffView struct {
+	app        *tview.Application
+	layout     *tview.Flex
+	summary    *tview.TextView
+	beforeList *tview.List
+	afterList  *tview.List
+	details    *tview.TextView
+	layerNames map[*tview.List][]string // Layer names in the order each list shows them
+	result     data.DiffResult
+	formatter  *clipboard.ClipboardFormatter
+}
+
+// NewDiffView creates a side-by-side view of the differences between drawing before and its revision after
+func NewDiffView(app *tview.Application, beforeName, afterName string, before, after *data.ExtractedData) *DiffView {
+	v := &DiffView{
+		app:        app,
+		summary:    tview.NewTextView().SetDynamicColors(true),
+		beforeList: tview.NewList(),
+		afterList:  tview.NewList(),
+		details:    tview.NewTextView().SetDynamicColors(true),
+		layerNames: make(map[*tview.List][]string),
+		result:     data.Diff(before, after),
+		formatter:  clipboard.NewClipboardFormatter(),
+	}
+	v.beforeList.SetBorder(true).SetTitle(tview.Escape(beforeName))
+	v.afterList.SetBorder(true).SetTitle(tview.Escape(afterName))
+	v.details.SetBorder(true).SetTitle("Changes")
+
+	v.writeSummary()
+	v.fillList(v.beforeList, before, v.result.RemovedLayers, "[red]-", v.result.RemovedEntities, "removed")
+	v.fillList(v.afterList, after, v.result.AddedLayers, "[green]+", v.result.AddedEntities, "added")
+
+	// Tab moves between the two drawings
+	for _, pair := range [][2]*tview.List{{v.beforeList, v.afterList}, {v.afterList, v.beforeList}} {
+		list, other := pair[0], pair[1]
+		list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			if event.Key() == tcell.KeyTab || event.Key() == tcell.KeyBacktab {
+				v.app.SetFocus(other)
+				v.showLayer(v.listLayerName(other))
+				return nil
+			}
+			return event
+		})
+		list.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+			v.showLayer(v.listLayerName(list))
+		})
+	}
+
+	lists := tview.NewFlex().
+		AddItem(v.beforeList, 0, 1, true).
+		AddItem(v.afterList, 0, 1, false)
+	v.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.summary, 1, 0, false).
+		AddItem(lists, 0, 2, true).
+		AddItem(v.details, 0, 1, false)
+
+	v.showLayer(v.listLayerName(v.beforeList))
+	return v
+}
+
+// writeSummary writes the counts of each kind of difference
+func (v *DiffView) writeSummary() {
+	v.summary.Clear()
+	if v.result.IsEmpty() {
+		fmt.Fprint(v.summary, "[green]No differences[-]")
+		return
+	}
+	fmt.Fprintf(v.summary, "Layers: [green]+%d[-] [red]-%d[-] [yellow]~%d[-]   Entities: [green]+%d[-] [red]-%d[-]",
+		len(v.result.AddedLayers), len(v.result.RemovedLayers), len(v.result.ChangedLayers),
+		len(v.result.AddedEntities), len(v.result.RemovedEntities))
+}
+
+// fillList lists the layers of a drawing, marking those only in this drawing with marker
+// and changed layers with "~". Each item's secondary text counts the layer's entities
+// only in this drawing, described as verb.
+func (v *DiffView) fillList(list *tview.List, drawing *data.ExtractedData, onlyHere []string, marker string, entities []data.Entity, verb string) {
+	list.Clear()
+	v.layerNames[list] = nil
+	if drawing == nil {
+		return
+	}
+
+	for _, layer := range drawing.Layers {
+		v.layerNames[list] = append(v.layerNames[list], layer.Name)
+		prefix := " "
+		switch {
+		case slices.Contains(onlyHere, layer.Name):
+			prefix = marker
+		case v.layerChange(layer.Name) != nil:
+			prefix = "[yellow]~"
+		}
+
+		count := 0
+		for _, entity := range entities {
+			if entity.GetLayer() == layer.Name {
+				count++
+			}
+		}
+		secondary := ""
+		if count > 0 {
+			secondary = fmt.Sprintf("%d entities %s", count, verb)
+		}
+		list.AddItem(fmt.Sprintf("%s %s[-]", prefix, tview.Escape(layer.Name)), secondary, 0, nil)
+	}
+}
+
+// listLayerName returns the name of the layer selected in list
+func (v *DiffView) listLayerName(list *tview.List) string {
+	names := v.layerNames[list]
+	index := list.GetCurrentItem()
+	if index < 0 || index >= len(names) {
+		return ""
+	}
+	return names[index]
+}
+
+// layerChange returns the state change of the named layer, or nil when it did not change
+func (v *DiffView) layerChange(name string) *data.LayerChange {
+	for i := range v.result.ChangedLayers {
+		if v.result.ChangedLayers[i].Name == name {
+			return &v.result.ChangedLayers[i]
+		}
+	}
+	return nil
+}
+
+// showLayer writes the state changes and entity changes of the named layer to the details pane
+func (v *DiffView) showLayer(name string) {
+	v.details.Clear()
+	if name == "" {
+		return
+	}
+
+	fmt.Fprintf(v.details, "[green]Layer:[-] %s\n", tview.Escape(name))
+	switch {
+	case slices.Contains(v.result.AddedLayers, name):
+		fmt.Fprintf(v.details, "[green]Added layer[-]\n")
+	case slices.Contains(v.result.RemovedLayers, name):
+		fmt.Fprintf(v.details, "[red]Removed layer[-]\n")
+	}
+	if change := v.layerChange(name); change != nil {
+		fmt.Fprintf(v.details, "[yellow]Changed:[-] %s\n", strings.Join(change.Changes, ", "))
+	}
+
+	for _, entity := range v.result.RemovedEntities {
+		if entity.GetLayer() == name {
+			fmt.Fprintf(v.details, "[red]- %s[-]\n", tview.Escape(v.formatter.FormatEntityForClipboard(entity)))
+		}
+	}
+	for _, entity := range v.result.AddedEntities {
+		if entity.GetLayer() == name {
+			fmt.Fprintf(v.details, "[green]+ %s[-]\n", tview.Escape(v.formatter.FormatEntityForClipboard(entity)))
+		}
+	}
+}
+
+// GetLayout returns the root primitive of the diff view
+func (v *DiffView) GetLayout() tview.Primitive {
+	return v.layout
+}
+
+// GetResult returns the differences shown by the view
+func (v *DiffView) GetResult() data.DiffResult {
+	return v.result
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffView(t *testing.T) {
+	before := &data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "Walls", IsOn: true}, {Name: "Old", IsOn: true}},
+		Lines:  []data.LineInfo{{Layer: "Walls", EndPoint: data.Point{X: 1}}},
+	}
+	after := &data.ExtractedData{
+		Layers:  []data.LayerInfo{{Name: "Walls", IsOn: false}, {Name: "New", IsOn: true}},
+		Circles: []data.CircleInfo{{Layer: "Walls", Radius: 2}},
+	}
+
+	view := NewDiffView(SetupTestApp(t), "rev1.dwg", "rev2.dwg", before, after)
+	require.NotNil(t, view.GetLayout())
+
+	assert.Equal(t, "rev1.dwg", view.beforeList.GetTitle())
+	assert.Equal(t, "rev2.dwg", view.afterList.GetTitle())
+	assert.Contains(t, view.summary.GetText(true), "Layers: +1 -1 ~1")
+
+	mainText, secondaryText := view.beforeList.GetItemText(0)
+	assert.Equal(t, "[yellow]~ Walls[-]", mainText)
+	assert.Equal(t, "1 entities removed", secondaryText)
+	mainText, _ = view.beforeList.GetItemText(1)
+	assert.Equal(t, "[red]- Old[-]", mainText)
+	mainText, _ = view.afterList.GetItemText(1)
+	assert.Equal(t, "[green]+ New[-]", mainText)
+
+	// The first layer's changes are shown straight away
+	details := view.details.GetText(true)
+	assert.Contains(t, details, "Changed: turned off")
+	assert.Contains(t, details, "- Line:")
+	assert.Contains(t, details, "+ Circle:")
+
+	// Tab moves to the revision and shows its selected layer
+	view.afterList.SetCurrentItem(1)
+	view.beforeList.GetInputCapture()(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone))
+	assert.Contains(t, view.details.GetText(true), "Added layer")
+}
+
+func TestDiffView_NoDifferences(t *testing.T) {
+	drawing := &data.ExtractedData{Layers: []data.LayerInfo{{Name: "0", IsOn: true}}}
+	view := NewDiffView(SetupTestApp(t), "a", "b", drawing, drawing)
+	assert.Equal(t, "No differences", view.summary.GetText(true))
+	assert.True(t, view.GetResult().IsEmpty())
+}
@@ -2,9 +2,15 @@ package tui
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/clipboard"
 	"github.com/remym/go-dwg-extractor/pkg/data"
 	"github.com/rivo/tview"
 )
@@ -12,13 +18,50 @@ import (
 // DXFView handles the display of DXF data
 type DXFView struct {
 	app               *tview.Application
+	overlays          *tview.Pages               // The pages above the status bar, with error dialogs on top
+	overlayFocus      map[string]tview.Primitive // Focus to restore when each overlay closes, by page
+	loading           *loadingOverlay            // Spinner shown while a long task runs; nil when hidden
+	fileBrowser       *FileBrowser               // Shown by Ctrl+O; nil when hidden
+	openHandler       func(path string)
+	reloadHandler     func(path string) // Called by Ctrl+R; nil when reloading is not possible
+	reload            *reloadState      // View state to restore once a reload is shown; nil otherwise
 	pages             *tview.Pages
+	statusBar         *StatusBar
 	textView          *tview.TextView
 	layers            *tview.List
 	entityList        *tview.List
 	searchInput       *tview.InputField
+	warningsButton    *tview.Button
+	warningsList      *tview.List
+	blockList         *tview.List // Entities of the block definition being viewed
+	selectionStatus   *tview.TextView
+	legendView        *tview.TextView
+	previewView       *tview.Box // ASCII preview of the current layer's geometry
+	previewRenderer   *PreviewRenderer
+	gotoInput         *tview.InputField
+	gotoOpen          bool          // Whether the jump-to-layer prompt is shown
+	attributeForm     *tview.Form   // Open block attribute editor, nil when closed
+	rangeForm         *tview.Form   // Open select-by-range form, nil when closed
+	exportForm        *tview.Form   // Open export selection form, nil when closed
+	recentLayers      *tview.List   // Open Ctrl+L list of recently viewed layers, nil when closed
+	layerHistory      *LayerHistory // Layers viewed this session, most recent first
+	mu                sync.RWMutex  // Guards data while Update replaces it, for readers outside the event loop
 	data              *data.ExtractedData
 	currentLayerIndex int
+	mouseEnabled      bool
+	showRawCodes      bool     // Entity details show raw DXF group codes
+	legendVisible     bool     // The entity type legend is shown above the entity list
+	previewVisible    bool     // The geometry preview is shown below the entity details
+	dedupEntities     bool     // The entity list hides duplicate entities
+	shownEntities     []int    // Indices into the current layer's entities shown in the entity list
+	blockPath         []string // Names of the nested block definitions being viewed, outermost first
+
+	// Describes entities in the entity lists; nil for the default
+	entityItemTemplate func(data.Entity) (string, string)
+
+	// Entity details can show coordinates relative to the drawing's lower-left extent
+	relativeCoords bool
+	origin         *data.Point // Found when relative coordinates are first shown
 
 	// Navigation components
 	navigator           Navigator
@@ -31,6 +74,33 @@ type DXFView struct {
 	// Error handling
 	errorHandler *ErrorHandler
 	errorLogger  *ErrorLogger
+
+	// Clipboard and status messages
+	clipboardHandler *ClipboardHandler
+	statusHandler    *StatusMessageHandler
+
+	// Entity selection
+	selection *SelectionState
+	shortcuts *ShortcutManager
+
+	// Undo history for visibility and selection changes
+	undoManager *UndoManager
+
+	// Tracks in-memory edits, such as block attribute changes, for the quit prompt
+	quitManager *QuitManager
+
+	// Path of the drawing shown; empty for sample data
+	sourcePath string
+
+	// Saves the view state of the drawing on quit and restores it on the next launch
+	sessionManager *SessionManager
+	// Drawing whose session was last restored, so reloading it keeps the current state
+	restoredPath string
+
+	// Accessibility settings such as entity colors
+	accessibility *AccessibilityManager
+	styling       *StylingManager
+	layout        *LayoutManager
 }
 
 // AppTUI represents the main TUI application
@@ -63,35 +133,131 @@ func NewDXFView(app *tview.Application) *DXFView {
 		SetFieldWidth(30).
 		SetPlaceholder("Type to filter layers... (Space/t: toggle visibility)")
 
+	// Create the warnings indicator and the list it expands into
+	warningsButton := tview.NewButton("")
+	warningsList := tview.NewList()
+	warningsList.SetBorder(true).SetTitle("Warnings")
+
+	// Create the list of a block definition's entities
+	blockList := tview.NewList()
+	blockList.SetBorder(true)
+
+	// Create the selection count indicator
+	selectionStatus := tview.NewTextView().SetDynamicColors(true)
+
+	// Create the entity type legend
+	legendView := tview.NewTextView().SetDynamicColors(true)
+
+	// Create the geometry preview pane, drawn at whatever size it is given
+	previewView := tview.NewBox()
+	previewView.SetBorder(true).SetTitle("Preview")
+
+	// Create the jump-to-layer prompt
+	gotoInput := tview.NewInputField().
+		SetLabel("Go to layer: ").
+		SetFieldWidth(8).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	gotoInput.SetBorder(true).SetTitle("Go to")
+
 	// Create pages container
 	pages := tview.NewPages()
 
+	// Create the status bar shown below every page
+	statusBar := NewStatusBar(app)
+	root := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(pages, 0, 1, true).
+		AddItem(statusBar, 1, 0, false)
+
+	// Error dialogs are overlaid on everything, leaving the pages' front page alone
+	overlays := tview.NewPages().AddPage("main", root, true, true)
+
 	view := &DXFView{
 		app:               app,
+		overlays:          overlays,
+		overlayFocus:      make(map[string]tview.Primitive),
 		pages:             pages,
+		statusBar:         statusBar,
 		textView:          textView,
 		layers:            layers,
 		entityList:        entityList,
 		searchInput:       searchInput,
+		warningsButton:    warningsButton,
+		warningsList:      warningsList,
+		blockList:         blockList,
+		selectionStatus:   selectionStatus,
+		legendView:        legendView,
+		previewView:       previewView,
+		previewRenderer:   NewPreviewRenderer(),
+		gotoInput:         gotoInput,
 		currentLayerIndex: -1,
+		mouseEnabled:      true,
+		layerHistory:      NewLayerHistory(DefaultLayerHistorySize),
 	}
 
 	// Initialize error handling
 	view.errorHandler = NewErrorHandler(view)
 	view.errorLogger = NewErrorLogger()
 
+	// Initialize clipboard and status handling
+	view.statusHandler = NewStatusMessageHandler(view)
+	view.clipboardHandler = NewClipboardHandler(view, clipboard.NewRealClipboardManager())
+	view.clipboardHandler.SetFallbackToFile(true)
+
+	// Initialize entity selection
+	view.selection = NewSelectionState()
+	view.shortcuts = NewShortcutManager(view)
+	view.undoManager = NewUndoManager(DefaultUndoDepth)
+	view.quitManager = NewQuitManager(view)
+	view.accessibility = NewAccessibilityManager(view)
+	view.styling = NewStylingManager(view)
+	view.styling.ApplyModernStyling()
+	view.layout = NewLayoutManager(view)
+	view.accessibility.SetTextScale(view.accessibility.TextScale())
+
 	// Set up search input handler
 	searchInput.SetChangedFunc(func(text string) {
 		view.FilterLayers(text)
 	})
 
+	// Enter jumps to the typed layer number, Escape cancels
+	gotoInput.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			_ = view.GotoLayer(gotoInput.GetText())
+		}
+		view.closeGotoPrompt()
+	})
+
+	// Selecting the warnings indicator expands it into the full list
+	warningsButton.SetSelectedFunc(view.showWarnings)
+
+	previewView.SetDrawFunc(func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+		// Stay inside the border
+		x, y, width, height = x+1, y+1, width-2, height-2
+		rows := strings.Split(view.previewRenderer.RenderStyled(view.currentLayerEntities(), width, height), "\n")
+		for i, row := range rows {
+			tview.Print(screen, row, x, y+i, width, tview.AlignLeft, tcell.ColorWhite)
+		}
+		return x, y, width, height
+	})
+
+	// The entity under the cursor is highlighted in the preview, which is redrawn with the list
+	entityList.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		view.previewRenderer.Highlight(view.entityAt(index))
+	})
+
 	// Set up keyboard navigation
 	view.setupKeybindings()
 
+	// Set up mouse click handling
+	view.setupMouseHandlers()
+
 	// Initialize navigation components
 	view.navigator = NewTUINavigator(app, searchInput, layers, entityList)
 	view.layersNavigator = NewTUIListNavigator(layers)
 	view.entitiesNavigator = NewTUIListNavigator(entityList)
+	// Lists wrap by default, as tview lists do
+	view.layersNavigator.SetWrapNavigation(true)
+	view.entitiesNavigator.SetWrapNavigation(true)
 	view.categorySelector = NewEnhancedCategorySelector(view)
 	view.itemSelector = NewEnhancedItemSelector(view)
 	view.breadcrumbNavigator = NewTUIBreadcrumbNavigator()
@@ -99,10 +265,18 @@ func NewDXFView(app *tview.Application) *DXFView {
 	return view
 }
 
-// Update updates the view with the given DXF data
+// Update updates the view with the given DXF data. It must run in the event loop, or
+// before the application runs; use UpdateAsync from other goroutines.
 func (v *DXFView) Update(data *data.ExtractedData) {
+	// Hold the lock while the lists are rebuilt, so Data and LayerCount never see
+	// a drawing the view has only partly taken on
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
 	v.data = data
 	v.currentLayerIndex = -1
+	v.blockPath = nil
+	v.origin = nil
 
 	// Clear the current content
 	v.textView.Clear()
@@ -110,44 +284,78 @@ func (v *DXFView) Update(data *data.ExtractedData) {
 	// Display DXF version
 	fmt.Fprintf(v.textView, "[green]DXF Version:[-] %s\n\n", data.DXFVersion)
 
+	// Display drawing units, when known
+	if data.Units != "" {
+		fmt.Fprintf(v.textView, "[green]Units:[-] %s\n\n", data.Units)
+	}
+
+	// Display drawing extents, with both versions when the header's look stale
+	v.writeExtents(data)
+
 	// Display number of layers
 	fmt.Fprintf(v.textView, "[green]Layers:[-] %d\n\n", len(data.Layers))
 
+	// Update the warnings indicator
+	v.warningsButton.SetLabel(fmt.Sprintf("⚠ %d warnings", len(data.Warnings)))
+
 	// Update layers list
 	v.updateLayersList()
 
 	// Show the layers view
 	v.showLayersView()
+
+	// Surface parser warnings in the status bar
+	if len(data.Warnings) > 0 {
+		message := data.Warnings[0]
+		if len(data.Warnings) > 1 {
+			message = fmt.Sprintf("%s (+%d more)", message, len(data.Warnings)-1)
+		}
+		v.errorHandler.DisplayError(NewUserError("DXF warning", message), ErrorDisplayStatusBar)
+	}
+
+	// Pick up where the previous launch on this drawing left off, or where the view was
+	// before a reload
+	v.restoreSession()
+	v.applyReload()
 }
 
 // updateLayersList updates the layers list with current data
 func (v *DXFView) updateLayersList() {
 	v.layers.Clear()
 	for i, layer := range v.data.Layers {
-		// Create a string representation of the layer
-		onOff := "ON"
-		if !layer.IsOn {
-			onOff = "OFF"
-		}
-		frozen := ""
-		if layer.IsFrozen {
-			frozen = " (FROZEN)"
-		}
-		layerText := fmt.Sprintf("%s (Color: %d, %s%s)",
-			layer.Name, layer.Color, onOff, frozen)
-
 		// Store the layer index as a reference
 		index := i
-		v.layers.AddItem(layerText, "", 0, func() {
+		v.layers.AddItem(v.layerItemText(layer, nil), "", 0, func() {
 			v.showLayerDetails(index)
 		})
 	}
 }
 
+// layerItemText returns the layers list text for a layer, e.g. "Walls (Color: 1, ON) [42]",
+// highlighting the byte spans of the name matched by the search query.
+// The name comes first, followed by " (", so layerNameAt can recover it.
+func (v *DXFView) layerItemText(layer data.LayerInfo, matches [][2]int) string {
+	onOff := "ON"
+	if !layer.IsOn {
+		onOff = "OFF"
+	}
+	frozen := ""
+	if layer.IsFrozen {
+		frozen = " (FROZEN)"
+	}
+	// The entity count badge is escaped so tview doesn't read it as a color tag
+	return fmt.Sprintf("%s (Color: %s, %s%s) %s",
+		highlightSpans(layer.Name, matches), v.layerColorText(layer.Color), onOff, frozen,
+		tview.Escape(fmt.Sprintf("[%d]", len(layer.Entities))))
+}
+
 // showLayersView shows the layers list view
 func (v *DXFView) showLayersView() {
 	// Create a flex container for the search input and layers list
 	listFlex := tview.NewFlex().SetDirection(tview.FlexRow)
+	if v.data != nil && len(v.data.Warnings) > 0 {
+		listFlex.AddItem(v.warningsButton, 1, 0, false)
+	}
 	listFlex.AddItem(v.searchInput, 1, 0, false)
 	listFlex.AddItem(v.layers, 0, 1, true)
 
@@ -169,6 +377,7 @@ func (v *DXFView) showLayerDetails(layerIndex int) {
 
 	v.currentLayerIndex = layerIndex
 	layer := v.data.Layers[layerIndex]
+	v.layerHistory.Visit(layer.Name)
 
 	// Update the entity list
 	v.entityList.Clear()
@@ -178,73 +387,304 @@ func (v *DXFView) showLayerDetails(layerIndex int) {
 		v.showLayersView()
 	})
 
-	// Add entities for this layer
-	entityCount := 0
-	for _, entity := range layer.Entities {
-		switch e := entity.(type) {
-		case *data.LineInfo:
-			v.entityList.AddItem(
-				fmt.Sprintf("Line (%.1f,%.1f) to (%.1f,%.1f)",
-					e.StartPoint.X, e.StartPoint.Y, e.EndPoint.X, e.EndPoint.Y),
-				fmt.Sprintf("Layer: %s, Color: %d", e.Layer, e.Color),
-				0, nil)
-			entityCount++
-		case *data.CircleInfo:
-			v.entityList.AddItem(
-				fmt.Sprintf("Circle center:(%.1f,%.1f) radius:%.1f",
-					e.Center.X, e.Center.Y, e.Radius),
-				fmt.Sprintf("Layer: %s, Color: %d", e.Layer, e.Color),
-				0, nil)
-			entityCount++
-		case *data.TextInfo:
-			v.entityList.AddItem(
-				fmt.Sprintf("Text: %s at (%.1f,%.1f)",
-					e.Value, e.InsertionPoint.X, e.InsertionPoint.Y),
-				fmt.Sprintf("Layer: %s, Height: %.1f", e.Layer, e.Height),
-				0, nil)
-			entityCount++
-		case *data.PolylineInfo:
-			v.entityList.AddItem(
-				fmt.Sprintf("Polyline with %d points", len(e.Points)),
-				fmt.Sprintf("Layer: %s, Color: %d, Closed: %v", e.Layer, e.Color, e.IsClosed),
-				0, nil)
-			entityCount++
-		case *data.BlockInfo:
-			v.entityList.AddItem(
-				fmt.Sprintf("Block: %s at (%.1f,%.1f)",
-					e.Name, e.InsertionPoint.X, e.InsertionPoint.Y),
-				fmt.Sprintf("Layer: %s, Rotation: %.1f", e.Layer, e.Rotation),
-				0, nil)
-			entityCount++
-		default:
-			// Handle any other entity types
-			v.entityList.AddItem(
-				fmt.Sprintf("Entity: %T", entity),
-				fmt.Sprintf("Layer: %s", entity.GetLayer()),
-				0, nil)
-			entityCount++
+	// Add entities for this layer, leaving out duplicates when deduplication is on
+	if v.dedupEntities {
+		v.shownEntities = data.UniqueIndices(layer.Entities, data.DefaultDedupEpsilon)
+	} else {
+		v.shownEntities = make([]int, len(layer.Entities))
+		for i := range layer.Entities {
+			v.shownEntities[i] = i
 		}
 	}
 
+	for _, index := range v.shownEntities {
+		mainText, secondaryText := v.entityListItem(layer.Entities[index])
+		v.entityList.AddItem(mainText, secondaryText, 0, nil)
+	}
+
 	// Update the text view with layer details
+	v.writeLayerSummary(layer, len(v.shownEntities))
+
+	// Mark entities selected earlier and count only those shown now
+	v.refreshSelection()
+
+	// Show the entities view
+	v.showEntitiesView()
+}
+
+// SetEntityItemTemplate sets the function giving the main and secondary text the entity
+// lists show for an entity, which may use tview color tags. A nil template restores the
+// default, which describes the geometry of each entity type. The open layer is shown
+// again with the new template.
+func (v *DXFView) SetEntityItemTemplate(template func(data.Entity) (main, secondary string)) {
+	v.entityItemTemplate = template
+	if page, _ := v.pages.GetFrontPage(); page == "entities" && v.data != nil && v.currentLayerIndex >= 0 {
+		current := v.entityList.GetCurrentItem()
+		v.showLayerDetails(v.currentLayerIndex)
+		v.entityList.SetCurrentItem(current)
+	}
+}
+
+// entityListItem returns the main and secondary list text describing an entity
+func (v *DXFView) entityListItem(entity data.Entity) (string, string) {
+	if v.entityItemTemplate != nil {
+		return v.entityItemTemplate(entity)
+	}
+	return v.defaultEntityListItem(entity)
+}
+
+// defaultEntityListItem describes an entity by its type and geometry, colored by type
+func (v *DXFView) defaultEntityListItem(entity data.Entity) (string, string) {
+	switch e := entity.(type) {
+	case *data.LineInfo:
+		return v.entityItemText("Line", fmt.Sprintf("Line (%.1f,%.1f) to (%.1f,%.1f)",
+				e.StartPoint.X, e.StartPoint.Y, e.EndPoint.X, e.EndPoint.Y)),
+			fmt.Sprintf("Layer: %s, Color: %d", e.Layer, e.Color)
+	case *data.CircleInfo:
+		return v.entityItemText("Circle", fmt.Sprintf("Circle center:(%.1f,%.1f) radius:%.1f",
+				e.Center.X, e.Center.Y, e.Radius)),
+			fmt.Sprintf("Layer: %s, Color: %d", e.Layer, e.Color)
+	case *data.TextInfo:
+		return v.entityItemText("Text", fmt.Sprintf("Text: %s at (%.1f,%.1f)",
+				e.Value, e.InsertionPoint.X, e.InsertionPoint.Y)),
+			fmt.Sprintf("Layer: %s, Height: %.1f", e.Layer, e.Height)
+	case *data.PolylineInfo:
+		return v.entityItemText("Polyline", fmt.Sprintf("Polyline with %d points", len(e.Points))),
+			fmt.Sprintf("Layer: %s, Color: %d, Closed: %v", e.Layer, e.Color, e.IsClosed)
+	case *data.BlockInfo:
+		return v.entityItemText("Block", fmt.Sprintf("Block: %s at (%.1f,%.1f)",
+				e.Name, e.InsertionPoint.X, e.InsertionPoint.Y)),
+			fmt.Sprintf("Layer: %s, Rotation: %.1f", e.Layer, e.Rotation)
+	case *data.DimensionInfo:
+		return fmt.Sprintf("Dimension: %s (%s)", e.DisplayText(), e.DimensionType),
+			fmt.Sprintf("Layer: %s, Measurement: %.2f", e.Layer, e.Measurement)
+	case *data.PointInfo:
+		return fmt.Sprintf("Point (%.1f, %.1f)", e.Location.X, e.Location.Y),
+			fmt.Sprintf("Layer: %s, Color: %d", e.Layer, e.Color)
+	case *data.HatchInfo:
+		return fmt.Sprintf("Hatch: pattern %s (%d boundary points)", e.PatternName, e.BoundaryPointCount),
+			fmt.Sprintf("Layer: %s, Color: %d, Solid: %v", e.Layer, e.Color, e.IsSolid)
+	default:
+		// Handle any other entity types
+		return fmt.Sprintf("Entity: %T", entity),
+			fmt.Sprintf("Layer: %s", entity.GetLayer())
+	}
+}
+
+// writeLayerSummary writes the layer properties to the text view
+func (v *DXFView) writeLayerSummary(layer data.LayerInfo, entityCount int) {
 	v.textView.Clear()
 	fmt.Fprintf(v.textView, "[green]Layer:[-] %s\n", layer.Name)
 	fmt.Fprintf(v.textView, "[green]Color:[-] %d\n", layer.Color)
 	fmt.Fprintf(v.textView, "[green]Status:[-] %s\n", map[bool]string{true: "ON", false: "OFF"}[layer.IsOn])
 	fmt.Fprintf(v.textView, "[green]Frozen:[-] %v\n", layer.IsFrozen)
 	fmt.Fprintf(v.textView, "[green]Line Type:[-] %s\n", layer.LineType)
+	if v.data != nil && v.data.LineTypes != nil {
+		if lineType := v.data.LineType(layer.LineType); lineType != nil {
+			if lineType.Description != "" {
+				fmt.Fprintf(v.textView, "[green]Description:[-] %s\n", tview.Escape(lineType.Description))
+			}
+			fmt.Fprintf(v.textView, "[green]Pattern:[-] %s\n", lineType.PatternString())
+		} else {
+			fmt.Fprintf(v.textView, "[red]Line type %s is not defined in the drawing[-]\n", layer.LineType)
+		}
+	}
 	fmt.Fprintf(v.textView, "[green]Entities:[-] %d\n\n", entityCount)
+}
 
-	// Show the entities view
-	v.showEntitiesView()
+// previewLayer shows a layer's details without leaving the layers view
+func (v *DXFView) previewLayer(layerIndex int) {
+	if v.data == nil || layerIndex < 0 || layerIndex >= len(v.data.Layers) {
+		return
+	}
+
+	layer := v.data.Layers[layerIndex]
+	v.writeLayerSummary(layer, len(layer.Entities))
+}
+
+// entityAt returns the entity at the given entity list index, or nil for the back entry
+func (v *DXFView) entityAt(listIndex int) data.Entity {
+	if v.data == nil || v.currentLayerIndex < 0 || v.currentLayerIndex >= len(v.data.Layers) {
+		return nil
+	}
+
+	// The first list item is the back entry
+	entities := v.data.Layers[v.currentLayerIndex].Entities
+	shownIndex := listIndex - 1
+	if shownIndex < 0 || shownIndex >= len(v.shownEntities) {
+		return nil
+	}
+	return entities[v.shownEntities[shownIndex]]
+}
+
+// showEntityAt shows the details of the entity at the given entity list index
+func (v *DXFView) showEntityAt(listIndex int) {
+	entity := v.entityAt(listIndex)
+	if entity == nil {
+		return
+	}
+
+	if v.showRawCodes {
+		v.writeRawCodes(entity)
+		return
+	}
+
+	origin, relative := v.relativeOrigin()
+	if relative {
+		entity = data.TranslateEntity(entity, -origin.X, -origin.Y)
+	}
+	if selector, ok := v.itemSelector.(*EnhancedItemSelector); ok {
+		selector.updateDetailsPane(entity)
+	}
+	if relative {
+		fmt.Fprintf(v.textView, "\n[gray]Coordinates relative to (%.1f, %.1f)[-]\n", origin.X, origin.Y)
+	}
+}
+
+// UpdateAsync shows d by queuing Update in the event loop, so it is safe to call from any
+// goroutine, such as one converting a drawing in the background
+func (v *DXFView) UpdateAsync(d *data.ExtractedData) {
+	v.app.QueueUpdateDraw(func() {
+		v.Update(d)
+	})
+}
+
+// Data returns the drawing shown, or nil before the first update. It is safe to call from
+// any goroutine.
+func (v *DXFView) Data() *data.ExtractedData {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.data
+}
+
+// LayerCount returns the number of layers of the drawing shown. It is safe to call from
+// any goroutine.
+func (v *DXFView) LayerCount() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.data == nil {
+		return 0
+	}
+	return len(v.data.Layers)
+}
+
+// extentsEpsilon is how far header and computed extents may differ before they are shown
+// as disagreeing
+const extentsEpsilon = 1e-6
+
+// writeExtents writes the drawing extents to the text view. When the extents stored in the
+// header disagree with those computed from the entities, both are shown as the header's may
+// be stale.
+func (v *DXFView) writeExtents(d *data.ExtractedData) {
+	computed, ok := d.ComputedExtents()
+	header := d.HeaderExtents
+	switch {
+	case header != nil && ok && !header.Near(computed, extentsEpsilon):
+		fmt.Fprintf(v.textView, "[green]Header Extents:[-] %s\n", formatExtents(*header))
+		fmt.Fprintf(v.textView, "[green]Computed Extents:[-] %s\n", formatExtents(computed))
+		fmt.Fprintf(v.textView, "[yellow]Header extents differ from the entities and may be stale[-]\n\n")
+	case header != nil:
+		fmt.Fprintf(v.textView, "[green]Extents:[-] %s\n\n", formatExtents(*header))
+	case ok:
+		fmt.Fprintf(v.textView, "[green]Extents:[-] %s\n\n", formatExtents(computed))
+	}
+}
+
+// formatExtents formats the lower-left and upper-right corners of a box
+func formatExtents(box data.BoundingBox) string {
+	return fmt.Sprintf("(%.2f, %.2f) to (%.2f, %.2f)", box.Min.X, box.Min.Y, box.Max.X, box.Max.Y)
+}
+
+// relativeOrigin returns the point entity coordinates are shown relative to, and false when
+// absolute coordinates are shown or the drawing has no extents
+func (v *DXFView) relativeOrigin() (data.Point, bool) {
+	if !v.relativeCoords || v.data == nil {
+		return data.Point{}, false
+	}
+	if v.origin == nil {
+		extents, ok := v.data.Extents()
+		if !ok {
+			return data.Point{}, false
+		}
+		v.origin = &extents.Min
+	}
+	return *v.origin, true
+}
+
+// ToggleRelativeCoordinates switches entity details between absolute coordinates and
+// coordinates offset by the drawing's lower-left extent
+func (v *DXFView) ToggleRelativeCoordinates() {
+	v.relativeCoords = !v.relativeCoords
+	if v.relativeCoords {
+		v.statusHandler.ShowMessage("Coordinates relative to drawing extents")
+	} else {
+		v.statusHandler.ShowMessage("Absolute coordinates")
+	}
+	if page, _ := v.pages.GetFrontPage(); page == "entities" {
+		v.showEntityAt(v.entityList.GetCurrentItem())
+	}
+}
+
+// ToggleRawCodes switches entity details between parsed fields and raw DXF group codes
+func (v *DXFView) ToggleRawCodes() {
+	v.showRawCodes = !v.showRawCodes
+	v.showEntityAt(v.entityList.GetCurrentItem())
+}
+
+// writeRawCodes writes the raw DXF group codes of an entity to the text view
+func (v *DXFView) writeRawCodes(entity data.Entity) {
+	v.textView.Clear()
+	fmt.Fprintf(v.textView, "[green]Raw DXF Codes[-]\n\n")
+
+	var codes []data.GroupCode
+	if holder, ok := entity.(data.RawCodeHolder); ok {
+		codes = holder.GetRawCodes()
+	}
+	if len(codes) == 0 {
+		fmt.Fprintf(v.textView, "Raw codes not captured (open the drawing with -raw)\n")
+		return
+	}
+
+	for _, gc := range codes {
+		fmt.Fprintf(v.textView, "[yellow]%3d[-]  %s\n", gc.Code, tview.Escape(gc.Value))
+	}
+}
+
+// showWarnings shows the parser warnings as a list
+func (v *DXFView) showWarnings() {
+	if v.data == nil || len(v.data.Warnings) == 0 {
+		return
+	}
+
+	v.warningsList.Clear()
+	v.warningsList.AddItem("← Back to Layers", "", 'b', func() {
+		v.showLayersView()
+	})
+	for _, warning := range v.data.Warnings {
+		v.warningsList.AddItem("⚠ "+warning, "", 0, nil)
+	}
+
+	v.pages.AddAndSwitchToPage("warnings", v.warningsList, true)
+	v.app.SetFocus(v.warningsList)
 }
 
 // showEntitiesView shows the entities list view
 func (v *DXFView) showEntitiesView() {
 	// Create a flex layout with the entities list and details
-	flex := tview.NewFlex().
-		AddItem(v.entityList, 0, 1, true).
+	listFlex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.selectionStatus, 1, 0, false)
+	if v.legendVisible {
+		listFlex.AddItem(v.legendView, 1, 0, false)
+	}
+	listFlex.AddItem(v.entityList, 0, 1, true)
+	detailsFlex := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(v.textView, 0, 1, false)
+	if v.previewVisible {
+		detailsFlex.AddItem(v.previewView, 0, 1, false)
+	}
+	flex := tview.NewFlex().
+		AddItem(listFlex, 0, 1, true).
+		AddItem(detailsFlex, 0, 1, false)
 
 	// Add or update the entities page
 	v.pages.AddAndSwitchToPage("entities", flex, true)
@@ -268,19 +708,51 @@ func (v *DXFView) setupKeybindings() {
 		return event
 	})
 
+	// Ctrl+O opens the file browser, Ctrl+R reloads the drawing, Ctrl+Shift+E exports the
+	// selection and Ctrl+L shows the recent layers from anywhere in the view
+	v.overlays.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if action, handled := v.shortcuts.HandleEvent(event); handled {
+			switch action {
+			case "open_file":
+				v.ShowFileBrowser()
+				return nil
+			case "refresh":
+				v.Reload()
+				return nil
+			case "export_selection":
+				v.showExportSelection()
+				return nil
+			case "recent_layers":
+				v.showRecentLayers()
+				return nil
+			}
+		}
+		return event
+	})
+
 	// Handle key events for the layers list
 	v.layers.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if action, handled := v.shortcuts.HandleEvent(event); handled {
+			if v.handleHistoryAction(action) || v.handleAccessibilityAction(action) {
+				return nil
+			}
+		}
+
 		switch event.Key() {
 		case tcell.KeyEnter:
 			if v.layers.GetItemCount() > 0 {
-				index := v.layers.GetCurrentItem()
-				v.showLayerDetails(index)
+				// A filtered list may be reordered, so find the layer by name
+				v.showLayerDetails(v.layerIndexByName(v.layerNameAt(v.layers.GetCurrentItem())))
 			}
 			return nil
 		case tcell.KeyEsc, tcell.KeyBackspace, tcell.KeyBackspace2:
 			// Move focus back to search
 			v.app.SetFocus(v.searchInput)
 			return nil
+		case tcell.KeyPgDn, tcell.KeyPgUp:
+			if v.wrapPage(v.layers, v.layersNavigator, event.Key()) {
+				return nil
+			}
 		case tcell.KeyRune:
 			// Space or 't' toggles layer visibility
 			if event.Rune() == ' ' || event.Rune() == 't' || event.Rune() == 'T' {
@@ -288,6 +760,60 @@ func (v *DXFView) setupKeybindings() {
 				v.ToggleLayerVisibility(idx)
 				return nil
 			}
+			// Shift+C copies every entity on the focused layer
+			if event.Rune() == 'C' {
+				v.copyFocusedLayer()
+				return nil
+			}
+			// 'w' toggles wrap-around navigation
+			if event.Rune() == 'w' {
+				v.toggleWrapNavigation(v.layersNavigator)
+				return nil
+			}
+			// Shift+S copies a per-layer summary of the drawing
+			if event.Rune() == 'S' {
+				v.copyLayerSummary()
+				return nil
+			}
+			// Shift+G copies every entity of the drawing grouped by layer
+			if event.Rune() == 'G' {
+				v.copyGroupedByLayer()
+				return nil
+			}
+			// 'y' copies the focused layer's name, Shift+Y the drawing's file path
+			if event.Rune() == 'y' {
+				v.copyFocusedLayerName()
+				return nil
+			}
+			if event.Rune() == 'Y' {
+				v.copyFilePath()
+				return nil
+			}
+			// Shift+R selects the entities within a coordinate range
+			if event.Rune() == 'R' {
+				v.showRangeSelect()
+				return nil
+			}
+			// 'g' prompts for a layer number to jump to
+			if event.Rune() == 'g' {
+				v.showGotoPrompt()
+				return nil
+			}
+			// Shift+F thaws every frozen layer
+			if event.Rune() == 'F' {
+				v.SetAllLayersFrozen(false)
+				return nil
+			}
+			// Shift+H turns every layer off
+			if event.Rune() == 'H' {
+				v.SetAllLayersVisible(false)
+				return nil
+			}
+			// Shift+W expands the parser warnings
+			if event.Rune() == 'W' && v.data != nil && len(v.data.Warnings) > 0 {
+				v.showWarnings()
+				return nil
+			}
 			// If a letter or number is pressed, focus on search and type
 			if (event.Rune() >= 'a' && event.Rune() <= 'z') ||
 				(event.Rune() >= 'A' && event.Rune() <= 'Z') ||
@@ -302,9 +828,119 @@ func (v *DXFView) setupKeybindings() {
 		return event
 	})
 
+	// Handle key events for the block definition list
+	v.blockList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEnter:
+			// Enter on a nested block insertion drills further in
+			if block, ok := v.blockEntityAt(v.blockList.GetCurrentItem()).(*data.BlockInfo); ok {
+				v.ShowBlockDefinition(block.Name)
+				return nil
+			}
+		case tcell.KeyEsc, tcell.KeyBackspace, tcell.KeyBackspace2:
+			v.closeBlockDefinition()
+			return nil
+		}
+		return event
+	})
+
+	// Handle key events for the warnings list
+	v.warningsList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc, tcell.KeyBackspace, tcell.KeyBackspace2:
+			v.showLayersView()
+			return nil
+		}
+		return event
+	})
+
 	// Handle key events for the entity list
 	v.entityList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if action, handled := v.shortcuts.HandleEvent(event); handled {
+			switch action {
+			case "select_all":
+				v.SelectAllVisible()
+				return nil
+			case "select_none":
+				v.ClearSelection()
+				return nil
+			}
+			if action == "toggle_preview" {
+				v.TogglePreview()
+				return nil
+			}
+			if v.handleHistoryAction(action) || v.handleAccessibilityAction(action) {
+				return nil
+			}
+		}
+
 		switch event.Key() {
+		case tcell.KeyRune:
+			// Space toggles selection of the current entity
+			if event.Rune() == ' ' {
+				v.ToggleEntitySelection(v.entityList.GetCurrentItem())
+				return nil
+			}
+			// 'r' switches the details pane between entity fields and raw group codes
+			if event.Rune() == 'r' {
+				v.ToggleRawCodes()
+				return nil
+			}
+			// Shift+R selects the entities within a coordinate range
+			if event.Rune() == 'R' {
+				v.showRangeSelect()
+				return nil
+			}
+			// 'c' switches entity details between absolute and relative coordinates
+			if event.Rune() == 'c' {
+				v.ToggleRelativeCoordinates()
+				return nil
+			}
+			// 'w' toggles wrap-around navigation
+			if event.Rune() == 'w' {
+				v.toggleWrapNavigation(v.entitiesNavigator)
+				return nil
+			}
+			// 'l' toggles the entity type legend
+			if event.Rune() == 'l' {
+				v.ToggleEntityLegend()
+				return nil
+			}
+			// 'd' hides or shows duplicate entities
+			if event.Rune() == 'd' {
+				v.ToggleDeduplication()
+				return nil
+			}
+			// 'h' toggles the header row of copied CSV
+			if event.Rune() == 'h' {
+				v.ToggleCSVHeader()
+				return nil
+			}
+			// ';' switches copied CSV between comma and semicolon delimiters
+			if event.Rune() == ';' {
+				v.ToggleCSVDelimiter()
+				return nil
+			}
+			// Shift+J switches copied JSON between indented and compact
+			if event.Rune() == 'J' {
+				v.ToggleJSONIndent()
+				return nil
+			}
+			// 'e' edits the attributes of the current block
+			if event.Rune() == 'e' {
+				v.showAttributeEditor()
+				return nil
+			}
+		case tcell.KeyEnter:
+			// Enter on a block insertion drills into its definition
+			if block, ok := v.entityAt(v.entityList.GetCurrentItem()).(*data.BlockInfo); ok {
+				v.ShowBlockDefinition(block.Name)
+				return nil
+			}
+		case tcell.KeyPgDn, tcell.KeyPgUp:
+			if v.wrapPage(v.entityList, v.entitiesNavigator, event.Key()) {
+				return nil
+			}
 		case tcell.KeyEsc, tcell.KeyBackspace, tcell.KeyBackspace2:
 			v.showLayersView()
 			return nil
@@ -313,43 +949,487 @@ func (v *DXFView) setupKeybindings() {
 	})
 }
 
-// GetLayout returns the pages container for the DXF view
-func (v *DXFView) GetLayout() *tview.Pages {
-	return v.pages
+// setupMouseHandlers sets up click handling for the layers and entity lists
+func (v *DXFView) setupMouseHandlers() {
+	// Single-click previews a layer, double-click drills into its entities
+	v.layers.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if !v.mouseEnabled {
+			return action, nil
+		}
+		if action != tview.MouseLeftClick && action != tview.MouseLeftDoubleClick {
+			return action, event
+		}
+
+		index := listIndexAtPoint(v.layers, event, v.layout.LayerItemRows())
+		if index < 0 {
+			return action, event
+		}
+
+		v.layers.SetCurrentItem(index)
+		v.app.SetFocus(v.layers)
+		layerIndex := v.layerIndexByName(v.layerNameAt(index))
+		if action == tview.MouseLeftDoubleClick {
+			v.showLayerDetails(layerIndex)
+		} else {
+			v.previewLayer(layerIndex)
+		}
+		return tview.MouseConsumed, nil
+	})
+
+	// Clicking an entity shows its details, the back item keeps its default behavior
+	v.entityList.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if !v.mouseEnabled {
+			return action, nil
+		}
+		if action != tview.MouseLeftClick && action != tview.MouseLeftDoubleClick {
+			return action, event
+		}
+
+		// Entity items always show their secondary text
+		index := listIndexAtPoint(v.entityList, event, 2)
+		if index <= 0 {
+			return action, event
+		}
+
+		v.entityList.SetCurrentItem(index)
+		v.app.SetFocus(v.entityList)
+		v.showEntityAt(index)
+		return tview.MouseConsumed, nil
+	})
 }
 
-// SetLayersChangedFunc sets the function to be called when a layer is selected
-func (v *DXFView) SetLayersChangedFunc(handler func(index int, name string, secondaryText string, shortcut rune)) {
-	v.layers.SetChangedFunc(handler)
+// SetMouseEnabled enables or disables mouse click handling in the lists
+func (v *DXFView) SetMouseEnabled(enabled bool) {
+	v.mouseEnabled = enabled
 }
 
-// SetLayersSelectedFunc sets the function to be called when a layer is selected
-func (v *DXFView) SetLayersSelectedFunc(handler func(index int, name string, secondaryText string, shortcut rune)) {
-	v.layers.SetSelectedFunc(handler)
+// IsMouseEnabled returns whether mouse click handling is enabled
+func (v *DXFView) IsMouseEnabled() bool {
+	return v.mouseEnabled
 }
 
-// ToggleLayerVisibility toggles the visibility (IsOn) of the layer at the given visible index.
-func (v *DXFView) ToggleLayerVisibility(visibleIndex int) {
-	if v.data == nil || v.layers.GetItemCount() == 0 || visibleIndex < 0 || visibleIndex >= v.layers.GetItemCount() {
-		return
+// listIndexAtPoint returns the list item under the mouse, or -1 if there is none.
+// rowsPerItem is two when the list shows secondary text and one when it does not.
+func listIndexAtPoint(list *tview.List, event *tcell.EventMouse, rowsPerItem int) int {
+	x, y := event.Position()
+	rectX, rectY, width, height := list.GetInnerRect()
+	if x < rectX || x >= rectX+width || y < rectY || y >= rectY+height {
+		return -1
 	}
-	// Find the actual layer index in v.data.Layers by matching name
-	mainText, _ := v.layers.GetItemText(visibleIndex)
-	// Extract the layer name from the display string (before first ' (')
-	name := mainText
-	if idx := strings.Index(mainText, " ("); idx > 0 {
-		name = mainText[:idx]
+
+	itemOffset, _ := list.GetOffset()
+	index := (y-rectY)/rowsPerItem + itemOffset
+	if index >= list.GetItemCount() {
+		return -1
+	}
+	return index
+}
+
+// selectedMarker prefixes the entity list text of selected entities
+const selectedMarker = "* "
+
+// entityID returns the selection ID of an entity: its DXF handle, which stays the same
+// however the list is filtered or sorted, or for entities without one, such as sample data,
+// a synthetic ID from the layer name and the entity's index within the layer
+func entityID(layerName string, entityIndex int, entity data.Entity) string {
+	if entity != nil {
+		if handle := entity.GetHandle(); handle != "" {
+			return handle
+		}
+	}
+	return fmt.Sprintf("%s:%d", layerName, entityIndex)
+}
+
+// visibleEntityIDs returns the selection IDs of the entities shown in the entity list
+func (v *DXFView) visibleEntityIDs() []string {
+	if v.data == nil || v.currentLayerIndex < 0 || v.currentLayerIndex >= len(v.data.Layers) {
+		return nil
+	}
+
+	layer := v.data.Layers[v.currentLayerIndex]
+	ids := make([]string, len(v.shownEntities))
+	for i, index := range v.shownEntities {
+		ids[i] = entityID(layer.Name, index, layer.Entities[index])
+	}
+	return ids
+}
+
+// ToggleEntitySelection toggles selection of the entity at the given entity list index
+func (v *DXFView) ToggleEntitySelection(listIndex int) {
+	ids := v.visibleEntityIDs()
+
+	// The first list item is the back entry
+	entityIndex := listIndex - 1
+	if entityIndex < 0 || entityIndex >= len(ids) {
+		return
+	}
+
+	v.changeSelection("Toggle entity selection", func() {
+		v.selection.ToggleSelection(ids[entityIndex])
+	})
+}
+
+// SelectAllVisible selects every entity shown in the entity list
+func (v *DXFView) SelectAllVisible() {
+	v.changeSelection("Select all visible entities", func() {
+		v.selection.SelectAll(v.visibleEntityIDs())
+	})
+}
+
+// ClearSelection deselects all entities
+func (v *DXFView) ClearSelection() {
+	v.changeSelection("Clear selection", v.selection.SelectNone)
+}
+
+// changeSelection applies a selection change and records it for undo when it changed anything
+func (v *DXFView) changeSelection(description string, change func()) {
+	before := sortedSelection(v.selection)
+	change()
+	after := sortedSelection(v.selection)
+
+	if !slices.Equal(before, after) {
+		v.undoManager.Record(&selectionCommand{view: v, description: description, before: before, after: after})
+	}
+	v.refreshSelection()
+}
+
+// restoreSelection replaces the selection with the given IDs
+func (v *DXFView) restoreSelection(ids []string) {
+	v.selection.SelectNone()
+	v.selection.SelectAll(ids)
+	v.refreshSelection()
+}
+
+// SelectedCount returns the number of selected entities shown in the active view
+func (v *DXFView) SelectedCount() int {
+	count := 0
+	for _, id := range v.visibleEntityIDs() {
+		if v.selection.IsSelected(id) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetSelectionState returns the entity selection state
+func (v *DXFView) GetSelectionState() *SelectionState {
+	return v.selection
+}
+
+// refreshSelection updates the selection markers and the "N selected" indicator
+func (v *DXFView) refreshSelection() {
+	for i, id := range v.visibleEntityIDs() {
+		listIndex := i + 1
+		if listIndex >= v.entityList.GetItemCount() {
+			break
+		}
+
+		mainText, secondaryText := v.entityList.GetItemText(listIndex)
+		mainText = strings.TrimPrefix(mainText, selectedMarker)
+		if v.selection.IsSelected(id) {
+			mainText = selectedMarker + mainText
+		}
+		v.entityList.SetItemText(listIndex, mainText, secondaryText)
+	}
+
+	status := fmt.Sprintf("%d selected", v.SelectedCount())
+	if v.dedupEntities && v.data != nil && v.currentLayerIndex >= 0 && v.currentLayerIndex < len(v.data.Layers) {
+		status += fmt.Sprintf(" · Showing %d of %d after dedup",
+			len(v.shownEntities), len(v.data.Layers[v.currentLayerIndex].Entities))
+	}
+	v.selectionStatus.SetText(status)
+}
+
+// ToggleDeduplication shows or hides duplicate entities in the entity list
+func (v *DXFView) ToggleDeduplication() {
+	v.dedupEntities = !v.dedupEntities
+	if v.data == nil || v.currentLayerIndex < 0 || v.currentLayerIndex >= len(v.data.Layers) {
+		return
+	}
+
+	v.showLayerDetails(v.currentLayerIndex)
+	if v.dedupEntities {
+		v.statusHandler.ShowMessage(fmt.Sprintf("Showing %d of %d after dedup",
+			len(v.shownEntities), len(v.data.Layers[v.currentLayerIndex].Entities)))
+	} else {
+		v.statusHandler.ShowMessage("Showing all entities")
+	}
+}
+
+// IsDeduplicating returns whether duplicate entities are hidden from the entity list
+func (v *DXFView) IsDeduplicating() bool {
+	return v.dedupEntities
+}
+
+// entityItemText colors an entity list item's text by entity type
+func (v *DXFView) entityItemText(kind, text string) string {
+	color, ok := v.accessibility.EntityTypeColors()[kind]
+	if !ok {
+		return text
+	}
+	return fmt.Sprintf("[%s]%s[-]", color, tview.Escape(text))
+}
+
+// handleAccessibilityAction runs palette and text size shortcut actions, reporting whether the action was one of them
+func (v *DXFView) handleAccessibilityAction(action string) bool {
+	switch action {
+	case "toggle_palette":
+		v.ToggleColorblindPalette()
+	case "text_larger":
+		v.accessibility.SetTextScale(v.accessibility.TextScale() + TextScaleStep)
+	case "text_smaller":
+		v.accessibility.SetTextScale(v.accessibility.TextScale() - TextScaleStep)
+	case "text_reset":
+		v.accessibility.SetTextScale(DefaultTextScale)
+	default:
+		return false
+	}
+	if action != "toggle_palette" {
+		v.statusHandler.ShowMessage(fmt.Sprintf("Text size %.0f%%", v.accessibility.TextScale()*100))
+	}
+	return true
+}
+
+// layerColorText formats an ACI layer color number in its display color
+func (v *DXFView) layerColorText(aci int) string {
+	color := v.accessibility.LayerColor(aci)
+	if color == "" {
+		return fmt.Sprintf("%d", aci)
+	}
+	return fmt.Sprintf("[%s]%d[-]", color, aci)
+}
+
+// ToggleColorblindPalette switches the colorblind-friendly palette on or off
+// and re-renders the layers and entities lists with the new colors
+func (v *DXFView) ToggleColorblindPalette() {
+	if v.accessibility.IsPaletteActive() {
+		v.accessibility.RestoreDefaultPalette()
+		v.statusHandler.ShowMessage("Colorblind palette off")
+	} else {
+		v.accessibility.ApplyPalette()
+		v.statusHandler.ShowMessage("Colorblind palette on")
+	}
+
+	if v.data == nil {
+		return
+	}
+	current := v.layers.GetCurrentItem()
+	v.refreshLayersList()
+	if current < v.layers.GetItemCount() {
+		v.layers.SetCurrentItem(current)
+	}
+
+	if page, _ := v.pages.GetFrontPage(); page == "entities" && v.currentLayerIndex >= 0 {
+		current = v.entityList.GetCurrentItem()
+		v.showLayerDetails(v.currentLayerIndex)
+		v.entityList.SetCurrentItem(current)
+	}
+	if v.legendVisible {
+		v.ShowEntityLegend()
+	}
+}
+
+// ShowEntityLegend shows the key of entity type colors above the entity list
+func (v *DXFView) ShowEntityLegend() {
+	colors := v.accessibility.EntityTypeColors()
+	parts := make([]string, 0, len(entityLegendOrder))
+	for _, kind := range entityLegendOrder {
+		parts = append(parts, fmt.Sprintf("[%s]■ %s[-]", colors[kind], kind))
+	}
+	v.legendView.SetText(strings.Join(parts, "  "))
+	v.setLegendVisible(true)
+}
+
+// HideEntityLegend hides the entity type legend
+func (v *DXFView) HideEntityLegend() {
+	v.setLegendVisible(false)
+}
+
+// ToggleEntityLegend shows or hides the entity type legend
+func (v *DXFView) ToggleEntityLegend() {
+	if v.legendVisible {
+		v.HideEntityLegend()
+	} else {
+		v.ShowEntityLegend()
+	}
+}
+
+// TogglePreview shows or hides the geometry preview of the current layer
+func (v *DXFView) TogglePreview() {
+	v.previewVisible = !v.previewVisible
+	if page, _ := v.pages.GetFrontPage(); page == "entities" {
+		v.showEntitiesView()
+	}
+}
+
+// IsPreviewVisible returns whether the geometry preview is shown
+func (v *DXFView) IsPreviewVisible() bool {
+	return v.previewVisible
+}
+
+// currentLayerEntities returns the entities of the layer being viewed, or nil when none is
+func (v *DXFView) currentLayerEntities() []data.Entity {
+	if v.data == nil || v.currentLayerIndex < 0 || v.currentLayerIndex >= len(v.data.Layers) {
+		return nil
+	}
+	return v.data.Layers[v.currentLayerIndex].Entities
+}
+
+// IsEntityLegendVisible returns whether the entity type legend is shown
+func (v *DXFView) IsEntityLegendVisible() bool {
+	return v.legendVisible
+}
+
+// setLegendVisible updates the legend state and rebuilds the entities page when it is showing
+func (v *DXFView) setLegendVisible(visible bool) {
+	v.legendVisible = visible
+	if page, _ := v.pages.GetFrontPage(); page == "entities" {
+		v.showEntitiesView()
+	}
+}
+
+// GetLayout returns the root of the DXF view: its pages above the status bar, under any error dialog
+func (v *DXFView) GetLayout() tview.Primitive {
+	return v.overlays
+}
+
+// SetErrorLogger replaces the logger errors shown in the view are recorded in
+func (v *DXFView) SetErrorLogger(logger *ErrorLogger) {
+	v.errorLogger = logger
+}
+
+// GetStatusBar returns the status bar shown below every page
+func (v *DXFView) GetStatusBar() *StatusBar {
+	return v.statusBar
+}
+
+// SetLayersChangedFunc sets the function to be called when a layer is selected
+func (v *DXFView) SetLayersChangedFunc(handler func(index int, name string, secondaryText string, shortcut rune)) {
+	v.layers.SetChangedFunc(handler)
+}
+
+// SetLayersSelectedFunc sets the function to be called when a layer is selected
+func (v *DXFView) SetLayersSelectedFunc(handler func(index int, name string, secondaryText string, shortcut rune)) {
+	v.layers.SetSelectedFunc(handler)
+}
+
+// ToggleLayerVisibility toggles the visibility (IsOn) of the layer at the given visible index.
+func (v *DXFView) ToggleLayerVisibility(visibleIndex int) {
+	if v.data == nil || v.layers.GetItemCount() == 0 || visibleIndex < 0 || visibleIndex >= v.layers.GetItemCount() {
+		return
 	}
+	// Find the actual layer index in v.data.Layers by matching name
+	name := v.layerNameAt(visibleIndex)
 	for i := range v.data.Layers {
 		if v.data.Layers[i].Name == name {
 			// Don't toggle frozen layers
 			if !v.data.Layers[i].IsFrozen {
-				v.data.Layers[i].IsOn = !v.data.Layers[i].IsOn
+				previous := v.data.Layers[i].IsOn
+				v.data.Layers[i].IsOn = !previous
+				v.undoManager.Record(&layerVisibilityCommand{view: v, layerName: name, previousIsOn: previous})
 			}
 			break
 		}
 	}
-	// Re-filter or update the list to reflect the change
+	v.refreshLayersList()
+}
+
+// SetAllLayersFrozen freezes or thaws every layer and refreshes the layers list.
+// Unlike ToggleLayerVisibility it changes frozen layers, which is what thawing needs.
+func (v *DXFView) SetAllLayersFrozen(frozen bool) {
+	if v.data == nil {
+		return
+	}
+
+	cmd := &layerStatesCommand{view: v}
+	for i := range v.data.Layers {
+		if v.data.Layers[i].IsFrozen != frozen {
+			cmd.before = append(cmd.before, stateOfLayer(v.data.Layers[i]))
+			v.data.Layers[i].IsFrozen = frozen
+			cmd.after = append(cmd.after, stateOfLayer(v.data.Layers[i]))
+		}
+	}
+	v.refreshLayersList()
+
+	changed := len(cmd.before)
+	if changed == 0 {
+		return
+	}
+	if frozen {
+		cmd.description = fmt.Sprintf("Froze %d %s", changed, layerNoun(changed))
+	} else {
+		cmd.description = fmt.Sprintf("Thawed %d %s", changed, layerNoun(changed))
+	}
+	v.undoManager.Record(cmd)
+	v.quitManager.SetUnsavedChanges(true)
+	v.statusHandler.ShowMessage(cmd.description)
+}
+
+// SetAllLayersVisible turns every layer on or off and refreshes the layers list.
+// Frozen layers are left as they are, as with ToggleLayerVisibility.
+func (v *DXFView) SetAllLayersVisible(on bool) {
+	if v.data == nil {
+		return
+	}
+
+	cmd := &layerStatesCommand{view: v}
+	for i := range v.data.Layers {
+		if !v.data.Layers[i].IsFrozen && v.data.Layers[i].IsOn != on {
+			cmd.before = append(cmd.before, stateOfLayer(v.data.Layers[i]))
+			v.data.Layers[i].IsOn = on
+			cmd.after = append(cmd.after, stateOfLayer(v.data.Layers[i]))
+		}
+	}
+	v.refreshLayersList()
+
+	changed := len(cmd.before)
+	if changed == 0 {
+		return
+	}
+	if on {
+		cmd.description = fmt.Sprintf("Showed %d %s", changed, layerNoun(changed))
+	} else {
+		cmd.description = fmt.Sprintf("Hid %d %s", changed, layerNoun(changed))
+	}
+	v.undoManager.Record(cmd)
+	v.quitManager.SetUnsavedChanges(true)
+	v.statusHandler.ShowMessage(cmd.description)
+}
+
+// layerNoun returns "layer" or "layers" for the count
+func layerNoun(count int) string {
+	if count == 1 {
+		return "layer"
+	}
+	return "layers"
+}
+
+// setLayerVisibility sets whether the named layer is on and refreshes the layers list
+func (v *DXFView) setLayerVisibility(name string, isOn bool) {
+	if i := v.layerIndexByName(name); i >= 0 {
+		v.data.Layers[i].IsOn = isOn
+	}
+	v.refreshLayersList()
+}
+
+// stateOfLayer returns the visibility and frozen state of a layer
+func stateOfLayer(layer data.LayerInfo) layerState {
+	return layerState{name: layer.Name, isOn: layer.IsOn, isFrozen: layer.IsFrozen}
+}
+
+// setLayerStates sets the visibility and frozen state of the named layers and refreshes the layers list
+func (v *DXFView) setLayerStates(states []layerState) {
+	for _, state := range states {
+		if i := v.layerIndexByName(state.name); i >= 0 {
+			v.data.Layers[i].IsOn = state.isOn
+			v.data.Layers[i].IsFrozen = state.isFrozen
+		}
+	}
+	v.refreshLayersList()
+}
+
+// refreshLayersList re-filters or updates the layers list to reflect layer changes
+func (v *DXFView) refreshLayersList() {
 	if v.searchInput != nil && v.searchInput.GetText() != "" {
 		v.FilterLayers(v.searchInput.GetText())
 	} else {
@@ -357,11 +1437,437 @@ func (v *DXFView) ToggleLayerVisibility(visibleIndex int) {
 	}
 }
 
+// Undo reverts the most recent visibility or selection change
+func (v *DXFView) Undo() bool {
+	return v.undoManager.Undo()
+}
+
+// Redo reapplies the most recently undone visibility or selection change
+func (v *DXFView) Redo() bool {
+	return v.undoManager.Redo()
+}
+
+// GetUndoManager returns the undo history of the view
+func (v *DXFView) GetUndoManager() *UndoManager {
+	return v.undoManager
+}
+
+// handleHistoryAction runs undo and redo shortcut actions, reporting whether the action was one of them
+func (v *DXFView) handleHistoryAction(action string) bool {
+	switch action {
+	case "undo":
+		v.Undo()
+		return true
+	case "redo":
+		v.Redo()
+		return true
+	}
+	return false
+}
+
+// entityColorText describes an entity's color number, showing ByLayer and ByBlock
+// colors resolved through the entity's layer
+func (v *DXFView) entityColorText(entity data.Entity) string {
+	color := entity.GetColor()
+	var name string
+	switch color {
+	case data.ColorByLayer:
+		name = "ByLayer"
+	case data.ColorByBlock:
+		name = "ByBlock"
+	default:
+		return strconv.Itoa(color)
+	}
+
+	index := v.layerIndexByName(entity.GetLayer())
+	if index < 0 {
+		return name
+	}
+	return fmt.Sprintf("%d (%s)", data.ResolveEntityColor(entity, v.data.Layers[index]), name)
+}
+
+// layerIndexByName returns the index of the named layer in the data, or -1 if not found
+func (v *DXFView) layerIndexByName(name string) int {
+	if v.data == nil {
+		return -1
+	}
+	for i := range v.data.Layers {
+		if v.data.Layers[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// showGotoPrompt shows a small input over the layers view for a layer number to jump to
+func (v *DXFView) showGotoPrompt() {
+	if v.layers.GetItemCount() == 0 {
+		return
+	}
+
+	v.gotoInput.SetText("")
+	v.gotoInput.SetTitle(fmt.Sprintf("Go to (1-%d)", v.layers.GetItemCount()))
+
+	v.gotoOpen = true
+	v.showOverlay("goto", v.gotoInput, 30, 3)
+}
+
+// closeGotoPrompt removes the jump-to-layer prompt and returns focus to the layers list
+func (v *DXFView) closeGotoPrompt() {
+	v.closeOverlay("goto")
+	v.gotoOpen = false
+	v.app.SetFocus(v.layers)
+}
+
+// showAttributeEditor opens a form over the entity list for editing the current block's attribute values
+func (v *DXFView) showAttributeEditor() {
+	block, ok := v.entityAt(v.entityList.GetCurrentItem()).(*data.BlockInfo)
+	if !ok {
+		return
+	}
+	if len(block.Attributes) == 0 {
+		v.statusHandler.ShowMessage(fmt.Sprintf("Block %s has no attributes to edit", block.Name))
+		return
+	}
+
+	form := tview.NewForm()
+	v.attributeForm = form
+	form.SetBorder(true).SetTitle(fmt.Sprintf("Edit attributes: %s", block.Name))
+	for _, attr := range block.Attributes {
+		form.AddInputField(attr.Tag, attr.Value, 30, nil, nil)
+	}
+	form.AddButton("Save", func() {
+		values := make([]string, len(block.Attributes))
+		for i := range block.Attributes {
+			values[i] = form.GetFormItem(i).(*tview.InputField).GetText()
+		}
+		v.ApplyAttributeEdits(block, values)
+		v.closeAttributeEditor()
+	})
+	form.AddButton("Cancel", v.closeAttributeEditor)
+	// Escape discards the edits
+	form.SetCancelFunc(v.closeAttributeEditor)
+
+	// Size the form to fit the attributes and buttons
+	v.showOverlay("attributes", form, 50, 2*len(block.Attributes)+5)
+}
+
+// closeAttributeEditor removes the attribute form and returns focus to the entity list
+func (v *DXFView) closeAttributeEditor() {
+	v.closeOverlay("attributes")
+	v.attributeForm = nil
+	v.app.SetFocus(v.entityList)
+	v.showEntityAt(v.entityList.GetCurrentItem())
+}
+
+// ApplyAttributeEdits sets the block's attribute values, in attribute order, and marks the
+// document as having unsaved changes if any value changed. It returns whether anything changed.
+// Values are updated in place, so copies of the block sharing its attributes see the edits too.
+func (v *DXFView) ApplyAttributeEdits(block *data.BlockInfo, values []string) bool {
+	changed := 0
+	for i := range block.Attributes {
+		if i >= len(values) || block.Attributes[i].Value == values[i] {
+			continue
+		}
+		block.Attributes[i].Value = values[i]
+		changed++
+	}
+	if changed == 0 {
+		return false
+	}
+
+	v.quitManager.SetUnsavedChanges(true)
+	v.statusHandler.ShowMessage(fmt.Sprintf("Updated %d attribute(s) of block %s", changed, block.Name))
+	return true
+}
+
+// ShowBlockDefinition lists the entities of the named block definition, nested inside
+// any definition already being viewed. It returns false, reporting why in the status,
+// when the block has no definition.
+func (v *DXFView) ShowBlockDefinition(name string) bool {
+	if v.data == nil {
+		return false
+	}
+	if v.data.BlockDefinitions == nil {
+		v.statusHandler.ShowMessage("Block definitions were not parsed")
+		return false
+	}
+	if _, ok := v.data.BlockDefinitions[name]; !ok {
+		v.statusHandler.ShowMessage(fmt.Sprintf("Block %s has no definition", name))
+		return false
+	}
+
+	v.blockPath = append(v.blockPath, name)
+	v.showBlockList()
+	return true
+}
+
+// showBlockList fills the block list with the entities of the innermost definition being viewed
+func (v *DXFView) showBlockList() {
+	definition := v.data.BlockDefinitions[v.blockPath[len(v.blockPath)-1]]
+
+	v.blockList.Clear()
+	v.blockList.SetTitle(fmt.Sprintf("Block: %s", strings.Join(v.blockPath, " > ")))
+	v.blockList.AddItem("← Back", "", 'b', v.closeBlockDefinition)
+	for _, entity := range definition.Entities {
+		mainText, secondaryText := v.entityListItem(entity)
+		v.blockList.AddItem(mainText, secondaryText, 0, nil)
+	}
+	v.blockList.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		v.showBlockEntityAt(index)
+	})
+
+	v.textView.Clear()
+	fmt.Fprintf(v.textView, "[green]Block:[-] %s\n", definition.Name)
+	fmt.Fprintf(v.textView, "[green]Base Point:[-] (%.2f, %.2f, %.2f)\n",
+		definition.BasePoint.X, definition.BasePoint.Y, definition.BasePoint.Z)
+	fmt.Fprintf(v.textView, "[green]Entities:[-] %d\n", len(definition.Entities))
+
+	flex := tview.NewFlex().
+		AddItem(v.blockList, 0, 1, true).
+		AddItem(v.textView, 0, 1, false)
+	v.pages.AddAndSwitchToPage("block", flex, true)
+	v.app.SetFocus(v.blockList)
+}
+
+// blockEntityAt returns the entity at the given block list index, or nil for the back entry
+func (v *DXFView) blockEntityAt(listIndex int) data.Entity {
+	if v.data == nil || len(v.blockPath) == 0 {
+		return nil
+	}
+
+	entities := v.data.BlockDefinitions[v.blockPath[len(v.blockPath)-1]].Entities
+	if listIndex < 1 || listIndex > len(entities) {
+		return nil
+	}
+	return entities[listIndex-1]
+}
+
+// showBlockEntityAt shows the details of the block definition entity at the given list index
+func (v *DXFView) showBlockEntityAt(listIndex int) {
+	entity := v.blockEntityAt(listIndex)
+	if entity == nil {
+		return
+	}
+	if selector, ok := v.itemSelector.(*EnhancedItemSelector); ok {
+		selector.updateDetailsPane(entity)
+	}
+}
+
+// closeBlockDefinition steps out of the innermost block definition, returning to the
+// entity list once no definition is left
+func (v *DXFView) closeBlockDefinition() {
+	if len(v.blockPath) > 0 {
+		v.blockPath = v.blockPath[:len(v.blockPath)-1]
+	}
+	if len(v.blockPath) > 0 {
+		v.showBlockList()
+		return
+	}
+
+	v.pages.RemovePage("block")
+	v.showEntitiesView()
+	v.app.SetFocus(v.entityList)
+	v.showEntityAt(v.entityList.GetCurrentItem())
+}
+
+// ToggleCSVHeader switches whether copied CSV starts with a header row
+func (v *DXFView) ToggleCSVHeader() {
+	include := !v.clipboardHandler.CSVHeader()
+	v.clipboardHandler.SetCSVHeader(include)
+	if include {
+		v.statusHandler.ShowMessage("CSV header row on")
+	} else {
+		v.statusHandler.ShowMessage("CSV header row off")
+	}
+}
+
+// ToggleCSVDelimiter switches the cells of copied CSV between comma and semicolon separation
+func (v *DXFView) ToggleCSVDelimiter() {
+	if v.clipboardHandler.CSVDelimiter() == ';' {
+		v.clipboardHandler.SetCSVDelimiter(',')
+		v.statusHandler.ShowMessage("CSV delimiter: comma")
+	} else {
+		v.clipboardHandler.SetCSVDelimiter(';')
+		v.statusHandler.ShowMessage("CSV delimiter: semicolon")
+	}
+}
+
+// ToggleJSONIndent switches copied JSON between indented and compact single-line output
+func (v *DXFView) ToggleJSONIndent() {
+	indent := !v.clipboardHandler.JSONIndent()
+	v.clipboardHandler.SetJSONIndent(indent)
+	if indent {
+		v.statusHandler.ShowMessage("Copied JSON is indented")
+	} else {
+		v.statusHandler.ShowMessage("Copied JSON is compact")
+	}
+}
+
+// GetQuitManager returns the quit manager tracking unsaved changes
+func (v *DXFView) GetQuitManager() *QuitManager {
+	return v.quitManager
+}
+
+// SetSession sets the session manager that saves the view state of the drawing at
+// sourcePath on quit and restores it when the drawing is loaded. A nil manager disables sessions.
+func (v *DXFView) SetSession(manager *SessionManager, sourcePath string) {
+	v.sessionManager = manager
+	v.sourcePath = sourcePath
+}
+
+// SetSourcePath sets the path of the drawing shown, copied by Shift+Y.
+// An empty path means the view shows sample data.
+func (v *DXFView) SetSourcePath(sourcePath string) {
+	v.sourcePath = sourcePath
+}
+
+// captureSession returns the current view state of the drawing
+func (v *DXFView) captureSession() Session {
+	pane := PaneSearch
+	switch v.app.GetFocus() {
+	case v.layers:
+		pane = PaneLayers
+	case v.entityList:
+		pane = PaneEntities
+	}
+	// A layer only counts as open while its entities are shown
+	layerIndex := -1
+	if page, _ := v.pages.GetFrontPage(); page == "entities" {
+		layerIndex = v.currentLayerIndex
+	}
+
+	return Session{
+		FilePath:    v.sourcePath,
+		FocusedPane: pane,
+		LayerIndex:  layerIndex,
+		SearchText:  v.searchInput.GetText(),
+		SelectedIDs: v.selection.GetSelectedItemIDs(),
+	}
+}
+
+// SaveSession saves the current view state of the drawing, if sessions are enabled
+func (v *DXFView) SaveSession() error {
+	if v.sessionManager == nil || v.sourcePath == "" || v.data == nil {
+		return nil
+	}
+	return v.sessionManager.Save(v.captureSession())
+}
+
+// restoreSession restores the saved view state of the drawing when it is opened, but not
+// when the drawing shown is updated again, such as by a reload. Sessions that no longer
+// fit the drawing, such as one whose layer is gone, are ignored.
+func (v *DXFView) restoreSession() {
+	if v.sourcePath == v.restoredPath {
+		return
+	}
+	v.restoredPath = v.sourcePath
+	if v.sessionManager == nil || v.sourcePath == "" {
+		return
+	}
+	session, ok := v.sessionManager.Load(v.sourcePath)
+	if !ok || session.LayerIndex >= len(v.data.Layers) {
+		return
+	}
+
+	v.searchInput.SetText(session.SearchText)
+	v.restoreSelection(session.SelectedIDs)
+	switch {
+	case session.LayerIndex >= 0:
+		v.showLayerDetails(session.LayerIndex)
+		if session.FocusedPane == PaneEntities {
+			v.app.SetFocus(v.entityList)
+		}
+	case session.FocusedPane == PaneLayers:
+		v.app.SetFocus(v.layers)
+	}
+}
+
+// GotoLayer moves the layers list selection to the given 1-based layer number.
+// Invalid or out-of-range input is reported in the status and leaves the selection unchanged.
+func (v *DXFView) GotoLayer(input string) error {
+	number, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		v.statusHandler.ShowMessage(fmt.Sprintf("Invalid layer number: %q", input))
+		return fmt.Errorf("invalid layer number %q: %w", input, err)
+	}
+
+	count := v.layers.GetItemCount()
+	if number < 1 || number > count {
+		v.statusHandler.ShowMessage(fmt.Sprintf("Layer %d is out of range (1-%d)", number, count))
+		return fmt.Errorf("layer %d out of range (1-%d)", number, count)
+	}
+
+	return v.layersNavigator.SetCurrentIndex(number - 1)
+}
+
+// layerNameAt returns the name of the layer shown at the given visible index
+func (v *DXFView) layerNameAt(visibleIndex int) string {
+	mainText, _ := v.layers.GetItemText(visibleIndex)
+	mainText = stripSearchHighlight.Replace(mainText)
+	// Extract the layer name from the display string (before first ' (')
+	name := mainText
+	if idx := strings.Index(mainText, " ("); idx > 0 {
+		name = mainText[:idx]
+	}
+	return name
+}
+
+// showCopyError reports a failed copy in the status bar through the error handler
+func (v *DXFView) showCopyError(err error) {
+	v.errorHandler.DisplayError(NewSystemError(fmt.Sprintf("Failed to copy: %v", err), err), ErrorDisplayStatusBar)
+}
+
+// copyFocusedLayer copies all entities of the focused layer to the clipboard
+func (v *DXFView) copyFocusedLayer() {
+	if v.data == nil || v.layers.GetItemCount() == 0 {
+		return
+	}
+
+	name := v.layerNameAt(v.layers.GetCurrentItem())
+	if err := v.clipboardHandler.CopyLayer(name); err != nil {
+		v.showCopyError(err)
+	}
+}
+
+// copyFocusedLayerName copies the name of the focused layer to clipboard and reports any error
+func (v *DXFView) copyFocusedLayerName() {
+	if v.data == nil || v.layers.GetItemCount() == 0 {
+		return
+	}
+
+	if err := v.clipboardHandler.CopyLayerName(v.layerNameAt(v.layers.GetCurrentItem())); err != nil {
+		v.showCopyError(err)
+	}
+}
+
+// copyFilePath copies the drawing's file path to clipboard and reports any error
+func (v *DXFView) copyFilePath() {
+	if err := v.clipboardHandler.CopyFilePath(); err != nil {
+		v.showCopyError(err)
+	}
+}
+
+// copyGroupedByLayer copies the whole drawing grouped by layer and reports any error
+func (v *DXFView) copyGroupedByLayer() {
+	if err := v.clipboardHandler.CopyGroupedByLayer(); err != nil {
+		v.showCopyError(err)
+	}
+}
+
+// copyLayerSummary copies the per-layer entity counts to clipboard and reports any error
+func (v *DXFView) copyLayerSummary() {
+	if err := v.clipboardHandler.CopyLayerSummary(); err != nil {
+		v.showCopyError(err)
+	}
+}
+
 // FilterLayers filters the layers list based on the provided query string.
 // The query can be:
 // - A simple string to filter by layer name (case-insensitive)
 // - "on:true" or "on:false" to filter by layer on/off status
 // - "frozen:true" or "frozen:false" to filter by frozen status
+// - "~" followed by text for a fuzzy match, best matches first (e.g. "~wals" matches "Walls")
 // - An empty string to clear all filters
 func (v *DXFView) FilterLayers(query string) {
 	if v.data == nil {
@@ -386,6 +1892,8 @@ func (v *DXFView) FilterLayers(query string) {
 
 	// Check for special filter types
 	var filterFunc func(layer data.LayerInfo) bool
+	var scores map[string]int               // Fuzzy match scores by layer name, for ranking
+	highlights := make(map[string][][2]int) // Matched byte spans by layer name
 
 	switch {
 	case strings.HasPrefix(query, "on:true"):
@@ -404,39 +1912,136 @@ func (v *DXFView) FilterLayers(query string) {
 		filterFunc = func(layer data.LayerInfo) bool {
 			return !layer.IsFrozen
 		}
+	case strings.HasPrefix(query, "~"):
+		// Fuzzy match by name (case-insensitive)
+		pattern := strings.TrimPrefix(query, "~")
+		scores = make(map[string]int)
+		filterFunc = func(layer data.LayerInfo) bool {
+			score, spans, ok := fuzzyMatch(pattern, strings.ToLower(layer.Name))
+			if ok {
+				scores[layer.Name] = score
+				highlights[layer.Name] = spans
+			}
+			return ok
+		}
 	default:
 		// Filter by name (case-insensitive)
 		filterFunc = func(layer data.LayerInfo) bool {
-			return strings.Contains(strings.ToLower(layer.Name), query)
+			index := strings.Index(strings.ToLower(layer.Name), query)
+			if index < 0 {
+				return false
+			}
+			highlights[layer.Name] = [][2]int{{index, index + len(query)}}
+			return true
 		}
 	}
 
-	// Filter and add layers
+	// Filter layers
+	var matches []int
 	for i, layer := range v.data.Layers {
 		if filterFunc(layer) {
-			onOff := "ON"
-			if !layer.IsOn {
-				onOff = "OFF"
-			}
-			frozen := ""
-			if layer.IsFrozen {
-				frozen = " (FROZEN)"
+			matches = append(matches, i)
+		}
+	}
+
+	// Rank fuzzy matches best first, preferring shorter names on ties
+	if scores != nil {
+		sort.SliceStable(matches, func(a, b int) bool {
+			nameA, nameB := v.data.Layers[matches[a]].Name, v.data.Layers[matches[b]].Name
+			if scores[nameA] != scores[nameB] {
+				return scores[nameA] > scores[nameB]
 			}
-			layerText := fmt.Sprintf("%s (Color: %d, %s%s)",
-				layer.Name, layer.Color, onOff, frozen)
+			return len(nameA) < len(nameB)
+		})
+	}
 
-			// Store the layer index as a reference
-			index := i
-			v.layers.AddItem(layerText, "", 0, func() {
-				v.showLayerDetails(index)
-			})
-		}
+	// Add the matching layers
+	for _, i := range matches {
+		// Store the layer index as a reference
+		index := i
+		layer := v.data.Layers[i]
+		v.layers.AddItem(v.layerItemText(layer, highlights[layer.Name]), "", 0, func() {
+			v.showLayerDetails(index)
+		})
 	}
 
 	// Restore scroll position if possible
 	v.layers.SetOffset(0, currentOffset)
 }
 
+// fuzzyScore reports whether the characters of pattern appear in order in name
+// and scores the match: every matched character counts, more so when it follows
+// the previous match or starts the name.
+func fuzzyScore(pattern, name string) (int, bool) {
+	score, _, ok := fuzzyMatch(pattern, name)
+	return score, ok
+}
+
+// fuzzyMatch is like fuzzyScore but also returns the byte spans of name the
+// characters of pattern matched, with consecutive characters in one span
+func fuzzyMatch(pattern, name string) (int, [][2]int, bool) {
+	score := 0
+	previous := -2
+	position := 0
+	var spans [][2]int
+	for _, r := range pattern {
+		offset := strings.IndexRune(name[position:], r)
+		if offset < 0 {
+			return 0, nil, false
+		}
+		index := position + offset
+
+		score++
+		if index == previous+1 {
+			score += 2
+		}
+		if index == 0 {
+			score += 3
+		}
+
+		end := index + utf8.RuneLen(r)
+		if len(spans) > 0 && spans[len(spans)-1][1] == index {
+			spans[len(spans)-1][1] = end
+		} else {
+			spans = append(spans, [2]int{index, end})
+		}
+
+		previous = index
+		position = end
+	}
+	return score, spans, true
+}
+
+// Search matches are highlighted in the layers list with these tags
+const (
+	searchHighlightStart = "[black:yellow]"
+	searchHighlightEnd   = "[-:-]"
+)
+
+// stripSearchHighlight removes the search highlight tags from a layers list item
+var stripSearchHighlight = strings.NewReplacer(searchHighlightStart, "", searchHighlightEnd, "")
+
+// highlightSpans wraps the given byte spans of name, in order and not overlapping, in the
+// search highlight tags. Spans are found in the lower-cased name, so they are ignored when
+// lower-casing changed its length.
+func highlightSpans(name string, spans [][2]int) string {
+	if len(spans) == 0 || len(strings.ToLower(name)) != len(name) {
+		return name
+	}
+
+	var b strings.Builder
+	position := 0
+	for _, span := range spans {
+		b.WriteString(name[position:span[0]])
+		b.WriteString(searchHighlightStart)
+		b.WriteString(name[span[0]:span[1]])
+		b.WriteString(searchHighlightEnd)
+		position = span[1]
+	}
+	b.WriteString(name[position:])
+	return b.String()
+}
+
 // Navigation Getter Methods
 
 // GetNavigator returns the main navigator for pane switching
@@ -444,6 +2049,36 @@ func (v *DXFView) GetNavigator() Navigator {
 	return v.navigator
 }
 
+// toggleWrapNavigation flips wrap-around navigation on a list navigator and reports the new state
+func (v *DXFView) toggleWrapNavigation(nav ListNavigator) {
+	nav.SetWrapNavigation(!nav.IsWrapNavigation())
+	if nav.IsWrapNavigation() {
+		v.statusHandler.ShowMessage("Wrap navigation on")
+	} else {
+		v.statusHandler.ShowMessage("Wrap navigation off")
+	}
+}
+
+// wrapPage wraps PgDn on the last item and PgUp on the first item when the list's
+// navigator has wrap enabled. Elsewhere the list pages as usual.
+func (v *DXFView) wrapPage(list *tview.List, nav ListNavigator, key tcell.Key) bool {
+	if !nav.IsWrapNavigation() || list.GetItemCount() == 0 {
+		return false
+	}
+
+	current := list.GetCurrentItem()
+	atEnd := key == tcell.KeyPgDn && current == list.GetItemCount()-1
+	atStart := key == tcell.KeyPgUp && current == 0
+	if !atEnd && !atStart {
+		return false
+	}
+
+	if err := nav.SetCurrentIndex(current); err != nil {
+		return false
+	}
+	return nav.HandleKeyPress(key, tcell.ModNone)
+}
+
 // GetListNavigator returns the list navigator for the specified list type
 func (v *DXFView) GetListNavigator(listType string) ListNavigator {
 	switch listType {
@@ -499,9 +2134,6 @@ func (v *DXFView) ShowError(err error) {
 		v.errorHandler.HandleError(systemErr)
 		v.errorLogger.LogError(systemErr, LogLevelError)
 	}
-
-	// Update status bar with error message
-	v.updateStatusWithError()
 }
 
 // ShowWarning displays a warning message
@@ -509,22 +2141,9 @@ func (v *DXFView) ShowWarning(message string) {
 	userErr := NewUserError("Warning", message)
 	v.errorHandler.HandleError(userErr)
 	v.errorLogger.LogError(userErr, LogLevelWarn)
-	v.updateStatusWithError()
 }
 
 // ClearError clears any displayed error
 func (v *DXFView) ClearError() {
-	v.errorHandler.isVisible = false
-	v.errorHandler.displayedText = ""
-	// Status will be updated through normal UI refresh
-}
-
-// updateStatusWithError updates the status bar with error information
-func (v *DXFView) updateStatusWithError() {
-	if v.errorHandler.IsErrorVisible() {
-		errorText := v.errorHandler.GetDisplayedError()
-		// For now, errors will be displayed through other UI elements
-		// Future implementation can integrate with status bar
-		_ = errorText
-	}
+	v.errorHandler.Dismiss()
 }
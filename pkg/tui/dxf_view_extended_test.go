@@ -99,6 +99,35 @@ func TestDXFView_ShowLayerDetails(t *testing.T) {
 	assert.Contains(t, text, "Layer: TestLayer", "Expected layer details to be shown")
 }
 
+func TestDXFView_LayerLineTypePattern(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{
+			{Name: "Center", IsOn: true, LineType: "dashdot"},
+			{Name: "Hidden", IsOn: true, LineType: "HIDDEN"},
+		},
+		LineTypes: map[string]*data.LineTypeInfo{
+			"DASHDOT": {Name: "DASHDOT", Description: "Dash dot [__ . __]", Pattern: []float64{0.5, -0.25, 0, -0.25}},
+		},
+	})
+
+	view.previewLayer(0)
+	text := view.textView.GetText(true)
+	assert.Contains(t, text, "Description: Dash dot [__ . __]")
+	assert.Contains(t, text, "Pattern: dash 0.50, gap 0.25, dot, gap 0.25")
+
+	view.previewLayer(1)
+	assert.Contains(t, view.textView.GetText(true), "Line type HIDDEN is not defined in the drawing")
+
+	// Without an LTYPE table there is nothing to resolve against
+	view.Update(&data.ExtractedData{Layers: []data.LayerInfo{{Name: "Hidden", IsOn: true, LineType: "HIDDEN"}}})
+	view.previewLayer(0)
+	text = view.textView.GetText(true)
+	assert.NotContains(t, text, "Pattern:")
+	assert.NotContains(t, text, "not defined")
+}
+
 func TestDXFView_ShowEntitiesView(t *testing.T) {
 	app := SetupTestApp(t)
 	view := NewDXFView(app)
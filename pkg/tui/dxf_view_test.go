@@ -1,12 +1,17 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/remym/go-dwg-extractor/pkg/data"
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDXFView(t *testing.T) {
@@ -30,6 +35,7 @@ func TestDXFView_Update(t *testing.T) {
 
 	testData := &data.ExtractedData{
 		DXFVersion: "R2020",
+		Units:      "Millimeters",
 		Layers: []data.LayerInfo{
 			{Name: "Layer1", IsOn: true, IsFrozen: false, Color: 1, Entities: []data.Entity{}},
 			{Name: "Layer2", IsOn: false, IsFrozen: true, Color: 2, Entities: []data.Entity{}},
@@ -40,6 +46,120 @@ func TestDXFView_Update(t *testing.T) {
 	view.Update(testData)
 	assert.Equal(t, testData, view.data, "Expected data to be set")
 	assert.Equal(t, -1, view.currentLayerIndex, "Expected currentLayerIndex to be reset")
+	assert.Contains(t, view.textView.GetText(true), "Units: Millimeters")
+
+	// Data without units, such as imported JSON, leaves the line out
+	view.Update(&data.ExtractedData{DXFVersion: "R2020"})
+	assert.NotContains(t, view.textView.GetText(true), "Units:")
+}
+
+func TestDXFView_UpdateAsync(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	app := tview.NewApplication().SetScreen(screen)
+	view := NewDXFView(app)
+	app.SetRoot(view.GetLayout(), true)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run()
+	}()
+	defer func() {
+		app.Stop()
+		<-done
+	}()
+
+	drawing := func(layerCount int) *data.ExtractedData {
+		d := &data.ExtractedData{DXFVersion: "R2020"}
+		for i := 0; i < layerCount; i++ {
+			d.Layers = append(d.Layers, data.LayerInfo{Name: fmt.Sprintf("Layer%d", i), IsOn: true})
+		}
+		return d
+	}
+
+	assert.Equal(t, 0, view.LayerCount())
+	assert.Nil(t, view.Data())
+
+	// Conversions finishing in the background update the view while it is being read
+	var wg sync.WaitGroup
+	for n := 1; n <= 5; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			view.UpdateAsync(drawing(n))
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		count := view.LayerCount()
+		assert.True(t, count >= 0 && count <= 5, "unexpected layer count %d", count)
+	}
+	wg.Wait()
+
+	final := drawing(3)
+	view.UpdateAsync(final)
+	require.Eventually(t, func() bool {
+		return view.Data() == final
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, view.LayerCount())
+}
+
+func TestDXFView_SetEntityItemTemplate(t *testing.T) {
+	view := NewDXFView(tview.NewApplication())
+	view.Update(&data.ExtractedData{Layers: []data.LayerInfo{{Name: "Walls", IsOn: true, Entities: []data.Entity{
+		&data.LineInfo{Layer: "Walls", Color: 3, Handle: "2F", EndPoint: data.Point{X: 5}},
+		&data.TextInfo{Layer: "Walls", Value: "A", Color: data.ColorByLayer, Handle: "30"},
+	}}}})
+	view.showLayerDetails(0)
+	mainText, _ := view.entityList.GetItemText(1)
+	assert.Contains(t, mainText, "Line (0.0,0.0) to (5.0,0.0)", "the default describes the geometry")
+
+	// Setting a template shows the open layer again with it
+	view.SetEntityItemTemplate(func(e data.Entity) (string, string) {
+		return fmt.Sprintf("#%s", e.GetHandle()), fmt.Sprintf("Color %d", e.GetColor())
+	})
+	require.Equal(t, 3, view.entityList.GetItemCount())
+	mainText, secondaryText := view.entityList.GetItemText(1)
+	assert.Equal(t, "#2F", mainText)
+	assert.Equal(t, "Color 3", secondaryText)
+	mainText, secondaryText = view.entityList.GetItemText(2)
+	assert.Equal(t, "#30", mainText)
+	assert.Equal(t, "Color 256", secondaryText)
+
+	// Selection markers are added to the template's text
+	view.ToggleEntitySelection(1)
+	mainText, _ = view.entityList.GetItemText(1)
+	assert.Equal(t, selectedMarker+"#2F", mainText)
+
+	view.SetEntityItemTemplate(nil)
+	mainText, _ = view.entityList.GetItemText(2)
+	assert.Contains(t, mainText, "Text: A at (0.0,0.0)")
+}
+
+func TestDXFView_UpdateExtents(t *testing.T) {
+	view := NewDXFView(tview.NewApplication())
+	layers := []data.LayerInfo{{Name: "0", IsOn: true, Entities: []data.Entity{
+		&data.LineInfo{StartPoint: data.Point{X: 1, Y: 2}, EndPoint: data.Point{X: 30, Y: 40}},
+	}}}
+
+	view.Update(&data.ExtractedData{Layers: layers})
+	text := view.textView.GetText(true)
+	assert.Contains(t, text, "Extents: (1.00, 2.00) to (30.00, 40.00)", "extents are computed without a header")
+	assert.NotContains(t, text, "stale")
+
+	matching := &data.BoundingBox{Min: data.Point{X: 1, Y: 2}, Max: data.Point{X: 30, Y: 40}}
+	view.Update(&data.ExtractedData{Layers: layers, HeaderExtents: matching})
+	text = view.textView.GetText(true)
+	assert.Contains(t, text, "Extents: (1.00, 2.00) to (30.00, 40.00)")
+	assert.NotContains(t, text, "Header Extents")
+
+	stale := &data.BoundingBox{Min: data.Point{X: 0, Y: 0}, Max: data.Point{X: 500, Y: 400}}
+	view.Update(&data.ExtractedData{Layers: layers, HeaderExtents: stale})
+	text = view.textView.GetText(true)
+	assert.Contains(t, text, "Header Extents: (0.00, 0.00) to (500.00, 400.00)")
+	assert.Contains(t, text, "Computed Extents: (1.00, 2.00) to (30.00, 40.00)")
+	assert.Contains(t, text, "may be stale")
+
+	view.Update(&data.ExtractedData{})
+	assert.NotContains(t, view.textView.GetText(true), "Extents")
 }
 
 // SetupTestApp creates a new tview application for testing purposes
@@ -276,7 +396,7 @@ func TestFilterLayers(t *testing.T) {
 		if view.layers.GetItemCount() != 1 {
 			t.Errorf("Expected 1 layer after filtering, got %d", view.layers.GetItemCount())
 		}
-		mainText, _ := view.layers.GetItemText(0)
+		mainText := view.layerNameAt(0)
 		if !strings.Contains(mainText, "Walls") {
 			t.Errorf("Expected layer 'Walls' after filtering, got '%s'", mainText)
 		}
@@ -289,7 +409,7 @@ func TestFilterLayers(t *testing.T) {
 		if view.layers.GetItemCount() != 1 {
 			t.Errorf("Expected 1 layer after filtering, got %d", view.layers.GetItemCount())
 		}
-		mainText, _ := view.layers.GetItemText(0)
+		mainText := view.layerNameAt(0)
 		if !strings.Contains(mainText, "Windows") {
 			t.Errorf("Expected layer 'Windows' after filtering, got '%s'", mainText)
 		}
@@ -311,12 +431,45 @@ func TestFilterLayers(t *testing.T) {
 		if view.layers.GetItemCount() != 1 {
 			t.Errorf("Expected 1 layer after filtering, got %d", view.layers.GetItemCount())
 		}
-		mainText, _ := view.layers.GetItemText(0)
+		mainText := view.layerNameAt(0)
 		if !strings.Contains(mainText, "Windows") {
 			t.Errorf("Expected layer 'Windows' after filtering, got '%s'", mainText)
 		}
 	})
 
+	// Test fuzzy matching
+	t.Run("Fuzzy match", func(t *testing.T) {
+		view.FilterLayers("~dor")
+		require.Equal(t, 1, view.layers.GetItemCount(), "Expected ~dor to match only Doors")
+		mainText := view.layerNameAt(0)
+		assert.Contains(t, mainText, "Doors")
+
+		view.FilterLayers("~wals")
+		require.Equal(t, 1, view.layers.GetItemCount())
+		mainText = view.layerNameAt(0)
+		assert.Contains(t, mainText, "Walls")
+	})
+
+	// Test fuzzy ranking
+	t.Run("Fuzzy matches ranked by score", func(t *testing.T) {
+		view.FilterLayers("~do")
+		require.Equal(t, 2, view.layers.GetItemCount())
+		first := view.layerNameAt(0)
+		second := view.layerNameAt(1)
+		assert.Contains(t, first, "Doors", "Expected the prefix match first")
+		assert.Contains(t, second, "Windows")
+	})
+
+	// Test opening a layer from the filtered list
+	t.Run("Enter opens the matched layer", func(t *testing.T) {
+		view.FilterLayers("~dor")
+		require.Equal(t, 1, view.layers.GetItemCount())
+		view.layers.SetCurrentItem(0)
+		result := view.layers.GetInputCapture()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+		assert.Nil(t, result)
+		assert.Equal(t, "Doors", view.data.Layers[view.currentLayerIndex].Name)
+	})
+
 	// Test clearing the filter
 	t.Run("Clear filter", func(t *testing.T) {
 		view.FilterLayers("")
@@ -357,6 +510,117 @@ func TestToggleLayerVisibility_EdgeCases(t *testing.T) {
 	})
 }
 
+// TestLayerEntityCountBadge tests the entity count shown after each layer name
+func TestLayerEntityCountBadge(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{
+			{Name: "Walls", IsOn: true, Color: 1, Entities: []data.Entity{
+				&data.LineInfo{Layer: "Walls"}, &data.LineInfo{Layer: "Walls"}, &data.CircleInfo{Layer: "Walls"},
+			}},
+			{Name: "Doors", IsOn: true, Color: 2},
+		},
+	})
+
+	mainText, _ := view.layers.GetItemText(0)
+	assert.True(t, strings.HasSuffix(mainText, tview.Escape("[3]")), "Expected the Walls count badge, got %q", mainText)
+	mainText, _ = view.layers.GetItemText(1)
+	assert.True(t, strings.HasSuffix(mainText, tview.Escape("[0]")), "Expected the Doors count badge, got %q", mainText)
+
+	// Toggling resolves the layer by name despite the badge
+	view.ToggleLayerVisibility(1)
+	assert.True(t, view.data.Layers[0].IsOn)
+	assert.False(t, view.data.Layers[1].IsOn)
+
+	// Filtered lists carry the badge too
+	view.FilterLayers("wall")
+	require.Equal(t, 1, view.layers.GetItemCount())
+	mainText, _ = view.layers.GetItemText(0)
+	assert.Contains(t, mainText, tview.Escape("[3]"))
+	view.ToggleLayerVisibility(0)
+	assert.False(t, view.data.Layers[0].IsOn)
+}
+
+// TestBulkLayerState tests thawing and hiding every layer at once
+func TestBulkLayerState(t *testing.T) {
+	newView := func() *DXFView {
+		view := NewDXFView(SetupTestApp(t))
+		view.Update(&data.ExtractedData{
+			Layers: []data.LayerInfo{
+				{Name: "Walls", IsOn: true},
+				{Name: "Doors", IsOn: false, IsFrozen: true},
+				{Name: "Notes", IsOn: true, IsFrozen: true},
+			},
+		})
+		view.showLayersView()
+		return view
+	}
+
+	t.Run("Shift+F thaws frozen layers", func(t *testing.T) {
+		view := newView()
+		view.layers.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'F', tcell.ModNone))
+
+		for _, layer := range view.data.Layers {
+			assert.False(t, layer.IsFrozen, "Expected %s to be thawed", layer.Name)
+		}
+		for i := 0; i < view.layers.GetItemCount(); i++ {
+			mainText, _ := view.layers.GetItemText(i)
+			assert.NotContains(t, mainText, "FROZEN")
+		}
+		assert.Empty(t, view.searchInput.GetText(), "Expected 'F' not to be typed into the search")
+		assert.True(t, view.GetQuitManager().HasUnsavedChanges())
+
+		// Thawed layers can be toggled again
+		view.ToggleLayerVisibility(1)
+		assert.True(t, view.data.Layers[1].IsOn)
+	})
+
+	t.Run("Shift+H hides layers that are not frozen", func(t *testing.T) {
+		view := newView()
+		view.layers.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'H', tcell.ModNone))
+
+		assert.False(t, view.data.Layers[0].IsOn)
+		assert.True(t, view.data.Layers[2].IsOn, "Expected frozen layers to keep their visibility")
+		assert.True(t, view.GetQuitManager().HasUnsavedChanges())
+	})
+
+	t.Run("Ctrl+Z undoes a bulk change in one step", func(t *testing.T) {
+		view := newView()
+		view.SetAllLayersVisible(false)
+		view.SetAllLayersFrozen(false)
+		require.Equal(t, 2, view.undoManager.UndoCount())
+
+		require.True(t, view.Undo())
+		assert.True(t, view.data.Layers[1].IsFrozen, "Expected the thaw to be undone")
+		assert.True(t, view.data.Layers[2].IsFrozen)
+		assert.False(t, view.data.Layers[0].IsOn, "Expected the hide to stay until undone")
+
+		require.True(t, view.Undo())
+		assert.True(t, view.data.Layers[0].IsOn, "Expected the hidden layer to be shown again")
+		mainText, _ := view.layers.GetItemText(0)
+		assert.Contains(t, mainText, "ON")
+
+		require.True(t, view.Redo())
+		assert.False(t, view.data.Layers[0].IsOn)
+	})
+
+	t.Run("no change leaves the document clean", func(t *testing.T) {
+		view := newView()
+		view.SetAllLayersFrozen(false)
+		view.GetQuitManager().SetUnsavedChanges(false)
+		view.SetAllLayersFrozen(false)
+		assert.False(t, view.GetQuitManager().HasUnsavedChanges())
+		assert.Equal(t, 1, view.undoManager.UndoCount(), "Expected no command for a change that did nothing")
+	})
+
+	t.Run("nil data", func(t *testing.T) {
+		view := NewDXFView(SetupTestApp(t))
+		view.SetAllLayersFrozen(true)  // Should not panic
+		view.SetAllLayersVisible(true) // Should not panic
+	})
+}
+
 // TestShowLayerDetails_WithDifferentEntities tests showing layer details with different entity types
 func TestShowLayerDetails_WithDifferentEntities(t *testing.T) {
 	app := SetupTestApp(t)
@@ -426,3 +690,685 @@ func TestShowLayerDetails_InvalidIndex(t *testing.T) {
 	view.showLayerDetails(-1) // Should not panic
 	view.showLayerDetails(10) // Should not panic
 }
+
+// TestShowLayerDetails_Dimension tests that dimensions are listed with their display text
+func TestShowLayerDetails_Point(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{
+			{
+				Name: "Survey",
+				IsOn: true,
+				Entities: []data.Entity{
+					&data.PointInfo{Location: data.Point{X: 12.34, Y: 5}, Layer: "Survey", Color: 2},
+				},
+			},
+		},
+	})
+	view.showLayerDetails(0)
+
+	mainText, secondaryText := view.entityList.GetItemText(1)
+	assert.Equal(t, "Point (12.3, 5.0)", mainText)
+	assert.Equal(t, "Layer: Survey, Color: 2", secondaryText)
+}
+
+func TestShowLayerDetails_Hatch(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{
+			{
+				Name: "Fill",
+				IsOn: true,
+				Entities: []data.Entity{
+					&data.HatchInfo{PatternName: "ANSI31", BoundaryPointCount: 4, Layer: "Fill", Color: 8},
+				},
+			},
+		},
+	})
+	view.showLayerDetails(0)
+
+	mainText, _ := view.entityList.GetItemText(1)
+	assert.Equal(t, "Hatch: pattern ANSI31 (4 boundary points)", mainText)
+}
+
+func TestShowLayerDetails_Dimension(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	testData := &data.ExtractedData{
+		DXFVersion: "R2020",
+		Layers: []data.LayerInfo{
+			{
+				Name:  "Dims",
+				IsOn:  true,
+				Color: 3,
+				Entities: []data.Entity{
+					&data.DimensionInfo{DimensionType: "Linear", Measurement: 1200, Layer: "Dims"},
+					&data.DimensionInfo{DimensionType: "Radius", TextOverride: "R50 TYP", Measurement: 50, Layer: "Dims"},
+				},
+			},
+		},
+	}
+
+	view.Update(testData)
+	view.showLayerDetails(0)
+
+	// Item 0 is the back entry
+	mainText, _ := view.entityList.GetItemText(1)
+	assert.Equal(t, "Dimension: 1200.00 (Linear)", mainText, "Expected measured value when no override is set")
+	mainText, _ = view.entityList.GetItemText(2)
+	assert.Equal(t, "Dimension: R50 TYP (Radius)", mainText, "Expected text override to be shown")
+}
+
+// clickList sends a left mouse action on the given list item, assuming a bordered list at
+// the origin whose items take two rows (main and secondary text)
+func clickList(list *tview.List, action tview.MouseAction, item int) bool {
+	return clickListRows(list, action, item, 2)
+}
+
+// clickListRows sends a left mouse action on the given list item, assuming a bordered list
+// at the origin whose items take rowsPerItem rows
+func clickListRows(list *tview.List, action tview.MouseAction, item, rowsPerItem int) bool {
+	list.SetRect(0, 0, 60, 20)
+	// The inner rect starts at row 1
+	event := tcell.NewEventMouse(2, 1+item*rowsPerItem, tcell.ButtonPrimary, 0)
+	consumed, _ := list.MouseHandler()(action, event, func(p tview.Primitive) {})
+	return consumed
+}
+
+func TestMouseClick_Layers(t *testing.T) {
+	tests := []struct {
+		name             string
+		action           tview.MouseAction
+		mouseEnabled     bool
+		expectedConsumed bool
+		expectedPage     string
+		expectedLayer    int
+	}{
+		{
+			name:             "single click previews layer",
+			action:           tview.MouseLeftClick,
+			mouseEnabled:     true,
+			expectedConsumed: true,
+			expectedPage:     "layers",
+			expectedLayer:    -1,
+		},
+		{
+			name:             "double click drills into entities",
+			action:           tview.MouseLeftDoubleClick,
+			mouseEnabled:     true,
+			expectedConsumed: true,
+			expectedPage:     "entities",
+			expectedLayer:    1,
+		},
+		{
+			name:             "clicks are ignored when mouse is disabled",
+			action:           tview.MouseLeftDoubleClick,
+			mouseEnabled:     false,
+			expectedConsumed: false,
+			expectedPage:     "layers",
+			expectedLayer:    -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := SetupTestApp(t)
+			view := NewDXFView(app)
+			view.Update(createTestDataWithMultipleItems())
+			view.SetMouseEnabled(tt.mouseEnabled)
+
+			consumed := clickList(view.layers, tt.action, 1)
+			assert.Equal(t, tt.expectedConsumed, consumed)
+
+			page, _ := view.pages.GetFrontPage()
+			assert.Equal(t, tt.expectedPage, page)
+			assert.Equal(t, tt.expectedLayer, view.currentLayerIndex)
+
+			if tt.mouseEnabled {
+				assert.Equal(t, 1, view.layers.GetCurrentItem(), "Clicked layer should become current")
+				assert.Contains(t, view.textView.GetText(true), "Layer: Layer2")
+			}
+		})
+	}
+}
+
+func TestMouseClick_LayersSingleSpaced(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+	view.accessibility.SetTextScale(MinTextScale)
+	require.False(t, view.layout.IsDoubleSpaced())
+
+	// Without secondary text every layer takes one row
+	consumed := clickListRows(view.layers, tview.MouseLeftClick, 1, 1)
+	assert.True(t, consumed)
+	assert.Equal(t, 1, view.layers.GetCurrentItem(), "Clicked layer should become current")
+	assert.Contains(t, view.textView.GetText(true), "Layer: Layer2")
+}
+
+func TestMouseClick_EntityShowsDetails(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+	view.showLayerDetails(0)
+
+	// Item 3 is the circle (item 0 is the back entry)
+	consumed := clickList(view.entityList, tview.MouseLeftClick, 3)
+	assert.True(t, consumed)
+	assert.Equal(t, 3, view.entityList.GetCurrentItem())
+
+	details := view.textView.GetText(true)
+	assert.Contains(t, details, "Circle Entity")
+	assert.Contains(t, details, "Radius: 2.5")
+}
+
+func TestShowEntityDetails_LineLength(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+	view.showLayerDetails(0)
+
+	// The first line runs from (0,0) to (10,10)
+	view.showEntityAt(1)
+	details := view.textView.GetText(true)
+	assert.Contains(t, details, "Line Entity")
+	assert.Contains(t, details, "Length: 14.14")
+}
+
+func TestSetMouseEnabled(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	assert.True(t, view.IsMouseEnabled(), "Mouse should be enabled by default")
+	view.SetMouseEnabled(false)
+	assert.False(t, view.IsMouseEnabled())
+}
+
+func TestDXFView_Update_ShowsWarnings(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	view.Update(&data.ExtractedData{DXFVersion: "R14"})
+	assert.False(t, view.errorHandler.IsErrorVisible(), "No warnings should show nothing")
+
+	view.Update(&data.ExtractedData{
+		DXFVersion: "R14",
+		Warnings:   []string{"DXF version R14 (AC1014) is older than R2000", "second warning"},
+	})
+	assert.True(t, view.errorHandler.IsErrorVisible())
+	assert.Equal(t, "DXF version R14 (AC1014) is older than R2000 (+1 more)", view.errorHandler.GetDisplayedError())
+}
+
+func TestDXFView_WarningsIndicator(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	view.Update(&data.ExtractedData{
+		Layers:   []data.LayerInfo{{Name: "0", IsOn: true}},
+		Warnings: []string{"Skipped 2 unrecognized SPLINE entities", "Layer 0 has no entities"},
+	})
+	assert.Equal(t, "⚠ 2 warnings", view.warningsButton.GetLabel())
+
+	// Selecting the indicator expands into the list of warnings
+	view.warningsButton.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p tview.Primitive) {})
+	page, _ := view.pages.GetFrontPage()
+	assert.Equal(t, "warnings", page)
+	require.Equal(t, 3, view.warningsList.GetItemCount(), "Expected back item plus one item per warning")
+	mainText, _ := view.warningsList.GetItemText(1)
+	assert.Equal(t, "⚠ Skipped 2 unrecognized SPLINE entities", mainText)
+
+	// Escape returns to the layers view
+	view.warningsList.GetInputCapture()(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone))
+	page, _ = view.pages.GetFrontPage()
+	assert.Equal(t, "layers", page)
+}
+
+func TestEntitySelection(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	testData := createTestDataWithMultipleItems()
+	testData.Layers[1].Entities = []data.Entity{&data.LineInfo{Layer: "Layer2"}}
+	view.Update(testData)
+	view.showLayerDetails(0)
+	assert.Equal(t, "0 selected", view.selectionStatus.GetText(true))
+
+	capture := view.entityList.GetInputCapture()
+
+	// Space toggles the current entity
+	view.entityList.SetCurrentItem(2)
+	capture(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone))
+	assert.Equal(t, 1, view.SelectedCount())
+	assert.Equal(t, "1 selected", view.selectionStatus.GetText(true))
+	mainText, _ := view.entityList.GetItemText(2)
+	assert.True(t, strings.HasPrefix(mainText, selectedMarker), "Selected entity should be marked")
+
+	// Space on the back item selects nothing
+	view.entityList.SetCurrentItem(0)
+	capture(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone))
+	assert.Equal(t, 1, view.SelectedCount())
+
+	// Ctrl+A selects every entity in the list
+	capture(tcell.NewEventKey(tcell.KeyCtrlA, 0, tcell.ModCtrl))
+	assert.Equal(t, 3, view.SelectedCount())
+	assert.Equal(t, "3 selected", view.selectionStatus.GetText(true))
+
+	// Another layer counts only its own entities
+	view.showLayerDetails(1)
+	assert.Equal(t, "0 selected", view.selectionStatus.GetText(true))
+	capture(tcell.NewEventKey(tcell.KeyCtrlA, 0, tcell.ModCtrl))
+	assert.Equal(t, "1 selected", view.selectionStatus.GetText(true))
+
+	// Ctrl+D clears everything
+	capture(tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl))
+	assert.Equal(t, "0 selected", view.selectionStatus.GetText(true))
+	assert.Equal(t, 0, view.GetSelectionState().GetSelectedCount())
+	mainText, _ = view.entityList.GetItemText(1)
+	assert.False(t, strings.HasPrefix(mainText, selectedMarker))
+}
+
+func TestEntitySelection_Handles(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	first := &data.LineInfo{Layer: "0", Handle: "2A"}
+	second := &data.CircleInfo{Layer: "0", Handle: "2B", Radius: 1}
+	layer := data.LayerInfo{Name: "0", IsOn: true, Entities: []data.Entity{first, second}}
+	view.Update(&data.ExtractedData{Layers: []data.LayerInfo{layer}})
+	view.showLayerDetails(0)
+
+	view.ToggleEntitySelection(2)
+	assert.True(t, view.GetSelectionState().IsSelected("2B"), "entities with handles are selected by handle")
+
+	// Reordering the entities keeps the selection on the same entity
+	view.data.Layers[0].Entities = []data.Entity{second, first}
+	view.showLayerDetails(0)
+	mainText, _ := view.entityList.GetItemText(1)
+	assert.True(t, strings.HasPrefix(mainText, selectedMarker))
+	mainText, _ = view.entityList.GetItemText(2)
+	assert.False(t, strings.HasPrefix(mainText, selectedMarker))
+
+	assert.Equal(t, "0:1", entityID("0", 1, &data.LineInfo{}), "entities without handles get synthetic IDs")
+}
+
+func TestDXFView_UpdateFromJSON(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	loaded, err := data.FromJSON(strings.NewReader(`[
+  {"type": "Line", "layer": "Walls", "startPoint": {"x": 0, "y": 0}, "endPoint": {"x": 10, "y": 0}, "color": 1},
+  {"type": "Point", "layer": "Marks", "location": {"x": 1, "y": 2, "z": 0}, "color": 2}
+]`))
+	require.NoError(t, err)
+
+	view.Update(loaded)
+	assert.Equal(t, 2, view.layers.GetItemCount())
+
+	view.showLayerDetails(1)
+	assert.Equal(t, 2, view.entityList.GetItemCount(), "Expected back item plus the point")
+	assert.Contains(t, view.textView.GetText(true), "Layer: Marks")
+}
+
+func TestToggleRawCodes(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	line := &data.LineInfo{Layer: "0", RawCodes: []data.GroupCode{{Code: 0, Value: "LINE"}, {Code: 8, Value: "0"}}}
+	circle := &data.CircleInfo{Layer: "0", Radius: 1}
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "0", IsOn: true, Entities: []data.Entity{line, circle}}},
+	})
+	view.showLayerDetails(0)
+	capture := view.entityList.GetInputCapture()
+
+	view.entityList.SetCurrentItem(1)
+	capture(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone))
+	details := view.textView.GetText(true)
+	assert.Contains(t, details, "Raw DXF Codes")
+	assert.Contains(t, details, "  0  LINE")
+	assert.Contains(t, details, "  8  0")
+
+	// Entities without raw codes explain how to capture them
+	view.entityList.SetCurrentItem(2)
+	view.showEntityAt(2)
+	assert.Contains(t, view.textView.GetText(true), "Raw codes not captured (open the drawing with -raw)")
+
+	// Toggling again returns to the entity fields
+	capture(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone))
+	assert.Contains(t, view.textView.GetText(true), "Circle Entity")
+}
+
+func TestEntityLegend(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	line := &data.LineInfo{Layer: "0"}
+	circle := &data.CircleInfo{Layer: "0", Radius: 1}
+	point := &data.PointInfo{Layer: "0"}
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "0", IsOn: true, Entities: []data.Entity{line, circle, point}}},
+	})
+	view.showLayerDetails(0)
+
+	// Entity items are colored by type; types without a color are left plain
+	lineText, _ := view.entityList.GetItemText(1)
+	circleText, _ := view.entityList.GetItemText(2)
+	pointText, _ := view.entityList.GetItemText(3)
+	assert.True(t, strings.HasPrefix(lineText, "[blue]"), lineText)
+	assert.True(t, strings.HasPrefix(circleText, "[green]"), circleText)
+	assert.Equal(t, "Point (0.0, 0.0)", pointText)
+
+	assert.False(t, view.IsEntityLegendVisible())
+	capture := view.entityList.GetInputCapture()
+	capture(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone))
+	assert.True(t, view.IsEntityLegendVisible())
+	legend := view.legendView.GetText(false)
+	for kind, color := range view.accessibility.EntityTypeColors() {
+		assert.Contains(t, legend, fmt.Sprintf("[%s]■ %s[-]", color, kind))
+	}
+
+	capture(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone))
+	assert.False(t, view.IsEntityLegendVisible())
+}
+
+func TestEntityDeduplication(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	line := &data.LineInfo{StartPoint: data.Point{X: 0, Y: 0}, EndPoint: data.Point{X: 5, Y: 5}, Layer: "0"}
+	swapped := &data.LineInfo{StartPoint: data.Point{X: 5, Y: 5}, EndPoint: data.Point{X: 0, Y: 0}, Layer: "0"}
+	circle := &data.CircleInfo{Layer: "0", Radius: 1}
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "0", IsOn: true, Entities: []data.Entity{line, swapped, circle}}},
+	})
+	view.showLayerDetails(0)
+	assert.Equal(t, 4, view.entityList.GetItemCount())
+
+	capture := view.entityList.GetInputCapture()
+	capture(tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone))
+	assert.True(t, view.IsDeduplicating())
+	assert.Equal(t, 3, view.entityList.GetItemCount(), "Expected the swapped duplicate line to be hidden")
+	assert.Contains(t, view.selectionStatus.GetText(true), "Showing 2 of 3 after dedup")
+
+	// List positions map to the entities that remain
+	view.showEntityAt(2)
+	assert.Contains(t, view.textView.GetText(true), "Circle Entity")
+	view.ToggleEntitySelection(2)
+	assert.True(t, view.GetSelectionState().IsSelected(entityID("0", 2, nil)))
+
+	capture(tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone))
+	assert.False(t, view.IsDeduplicating())
+	assert.Equal(t, 4, view.entityList.GetItemCount())
+	assert.NotContains(t, view.selectionStatus.GetText(true), "after dedup")
+}
+
+func TestAttributeEditor(t *testing.T) {
+	newView := func() (*DXFView, *data.ExtractedData) {
+		view := NewDXFView(SetupTestApp(t))
+		block := &data.BlockInfo{
+			Name:  "ROOM_TAG",
+			Layer: "0",
+			Attributes: []data.AttributeInfo{
+				{Tag: "NUMBER", Value: "101"},
+				{Tag: "NAME", Value: "Office"},
+			},
+		}
+		dxfData := &data.ExtractedData{
+			Layers: []data.LayerInfo{{Name: "0", IsOn: true, Entities: []data.Entity{block, &data.LineInfo{Layer: "0"}}}},
+			// Like the parser, the typed slice holds a copy sharing the attributes
+			Blocks: []data.BlockInfo{*block},
+		}
+		view.Update(dxfData)
+		view.showLayerDetails(0)
+		view.entityList.SetCurrentItem(1)
+		return view, dxfData
+	}
+	pressE := func(view *DXFView) {
+		view.entityList.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone))
+	}
+
+	t.Run("save writes values back and marks unsaved changes", func(t *testing.T) {
+		view, dxfData := newView()
+		pressE(view)
+		require.NotNil(t, view.attributeForm)
+		page, _ := view.pages.GetFrontPage()
+		assert.Equal(t, "attributes", page)
+		assert.Equal(t, 2, view.attributeForm.GetFormItemCount())
+		assert.Equal(t, "NUMBER", view.attributeForm.GetFormItem(0).GetLabel())
+
+		view.attributeForm.GetFormItem(0).(*tview.InputField).SetText("102")
+		view.attributeForm.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p tview.Primitive) {})
+
+		assert.False(t, view.pages.HasPage("attributes"))
+		assert.Equal(t, "102", dxfData.Blocks[0].Attributes[0].Value)
+		assert.Equal(t, "Office", dxfData.Blocks[0].Attributes[1].Value)
+		assert.True(t, view.GetQuitManager().HasUnsavedChanges())
+		assert.Contains(t, view.textView.GetText(true), "NUMBER: 102")
+	})
+
+	t.Run("escape cancels without applying", func(t *testing.T) {
+		view, dxfData := newView()
+		pressE(view)
+		require.NotNil(t, view.attributeForm)
+
+		input := view.attributeForm.GetFormItem(0).(*tview.InputField)
+		input.SetText("999")
+		input.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), func(p tview.Primitive) {})
+
+		assert.False(t, view.pages.HasPage("attributes"))
+		assert.Equal(t, "101", dxfData.Blocks[0].Attributes[0].Value)
+		assert.False(t, view.GetQuitManager().HasUnsavedChanges())
+	})
+
+	t.Run("non-block entities are not editable", func(t *testing.T) {
+		view, _ := newView()
+		view.entityList.SetCurrentItem(2)
+		pressE(view)
+		assert.False(t, view.pages.HasPage("attributes"))
+	})
+
+	t.Run("unchanged values leave the document clean", func(t *testing.T) {
+		view, dxfData := newView()
+		assert.False(t, view.ApplyAttributeEdits(&dxfData.Blocks[0], []string{"101", "Office"}))
+		assert.False(t, view.GetQuitManager().HasUnsavedChanges())
+	})
+}
+
+func TestBlockDefinitionDrillIn(t *testing.T) {
+	newView := func() *DXFView {
+		view := NewDXFView(SetupTestApp(t))
+		door := &data.BlockInfo{Name: "DOOR", Layer: "0"}
+		dxfData := &data.ExtractedData{
+			Layers: []data.LayerInfo{{Name: "0", IsOn: true, Entities: []data.Entity{
+				door,
+				&data.BlockInfo{Name: "WINDOW", Layer: "0"},
+				&data.LineInfo{Layer: "0"},
+			}}},
+			Blocks: []data.BlockInfo{*door},
+			BlockDefinitions: map[string]*data.BlockDefinition{
+				"DOOR": {Name: "DOOR", Entities: []data.Entity{
+					&data.LineInfo{Layer: "0", EndPoint: data.Point{X: 1}},
+					&data.BlockInfo{Name: "HINGE", Layer: "0"},
+				}},
+				"HINGE": {Name: "HINGE", Entities: []data.Entity{
+					&data.CircleInfo{Layer: "0", Radius: 0.25},
+				}},
+			},
+		}
+		view.Update(dxfData)
+		view.showLayerDetails(0)
+		return view
+	}
+	press := func(list *tview.List, key tcell.Key) {
+		list.GetInputCapture()(tcell.NewEventKey(key, 0, tcell.ModNone))
+	}
+
+	t.Run("enter on a block insertion lists its definition", func(t *testing.T) {
+		view := newView()
+		view.entityList.SetCurrentItem(1)
+		press(view.entityList, tcell.KeyEnter)
+
+		page, _ := view.pages.GetFrontPage()
+		assert.Equal(t, "block", page)
+		assert.Equal(t, 3, view.blockList.GetItemCount())
+		assert.Equal(t, "Block: DOOR", view.blockList.GetTitle())
+		mainText, _ := view.blockList.GetItemText(2)
+		assert.Contains(t, mainText, "HINGE")
+	})
+
+	t.Run("nested insertions drill further and escape steps back out", func(t *testing.T) {
+		view := newView()
+		view.entityList.SetCurrentItem(1)
+		press(view.entityList, tcell.KeyEnter)
+
+		view.blockList.SetCurrentItem(2)
+		press(view.blockList, tcell.KeyEnter)
+		assert.Equal(t, "Block: DOOR > HINGE", view.blockList.GetTitle())
+		assert.Equal(t, 2, view.blockList.GetItemCount())
+
+		press(view.blockList, tcell.KeyEsc)
+		assert.Equal(t, "Block: DOOR", view.blockList.GetTitle())
+
+		press(view.blockList, tcell.KeyEsc)
+		page, _ := view.pages.GetFrontPage()
+		assert.Equal(t, "entities", page)
+		assert.False(t, view.pages.HasPage("block"))
+	})
+
+	t.Run("undefined blocks are reported in the status", func(t *testing.T) {
+		view := newView()
+		view.entityList.SetCurrentItem(2)
+		press(view.entityList, tcell.KeyEnter)
+
+		page, _ := view.pages.GetFrontPage()
+		assert.Equal(t, "entities", page)
+		assert.Contains(t, view.statusHandler.GetCurrentMessage(), "Block WINDOW has no definition")
+	})
+
+	t.Run("enter on other entities is left to the list", func(t *testing.T) {
+		view := newView()
+		view.entityList.SetCurrentItem(3)
+		assert.NotNil(t, view.entityList.GetInputCapture()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)))
+	})
+}
+
+func TestShowEntityDetails_Polyline(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	polyline := &data.PolylineInfo{
+		Points:   []data.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 3}, {X: 0, Y: 3}},
+		Layer:    "0",
+		IsClosed: true,
+	}
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "0", IsOn: true, Entities: []data.Entity{polyline}}},
+	})
+	view.showLayerDetails(0)
+
+	view.showEntityAt(1)
+	details := view.textView.GetText(true)
+	assert.Contains(t, details, "Polyline Entity")
+	assert.Contains(t, details, "Length: 10.00")
+	assert.Contains(t, details, "Area: 6.00")
+}
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"wals", "walls", true},
+		{"dor", "doors", true},
+		{"dor", "walls", false},
+		{"sw", "walls", false},
+		{"", "walls", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" in "+tt.name, func(t *testing.T) {
+			_, ok := fuzzyScore(tt.pattern, tt.name)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+
+	prefix, _ := fuzzyScore("wa", "walls")
+	scattered, _ := fuzzyScore("wa", "new area")
+	assert.Greater(t, prefix, scattered, "Expected a prefix run to outscore a scattered match")
+}
+
+func TestFilterLayers_HighlightsMatches(t *testing.T) {
+	view := NewDXFView(tview.NewApplication())
+	view.Update(&data.ExtractedData{Layers: []data.LayerInfo{
+		{Name: "Walls", IsOn: true, Color: 1},
+		{Name: "Doors", IsOn: true, Color: 2},
+	}})
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"ALL", "W[black:yellow]all[-:-]s"},
+		{"~wls", "[black:yellow]W[-:-]a[black:yellow]l[-:-]l[black:yellow]s[-:-]"},
+		{"~wal", "[black:yellow]Wal[-:-]ls"},
+		{"on:true", "Walls"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			view.FilterLayers(tt.query)
+			mainText, _ := view.layers.GetItemText(0)
+			assert.True(t, strings.HasPrefix(mainText, tt.expected+" ("), mainText)
+			assert.Equal(t, "Walls", view.layerNameAt(0))
+		})
+	}
+
+	// Toggling visibility still finds the highlighted layer
+	view.FilterLayers("wall")
+	view.ToggleLayerVisibility(0)
+	assert.False(t, view.data.Layers[0].IsOn)
+}
+
+func TestToggleRelativeCoordinates(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+
+	line := &data.LineInfo{Layer: "0", StartPoint: data.Point{X: 110, Y: 220}, EndPoint: data.Point{X: 150, Y: 220}}
+	circle := &data.CircleInfo{Layer: "0", Center: data.Point{X: 130, Y: 240}, Radius: 10}
+	text := &data.TextInfo{Layer: "Notes", Value: "A", InsertionPoint: data.Point{X: 100, Y: 250}}
+	view.Update(&data.ExtractedData{Layers: []data.LayerInfo{
+		{Name: "0", IsOn: true, Entities: []data.Entity{line, circle}},
+		{Name: "Notes", IsOn: true, Entities: []data.Entity{text}},
+	}})
+	view.showLayerDetails(0)
+	capture := view.entityList.GetInputCapture()
+
+	view.entityList.SetCurrentItem(1)
+	view.showEntityAt(1)
+	assert.Contains(t, view.textView.GetText(true), "Start Point: (110.0, 220.0)")
+
+	// The drawing's lower-left extent is (100, 220), from the text and the line
+	capture(tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone))
+	details := view.textView.GetText(true)
+	assert.Contains(t, details, "Start Point: (10.0, 0.0)")
+	assert.Contains(t, details, "End Point: (50.0, 0.0)")
+	assert.Contains(t, details, "Coordinates relative to (100.0, 220.0)")
+	message, _ := view.GetStatusBar().Message()
+	assert.Equal(t, "Coordinates relative to drawing extents", message)
+
+	// Radii are unaffected
+	view.entityList.SetCurrentItem(2)
+	view.showEntityAt(2)
+	details = view.textView.GetText(true)
+	assert.Contains(t, details, "Center: (30.0, 20.0)")
+	assert.Contains(t, details, "Radius: 10.0")
+	assert.Equal(t, data.Point{X: 130, Y: 240}, circle.Center, "the drawing itself is not changed")
+
+	capture(tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone))
+	details = view.textView.GetText(true)
+	assert.Contains(t, details, "Center: (130.0, 240.0)")
+	assert.NotContains(t, details, "Coordinates relative to")
+	message, _ = view.GetStatusBar().Message()
+	assert.Equal(t, "Absolute coordinates", message)
+}
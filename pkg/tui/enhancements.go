@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"math"
+
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -61,16 +63,44 @@ Navigation:
   ↑/↓     - Navigate lists
   Tab     - Switch between panes
   Enter   - Select item
+  Click   - Preview layer / show entity details
+  Double-click - Open layer entities
   
 Search and Filter:
   Ctrl+F  - Focus search
+  Ctrl+O  - Open another drawing
+  Ctrl+L  - Jump to a recently viewed layer
   /       - Quick search
-  Ctrl+R  - Refresh view
+  Ctrl+R  - Reload the drawing from disk
   
 Selection and Copy:
   Space   - Toggle selection
   Ctrl+C  - Copy selected items
-  Ctrl+A  - Select all
+  Ctrl+Shift+E - Export selected items to CSV, JSON and DXF files
+  Shift+C - Copy all entities on layer
+  Shift+S - Copy layer summary
+  Shift+G - Copy all entities grouped by layer
+  y       - Copy layer name
+  Shift+Y - Copy drawing file path
+  Shift+W - Show parser warnings
+  Shift+F - Thaw all frozen layers
+  Shift+H - Hide all layers
+  Ctrl+A  - Select all visible entities
+  Ctrl+D  - Clear selection
+  Shift+R - Select entities within a coordinate range
+  r       - Toggle raw DXF codes in entity details
+  c       - Toggle coordinates relative to the drawing extents
+  w       - Toggle wrap-around list navigation
+  g       - Jump to a layer by its number
+  l       - Toggle entity type legend
+  d       - Toggle hiding duplicate entities
+  e       - Edit the selected block's attributes
+  Enter   - Open the selected block's definition
+  h       - Toggle the header row in copied CSV
+  ;       - Switch copied CSV between comma and semicolon delimiters
+  Shift+J - Switch copied JSON between indented and compact
+  Ctrl+Z  - Undo visibility or selection change
+  Ctrl+Y  - Redo visibility or selection change
   
 Help and Exit:
   F1      - Toggle this help
@@ -82,11 +112,13 @@ View Controls:
   Ctrl+1  - Focus layers
   Ctrl+2  - Focus entities
   Ctrl+3  - Focus details
+  Ctrl+P  - Toggle geometry preview of the current layer (highlights the selected entity)
   
 Accessibility:
   Ctrl++  - Increase text size
   Ctrl+-  - Decrease text size
-  Ctrl+0  - Reset text size`
+  Ctrl+0  - Reset text size
+  Ctrl+B  - Toggle colorblind-friendly palette`
 }
 
 // StylingManager manages visual styling and colors
@@ -159,6 +191,11 @@ func NewShortcutManager(view *DXFView) *ShortcutManager {
 
 // HandleKeyPress handles keyboard shortcuts and returns action and handled status
 func (sm *ShortcutManager) HandleKeyPress(key tcell.Key, modifiers tcell.ModMask) (string, bool) {
+	// Ctrl+Shift+E, where the terminal reports Shift with Ctrl
+	if key == tcell.KeyCtrlE && modifiers == tcell.ModCtrl|tcell.ModShift {
+		return "export_selection", true
+	}
+
 	// Handle Ctrl+key combinations
 	if modifiers == tcell.ModCtrl {
 		switch key {
@@ -170,6 +207,22 @@ func (sm *ShortcutManager) HandleKeyPress(key tcell.Key, modifiers tcell.ModMask
 			return "refresh", true
 		case tcell.KeyCtrlF:
 			return "focus_search", true
+		case tcell.KeyCtrlA:
+			return "select_all", true
+		case tcell.KeyCtrlD:
+			return "select_none", true
+		case tcell.KeyCtrlZ:
+			return "undo", true
+		case tcell.KeyCtrlY:
+			return "redo", true
+		case tcell.KeyCtrlB:
+			return "toggle_palette", true
+		case tcell.KeyCtrlP:
+			return "toggle_preview", true
+		case tcell.KeyCtrlO:
+			return "open_file", true
+		case tcell.KeyCtrlL:
+			return "recent_layers", true
 		}
 	}
 
@@ -182,11 +235,28 @@ func (sm *ShortcutManager) HandleKeyPress(key tcell.Key, modifiers tcell.ModMask
 	return "", false
 }
 
+// HandleEvent handles a key event, including Ctrl+rune shortcuts that
+// HandleKeyPress cannot see, and returns action and handled status
+func (sm *ShortcutManager) HandleEvent(event *tcell.EventKey) (string, bool) {
+	if event.Key() == tcell.KeyRune && event.Modifiers()&tcell.ModCtrl != 0 {
+		switch event.Rune() {
+		case '+', '=':
+			return "text_larger", true
+		case '-':
+			return "text_smaller", true
+		case '0':
+			return "text_reset", true
+		}
+	}
+	return sm.HandleKeyPress(event.Key(), event.Modifiers())
+}
+
 // AccessibilityManager manages accessibility features
 type AccessibilityManager struct {
 	view            *DXFView
 	enabledFeatures map[string]bool
 	featureValues   map[string]interface{}
+	paletteActive   bool // Colors are translated through the colorblind palette
 }
 
 // NewAccessibilityManager creates a new accessibility manager
@@ -210,7 +280,7 @@ func (am *AccessibilityManager) initializeFeatures() {
 		"screen_reader":       "aria-labels",
 		"focus_indicators":    "prominent",
 		"colorblind_friendly": "viridis",
-		"large_text":          1.5,
+		"large_text":          DefaultTextScale,
 	}
 
 	for feature, value := range features {
@@ -219,6 +289,125 @@ func (am *AccessibilityManager) initializeFeatures() {
 	}
 }
 
+// entityLegendOrder lists the entity types shown in the entity legend
+var entityLegendOrder = []string{"Line", "Circle", "Text", "Block", "Polyline"}
+
+// defaultEntityTypeColors maps entity types to the tview colors used in the entity list
+var defaultEntityTypeColors = map[string]string{
+	"Line":     "blue",
+	"Circle":   "green",
+	"Text":     "yellow",
+	"Block":    "magenta",
+	"Polyline": "cyan",
+}
+
+// aciColorNames maps the standard AutoCAD Color Index values to tview colors
+var aciColorNames = map[int]string{
+	1: "red",
+	2: "yellow",
+	3: "green",
+	4: "cyan",
+	5: "blue",
+	6: "magenta",
+	7: "white",
+}
+
+// viridisPalette maps default colors to viridis colors that stay distinguishable
+// with common forms of color blindness. Neutral colors are left unchanged.
+var viridisPalette = map[string]string{
+	"red":     "#440154",
+	"magenta": "#443983",
+	"blue":    "#31688e",
+	"cyan":    "#21918c",
+	"green":   "#35b779",
+	"yellow":  "#fde725",
+}
+
+// EntityTypeColors returns the tview color used for each entity type in the entity list
+func (am *AccessibilityManager) EntityTypeColors() map[string]string {
+	colors := make(map[string]string, len(defaultEntityTypeColors))
+	for kind, color := range defaultEntityTypeColors {
+		colors[kind] = am.TranslateColor(color)
+	}
+	return colors
+}
+
+// LayerColor returns the tview color for an ACI layer color, or "" when the index has no standard color
+func (am *AccessibilityManager) LayerColor(aci int) string {
+	color, ok := aciColorNames[aci]
+	if !ok {
+		return ""
+	}
+	return am.TranslateColor(color)
+}
+
+// TranslateColor maps a default color through the colorblind palette when it is active
+func (am *AccessibilityManager) TranslateColor(color string) string {
+	if !am.paletteActive {
+		return color
+	}
+	if mapped, ok := viridisPalette[color]; ok {
+		return mapped
+	}
+	return color
+}
+
+// ApplyPalette turns on the colorblind-friendly palette and translates the
+// styling manager's applied colors through it
+func (am *AccessibilityManager) ApplyPalette() {
+	am.paletteActive = true
+	if am.view == nil || am.view.styling == nil {
+		return
+	}
+	for component, colors := range am.view.styling.appliedColors {
+		translated := make([]string, len(colors))
+		for i, color := range colors {
+			translated[i] = am.TranslateColor(color)
+		}
+		am.view.styling.appliedColors[component] = translated
+	}
+}
+
+// RestoreDefaultPalette turns off the colorblind-friendly palette and restores the default colors
+func (am *AccessibilityManager) RestoreDefaultPalette() {
+	am.paletteActive = false
+	if am.view != nil && am.view.styling != nil {
+		am.view.styling.ApplyModernStyling()
+	}
+}
+
+// IsPaletteActive returns whether the colorblind-friendly palette is in use
+func (am *AccessibilityManager) IsPaletteActive() bool {
+	return am.paletteActive
+}
+
+// Text scale limits, the default the text size reset returns to and the step used by the text size shortcuts
+const (
+	MinTextScale     = 1.0
+	MaxTextScale     = 2.0
+	DefaultTextScale = 1.5
+	TextScaleStep    = 0.25
+)
+
+// SetTextScale sets the large_text scale, clamped to [MinTextScale, MaxTextScale],
+// and re-applies the view's layout for it
+func (am *AccessibilityManager) SetTextScale(scale float64) {
+	scale = math.Max(MinTextScale, math.Min(MaxTextScale, scale))
+	am.featureValues["large_text"] = scale
+	if am.view != nil && am.view.layout != nil {
+		am.view.layout.SetTextScale(scale)
+		am.view.layout.ApplyTextScale()
+	}
+}
+
+// TextScale returns the current large_text scale
+func (am *AccessibilityManager) TextScale() float64 {
+	if scale, ok := am.featureValues["large_text"].(float64); ok {
+		return scale
+	}
+	return MinTextScale
+}
+
 // IsFeatureEnabled returns whether an accessibility feature is enabled
 func (am *AccessibilityManager) IsFeatureEnabled(feature string) bool {
 	return am.enabledFeatures[feature]
@@ -249,12 +438,18 @@ func (qm *QuitManager) SetUnsavedChanges(hasChanges bool) {
 	qm.hasUnsavedChanges = hasChanges
 }
 
+// HasUnsavedChanges returns whether there are unsaved changes
+func (qm *QuitManager) HasUnsavedChanges() bool {
+	return qm.hasUnsavedChanges
+}
+
 // AttemptQuit attempts to quit the application and returns whether a prompt was shown
 func (qm *QuitManager) AttemptQuit(userChoice string) bool {
 	// Force quit bypasses all prompts
 	if userChoice == "force" {
 		qm.shouldExit = true
 		qm.promptShown = false
+		qm.saveSession()
 		return false
 	}
 
@@ -262,6 +457,7 @@ func (qm *QuitManager) AttemptQuit(userChoice string) bool {
 	if !qm.hasUnsavedChanges {
 		qm.shouldExit = true
 		qm.promptShown = false
+		qm.saveSession()
 		return false
 	}
 
@@ -270,6 +466,7 @@ func (qm *QuitManager) AttemptQuit(userChoice string) bool {
 	switch userChoice {
 	case "yes":
 		qm.shouldExit = true
+		qm.saveSession()
 	case "no":
 		qm.shouldExit = false
 	default:
@@ -284,6 +481,17 @@ func (qm *QuitManager) ShouldExit() bool {
 	return qm.shouldExit
 }
 
+// saveSession saves the view state for the next launch. The application is exiting,
+// so a failure is only logged.
+func (qm *QuitManager) saveSession() {
+	if qm.view == nil {
+		return
+	}
+	if err := qm.view.SaveSession(); err != nil {
+		qm.view.errorLogger.LogError(NewSystemError("failed to save session", err), LogLevelWarn)
+	}
+}
+
 // LayoutManager manages responsive UI layout
 type LayoutManager struct {
 	view              *DXFView
@@ -291,6 +499,7 @@ type LayoutManager struct {
 	terminalHeight    int
 	currentLayout     string
 	visibleComponents []string
+	textScale         float64
 }
 
 // NewLayoutManager creates a new layout manager
@@ -299,7 +508,53 @@ func NewLayoutManager(view *DXFView) *LayoutManager {
 		view:              view,
 		currentLayout:     "four_pane",
 		visibleComponents: []string{},
+		textScale:         MinTextScale,
+	}
+}
+
+// SetTextScale sets the text scale the layout reserves space for
+func (lm *LayoutManager) SetTextScale(scale float64) {
+	lm.textScale = scale
+}
+
+// IsDoubleSpaced returns whether list items are followed by a blank line.
+// Terminals cannot change font size, so large text is shown as extra spacing.
+func (lm *LayoutManager) IsDoubleSpaced() bool {
+	return lm.textScale >= 1.5
+}
+
+// LayerItemRows returns how many rows each layers list item takes: two when
+// double spacing shows its blank secondary line, one otherwise
+func (lm *LayoutManager) LayerItemRows() int {
+	if lm.IsDoubleSpaced() {
+		return 2
+	}
+	return 1
+}
+
+// BorderPadding returns the padding inside bordered components: one cell
+// for every scale step above 1.5
+func (lm *LayoutManager) BorderPadding() int {
+	if lm.textScale <= 1.5 {
+		return 0
 	}
+	return int(math.Round((lm.textScale - 1.5) / TextScaleStep))
+}
+
+// ApplyTextScale applies the item spacing and border padding for the text scale to the view
+func (lm *LayoutManager) ApplyTextScale() {
+	if lm.view == nil {
+		return
+	}
+
+	// The layers list has no secondary text, so showing it leaves a blank line after each item
+	lm.view.layers.ShowSecondaryText(lm.IsDoubleSpaced())
+
+	pad := lm.BorderPadding()
+	lm.view.layers.SetBorderPadding(pad, pad, pad, pad)
+	lm.view.entityList.SetBorderPadding(pad, pad, pad, pad)
+	lm.view.warningsList.SetBorderPadding(pad, pad, pad, pad)
+	lm.view.textView.SetBorderPadding(pad, pad, pad, pad)
 }
 
 // SetTerminalSize sets the terminal dimensions
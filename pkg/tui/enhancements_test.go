@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/data"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -172,6 +173,13 @@ func TestKeyboardShortcuts(t *testing.T) {
 			expectedAction:  "refresh",
 			expectedHandled: true,
 		},
+		{
+			name:            "Ctrl+Shift+E exports the selection",
+			key:             tcell.KeyCtrlE,
+			modifiers:       tcell.ModCtrl | tcell.ModShift,
+			expectedAction:  "export_selection",
+			expectedHandled: true,
+		},
 		{
 			name:            "Ctrl+F focuses search",
 			key:             tcell.KeyCtrlF,
@@ -179,6 +187,41 @@ func TestKeyboardShortcuts(t *testing.T) {
 			expectedAction:  "focus_search",
 			expectedHandled: true,
 		},
+		{
+			name:            "Ctrl+A selects all visible entities",
+			key:             tcell.KeyCtrlA,
+			modifiers:       tcell.ModCtrl,
+			expectedAction:  "select_all",
+			expectedHandled: true,
+		},
+		{
+			name:            "Ctrl+D clears selection",
+			key:             tcell.KeyCtrlD,
+			modifiers:       tcell.ModCtrl,
+			expectedAction:  "select_none",
+			expectedHandled: true,
+		},
+		{
+			name:            "Ctrl+Z undoes last change",
+			key:             tcell.KeyCtrlZ,
+			modifiers:       tcell.ModCtrl,
+			expectedAction:  "undo",
+			expectedHandled: true,
+		},
+		{
+			name:            "Ctrl+Y redoes last undone change",
+			key:             tcell.KeyCtrlY,
+			modifiers:       tcell.ModCtrl,
+			expectedAction:  "redo",
+			expectedHandled: true,
+		},
+		{
+			name:            "Ctrl+B toggles colorblind palette",
+			key:             tcell.KeyCtrlB,
+			modifiers:       tcell.ModCtrl,
+			expectedAction:  "toggle_palette",
+			expectedHandled: true,
+		},
 		{
 			name:            "Escape clears selection and errors",
 			key:             tcell.KeyEscape,
@@ -398,3 +441,92 @@ func TestUIResponsiveness(t *testing.T) {
 		})
 	}
 }
+
+// TestColorblindPalette tests toggling the colorblind-friendly palette
+func TestColorblindPalette(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "Walls", Color: 1, IsOn: true}},
+	})
+
+	assert.Equal(t, []string{"blue", "green", "yellow"}, view.styling.GetAppliedColors("layerList"))
+	layerText, _ := view.layers.GetItemText(0)
+	assert.Contains(t, layerText, "[red]1[-]")
+
+	capture := view.layers.GetInputCapture()
+	capture(tcell.NewEventKey(tcell.KeyCtrlB, 0, tcell.ModCtrl))
+	assert.True(t, view.accessibility.IsPaletteActive())
+	assert.Equal(t, []string{"#31688e", "#35b779", "#fde725"}, view.styling.GetAppliedColors("layerList"))
+	assert.Equal(t, []string{"white", "gray"}, view.styling.GetAppliedColors("detailView"), "Neutral colors should be unchanged")
+	layerText, _ = view.layers.GetItemText(0)
+	assert.Contains(t, layerText, "[#440154]1[-]")
+	assert.Equal(t, "#21918c", view.accessibility.EntityTypeColors()["Polyline"])
+
+	capture(tcell.NewEventKey(tcell.KeyCtrlB, 0, tcell.ModCtrl))
+	assert.False(t, view.accessibility.IsPaletteActive())
+	assert.Equal(t, []string{"blue", "green", "yellow"}, view.styling.GetAppliedColors("layerList"))
+	layerText, _ = view.layers.GetItemText(0)
+	assert.Contains(t, layerText, "[red]1[-]")
+}
+
+// TestTextScale tests text size scaling through layout spacing and padding
+func TestTextScale(t *testing.T) {
+	tests := []struct {
+		name            string
+		scale           float64
+		expectedScale   float64
+		expectedSpacing bool
+		expectedPadding int
+	}{
+		{name: "Normal size", scale: 1.0, expectedScale: 1.0, expectedSpacing: false, expectedPadding: 0},
+		{name: "Large text double-spaces items", scale: 1.5, expectedScale: 1.5, expectedSpacing: true, expectedPadding: 0},
+		{name: "Larger text widens borders", scale: 2.0, expectedScale: 2.0, expectedSpacing: true, expectedPadding: 2},
+		{name: "Scale clamped to maximum", scale: 3.0, expectedScale: 2.0, expectedSpacing: true, expectedPadding: 2},
+		{name: "Scale clamped to minimum", scale: 0.5, expectedScale: 1.0, expectedSpacing: false, expectedPadding: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := SetupTestApp(t)
+			view := NewDXFView(app)
+
+			view.accessibility.SetTextScale(tt.scale)
+			assert.Equal(t, tt.expectedScale, view.accessibility.TextScale())
+			assert.Equal(t, tt.expectedScale, view.accessibility.GetFeatureValue("large_text"))
+			assert.Equal(t, tt.expectedSpacing, view.layout.IsDoubleSpaced())
+
+			assert.Equal(t, tt.expectedPadding, view.layout.BorderPadding())
+			view.layers.SetRect(0, 0, 40, 20)
+			x, y, width, height := view.layers.GetInnerRect()
+			// The border takes one cell on each side, padding is inside it
+			assert.Equal(t, []int{1 + tt.expectedPadding, 1 + tt.expectedPadding, 38 - 2*tt.expectedPadding, 18 - 2*tt.expectedPadding}, []int{x, y, width, height})
+		})
+	}
+}
+
+// TestTextScaleShortcuts tests Ctrl++, Ctrl+- and Ctrl+0 on the layers list
+func TestTextScaleShortcuts(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(&data.ExtractedData{Layers: []data.LayerInfo{{Name: "Walls", IsOn: true}}})
+	capture := view.layers.GetInputCapture()
+
+	assert.Equal(t, 1.5, view.accessibility.TextScale())
+
+	capture(tcell.NewEventKey(tcell.KeyRune, '+', tcell.ModCtrl))
+	assert.Equal(t, 1.75, view.accessibility.TextScale())
+	view.entityList.SetRect(0, 0, 40, 20)
+	_, y, _, _ := view.entityList.GetInnerRect()
+	assert.Equal(t, 2, y, "Expected one cell of padding inside the border")
+
+	capture(tcell.NewEventKey(tcell.KeyRune, '-', tcell.ModCtrl))
+	capture(tcell.NewEventKey(tcell.KeyRune, '-', tcell.ModCtrl))
+	assert.Equal(t, 1.25, view.accessibility.TextScale())
+	assert.False(t, view.layout.IsDoubleSpaced())
+
+	capture(tcell.NewEventKey(tcell.KeyRune, '+', tcell.ModCtrl))
+	capture(tcell.NewEventKey(tcell.KeyRune, '0', tcell.ModCtrl))
+	assert.Equal(t, DefaultTextScale, view.accessibility.TextScale(), "Ctrl+0 should restore the default size")
+	assert.Equal(t, "", view.searchInput.GetText(), "Ctrl+digit should not be redirected to search")
+}
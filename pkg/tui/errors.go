@@ -1,9 +1,14 @@
 package tui
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"sync"
 	"time"
+
+	"github.com/rivo/tview"
 )
 
 // ErrorType represents different categories of errors
@@ -147,6 +152,12 @@ const (
 	ErrorDisplayTemporary
 )
 
+// Overlay pages errors are shown on
+const (
+	errorModalPage = "error"
+	errorToastPage = "error-toast"
+)
+
 // ErrorHandler manages error display in the TUI
 type ErrorHandler struct {
 	view          *DXFView
@@ -154,6 +165,9 @@ type ErrorHandler struct {
 	displayType   ErrorDisplayType
 	isVisible     bool
 	displayedText string
+	previousFocus tview.Primitive // Focus to restore when the error modal is dismissed
+	generation    int             // Incremented by each display so stale timers leave newer errors alone
+	toastCount    int             // Incremented by each temporary error so stale timers leave newer toasts alone
 }
 
 // NewErrorHandler creates a new error handler
@@ -169,8 +183,124 @@ func (eh *ErrorHandler) DisplayError(err AppError, displayType ErrorDisplayType)
 	eh.displayType = displayType
 	eh.isVisible = true
 	eh.displayedText = err.UserMessage()
+	eh.generation++
+
+	if eh.view == nil || eh.view.overlays == nil {
+		return
+	}
+	switch displayType {
+	case ErrorDisplayModal:
+		eh.showModal(err)
+	case ErrorDisplayStatusBar:
+		// User errors are mistakes the user can fix, shown as warnings
+		level := StatusError
+		if err.Type() == ErrorTypeUser {
+			level = StatusWarn
+		}
+		eh.view.statusBar.SetStatus(err.UserMessage(), level)
+	case ErrorDisplayTemporary:
+		eh.showTemporary(err)
+	}
+}
+
+// showModal shows the error and its recovery suggestion in a dialog whose OK button
+// dismisses it and gives focus back to where it was
+func (eh *ErrorHandler) showModal(err AppError) {
+	text := err.UserMessage()
+	if suggestion := err.RecoverySuggestion(); suggestion != "" {
+		text += "\n\n" + suggestion
+	}
+	modal := tview.NewModal().
+		SetText(tview.Escape(text)).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			eh.Dismiss()
+		})
+
+	// A modal replacing another keeps the focus from before the first
+	if !eh.view.overlays.HasPage(errorModalPage) {
+		eh.previousFocus = eh.view.app.GetFocus()
+	}
+	eh.view.overlays.AddPage(errorModalPage, modal, true, true)
+	eh.view.app.SetFocus(modal)
+}
+
+// showTemporary shows the error in a box above the status bar, without taking focus,
+// and removes it after DefaultStatusTimeout
+func (eh *ErrorHandler) showTemporary(err AppError) {
+	message := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(tview.Escape(err.UserMessage()))
+	message.SetBorder(true)
+
+	// Center the box horizontally, leaving the rest of the screen visible
+	row := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(message, 0, 2, false).
+		AddItem(nil, 0, 1, false)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(row, 3, 0, false).
+		AddItem(nil, 1, 0, false)
+
+	eh.keepFocus(func() {
+		eh.view.overlays.AddPage(errorToastPage, layout, true, true)
+	})
+
+	eh.toastCount++
+	toast, generation := eh.toastCount, eh.generation
+	time.AfterFunc(DefaultStatusTimeout, func() {
+		eh.view.app.QueueUpdateDraw(func() {
+			eh.expireToast(toast, generation)
+		})
+	})
+}
+
+// expireToast removes the temporary error shown as toast unless a newer one replaced it.
+// The displayed error is only cleared when nothing else was displayed since, as a status
+// bar or modal error shown meanwhile is still current.
+func (eh *ErrorHandler) expireToast(toast, generation int) {
+	if eh.toastCount != toast {
+		return
+	}
+	if eh.generation == generation {
+		eh.isVisible = false
+		eh.displayedText = ""
+	}
+	eh.keepFocus(func() {
+		eh.view.overlays.RemovePage(errorToastPage)
+	})
+}
+
+// keepFocus runs change, which adds or removes overlay pages, and puts the focus back
+// where it was, since changing pages moves it
+func (eh *ErrorHandler) keepFocus(change func()) {
+	focus := eh.view.app.GetFocus()
+	change()
+	if focus != nil {
+		eh.view.app.SetFocus(focus)
+	}
+}
+
+// Dismiss hides the displayed error, restoring the focus an error modal took
+func (eh *ErrorHandler) Dismiss() {
+	eh.isVisible = false
+	eh.displayedText = ""
+	eh.generation++
+	if eh.view == nil || eh.view.overlays == nil {
+		return
+	}
 
-	// For now, just set the displayed text - actual TUI integration would go here
+	focus := eh.view.app.GetFocus()
+	if eh.view.overlays.HasPage(errorModalPage) {
+		focus = eh.previousFocus
+	}
+	eh.view.overlays.RemovePage(errorModalPage).RemovePage(errorToastPage)
+	eh.previousFocus = nil
+	if focus != nil {
+		eh.view.app.SetFocus(focus)
+	}
 }
 
 // HandleError handles an application error
@@ -230,9 +360,29 @@ type LogEntry struct {
 	Error     AppError
 }
 
-// ErrorLogger handles error logging
+// LogFormat is how entries are written to the log file
+type LogFormat int
+
+const (
+	LogFormatText LogFormat = iota // Timestamped lines
+	LogFormatJSON                  // One JSON object per line
+)
+
+// jsonLogEntry is the JSON form of a log file entry
+type jsonLogEntry struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+}
+
+// ErrorLogger handles error logging. It is safe for concurrent use.
 type ErrorLogger struct {
-	entries []LogEntry
+	mu       sync.Mutex
+	entries  []LogEntry
+	minLevel LogLevel
+	format   LogFormat
+	file     *os.File // Optional log file each entry is appended to
 }
 
 // NewErrorLogger creates a new error logger
@@ -242,8 +392,42 @@ func NewErrorLogger() *ErrorLogger {
 	}
 }
 
+// NewFileErrorLogger creates an error logger that also appends each entry to the file at
+// path as a timestamped line, creating the file if needed
+func NewFileErrorLogger(path string) (*ErrorLogger, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	logger := NewErrorLogger()
+	logger.file = file
+	return logger, nil
+}
+
+// SetMinLevel suppresses entries below level
+func (el *ErrorLogger) SetMinLevel(level LogLevel) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	el.minLevel = level
+}
+
+// SetFormat sets how entries are written to the log file
+func (el *ErrorLogger) SetFormat(format LogFormat) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	el.format = format
+}
+
 // LogError logs an error with the specified level
 func (el *ErrorLogger) LogError(err AppError, level LogLevel) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if level < el.minLevel {
+		return
+	}
+
 	entry := LogEntry{
 		Timestamp: time.Now(),
 		Level:     level,
@@ -255,11 +439,57 @@ func (el *ErrorLogger) LogError(err AppError, level LogLevel) {
 
 	// Also log to standard logger
 	log.Printf("[%s] %s: %s", level.String(), err.Type().String(), err.Error())
+
+	if el.file != nil {
+		if err := el.writeEntry(entry); err != nil {
+			log.Printf("failed to write log file: %v", err)
+		}
+	}
+}
+
+// writeEntry appends an entry to the log file, syncing it so a crash keeps it
+func (el *ErrorLogger) writeEntry(entry LogEntry) error {
+	var line string
+	switch el.format {
+	case LogFormatJSON:
+		content, err := json.Marshal(jsonLogEntry{
+			Timestamp: entry.Timestamp.Format(time.RFC3339Nano),
+			Level:     entry.Level.String(),
+			Type:      entry.Error.Type().String(),
+			Message:   entry.Message,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to format log entry as JSON: %w", err)
+		}
+		line = string(content) + "\n"
+	default:
+		line = fmt.Sprintf("%s [%s] %s: %s\n", entry.Timestamp.Format(time.RFC3339),
+			entry.Level.String(), entry.Error.Type().String(), entry.Message)
+	}
+	if _, err := el.file.WriteString(line); err != nil {
+		return err
+	}
+	return el.file.Sync()
+}
+
+// Close closes the log file, if there is one
+func (el *ErrorLogger) Close() error {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if el.file == nil {
+		return nil
+	}
+	err := el.file.Close()
+	el.file = nil
+	return err
 }
 
 // GetLogEntries returns all log entries
 func (el *ErrorLogger) GetLogEntries() []LogEntry {
-	return el.entries
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	return append([]LogEntry(nil), el.entries...)
 }
 
 // RecoveryResult represents the result of an error recovery attempt
@@ -1,10 +1,19 @@
 package tui
 
 import (
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestErrorCategories tests different error categories
@@ -148,6 +157,82 @@ func TestErrorDisplayInTUI(t *testing.T) {
 	}
 }
 
+// TestErrorDisplay_Overlays tests that errors are drawn on the view's overlay pages
+func TestErrorDisplay_Overlays(t *testing.T) {
+	t.Run("modal shows the suggestion and OK restores focus", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestData())
+		app.SetFocus(view.layers)
+
+		err := NewSystemError("disk full", errors.New("no space left on device"))
+		view.errorHandler.DisplayError(err, ErrorDisplayModal)
+
+		page, _ := view.overlays.GetFrontPage()
+		assert.Equal(t, errorModalPage, page)
+		button, ok := app.GetFocus().(*tview.Button)
+		require.True(t, ok, "Expected the OK button to have focus")
+		assert.Equal(t, "OK", button.GetLabel())
+		layersPage, _ := view.pages.GetFrontPage()
+		assert.Equal(t, "layers", layersPage, "Expected the view's pages to be left alone")
+
+		button.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p tview.Primitive) {})
+		page, _ = view.overlays.GetFrontPage()
+		assert.Equal(t, "main", page)
+		assert.Equal(t, view.layers, app.GetFocus())
+		assert.False(t, view.errorHandler.IsErrorVisible())
+	})
+
+	t.Run("temporary message does not take focus", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestData())
+		app.SetFocus(view.layers)
+
+		view.errorHandler.DisplayError(NewNetworkError("timeout", "connection timed out"), ErrorDisplayTemporary)
+		page, _ := view.overlays.GetFrontPage()
+		assert.Equal(t, errorToastPage, page)
+		assert.Equal(t, view.layers, app.GetFocus())
+
+		view.ClearError()
+		page, _ = view.overlays.GetFrontPage()
+		assert.Equal(t, "main", page)
+		assert.Equal(t, view.layers, app.GetFocus())
+	})
+
+	t.Run("temporary message expires after a newer status bar error", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestData())
+
+		view.errorHandler.HandleError(NewNetworkError("timeout", "connection timed out"))
+		toast, generation := view.errorHandler.toastCount, view.errorHandler.generation
+		view.ShowWarning("layer not found")
+
+		view.errorHandler.expireToast(toast, generation)
+		assert.False(t, view.overlays.HasPage(errorToastPage))
+		assert.True(t, view.errorHandler.IsErrorVisible(), "the warning shown since is still current")
+
+		// A newer toast outlives the timer of the one it replaced
+		view.errorHandler.HandleError(NewNetworkError("timeout", "first"))
+		toast, generation = view.errorHandler.toastCount, view.errorHandler.generation
+		view.errorHandler.HandleError(NewNetworkError("timeout", "second"))
+		view.errorHandler.expireToast(toast, generation)
+		assert.True(t, view.overlays.HasPage(errorToastPage))
+	})
+
+	t.Run("status bar errors use the status bar", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+
+		view.errorHandler.DisplayError(NewUserError("minor issue", "layer not found"), ErrorDisplayStatusBar)
+		page, _ := view.overlays.GetFrontPage()
+		assert.Equal(t, "main", page)
+		message, _ := view.GetStatusBar().Message()
+		assert.Equal(t, "layer not found", message)
+	})
+}
+
 // TestErrorLogging tests error logging functionality
 func TestErrorLogging(t *testing.T) {
 	tests := []struct {
@@ -198,6 +283,107 @@ func TestErrorLogging(t *testing.T) {
 	}
 }
 
+// TestFileErrorLogger tests that logged errors are appended to the log file
+func TestFileErrorLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	require.NoError(t, os.WriteFile(path, []byte("earlier run\n"), 0644))
+
+	logger, err := NewFileErrorLogger(path)
+	require.NoError(t, err)
+	logger.SetMinLevel(LogLevelWarn)
+
+	logger.LogError(NewUserError("just info", "info"), LogLevelInfo)
+	logger.LogError(NewUserError("bad layer", "Layer not found"), LogLevelWarn)
+	logger.LogError(NewSystemError("disk full", errors.New("no space")), LogLevelError)
+	require.NoError(t, logger.Close())
+
+	assert.Len(t, logger.GetLogEntries(), 2, "Expected entries below the minimum level to be suppressed")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "earlier run", lines[0], "Expected the file to be appended to")
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\S+ \[WARN\] User: bad layer: Layer not found$`, lines[1])
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\S+ \[ERROR\] System: disk full$`, lines[2])
+
+	_, err = NewFileErrorLogger(filepath.Join(t.TempDir(), "missing", "errors.log"))
+	assert.ErrorContains(t, err, "failed to open log file")
+}
+
+// TestFileErrorLogger_JSON tests that JSON log lines parse back into their fields
+func TestFileErrorLogger_JSON(t *testing.T) {
+	tests := []struct {
+		err          AppError
+		level        LogLevel
+		expectedType string
+	}{
+		{NewUserError("bad layer", "Layer not found"), LogLevelWarn, "User"},
+		{NewSystemError("disk full", errors.New("no space")), LogLevelError, "System"},
+		{NewNetworkError("timeout", "connection timed out"), LogLevelError, "Network"},
+		{NewConversionError("convert failed", "invalid DWG"), LogLevelInfo, "Conversion"},
+	}
+
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	logger, err := NewFileErrorLogger(path)
+	require.NoError(t, err)
+	logger.SetFormat(LogFormatJSON)
+	for _, tt := range tests {
+		logger.LogError(tt.err, tt.level)
+	}
+	require.NoError(t, logger.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, len(tests))
+
+	for i, tt := range tests {
+		t.Run(tt.expectedType, func(t *testing.T) {
+			var entry map[string]string
+			require.NoError(t, json.Unmarshal([]byte(lines[i]), &entry))
+			assert.Equal(t, tt.level.String(), entry["level"])
+			assert.Equal(t, tt.expectedType, entry["type"])
+			assert.Equal(t, tt.err.Error(), entry["message"])
+			_, err := time.Parse(time.RFC3339Nano, entry["timestamp"])
+			assert.NoError(t, err)
+			assert.Len(t, entry, 4)
+		})
+	}
+
+	// The in-memory entries don't depend on the format
+	entries := logger.GetLogEntries()
+	require.Len(t, entries, len(tests))
+	assert.Equal(t, tests[0].err, entries[0].Error)
+}
+
+// TestFileErrorLogger_Concurrent tests that concurrent entries are written whole
+func TestFileErrorLogger_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, err := NewFileErrorLogger(path)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.LogError(NewUserError("concurrent entry", "entry"), LogLevelError)
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, logger.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 20)
+	for _, line := range lines {
+		assert.True(t, strings.HasSuffix(line, "[ERROR] User: concurrent entry: entry"), line)
+	}
+	assert.Len(t, logger.GetLogEntries(), 20)
+}
+
 // TestErrorHandlerIntegration tests integration between error handler and TUI
 func TestErrorHandlerIntegration(t *testing.T) {
 	tests := []struct {
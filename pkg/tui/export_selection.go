@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/rivo/tview"
+)
+
+// exportSelectionPage is the page the export selection form is shown on
+const exportSelectionPage = "export-selection"
+
+// exportFileNames maps the formats ExportSelection writes to the names of their files
+var exportFileNames = map[string]string{
+	"text":     "selection.txt",
+	"csv":      "selection.csv",
+	"json":     "selection.json",
+	"dxf":      "selection.dxf",
+	"schedule": "selection-schedule.csv",
+}
+
+// defaultExportFormats are the formats the export selection form starts out with
+const defaultExportFormats = "csv, json, dxf"
+
+// ExportSelection writes the selected entities of all layers to dir, creating it if
+// needed, with one file per format named after it, such as selection.csv. It returns the
+// paths of the files written. A format that fails does not stop the others; the error
+// then names the formats that failed.
+func (ch *ClipboardHandler) ExportSelection(dir string, formats []string) ([]string, error) {
+	if ch.view.data == nil {
+		return nil, fmt.Errorf("no data available")
+	}
+	entities := ch.view.selectedEntities()
+	if len(entities) == 0 {
+		return nil, fmt.Errorf("no entities selected")
+	}
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("no export formats given")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	var paths, failed []string
+	var errs []error
+	for _, format := range formats {
+		format = strings.ToLower(strings.TrimSpace(format))
+		path, err := ch.exportFormat(dir, format, entities)
+		if err != nil {
+			failed = append(failed, format)
+			errs = append(errs, fmt.Errorf("%s: %w", format, err))
+			continue
+		}
+		paths = append(paths, path)
+	}
+
+	if len(errs) > 0 {
+		return paths, fmt.Errorf("failed to export %s: %w", strings.Join(failed, ", "), errors.Join(errs...))
+	}
+	return paths, nil
+}
+
+// exportFormat writes entities to the file of a format in dir and returns its path
+func (ch *ClipboardHandler) exportFormat(dir, format string, entities []data.Entity) (string, error) {
+	name, ok := exportFileNames[format]
+	if !ok {
+		return "", fmt.Errorf("unsupported export format %q", format)
+	}
+
+	content, err := ch.formatEntitiesAs(format, entities)
+	if err != nil {
+		return "", err
+	}
+	// The copied ENTITIES section becomes a file CAD tools open once it ends in EOF
+	if format == "dxf" {
+		content += "  0\nEOF"
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return path, nil
+}
+
+// selectedEntities returns the selected entities of all layers, in layer order
+func (v *DXFView) selectedEntities() []data.Entity {
+	var entities []data.Entity
+	for _, layer := range v.data.Layers {
+		for i, entity := range layer.Entities {
+			if v.selection.IsSelected(entityID(layer.Name, i, entity)) {
+				entities = append(entities, entity)
+			}
+		}
+	}
+	return entities
+}
+
+// showExportSelection opens a form for the directory and formats to export the selected
+// entities to
+func (v *DXFView) showExportSelection() {
+	if v.data == nil || v.exportForm != nil {
+		return
+	}
+	if v.selection.GetSelectedCount() == 0 {
+		v.statusHandler.ShowMessage("Select entities to export first")
+		return
+	}
+
+	dir := "."
+	if v.sourcePath != "" {
+		dir = filepath.Dir(v.sourcePath)
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle("Export selection")
+	form.AddInputField("Directory", dir, 40, nil, nil)
+	form.AddInputField("Formats", defaultExportFormats, 40, nil, nil)
+	form.AddButton("Export", func() {
+		dir := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		formats := strings.Split(form.GetFormItem(1).(*tview.InputField).GetText(), ",")
+		if dir == "" {
+			v.statusHandler.show("Enter a directory to export to", StatusError)
+			return
+		}
+		v.closeExportSelection()
+		paths, err := v.clipboardHandler.ExportSelection(dir, formats)
+		if err != nil {
+			v.statusHandler.show(fmt.Sprintf("Exported %d files; %v", len(paths), err), StatusError)
+			return
+		}
+		v.statusHandler.ShowMessage(fmt.Sprintf("Exported %d files to %s", len(paths), dir))
+	})
+	form.AddButton("Cancel", v.closeExportSelection)
+	// Escape closes the form without exporting
+	form.SetCancelFunc(v.closeExportSelection)
+
+	v.exportForm = form
+	v.showOverlay(exportSelectionPage, form, 56, 9)
+}
+
+// closeExportSelection removes the export selection form and returns focus to where it was
+func (v *DXFView) closeExportSelection() {
+	if v.exportForm == nil {
+		return
+	}
+	v.exportForm = nil
+	v.closeOverlay(exportSelectionPage)
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardHandler_ExportSelection(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createRangeTestData())
+	handler := view.clipboardHandler
+
+	_, err := handler.ExportSelection(t.TempDir(), []string{"csv"})
+	assert.EqualError(t, err, "no entities selected")
+
+	// The first wall and the text on another layer
+	view.selection.SelectAll([]string{"Walls:0", "Notes:0"})
+
+	t.Run("every format is written", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "deliverable")
+		paths, err := handler.ExportSelection(dir, []string{"csv", " JSON", "dxf"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(dir, "selection.csv"),
+			filepath.Join(dir, "selection.json"),
+			filepath.Join(dir, "selection.dxf"),
+		}, paths)
+
+		csv, err := os.ReadFile(paths[0])
+		require.NoError(t, err)
+		assert.Contains(t, string(csv), "Line,Walls")
+		assert.Contains(t, string(csv), "Text,Notes")
+
+		content, err := os.ReadFile(paths[1])
+		require.NoError(t, err)
+		var entities []map[string]interface{}
+		require.NoError(t, json.Unmarshal(content, &entities))
+		assert.Len(t, entities, 2)
+
+		dxf, err := os.ReadFile(paths[2])
+		require.NoError(t, err)
+		assert.Contains(t, string(dxf), "LINE")
+		assert.Contains(t, string(dxf), "TEXT")
+		assert.Contains(t, string(dxf), "ENDSEC\n  0\nEOF\n")
+	})
+
+	t.Run("a failing format does not stop the others", func(t *testing.T) {
+		dir := t.TempDir()
+		// A directory in the way of the JSON file makes writing it fail
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "selection.json"), 0755))
+
+		paths, err := handler.ExportSelection(dir, []string{"csv", "json", "xml", "text"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to export json, xml")
+		assert.Contains(t, err.Error(), `unsupported export format "xml"`)
+		assert.Equal(t, []string{filepath.Join(dir, "selection.csv"), filepath.Join(dir, "selection.txt")}, paths)
+	})
+
+	t.Run("the directory cannot be created", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, nil, 0644))
+
+		paths, err := handler.ExportSelection(filepath.Join(file, "out"), []string{"csv"})
+		assert.ErrorContains(t, err, "failed to create export directory")
+		assert.Empty(t, paths)
+	})
+}
+
+func TestDXFView_ExportSelectionForm(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createRangeTestData())
+	app.SetFocus(view.layers)
+	shortcut := tcell.NewEventKey(tcell.KeyCtrlE, 0, tcell.ModCtrl|tcell.ModShift)
+
+	// Without a selection there is nothing to export
+	view.overlays.GetInputCapture()(shortcut)
+	assert.Nil(t, view.exportForm)
+	message, _ := view.GetStatusBar().Message()
+	assert.Equal(t, "Select entities to export first", message)
+
+	view.selection.SelectAll([]string{"Walls:0", "Walls:2"})
+	view.overlays.GetInputCapture()(shortcut)
+	require.NotNil(t, view.exportForm)
+	assert.True(t, view.handlesEsc())
+	assert.Equal(t, defaultExportFormats, view.exportForm.GetFormItem(1).(*tview.InputField).GetText())
+
+	dir := t.TempDir()
+	view.exportForm.GetFormItem(0).(*tview.InputField).SetText(dir)
+	view.exportForm.GetButton(0).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	assert.Nil(t, view.exportForm)
+	assert.False(t, view.pages.HasPage(exportSelectionPage))
+	assert.Equal(t, view.layers, app.GetFocus(), "focus returns to where it was")
+	message, _ = view.GetStatusBar().Message()
+	assert.Equal(t, "Exported 3 files to "+dir, message)
+	assert.FileExists(t, filepath.Join(dir, "selection.dxf"))
+
+	// Escape closes the form without exporting
+	view.overlays.GetInputCapture()(shortcut)
+	require.NotNil(t, view.exportForm)
+	view.exportForm.InputHandler()(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone), func(p tview.Primitive) {})
+	assert.Nil(t, view.exportForm)
+	assert.False(t, view.handlesEsc())
+}
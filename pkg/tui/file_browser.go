@@ -0,0 +1,191 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// fileBrowserPage is the overlay page the file browser is shown on
+const fileBrowserPage = "open-file"
+
+// drawingExtensions are the file extensions the file browser lists
+var drawingExtensions = map[string]bool{".dwg": true, ".dxf": true}
+
+// FileBrowser is a list of the subdirectories and drawings of a directory,
+// for picking a drawing to open
+type FileBrowser struct {
+	*tview.List
+	dir      string
+	entries  []string // Paths of the listed items; the parent directory comes first
+	onSelect func(path string)
+	onError  func(err error)
+}
+
+// NewFileBrowser creates a file browser. onSelect is called with the path of the drawing
+// picked, and onError with a directory that could not be opened.
+func NewFileBrowser(onSelect func(path string), onError func(err error)) *FileBrowser {
+	b := &FileBrowser{
+		List:     tview.NewList().ShowSecondaryText(false),
+		onSelect: onSelect,
+		onError:  onError,
+	}
+	b.SetBorder(true)
+	b.SetSelectedFunc(func(index int, _ string, _ string, _ rune) {
+		b.open(index)
+	})
+	return b
+}
+
+// Dir returns the directory listed
+func (b *FileBrowser) Dir() string {
+	return b.dir
+}
+
+// SetDirectory lists dir. The listing is left unchanged if dir cannot be read.
+func (b *FileBrowser) SetDirectory(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open directory %s: %w", dir, err)
+	}
+
+	var dirs, files []string
+	for _, entry := range dirEntries {
+		switch {
+		case entry.IsDir():
+			dirs = append(dirs, entry.Name())
+		case drawingExtensions[strings.ToLower(filepath.Ext(entry.Name()))]:
+			files = append(files, entry.Name())
+		}
+	}
+	byName := func(names []string) {
+		sort.Slice(names, func(i, j int) bool {
+			return strings.ToLower(names[i]) < strings.ToLower(names[j])
+		})
+	}
+	byName(dirs)
+	byName(files)
+
+	b.dir = dir
+	b.entries = b.entries[:0]
+	b.Clear()
+	if parent := filepath.Dir(dir); parent != dir {
+		b.entries = append(b.entries, parent)
+		b.AddItem("../", "", 0, nil)
+	}
+	for _, name := range dirs {
+		b.entries = append(b.entries, filepath.Join(dir, name))
+		b.AddItem(tview.Escape(name)+"/", "", 0, nil)
+	}
+	for _, name := range files {
+		b.entries = append(b.entries, filepath.Join(dir, name))
+		b.AddItem(tview.Escape(name), "", 0, nil)
+	}
+	b.SetTitle(" Open drawing: " + tview.Escape(dir) + " ")
+	return nil
+}
+
+// open enters the directory at index, or picks the drawing at index
+func (b *FileBrowser) open(index int) {
+	if index < 0 || index >= len(b.entries) {
+		return
+	}
+	path := b.entries[index]
+	info, err := os.Stat(path)
+	if err != nil {
+		b.onError(fmt.Errorf("failed to open %s: %w", path, err))
+		return
+	}
+	if !info.IsDir() {
+		b.onSelect(path)
+		return
+	}
+
+	previous := b.dir
+	if err := b.SetDirectory(path); err != nil {
+		b.onError(err)
+		return
+	}
+	// Going up keeps the directory just left selected
+	for i, entry := range b.entries {
+		if entry == previous {
+			b.SetCurrentItem(i)
+			break
+		}
+	}
+}
+
+// up enters the parent directory, listed first as ../ everywhere but at a filesystem root
+func (b *FileBrowser) up() {
+	if parent := filepath.Dir(b.dir); parent != b.dir {
+		b.open(0)
+	}
+}
+
+// SetOpenHandler sets the function Ctrl+O calls with the path of the drawing picked in
+// the file browser. Without one, Ctrl+O does nothing.
+func (v *DXFView) SetOpenHandler(handler func(path string)) {
+	v.openHandler = handler
+}
+
+// ShowFileBrowser shows the file browser above the view, listing the directory of the
+// drawing shown or the working directory. Esc closes it.
+func (v *DXFView) ShowFileBrowser() {
+	if v.openHandler == nil || v.IsLoading() || v.overlays.HasPage(fileBrowserPage) {
+		return
+	}
+
+	browser := NewFileBrowser(func(path string) {
+		v.HideFileBrowser()
+		v.openHandler(path)
+	}, func(err error) {
+		v.statusHandler.show(err.Error(), StatusError)
+	})
+	browser.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			v.HideFileBrowser()
+			return nil
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			browser.up()
+			return nil
+		}
+		return event
+	})
+
+	dir := "."
+	if v.sourcePath != "" {
+		dir = filepath.Dir(v.sourcePath)
+	}
+	if err := browser.SetDirectory(dir); err != nil {
+		v.statusHandler.show(err.Error(), StatusError)
+		return
+	}
+
+	// The browser takes three fifths of the view each way
+	v.fileBrowser = browser
+	v.showOverlay(fileBrowserPage, browser, -3, -3)
+}
+
+// handlesEsc reports whether an overlay that Esc closes or cancels is shown
+func (v *DXFView) handlesEsc() bool {
+	return v.IsLoading() || v.gotoOpen || len(v.blockPath) > 0 || v.fileBrowser != nil || v.attributeForm != nil || v.rangeForm != nil || v.exportForm != nil || v.recentLayers != nil
+}
+
+// HideFileBrowser closes the file browser and gives focus back to where it was
+func (v *DXFView) HideFileBrowser() {
+	if v.fileBrowser == nil {
+		return
+	}
+	v.fileBrowser = nil
+	v.closeOverlay(fileBrowserPage)
+}
@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createBrowserTree creates a directory with subdirectories, drawings and other files
+func createBrowserTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range []string{"plans", "Archive"} {
+		require.NoError(t, os.Mkdir(filepath.Join(root, dir), 0o755))
+	}
+	for _, file := range []string{"b.dwg", "A.DXF", "notes.txt", "plans/floor.dxf"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, file), nil, 0o644))
+	}
+	return root
+}
+
+// browserItems returns the item texts of a file browser
+func browserItems(b *FileBrowser) []string {
+	items := make([]string, b.GetItemCount())
+	for i := range items {
+		items[i], _ = b.GetItemText(i)
+	}
+	return items
+}
+
+func TestFileBrowser_SetDirectory(t *testing.T) {
+	root := createBrowserTree(t)
+	browser := NewFileBrowser(func(string) {}, func(error) {})
+
+	require.NoError(t, browser.SetDirectory(root))
+	assert.Equal(t, root, browser.Dir())
+	assert.Equal(t, []string{"../", "Archive/", "plans/", "A.DXF", "b.dwg"}, browserItems(browser),
+		"directories come before drawings and other files are left out")
+	assert.Contains(t, browser.GetTitle(), root)
+
+	err := browser.SetDirectory(filepath.Join(root, "missing"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to open directory")
+	assert.Equal(t, root, browser.Dir(), "the listing is kept when a directory cannot be read")
+}
+
+func TestFileBrowser_Navigation(t *testing.T) {
+	root := createBrowserTree(t)
+	var selected string
+	browser := NewFileBrowser(func(path string) { selected = path }, func(err error) {
+		t.Fatalf("unexpected error: %v", err)
+	})
+	require.NoError(t, browser.SetDirectory(root))
+
+	// Enter plans/
+	browser.open(2)
+	assert.Equal(t, filepath.Join(root, "plans"), browser.Dir())
+	assert.Equal(t, []string{"../", "floor.dxf"}, browserItems(browser))
+
+	// Going up reselects the directory just left
+	browser.open(0)
+	assert.Equal(t, root, browser.Dir())
+	assert.Equal(t, 2, browser.GetCurrentItem())
+
+	browser.open(4)
+	assert.Equal(t, filepath.Join(root, "b.dwg"), selected)
+	assert.Equal(t, root, browser.Dir())
+
+	// A filesystem root has no parent to go up to
+	top := filepath.VolumeName(root) + string(filepath.Separator)
+	require.NoError(t, browser.SetDirectory(top))
+	browser.up()
+	assert.Equal(t, top, browser.Dir())
+}
+
+func TestFileBrowser_PermissionDenied(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("directory permissions are not enforced")
+	}
+	root := createBrowserTree(t)
+	locked := filepath.Join(root, "plans")
+	require.NoError(t, os.Chmod(locked, 0o000))
+	t.Cleanup(func() { _ = os.Chmod(locked, 0o755) })
+
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestData())
+	view.SetSourcePath(filepath.Join(root, "b.dwg"))
+	view.SetOpenHandler(func(string) {})
+
+	view.ShowFileBrowser()
+	require.NotNil(t, view.fileBrowser)
+	view.fileBrowser.open(2)
+
+	message, level := view.GetStatusBar().Message()
+	assert.Contains(t, message, "failed to open directory")
+	assert.Equal(t, StatusError, level)
+	assert.True(t, view.overlays.HasPage(fileBrowserPage), "the browser stays open")
+	assert.Equal(t, root, view.fileBrowser.Dir())
+}
+
+func TestDXFView_FileBrowser(t *testing.T) {
+	root := createBrowserTree(t)
+	ctrlO := tcell.NewEventKey(tcell.KeyCtrlO, 0, tcell.ModCtrl)
+
+	t.Run("Ctrl+O needs an open handler", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestData())
+
+		view.overlays.GetInputCapture()(ctrlO)
+		assert.False(t, view.overlays.HasPage(fileBrowserPage))
+	})
+
+	t.Run("picking a drawing", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestData())
+		view.SetSourcePath(filepath.Join(root, "plans", "floor.dxf"))
+		app.SetFocus(view.layers)
+
+		var opened string
+		view.SetOpenHandler(func(path string) { opened = path })
+		assert.Nil(t, view.overlays.GetInputCapture()(ctrlO))
+		require.True(t, view.overlays.HasPage(fileBrowserPage))
+		assert.True(t, view.handlesEsc())
+		assert.Equal(t, view.fileBrowser, app.GetFocus())
+		assert.Equal(t, filepath.Join(root, "plans"), view.fileBrowser.Dir(),
+			"the browser starts in the directory of the drawing shown")
+
+		// Backspace goes up
+		capture := view.fileBrowser.GetInputCapture()
+		assert.Nil(t, capture(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone)))
+		assert.Equal(t, root, view.fileBrowser.Dir())
+
+		view.fileBrowser.open(3)
+		assert.Equal(t, filepath.Join(root, "A.DXF"), opened)
+		assert.False(t, view.overlays.HasPage(fileBrowserPage))
+		assert.False(t, view.handlesEsc())
+		assert.Equal(t, view.layers, app.GetFocus(), "focus returns to where it was")
+	})
+
+	t.Run("Esc closes the browser", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestData())
+		app.SetFocus(view.entityList)
+
+		opened := false
+		view.SetOpenHandler(func(string) { opened = true })
+		view.ShowFileBrowser()
+		require.NotNil(t, view.fileBrowser)
+
+		assert.Nil(t, view.fileBrowser.GetInputCapture()(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)))
+		assert.False(t, view.overlays.HasPage(fileBrowserPage))
+		assert.Nil(t, view.fileBrowser)
+		assert.Equal(t, view.entityList, app.GetFocus())
+		assert.False(t, opened)
+	})
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/rivo/tview"
+)
+
+// DefaultLayerHistorySize is how many recently viewed layers Ctrl+L lists
+const DefaultLayerHistorySize = 10
+
+// recentLayersPage is the page the recent layers list is shown on
+const recentLayersPage = "recent-layers"
+
+// LayerHistory keeps the names of the most recently viewed layers, most recent first.
+// Layers are tracked by name so the history survives reloading the drawing.
+type LayerHistory struct {
+	names    []string
+	capacity int
+}
+
+// NewLayerHistory creates a history of at most capacity layers
+func NewLayerHistory(capacity int) *LayerHistory {
+	return &LayerHistory{capacity: capacity}
+}
+
+// Visit moves a layer to the front of the history, dropping the oldest layer when full
+func (h *LayerHistory) Visit(name string) {
+	if i := slices.Index(h.names, name); i >= 0 {
+		h.names = slices.Delete(h.names, i, i+1)
+	}
+	h.names = slices.Insert(h.names, 0, name)
+	if len(h.names) > h.capacity {
+		h.names = h.names[:h.capacity]
+	}
+}
+
+// Recent returns the names of the layers in the history, most recent first
+func (h *LayerHistory) Recent() []string {
+	return slices.Clone(h.names)
+}
+
+// showRecentLayers lists the recently viewed layers of the drawing; choosing one opens it.
+// Layers no longer in the drawing are left out.
+func (v *DXFView) showRecentLayers() {
+	if v.data == nil || v.IsLoading() || v.recentLayers != nil {
+		return
+	}
+
+	list := tview.NewList()
+	list.SetBorder(true).SetTitle("Recent layers")
+	for _, name := range v.layerHistory.Recent() {
+		index := v.layerIndex(name)
+		if index < 0 {
+			continue
+		}
+		entities := len(v.data.Layers[index].Entities)
+		list.AddItem(tview.Escape(name), fmt.Sprintf("%d entities", entities), 0, func() {
+			v.closeRecentLayers()
+			v.showLayerDetails(index)
+		})
+	}
+	if list.GetItemCount() == 0 {
+		v.statusHandler.ShowMessage("No layers viewed yet")
+		return
+	}
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			v.closeRecentLayers()
+			return nil
+		}
+		return event
+	})
+
+	v.recentLayers = list
+	v.showOverlay(recentLayersPage, list, 40, 2*list.GetItemCount()+2)
+}
+
+// closeRecentLayers removes the recent layers list and returns focus to where it was
+func (v *DXFView) closeRecentLayers() {
+	if v.recentLayers == nil {
+		return
+	}
+	v.recentLayers = nil
+	v.closeOverlay(recentLayersPage)
+}
+
+// layerIndex returns the index of the named layer in the drawing, or -1 if there is none
+func (v *DXFView) layerIndex(name string) int {
+	return slices.IndexFunc(v.data.Layers, func(layer data.LayerInfo) bool {
+		return layer.Name == name
+	})
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayerHistory(t *testing.T) {
+	history := NewLayerHistory(3)
+	assert.Empty(t, history.Recent())
+
+	history.Visit("A")
+	history.Visit("B")
+	history.Visit("C")
+	assert.Equal(t, []string{"C", "B", "A"}, history.Recent())
+
+	// Revisiting moves a layer to the front instead of repeating it
+	history.Visit("A")
+	assert.Equal(t, []string{"A", "C", "B"}, history.Recent())
+
+	// The oldest layer drops out when the history is full
+	history.Visit("D")
+	assert.Equal(t, []string{"D", "A", "C"}, history.Recent())
+
+	recent := history.Recent()
+	recent[0] = "changed"
+	assert.Equal(t, "D", history.Recent()[0], "Recent returns a copy")
+}
+
+// recentLayerNames returns the layer names listed by the recent layers list
+func recentLayerNames(list *tview.List) []string {
+	names := make([]string, list.GetItemCount())
+	for i := range names {
+		names[i], _ = list.GetItemText(i)
+	}
+	return names
+}
+
+func TestDXFView_RecentLayers(t *testing.T) {
+	ctrlL := tcell.NewEventKey(tcell.KeyCtrlL, 0, tcell.ModCtrl)
+
+	t.Run("nothing viewed yet", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestDataWithMultipleItems())
+
+		assert.Nil(t, view.overlays.GetInputCapture()(ctrlL))
+		assert.Nil(t, view.recentLayers)
+		message, _ := view.GetStatusBar().Message()
+		assert.Equal(t, "No layers viewed yet", message)
+	})
+
+	t.Run("jumping to a recent layer", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestDataWithMultipleItems())
+		for _, index := range []int{0, 2, 1, 2} {
+			view.showLayerDetails(index)
+		}
+		view.showLayersView()
+		app.SetFocus(view.layers)
+
+		assert.Nil(t, view.overlays.GetInputCapture()(ctrlL))
+		require.NotNil(t, view.recentLayers)
+		assert.True(t, view.pages.HasPage(recentLayersPage))
+		assert.True(t, view.handlesEsc())
+		assert.Equal(t, view.recentLayers, app.GetFocus())
+		assert.Equal(t, []string{"Layer3", "Layer2", "Layer1"}, recentLayerNames(view.recentLayers))
+
+		// Choosing the second entry opens Layer2
+		view.recentLayers.SetCurrentItem(1)
+		view.recentLayers.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p tview.Primitive) {})
+		assert.Nil(t, view.recentLayers)
+		assert.False(t, view.pages.HasPage(recentLayersPage))
+		assert.Equal(t, 1, view.currentLayerIndex)
+		assert.Equal(t, []string{"Layer2", "Layer3", "Layer1"}, view.layerHistory.Recent())
+	})
+
+	t.Run("Esc closes the list", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestDataWithMultipleItems())
+		view.showLayerDetails(0)
+		app.SetFocus(view.entityList)
+
+		view.showRecentLayers()
+		require.NotNil(t, view.recentLayers)
+		assert.Nil(t, view.recentLayers.GetInputCapture()(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)))
+		assert.Nil(t, view.recentLayers)
+		assert.False(t, view.handlesEsc())
+		assert.Equal(t, view.entityList, app.GetFocus())
+	})
+
+	t.Run("layers no longer in the drawing are left out", func(t *testing.T) {
+		app := SetupTestApp(t)
+		view := NewDXFView(app)
+		view.Update(createTestDataWithMultipleItems())
+		view.showLayerDetails(0)
+		view.showLayerDetails(2)
+
+		view.Update(&data.ExtractedData{Layers: []data.LayerInfo{{Name: "Layer1", IsOn: true}}})
+		view.showRecentLayers()
+		require.NotNil(t, view.recentLayers)
+		assert.Equal(t, []string{"Layer1"}, recentLayerNames(view.recentLayers))
+	})
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// loadingPage is the overlay page the loading spinner is shown on
+const loadingPage = "loading"
+
+// spinnerInterval is how often the loading spinner advances
+const spinnerInterval = 100 * time.Millisecond
+
+// spinnerFrames are drawn in turn by the loading spinner
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// loadingOverlay is a box with a spinner shown above the view while a long task runs
+type loadingOverlay struct {
+	text    *tview.TextView
+	message string
+	frame   int
+	cancel  func()        // Called when Esc is pressed; nil when the task cannot be cancelled
+	stop    chan struct{} // Closed when the overlay is hidden, stopping the spinner
+}
+
+// render shows the current spinner frame and message
+func (o *loadingOverlay) render() {
+	text := fmt.Sprintf("%s %s", spinnerFrames[o.frame], tview.Escape(o.message))
+	if o.cancel != nil {
+		text += "\n\n[gray]Press Esc to cancel[-]"
+	}
+	o.text.SetText(text)
+}
+
+// ShowLoading shows msg with a spinner above the view, taking focus until HideLoading.
+// Calling it again while the spinner is shown replaces the message.
+func (v *DXFView) ShowLoading(msg string) {
+	if v.loading != nil {
+		v.loading.message = msg
+		v.loading.render()
+		return
+	}
+
+	text := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+	text.SetBorder(true).SetTitle("Loading")
+	overlay := &loadingOverlay{
+		text:    text,
+		message: msg,
+		stop:    make(chan struct{}),
+	}
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			v.CancelLoading()
+		}
+		return nil
+	})
+	overlay.render()
+
+	// The box takes half the view's width
+	v.loading = overlay
+	v.showOverlay(loadingPage, text, -2, 5)
+	go v.spin(overlay)
+}
+
+// spin advances the overlay's spinner until it is hidden
+func (v *DXFView) spin(overlay *loadingOverlay) {
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-overlay.stop:
+			return
+		case <-ticker.C:
+			v.app.QueueUpdateDraw(func() {
+				if v.loading != overlay {
+					return
+				}
+				overlay.frame = (overlay.frame + 1) % len(spinnerFrames)
+				overlay.render()
+			})
+		}
+	}
+}
+
+// SetLoadingCancel sets the function Esc calls to cancel the task the spinner is shown for
+func (v *DXFView) SetLoadingCancel(cancel func()) {
+	if v.loading == nil {
+		return
+	}
+	v.loading.cancel = cancel
+	v.loading.render()
+}
+
+// CancelLoading cancels the task the spinner is shown for. It reports false when no
+// spinner is shown or its task cannot be cancelled.
+func (v *DXFView) CancelLoading() bool {
+	if v.loading == nil || v.loading.cancel == nil {
+		return false
+	}
+	cancel := v.loading.cancel
+	v.loading.cancel = nil
+	v.loading.message = "Cancelling..."
+	v.loading.render()
+	cancel()
+	return true
+}
+
+// IsLoading reports whether the loading spinner is shown
+func (v *DXFView) IsLoading() bool {
+	return v.loading != nil
+}
+
+// HideLoading removes the loading spinner and gives focus back to where it was
+func (v *DXFView) HideLoading() {
+	if v.loading == nil {
+		return
+	}
+	overlay := v.loading
+	v.loading = nil
+	close(overlay.stop)
+
+	v.closeOverlay(loadingPage)
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDXFView_Loading(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestData())
+	app.SetFocus(view.layers)
+
+	view.ShowLoading("Converting plan.dwg...")
+	require.True(t, view.IsLoading())
+	assert.True(t, view.overlays.HasPage(loadingPage))
+	assert.Equal(t, view.loading.text, app.GetFocus(), "the spinner takes focus")
+	assert.Contains(t, view.loading.text.GetText(true), "Converting plan.dwg...")
+	assert.NotContains(t, view.loading.text.GetText(true), "Esc", "without a cancel function Esc does nothing")
+	assert.False(t, view.CancelLoading())
+
+	// Esc calls the cancel function once
+	cancelled := 0
+	view.SetLoadingCancel(func() { cancelled++ })
+	assert.Contains(t, view.loading.text.GetText(true), "Press Esc to cancel")
+	capture := view.loading.text.GetInputCapture()
+	assert.Nil(t, capture(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)))
+	assert.Nil(t, capture(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)))
+	assert.Equal(t, 1, cancelled)
+	assert.Contains(t, view.loading.text.GetText(true), "Cancelling...")
+
+	// Other keys do not reach the view below
+	assert.Nil(t, capture(tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone)))
+
+	view.HideLoading()
+	assert.False(t, view.IsLoading())
+	assert.False(t, view.overlays.HasPage(loadingPage))
+	assert.Equal(t, view.layers, app.GetFocus(), "focus returns to where it was")
+	view.HideLoading()
+}
+
+func TestDXFView_LoadingSpinnerAdvances(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	app := tview.NewApplication().SetScreen(screen)
+	view := NewDXFView(app)
+	app.SetRoot(view.GetLayout(), true)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run()
+	}()
+	defer func() {
+		app.Stop()
+		<-done
+	}()
+
+	app.QueueUpdateDraw(func() {
+		view.ShowLoading("Converting plan.dwg...")
+	})
+
+	// The screen is read in the event loop, which draws to it
+	screenText := func() string {
+		contents := make(chan string, 1)
+		app.QueueUpdate(func() {
+			cells, _, _ := screen.GetContents()
+			var text strings.Builder
+			for _, cell := range cells {
+				text.WriteString(string(cell.Runes))
+			}
+			contents <- text.String()
+		})
+		return <-contents
+	}
+	require.Eventually(t, func() bool {
+		return strings.Contains(screenText(), spinnerFrames[0]+" Converting plan.dwg...")
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return strings.Contains(screenText(), spinnerFrames[1]+" Converting plan.dwg...")
+	}, 2*time.Second, 10*time.Millisecond, "the spinner should advance")
+
+	app.QueueUpdateDraw(view.HideLoading)
+	require.Eventually(t, func() bool {
+		return !strings.Contains(screenText(), "Converting plan.dwg...")
+	}, 2*time.Second, 10*time.Millisecond)
+}
@@ -19,6 +19,7 @@ type Navigator interface {
 type ListNavigator interface {
 	SetCurrentIndex(index int) error
 	SetWrapNavigation(wrap bool)
+	IsWrapNavigation() bool
 	HandleKeyPress(key tcell.Key, mod tcell.ModMask) bool
 	GetCurrentIndex() int
 }
@@ -197,9 +198,20 @@ func (ln *TUIListNavigator) SetCurrentIndex(index int) error {
 	return nil
 }
 
-// SetWrapNavigation enables or disables wrap navigation
+// SetWrapNavigation enables or disables wrap navigation. With wrap enabled, Up on the
+// first item and PgUp from the first item move to the last item, and Down and PgDn
+// from the last item move to the first. Home and End always go to the first and last
+// item. The list's own Up/Down wrapping is updated to match.
 func (ln *TUIListNavigator) SetWrapNavigation(wrap bool) {
 	ln.wrapEnabled = wrap
+	if ln.list != nil {
+		ln.list.SetWrapAround(wrap)
+	}
+}
+
+// IsWrapNavigation returns whether wrap navigation is enabled
+func (ln *TUIListNavigator) IsWrapNavigation() bool {
+	return ln.wrapEnabled
 }
 
 // HandleKeyPress handles key presses for list navigation
@@ -286,7 +298,12 @@ func (ln *TUIListNavigator) pageDown() bool {
 	pageSize := 5 // Default page size
 	newIndex := ln.currentIndex + pageSize
 	if newIndex >= itemCount {
-		newIndex = itemCount - 1
+		// Stop at the last item first; paging again from there wraps to the top
+		if ln.wrapEnabled && ln.currentIndex == itemCount-1 {
+			newIndex = 0
+		} else {
+			newIndex = itemCount - 1
+		}
 	}
 
 	ln.SetCurrentIndex(newIndex)
@@ -303,7 +320,12 @@ func (ln *TUIListNavigator) pageUp() bool {
 	pageSize := 5 // Default page size
 	newIndex := ln.currentIndex - pageSize
 	if newIndex < 0 {
-		newIndex = 0
+		// Stop at the first item first; paging again from there wraps to the bottom
+		if ln.wrapEnabled && ln.currentIndex == 0 {
+			newIndex = itemCount - 1
+		} else {
+			newIndex = 0
+		}
 	}
 
 	ln.SetCurrentIndex(newIndex)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -132,6 +133,38 @@ func TestKeyboardNavigation_WithinLists(t *testing.T) {
 			expectedIndex: 3, // should jump by page size, but limited to last item (3 entities + 1 back item = 4 total, so index 3)
 			shouldWrap:    false,
 		},
+		{
+			name:          "Page down stops at last item without wrap",
+			listType:      "entities",
+			key:           tcell.KeyPgDn,
+			initialIndex:  3,
+			expectedIndex: 3,
+			shouldWrap:    false,
+		},
+		{
+			name:          "Page down clamps to last item before wrapping",
+			listType:      "entities",
+			key:           tcell.KeyPgDn,
+			initialIndex:  1,
+			expectedIndex: 3,
+			shouldWrap:    true,
+		},
+		{
+			name:          "Page down past the end wraps to top",
+			listType:      "entities",
+			key:           tcell.KeyPgDn,
+			initialIndex:  3,
+			expectedIndex: 0,
+			shouldWrap:    true,
+		},
+		{
+			name:          "Page up past the start wraps to bottom",
+			listType:      "entities",
+			key:           tcell.KeyPgUp,
+			initialIndex:  0,
+			expectedIndex: 3,
+			shouldWrap:    true,
+		},
 		{
 			name:          "Home key goes to first item",
 			listType:      "layers",
@@ -140,6 +173,22 @@ func TestKeyboardNavigation_WithinLists(t *testing.T) {
 			expectedIndex: 0,
 			shouldWrap:    false,
 		},
+		{
+			name:          "Home key ignores wrap",
+			listType:      "layers",
+			key:           tcell.KeyHome,
+			initialIndex:  1,
+			expectedIndex: 0,
+			shouldWrap:    true,
+		},
+		{
+			name:          "End key ignores wrap",
+			listType:      "layers",
+			key:           tcell.KeyEnd,
+			initialIndex:  1,
+			expectedIndex: 2,
+			shouldWrap:    true,
+		},
 		{
 			name:          "End key goes to last item",
 			listType:      "layers",
@@ -490,3 +539,90 @@ func createTestDataWithDetailedEntities() *data.ExtractedData {
 		},
 	}
 }
+
+// TestWrapNavigationToggle tests the 'w' preference toggle and wrapped paging in the live lists
+func TestWrapNavigationToggle(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+	view.showLayerDetails(0)
+
+	nav := view.GetListNavigator("entities")
+	assert.True(t, nav.IsWrapNavigation(), "Lists should wrap by default")
+
+	capture := view.entityList.GetInputCapture()
+	capture(tcell.NewEventKey(tcell.KeyRune, 'w', tcell.ModNone))
+	assert.False(t, nav.IsWrapNavigation())
+	assert.Equal(t, "Wrap navigation off", view.statusHandler.GetCurrentMessage())
+
+	// Without wrap, PgDn on the last item is left to the list
+	view.entityList.SetCurrentItem(3)
+	assert.NotNil(t, capture(tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone)))
+
+	capture(tcell.NewEventKey(tcell.KeyRune, 'w', tcell.ModNone))
+	assert.True(t, nav.IsWrapNavigation())
+	assert.Equal(t, "Wrap navigation on", view.statusHandler.GetCurrentMessage())
+
+	// With wrap, PgDn on the last item goes to the top and PgUp on the first to the bottom
+	assert.Nil(t, capture(tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone)))
+	assert.Equal(t, 0, view.entityList.GetCurrentItem())
+	assert.Nil(t, capture(tcell.NewEventKey(tcell.KeyPgUp, 0, tcell.ModNone)))
+	assert.Equal(t, 3, view.entityList.GetCurrentItem())
+
+	// The layers list has its own preference
+	view.layers.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'w', tcell.ModNone))
+	assert.False(t, view.GetListNavigator("layers").IsWrapNavigation())
+	assert.True(t, nav.IsWrapNavigation())
+}
+
+func TestGotoLayer(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedIndex   int
+		expectError     bool
+		expectedMessage string
+	}{
+		{name: "jumps to the typed layer", input: "3", expectedIndex: 2},
+		{name: "first layer", input: "1", expectedIndex: 0},
+		{name: "past the end", input: "4", expectedIndex: 1, expectError: true, expectedMessage: "Layer 4 is out of range (1-3)"},
+		{name: "zero", input: "0", expectedIndex: 1, expectError: true, expectedMessage: "Layer 0 is out of range (1-3)"},
+		{name: "empty input", input: "", expectedIndex: 1, expectError: true, expectedMessage: `Invalid layer number: ""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := SetupTestApp(t)
+			view := NewDXFView(app)
+			view.Update(createTestDataWithMultipleItems())
+			view.layers.SetCurrentItem(1)
+
+			err := view.GotoLayer(tt.input)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedMessage, view.statusHandler.GetCurrentMessage())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedIndex, view.layers.GetCurrentItem())
+		})
+	}
+}
+
+func TestGotoPrompt(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+	view.showLayersView()
+
+	capture := view.layers.GetInputCapture()
+	capture(tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone))
+	page, _ := view.pages.GetFrontPage()
+	assert.Equal(t, "goto", page, "Expected 'g' to open the goto prompt")
+	assert.Empty(t, view.searchInput.GetText(), "Expected 'g' not to be typed into the search")
+
+	view.gotoInput.SetText("2")
+	view.gotoInput.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p tview.Primitive) {})
+	assert.Equal(t, 1, view.layers.GetCurrentItem())
+	assert.False(t, view.pages.HasPage("goto"), "Expected the prompt to close after Enter")
+}
@@ -0,0 +1,55 @@
+package tui
+
+import "github.com/rivo/tview"
+
+// topOverlayPages are the overlays shown above the status bar, over the whole view,
+// rather than over the current page
+var topOverlayPages = map[string]bool{loadingPage: true, fileBrowserPage: true}
+
+// overlayPages returns the pages the named overlay is shown on
+func (v *DXFView) overlayPages(page string) *tview.Pages {
+	if topOverlayPages[page] {
+		return v.overlays
+	}
+	return v.pages
+}
+
+// showOverlay shows p centered as the named page, leaving the view visible around it, and
+// gives it focus. A positive width or height is a size in cells; a negative one makes p
+// take -size parts of the space, against one part for each margin. closeOverlay gives the
+// focus back to where it was.
+func (v *DXFView) showOverlay(page string, p tview.Primitive, width, height int) {
+	column := tview.NewFlex().SetDirection(tview.FlexRow).AddItem(nil, 0, 1, false)
+	addOverlayItem(column, p, height)
+	column.AddItem(nil, 0, 1, false)
+
+	layout := tview.NewFlex().AddItem(nil, 0, 1, false)
+	addOverlayItem(layout, column, width)
+	layout.AddItem(nil, 0, 1, false)
+
+	v.overlayFocus[page] = v.app.GetFocus()
+	v.overlayPages(page).AddPage(page, layout, true, true)
+	v.app.SetFocus(p)
+}
+
+// addOverlayItem adds p to the flex with a fixed size in cells, or a proportion when size is negative
+func addOverlayItem(flex *tview.Flex, p tview.Primitive, size int) {
+	if size < 0 {
+		flex.AddItem(p, 0, -size, true)
+		return
+	}
+	flex.AddItem(p, size, 0, true)
+}
+
+// closeOverlay removes the named overlay shown by showOverlay and gives focus back to where it was
+func (v *DXFView) closeOverlay(page string) {
+	v.overlayPages(page).RemovePage(page)
+	focus, ok := v.overlayFocus[page]
+	if !ok {
+		return
+	}
+	delete(v.overlayFocus, page)
+	if focus != nil {
+		v.app.SetFocus(focus)
+	}
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowOverlay(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	app.SetFocus(view.layers)
+
+	box := tview.NewBox()
+	view.showOverlay("test", box, 20, 5)
+	assert.True(t, view.pages.HasPage("test"))
+	assert.Equal(t, box, app.GetFocus())
+
+	// The overlay is centered at its fixed size
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	screen.SetSize(100, 41)
+	view.pages.SetRect(0, 0, 100, 41)
+	view.pages.Draw(screen)
+	_, _, width, height := box.GetRect()
+	assert.Equal(t, 20, width)
+	assert.Equal(t, 5, height)
+
+	view.closeOverlay("test")
+	assert.False(t, view.pages.HasPage("test"))
+	assert.Equal(t, view.layers, app.GetFocus(), "Expected focus back where it was")
+
+	// Closing again does nothing
+	assert.NotPanics(t, func() { view.closeOverlay("test") })
+}
+
+func TestShowOverlay_TopPages(t *testing.T) {
+	view := NewDXFView(SetupTestApp(t))
+
+	view.showOverlay(fileBrowserPage, tview.NewBox(), -3, -3)
+	assert.True(t, view.overlays.HasPage(fileBrowserPage), "Expected the browser above the status bar")
+	assert.False(t, view.pages.HasPage(fileBrowserPage))
+
+	view.closeOverlay(fileBrowserPage)
+	assert.False(t, view.overlays.HasPage(fileBrowserPage))
+}
@@ -0,0 +1,363 @@
+package tui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/remym/go-dwg-extractor/pkg/clipboard"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/rivo/tview"
+)
+
+// nothingToPreview is shown when there is no geometry with a usable extent
+const nothingToPreview = "Nothing to preview"
+
+// Preview glyphs for the geometry that can be drawn
+const (
+	previewPointGlyph  = '+'
+	previewCircleGlyph = 'o'
+	previewTextGlyph   = 'T'
+)
+
+// previewStyle is how a preview cell is shown when an entity is highlighted
+type previewStyle int
+
+const (
+	previewPlain     previewStyle = iota // Dimmed while another entity is highlighted
+	previewHighlight                     // Part of the highlighted entity
+	previewMarker                        // Marker of a highlighted entity without extent, which blinks
+)
+
+// Preview style tags used by RenderStyled
+const (
+	previewDimTag       = "[gray::d]"
+	previewHighlightTag = "[yellow::b]"
+	previewMarkerTag    = "[yellow::bl]"
+)
+
+// PreviewRenderer rasterizes entity geometry into a character grid.
+// Lines and polylines are plotted with box-drawing characters following their slope,
+// circles are approximated with 'o' and points are marked with '+'. Other entity types are ignored,
+// except a highlighted text entity, which is marked with 'T' at its insertion point.
+type PreviewRenderer struct {
+	cellAspect float64     // Height of a terminal cell relative to its width
+	highlight  data.Entity // Entity drawn in the highlight color by RenderStyled; nil for none
+	colorMode  clipboard.ColorMode
+
+	// The last render, written by WriteASCII
+	last     *previewGrid
+	lastText string // Shown instead of the grid when last is nil
+}
+
+// NewPreviewRenderer creates a preview renderer for terminal cells twice as tall as they are wide
+func NewPreviewRenderer() *PreviewRenderer {
+	return &PreviewRenderer{cellAspect: 2, colorMode: clipboard.ColorModeEntity}
+}
+
+// Highlight sets the entity RenderStyled draws in the highlight color, dimming the others.
+// A nil entity clears the highlight.
+func (r *PreviewRenderer) Highlight(e data.Entity) {
+	r.highlight = e
+}
+
+// Highlighted returns the highlighted entity, or nil when there is none
+func (r *PreviewRenderer) Highlighted() data.Entity {
+	return r.highlight
+}
+
+// previewExtents is the bounding box of the geometry being previewed
+type previewExtents struct {
+	minX, minY, maxX, maxY float64
+	empty                  bool
+}
+
+// add grows the extents to include the point
+func (e *previewExtents) add(x, y float64) {
+	if e.empty {
+		e.minX, e.maxX, e.minY, e.maxY = x, x, y, y
+		e.empty = false
+		return
+	}
+	e.minX = math.Min(e.minX, x)
+	e.maxX = math.Max(e.maxX, x)
+	e.minY = math.Min(e.minY, y)
+	e.maxY = math.Max(e.maxY, y)
+}
+
+// Render draws the entities into a grid of width by height cells, scaled to fit the
+// drawing extents, and returns its rows joined by newlines. It returns "Nothing to preview"
+// when there is no drawable geometry or its extents are a single point.
+func (r *PreviewRenderer) Render(entities []data.Entity, width, height int) string {
+	grid, message := r.rasterize(entities, width, height)
+	if grid == nil {
+		return message
+	}
+	return grid.String()
+}
+
+// RenderStyled draws the entities like Render, returning rows with tview style tags.
+// When an entity is highlighted and among the entities, it is drawn in yellow on top of
+// the others, which are dimmed; a highlighted point or text marker blinks.
+func (r *PreviewRenderer) RenderStyled(entities []data.Entity, width, height int) string {
+	grid, message := r.rasterize(entities, width, height)
+	if grid == nil {
+		return message
+	}
+	if !grid.highlighted {
+		return tview.Escape(grid.String())
+	}
+	return grid.styledString()
+}
+
+// rasterize draws the entities into a grid, or returns the message to show instead
+func (r *PreviewRenderer) rasterize(entities []data.Entity, width, height int) (*previewGrid, string) {
+	grid, message := r.plot(entities, width, height)
+	r.last, r.lastText = grid, message
+	return grid, message
+}
+
+// plot does the work of rasterize
+func (r *PreviewRenderer) plot(entities []data.Entity, width, height int) (*previewGrid, string) {
+	if width < 1 || height < 1 {
+		return nil, ""
+	}
+
+	extents := r.extents(entities)
+	spanX, spanY := extents.maxX-extents.minX, extents.maxY-extents.minY
+	if extents.empty || (spanX == 0 && spanY == 0) {
+		return nil, nothingToPreview
+	}
+
+	// Use one scale for both axes so shapes keep their proportions
+	scale := math.Inf(1)
+	if spanX > 0 {
+		scale = float64(width-1) / spanX
+	}
+	if spanY > 0 {
+		scale = math.Min(scale, float64(height-1)*r.cellAspect/spanY)
+	}
+
+	grid := newPreviewGrid(width, height)
+	toCell := func(p data.Point) (int, int) {
+		col := int(math.Round((p.X - extents.minX) * scale))
+		row := int(math.Round((extents.maxY - p.Y) * scale / r.cellAspect))
+		return col, row
+	}
+	segment := func(a, b data.Point) {
+		x0, y0 := toCell(a)
+		x1, y1 := toCell(b)
+		grid.line(x0, y0, x1, y1)
+	}
+
+	draw := func(entity data.Entity) {
+		switch e := entity.(type) {
+		case *data.LineInfo:
+			segment(e.StartPoint, e.EndPoint)
+		case *data.PolylineInfo:
+			for i := 1; i < len(e.Points); i++ {
+				segment(e.Points[i-1], e.Points[i])
+			}
+			if e.IsClosed && len(e.Points) > 2 {
+				segment(e.Points[len(e.Points)-1], e.Points[0])
+			}
+		case *data.CircleInfo:
+			col, row := toCell(e.Center)
+			grid.circle(col, row, e.Radius*scale, e.Radius*scale/r.cellAspect)
+		}
+	}
+	mark := func(entity data.Entity) {
+		switch e := entity.(type) {
+		case *data.PointInfo:
+			col, row := toCell(e.Location)
+			grid.set(col, row, previewPointGlyph)
+		case *data.TextInfo:
+			// Text has no extent of its own, so it is only marked when highlighted
+			if grid.pen != previewPlain {
+				col, row := toCell(e.InsertionPoint)
+				grid.set(col, row, previewTextGlyph)
+			}
+		}
+	}
+
+	var highlighted data.Entity
+	for _, entity := range entities {
+		if r.highlight != nil && entity == r.highlight {
+			highlighted = entity
+			continue
+		}
+		draw(entity)
+	}
+
+	// Points are drawn last so they stay visible on top of other geometry
+	for _, entity := range entities {
+		if entity != highlighted {
+			mark(entity)
+		}
+	}
+
+	// The highlighted entity goes on top of everything
+	if highlighted != nil {
+		grid.highlighted = true
+		grid.pen = previewHighlight
+		draw(highlighted)
+		grid.pen = previewMarker
+		mark(highlighted)
+	}
+
+	return grid, ""
+}
+
+// extents returns the bounding box of the drawable geometry of the entities
+func (r *PreviewRenderer) extents(entities []data.Entity) previewExtents {
+	extents := previewExtents{empty: true}
+	for _, entity := range entities {
+		switch e := entity.(type) {
+		case *data.LineInfo:
+			extents.add(e.StartPoint.X, e.StartPoint.Y)
+			extents.add(e.EndPoint.X, e.EndPoint.Y)
+		case *data.PolylineInfo:
+			for _, p := range e.Points {
+				extents.add(p.X, p.Y)
+			}
+		case *data.CircleInfo:
+			extents.add(e.Center.X-e.Radius, e.Center.Y-e.Radius)
+			extents.add(e.Center.X+e.Radius, e.Center.Y+e.Radius)
+		case *data.PointInfo:
+			extents.add(e.Location.X, e.Location.Y)
+		}
+	}
+	return extents
+}
+
+// previewGrid is a fixed-size grid of preview characters
+type previewGrid struct {
+	width, height int
+	cells         [][]rune
+	styles        [][]previewStyle
+	pen           previewStyle // Style given to the cells set next
+	highlighted   bool         // Some cells belong to a highlighted entity
+}
+
+// newPreviewGrid creates a grid of the given size filled with spaces
+func newPreviewGrid(width, height int) *previewGrid {
+	cells := make([][]rune, height)
+	styles := make([][]previewStyle, height)
+	for i := range cells {
+		cells[i] = []rune(strings.Repeat(" ", width))
+		styles[i] = make([]previewStyle, width)
+	}
+	return &previewGrid{width: width, height: height, cells: cells, styles: styles}
+}
+
+// set places a glyph in a cell with the current pen style, ignoring cells outside the grid
+func (g *previewGrid) set(col, row int, glyph rune) {
+	if col < 0 || row < 0 || col >= g.width || row >= g.height {
+		return
+	}
+	g.cells[row][col] = glyph
+	g.styles[row][col] = g.pen
+}
+
+// line plots a segment between two cells with Bresenham's algorithm, using a glyph matching its slope
+func (g *previewGrid) line(x0, y0, x1, y1 int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	glyph := lineGlyph(x1-x0, y1-y0)
+	stepX, stepY := 1, 1
+	if x0 > x1 {
+		stepX = -1
+	}
+	if y0 > y1 {
+		stepY = -1
+	}
+
+	err := dx + dy
+	for {
+		g.set(x0, y0, glyph)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += stepX
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += stepY
+		}
+	}
+}
+
+// circle approximates an ellipse with the given radii in cells around a center cell
+func (g *previewGrid) circle(col, row int, radiusX, radiusY float64) {
+	// Sample often enough that neighbouring samples land in adjacent cells
+	steps := int(math.Ceil(2*math.Pi*math.Max(radiusX, radiusY))) * 2
+	if steps < 8 {
+		steps = 8
+	}
+	for i := 0; i < steps; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(steps)
+		x := col + int(math.Round(radiusX*math.Cos(angle)))
+		y := row - int(math.Round(radiusY*math.Sin(angle)))
+		g.set(x, y, previewCircleGlyph)
+	}
+}
+
+// String returns the grid rows joined by newlines, without trailing spaces
+func (g *previewGrid) String() string {
+	rows := make([]string, g.height)
+	for i, cells := range g.cells {
+		rows[i] = strings.TrimRight(string(cells), " ")
+	}
+	return strings.Join(rows, "\n")
+}
+
+// styledString returns the grid rows like String, with a style tag wherever the cell style changes
+func (g *previewGrid) styledString() string {
+	tags := map[previewStyle]string{
+		previewPlain:     previewDimTag,
+		previewHighlight: previewHighlightTag,
+		previewMarker:    previewMarkerTag,
+	}
+	rows := make([]string, g.height)
+	for i, cells := range g.cells {
+		var b strings.Builder
+		end := len(cells)
+		for end > 0 && cells[end-1] == ' ' {
+			end--
+		}
+		style := previewStyle(-1)
+		for col := 0; col < end; col++ {
+			if cells[col] != ' ' && g.styles[i][col] != style {
+				style = g.styles[i][col]
+				b.WriteString(tags[style])
+			}
+			b.WriteRune(cells[col])
+		}
+		rows[i] = b.String()
+	}
+	return strings.Join(rows, "\n")
+}
+
+// lineGlyph returns the box-drawing character closest to a segment's direction in cells,
+// with rows growing downwards
+func lineGlyph(dx, dy int) rune {
+	switch {
+	case abs(dx) >= 2*abs(dy):
+		return '─'
+	case abs(dy) >= 2*abs(dx):
+		return '│'
+	case (dx > 0) == (dy > 0):
+		return '╲'
+	default:
+		return '╱'
+	}
+}
+
+// abs returns the absolute value of an int
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/remym/go-dwg-extractor/pkg/clipboard"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+)
+
+// svgSize is the width or height in pixels of the longer side of an exported SVG
+const svgSize = 800
+
+// WriteASCII writes the grid last rendered by Render or RenderStyled as plain text, one row
+// per line. It writes "Nothing to preview" when that render had nothing to draw.
+func (r *PreviewRenderer) WriteASCII(w io.Writer) error {
+	content := r.lastText + "\n"
+	if r.last != nil {
+		content = r.last.String() + "\n"
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		return fmt.Errorf("failed to write preview: %w", err)
+	}
+	return nil
+}
+
+// SetColorMode sets which color WriteSVG strokes entities with: their own color, or in
+// layer mode the color of their layer when they are ByLayer or ByBlock
+func (r *PreviewRenderer) SetColorMode(mode clipboard.ColorMode) {
+	r.colorMode = mode
+}
+
+// WriteSVG writes the lines, circles and polylines of all layers of the drawing as an SVG
+// document whose view box is the drawing extents, with Y pointing up as in the drawing.
+// Entities are stroked in their ACI color, or black for white and colors the mode leaves
+// unresolved. A drawing without such geometry gives an empty but valid document.
+func (r *PreviewRenderer) WriteSVG(w io.Writer, d *data.ExtractedData) error {
+	var drawable []data.Entity
+	for _, entity := range d.AllEntities() {
+		switch entity.(type) {
+		case *data.LineInfo, *data.CircleInfo, *data.PolylineInfo:
+			drawable = append(drawable, entity)
+		}
+	}
+	if r.colorMode == clipboard.ColorModeLayer {
+		drawable = data.ResolveColors(drawable, d.Layers)
+	}
+
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	extents := r.extents(drawable)
+	if extents.empty {
+		b.WriteString("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"0\" height=\"0\"></svg>\n")
+		return writeSVG(w, b.String())
+	}
+
+	// Leave a margin so strokes on the extents are not clipped
+	spanX, spanY := extents.maxX-extents.minX, extents.maxY-extents.minY
+	margin := math.Max(spanX, spanY) * 0.02
+	if margin == 0 {
+		margin = 1
+	}
+	viewX, viewY := extents.minX-margin, -extents.maxY-margin
+	viewWidth, viewHeight := spanX+2*margin, spanY+2*margin
+	scale := svgSize / math.Max(viewWidth, viewHeight)
+
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"%s %s %s %s\">\n",
+		int(math.Round(viewWidth*scale)), int(math.Round(viewHeight*scale)),
+		svgNumber(viewX), svgNumber(viewY), svgNumber(viewWidth), svgNumber(viewHeight))
+	// Flipping Y lets coordinates be written as they are in the drawing
+	fmt.Fprintf(&b, "<g transform=\"scale(1,-1)\" fill=\"none\" stroke=\"black\" stroke-width=\"%s\">\n", svgNumber(1/scale))
+	for _, entity := range drawable {
+		switch e := entity.(type) {
+		case *data.LineInfo:
+			fmt.Fprintf(&b, "<line x1=\"%s\" y1=\"%s\" x2=\"%s\" y2=\"%s\"%s/>\n",
+				svgNumber(e.StartPoint.X), svgNumber(e.StartPoint.Y), svgNumber(e.EndPoint.X), svgNumber(e.EndPoint.Y),
+				svgStroke(e.Color))
+		case *data.CircleInfo:
+			fmt.Fprintf(&b, "<circle cx=\"%s\" cy=\"%s\" r=\"%s\"%s/>\n",
+				svgNumber(e.Center.X), svgNumber(e.Center.Y), svgNumber(e.Radius), svgStroke(e.Color))
+		case *data.PolylineInfo:
+			points := make([]string, len(e.Points))
+			for i, p := range e.Points {
+				points[i] = svgNumber(p.X) + "," + svgNumber(p.Y)
+			}
+			element := "polyline"
+			if e.IsClosed {
+				element = "polygon"
+			}
+			fmt.Fprintf(&b, "<%s points=\"%s\"%s/>\n", element, strings.Join(points, " "), svgStroke(e.Color))
+		}
+	}
+	b.WriteString("</g>\n</svg>\n")
+
+	return writeSVG(w, b.String())
+}
+
+// writeSVG writes a finished SVG document
+func writeSVG(w io.Writer, document string) error {
+	if _, err := io.WriteString(w, document); err != nil {
+		return fmt.Errorf("failed to write SVG: %w", err)
+	}
+	return nil
+}
+
+// svgStroke returns the stroke attribute of an element in the given ACI color, or nothing
+// to keep the black of the group for white, ByLayer and ByBlock
+func svgStroke(aci int) string {
+	rgb, ok := data.ACIToRGB(aci)
+	if !ok || aci == 7 {
+		return ""
+	}
+	return fmt.Sprintf(" stroke=\"#%02x%02x%02x\"", rgb.R, rgb.G, rgb.B)
+}
+
+// svgNumber formats a coordinate rounded to six decimals, with as few digits as needed
+func svgNumber(v float64) string {
+	// Adding zero turns a rounded -0 into 0
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6+0, 'f', -1, 64)
+}
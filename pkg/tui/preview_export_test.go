@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/clipboard"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireValidXML fails the test when the document is not well-formed XML
+func requireValidXML(t *testing.T, document string) {
+	t.Helper()
+	decoder := xml.NewDecoder(bytes.NewBufferString(document))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			require.Equal(t, "EOF", err.Error(), "document is not valid XML")
+			return
+		}
+	}
+}
+
+func TestPreviewRenderer_WriteSVG(t *testing.T) {
+	renderer := NewPreviewRenderer()
+
+	t.Run("geometry is scaled to the extents", func(t *testing.T) {
+		d := &data.ExtractedData{
+			Lines:   []data.LineInfo{{EndPoint: data.Point{X: 100, Y: 50}}},
+			Circles: []data.CircleInfo{{Center: data.Point{X: 50, Y: 25}, Radius: 10}},
+			Polylines: []data.PolylineInfo{
+				{Points: []data.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}, IsClosed: true},
+				{Points: []data.Point{{X: 20, Y: 0}, {X: 30, Y: 5}}},
+			},
+			Texts: []data.TextInfo{{Value: "Ignored", InsertionPoint: data.Point{X: 500, Y: 500}}},
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, renderer.WriteSVG(&buf, d))
+		svg := buf.String()
+		requireValidXML(t, svg)
+
+		// The view box is the extents with a 2% margin, the longer side 800 pixels
+		assert.Contains(t, svg, `width="800" height="415" viewBox="-2 -52 104 54"`)
+		assert.Contains(t, svg, `<line x1="0" y1="0" x2="100" y2="50"/>`)
+		assert.Contains(t, svg, `<circle cx="50" cy="25" r="10"/>`)
+		assert.Contains(t, svg, `<polygon points="0,0 10,0 10,10"/>`)
+		assert.Contains(t, svg, `<polyline points="20,0 30,5"/>`)
+		assert.NotContains(t, svg, "Ignored")
+	})
+
+	t.Run("empty drawing", func(t *testing.T) {
+		for _, d := range []*data.ExtractedData{nil, {}, {Texts: []data.TextInfo{{Value: "Only text"}}}} {
+			var buf bytes.Buffer
+			require.NoError(t, renderer.WriteSVG(&buf, d))
+			requireValidXML(t, buf.String())
+			assert.Contains(t, buf.String(), `<svg xmlns="http://www.w3.org/2000/svg" width="0" height="0"></svg>`)
+		}
+	})
+}
+
+func TestPreviewRenderer_WriteSVGColors(t *testing.T) {
+	d := &data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "Walls", Color: 1}},
+		Lines: []data.LineInfo{
+			{Layer: "Walls", Color: data.ColorByLayer, EndPoint: data.Point{X: 10}},
+			{Layer: "Walls", Color: 5, EndPoint: data.Point{Y: 10}},
+			{Layer: "Walls", Color: 7, EndPoint: data.Point{X: 10, Y: 10}},
+		},
+	}
+	renderer := NewPreviewRenderer()
+
+	var buf bytes.Buffer
+	require.NoError(t, renderer.WriteSVG(&buf, d))
+	assert.Contains(t, buf.String(), `<line x1="0" y1="0" x2="10" y2="0"/>`, "ByLayer keeps the default black")
+	assert.Contains(t, buf.String(), `<line x1="0" y1="0" x2="0" y2="10" stroke="#0000ff"/>`)
+	assert.Contains(t, buf.String(), `<line x1="0" y1="0" x2="10" y2="10"/>`, "white is drawn black")
+
+	buf.Reset()
+	renderer.SetColorMode(clipboard.ColorModeLayer)
+	require.NoError(t, renderer.WriteSVG(&buf, d))
+	assert.Contains(t, buf.String(), `<line x1="0" y1="0" x2="10" y2="0" stroke="#ff0000"/>`)
+	assert.Contains(t, buf.String(), `<line x1="0" y1="0" x2="0" y2="10" stroke="#0000ff"/>`)
+}
+
+func TestPreviewRenderer_WriteASCII(t *testing.T) {
+	renderer := NewPreviewRenderer()
+	line := &data.LineInfo{EndPoint: data.Point{X: 10}}
+
+	var buf bytes.Buffer
+	renderer.Highlight(line)
+	renderer.RenderStyled([]data.Entity{line}, 5, 2)
+	require.NoError(t, renderer.WriteASCII(&buf))
+	assert.Equal(t, "─────\n\n", buf.String(), "the text is written without style tags")
+
+	buf.Reset()
+	renderer.Render(nil, 5, 2)
+	require.NoError(t, renderer.WriteASCII(&buf))
+	assert.Equal(t, nothingToPreview+"\n", buf.String())
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewRenderer_Render(t *testing.T) {
+	renderer := NewPreviewRenderer()
+
+	tests := []struct {
+		name     string
+		entities []data.Entity
+		width    int
+		height   int
+		expected string
+	}{
+		{
+			name:     "no entities",
+			width:    10,
+			height:   5,
+			expected: nothingToPreview,
+		},
+		{
+			name:     "single point has no extent",
+			entities: []data.Entity{&data.PointInfo{Location: data.Point{X: 3, Y: 4}}},
+			width:    10,
+			height:   5,
+			expected: nothingToPreview,
+		},
+		{
+			name:     "only undrawable entities",
+			entities: []data.Entity{&data.TextInfo{Value: "Hello"}},
+			width:    10,
+			height:   5,
+			expected: nothingToPreview,
+		},
+		{
+			name:     "horizontal line spans the width",
+			entities: []data.Entity{&data.LineInfo{EndPoint: data.Point{X: 10}}},
+			width:    5,
+			height:   3,
+			expected: "─────\n\n",
+		},
+		{
+			name:     "vertical line spans the height",
+			entities: []data.Entity{&data.LineInfo{EndPoint: data.Point{Y: 10}}},
+			width:    3,
+			height:   3,
+			expected: "│\n│\n│",
+		},
+		{
+			name: "points are drawn over lines",
+			entities: []data.Entity{
+				&data.PointInfo{Location: data.Point{X: 10}},
+				&data.LineInfo{EndPoint: data.Point{X: 10}},
+			},
+			width:    5,
+			height:   1,
+			expected: "────+",
+		},
+		{
+			name: "closed polyline draws all sides",
+			entities: []data.Entity{&data.PolylineInfo{
+				Points:   []data.Point{{X: 0, Y: 0}, {X: 8, Y: 0}, {X: 8, Y: 4}, {X: 0, Y: 4}},
+				IsClosed: true,
+			}},
+			width:  9,
+			height: 3,
+			// Later sides are drawn over the corners of earlier ones
+			expected: "│────────\n" +
+				"│       │\n" +
+				"│───────│",
+		},
+		{
+			name:   "zero size grid",
+			width:  0,
+			height: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, renderer.Render(tt.entities, tt.width, tt.height))
+		})
+	}
+}
+
+func TestPreviewRenderer_Circle(t *testing.T) {
+	renderer := NewPreviewRenderer()
+	circle := &data.CircleInfo{Center: data.Point{X: 5, Y: 5}, Radius: 5}
+
+	rows := strings.Split(renderer.Render([]data.Entity{circle}, 21, 11), "\n")
+	require.Len(t, rows, 11)
+
+	// The circle touches every side of the grid and leaves its center empty
+	assert.Equal(t, 'o', rune(rows[0][10]))
+	assert.Equal(t, 'o', rune(rows[10][10]))
+	assert.Equal(t, "o", rows[5][:1])
+	assert.Len(t, rows[5], 21)
+	assert.Equal(t, ' ', rune(rows[5][10]))
+}
+
+func TestPreviewToggle(t *testing.T) {
+	view := NewDXFView(SetupTestApp(t))
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "0", IsOn: true, Entities: []data.Entity{
+			&data.LineInfo{Layer: "0", EndPoint: data.Point{X: 10, Y: 10}},
+		}}},
+	})
+	assert.Nil(t, view.currentLayerEntities())
+
+	view.showLayerDetails(0)
+	assert.Len(t, view.currentLayerEntities(), 1)
+	assert.False(t, view.IsPreviewVisible())
+
+	ctrlP := tcell.NewEventKey(tcell.KeyCtrlP, 'p', tcell.ModCtrl)
+	assert.Nil(t, view.entityList.GetInputCapture()(ctrlP))
+	assert.True(t, view.IsPreviewVisible())
+	page, _ := view.pages.GetFrontPage()
+	assert.Equal(t, "entities", page)
+
+	view.entityList.GetInputCapture()(ctrlP)
+	assert.False(t, view.IsPreviewVisible())
+}
+
+func TestPreviewRenderer_Highlight(t *testing.T) {
+	renderer := NewPreviewRenderer()
+	top := &data.LineInfo{StartPoint: data.Point{Y: 4}, EndPoint: data.Point{X: 8, Y: 4}}
+	bottom := &data.LineInfo{EndPoint: data.Point{X: 8}}
+	point := &data.PointInfo{Location: data.Point{X: 8, Y: 2}}
+	text := &data.TextInfo{Value: "Hi", InsertionPoint: data.Point{Y: 2}}
+	entities := []data.Entity{top, bottom, point, text}
+
+	assert.Equal(t, "─────────\n        +\n─────────", renderer.RenderStyled(entities, 9, 3),
+		"nothing is styled without a highlight")
+
+	renderer.Highlight(bottom)
+	assert.Equal(t, bottom, renderer.Highlighted())
+	assert.Equal(t, "[gray::d]─────────\n"+
+		"        [gray::d]+\n"+
+		"[yellow::b]─────────", renderer.RenderStyled(entities, 9, 3))
+	assert.Equal(t, "─────────\n        +\n─────────", renderer.Render(entities, 9, 3),
+		"plain rendering ignores the highlight")
+
+	renderer.Highlight(text)
+	assert.Equal(t, "[gray::d]─────────\n"+
+		"[yellow::bl]T       [gray::d]+\n"+
+		"[gray::d]─────────", renderer.RenderStyled(entities, 9, 3),
+		"highlighted text blinks a marker at its insertion point")
+
+	renderer.Highlight(&data.LineInfo{EndPoint: data.Point{X: 8}})
+	assert.Equal(t, "─────────\n        +\n─────────", renderer.RenderStyled(entities, 9, 3),
+		"an entity that is not previewed is not highlighted")
+
+	renderer.Highlight(nil)
+	assert.Nil(t, renderer.Highlighted())
+}
+
+func TestPreviewHighlightFollowsEntityList(t *testing.T) {
+	line := &data.LineInfo{Layer: "0", EndPoint: data.Point{X: 10, Y: 10}}
+	circle := &data.CircleInfo{Layer: "0", Radius: 2}
+	view := NewDXFView(SetupTestApp(t))
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{{Name: "0", IsOn: true, Entities: []data.Entity{line, circle}}},
+	})
+	view.showLayerDetails(0)
+
+	view.entityList.SetCurrentItem(1)
+	assert.Equal(t, line, view.previewRenderer.Highlighted())
+	view.entityList.SetCurrentItem(2)
+	assert.Equal(t, circle, view.previewRenderer.Highlighted())
+	view.entityList.SetCurrentItem(0)
+	assert.Nil(t, view.previewRenderer.Highlighted(), "the back entry highlights nothing")
+}
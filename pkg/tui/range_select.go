@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/rivo/tview"
+)
+
+// rangeSelectPage is the page the select-by-range form is shown on
+const rangeSelectPage = "select-range"
+
+// rangeFields are the labels of the select-by-range form's inputs, in form order
+var rangeFields = []string{"Min X", "Min Y", "Max X", "Max Y"}
+
+// SelectEntitiesInRange adds every entity whose bounds fall within or intersect the box
+// between min and max to the selection, and returns how many entities that is.
+// The corners may be given in any order. Entities without bounds, such as hatches, are ignored.
+func (v *DXFView) SelectEntitiesInRange(min, max data.Point) int {
+	if v.data == nil {
+		return 0
+	}
+
+	box := data.BoundingBox{
+		Min: data.Point{X: math.Min(min.X, max.X), Y: math.Min(min.Y, max.Y)},
+		Max: data.Point{X: math.Max(min.X, max.X), Y: math.Max(min.Y, max.Y)},
+	}
+	var ids []string
+	for _, layer := range v.data.Layers {
+		for i, entity := range layer.Entities {
+			if bounds, ok := data.ComputeBounds(entity); ok && bounds.Intersects(box) {
+				ids = append(ids, entityID(layer.Name, i, entity))
+			}
+		}
+	}
+
+	v.changeSelection("Select entities in range", func() {
+		v.selection.SelectAll(ids)
+	})
+	return len(ids)
+}
+
+// showRangeSelect opens a form for the corners of a box to select entities in,
+// filled in with the drawing's extents
+func (v *DXFView) showRangeSelect() {
+	if v.data == nil || v.rangeForm != nil {
+		return
+	}
+
+	extents, _ := v.data.Extents()
+	values := []float64{extents.Min.X, extents.Min.Y, extents.Max.X, extents.Max.Y}
+
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle("Select by coordinate range")
+	for i, label := range rangeFields {
+		form.AddInputField(label, strconv.FormatFloat(values[i], 'f', -1, 64), 20, tview.InputFieldFloat, nil)
+	}
+	form.AddButton("Select", func() {
+		corners, err := rangeFormValues(form)
+		if err != nil {
+			v.statusHandler.show(err.Error(), StatusError)
+			return
+		}
+		v.closeRangeSelect()
+		count := v.SelectEntitiesInRange(data.Point{X: corners[0], Y: corners[1]}, data.Point{X: corners[2], Y: corners[3]})
+		v.statusHandler.ShowMessage(fmt.Sprintf("Selected %d entities in range", count))
+	})
+	form.AddButton("Cancel", v.closeRangeSelect)
+	// Escape closes the form without selecting
+	form.SetCancelFunc(v.closeRangeSelect)
+
+	v.rangeForm = form
+	v.showOverlay(rangeSelectPage, form, 40, 2*len(rangeFields)+5)
+}
+
+// rangeFormValues returns the numbers typed into the select-by-range form, in form order
+func rangeFormValues(form *tview.Form) ([]float64, error) {
+	values := make([]float64, len(rangeFields))
+	for i, label := range rangeFields {
+		text := form.GetFormItem(i).(*tview.InputField).GetText()
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number, got %q", label, text)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// closeRangeSelect removes the select-by-range form and returns focus to where it was
+func (v *DXFView) closeRangeSelect() {
+	if v.rangeForm == nil {
+		return
+	}
+	v.rangeForm = nil
+	v.closeOverlay(rangeSelectPage)
+}
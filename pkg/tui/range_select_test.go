@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRangeTestData returns a drawing with entities inside, across and outside the box (0,0)-(10,10)
+func createRangeTestData() *data.ExtractedData {
+	return &data.ExtractedData{Layers: []data.LayerInfo{
+		{Name: "Walls", IsOn: true, Entities: []data.Entity{
+			&data.LineInfo{StartPoint: data.Point{X: 1, Y: 1}, EndPoint: data.Point{X: 4, Y: 1}, Layer: "Walls"},
+			&data.LineInfo{StartPoint: data.Point{X: 20, Y: 20}, EndPoint: data.Point{X: 30, Y: 20}, Layer: "Walls"},
+			&data.CircleInfo{Center: data.Point{X: 12, Y: 5}, Radius: 3, Layer: "Walls"},
+		}},
+		{Name: "Notes", IsOn: true, Entities: []data.Entity{
+			&data.TextInfo{Value: "A", InsertionPoint: data.Point{X: 10, Y: 10}, Layer: "Notes"},
+			&data.HatchInfo{PatternName: "SOLID", Layer: "Notes"},
+		}},
+	}}
+}
+
+func TestDXFView_SelectEntitiesInRange(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createRangeTestData())
+
+	// The line inside, the circle crossing the edge and the text on the corner are selected
+	count := view.SelectEntitiesInRange(data.Point{X: 10, Y: 10}, data.Point{X: 0, Y: 0})
+	assert.Equal(t, 3, count)
+	ids := view.GetSelectionState().GetSelectedItemIDs()
+	sort.Strings(ids)
+	assert.Equal(t, []string{"Notes:0", "Walls:0", "Walls:2"}, ids)
+
+	// The selection is added to, and undone in one step
+	view.SelectEntitiesInRange(data.Point{X: 19, Y: 19}, data.Point{X: 21, Y: 21})
+	assert.Equal(t, 4, view.GetSelectionState().GetSelectedCount())
+	require.True(t, view.undoManager.Undo())
+	assert.Equal(t, 3, view.GetSelectionState().GetSelectedCount())
+
+	// Entity list markers follow the selection
+	view.showLayerDetails(0)
+	mainText, _ := view.entityList.GetItemText(1)
+	assert.Contains(t, mainText, selectedMarker)
+	mainText, _ = view.entityList.GetItemText(2)
+	assert.NotContains(t, mainText, selectedMarker)
+
+	assert.Equal(t, 0, view.SelectEntitiesInRange(data.Point{X: 100, Y: 100}, data.Point{X: 200, Y: 200}))
+	assert.Equal(t, 0, NewDXFView(app).SelectEntitiesInRange(data.Point{}, data.Point{X: 1, Y: 1}), "no drawing, nothing to select")
+}
+
+// setRangeFields types values into the select-by-range form
+func setRangeFields(form *tview.Form, values ...string) {
+	for i, value := range values {
+		form.GetFormItem(i).(*tview.InputField).SetText(value)
+	}
+}
+
+func TestDXFView_RangeSelectForm(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createRangeTestData())
+	app.SetFocus(view.layers)
+
+	view.layers.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'R', tcell.ModNone))
+	require.NotNil(t, view.rangeForm)
+	assert.True(t, view.handlesEsc())
+	assert.True(t, view.rangeForm.HasFocus())
+
+	// The form starts out with the drawing's extents
+	values, err := rangeFormValues(view.rangeForm)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 1, 30, 20}, values)
+
+	// A value that is not a number keeps the form open
+	setRangeFields(view.rangeForm, "0", "-", "10", "10")
+	selectButton := view.rangeForm.GetButton(0)
+	selectButton.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	require.NotNil(t, view.rangeForm)
+	message, level := view.GetStatusBar().Message()
+	assert.Equal(t, `Min Y must be a number, got "-"`, message)
+	assert.Equal(t, StatusError, level)
+
+	setRangeFields(view.rangeForm, "0", "0", "10", "10")
+	selectButton.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	assert.Nil(t, view.rangeForm)
+	assert.False(t, view.pages.HasPage(rangeSelectPage))
+	assert.Equal(t, view.layers, app.GetFocus(), "focus returns to where it was")
+	assert.Equal(t, 3, view.GetSelectionState().GetSelectedCount())
+	message, _ = view.GetStatusBar().Message()
+	assert.Equal(t, "Selected 3 entities in range", message)
+}
+
+func TestDXFView_RangeSelectFormCancel(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createRangeTestData())
+	view.showLayerDetails(0)
+	app.SetFocus(view.entityList)
+
+	view.entityList.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'R', tcell.ModNone))
+	require.NotNil(t, view.rangeForm)
+
+	view.rangeForm.InputHandler()(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone), func(p tview.Primitive) {})
+	assert.Nil(t, view.rangeForm)
+	assert.False(t, view.handlesEsc())
+	assert.Equal(t, view.entityList, app.GetFocus())
+	assert.Equal(t, 0, view.GetSelectionState().GetSelectedCount())
+}
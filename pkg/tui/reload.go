@@ -0,0 +1,60 @@
+package tui
+
+// reloadState is the part of the view a Ctrl+R reload keeps, applied by the Update that
+// shows the reloaded drawing
+type reloadState struct {
+	path         string // Drawing reloaded; the state is dropped if another one is shown instead
+	layerName    string // Layer shown or selected in the layers list before the reload
+	showEntities bool   // The entities of the layer were shown rather than the layers list
+}
+
+// SetReloadHandler sets the function Ctrl+R calls with the path of the drawing shown, or
+// "" for sample data, to read it again and pass the result to Update. Without one,
+// Ctrl+R does nothing.
+func (v *DXFView) SetReloadHandler(handler func(path string)) {
+	v.reloadHandler = handler
+}
+
+// Reload reads the drawing shown again through the reload handler. The layer being viewed
+// stays open, or selected in the layers list, when the reloaded drawing still has it.
+func (v *DXFView) Reload() {
+	if v.reloadHandler == nil || v.data == nil || v.IsLoading() {
+		return
+	}
+
+	// Layers are kept by name, since the list may be filtered by a search
+	state := &reloadState{path: v.sourcePath}
+	if page, _ := v.pages.GetFrontPage(); page == "entities" {
+		if v.currentLayerIndex >= 0 && v.currentLayerIndex < len(v.data.Layers) {
+			state.layerName, state.showEntities = v.data.Layers[v.currentLayerIndex].Name, true
+		}
+	} else if current := v.layers.GetCurrentItem(); current < v.layers.GetItemCount() {
+		state.layerName = v.layerNameAt(current)
+	}
+	v.reload = state
+	v.reloadHandler(v.sourcePath)
+}
+
+// applyReload restores the view state kept by Reload, when the drawing shown is the one
+// reloaded and still has the layer
+func (v *DXFView) applyReload() {
+	state := v.reload
+	v.reload = nil
+	if state == nil || state.path != v.sourcePath {
+		return
+	}
+	if v.layerIndexByName(state.layerName) < 0 {
+		return
+	}
+
+	if state.showEntities {
+		v.showLayerDetails(v.layerIndexByName(state.layerName))
+		return
+	}
+	for i := 0; i < v.layers.GetItemCount(); i++ {
+		if v.layerNameAt(i) == state.layerName {
+			v.layers.SetCurrentItem(i)
+			return
+		}
+	}
+}
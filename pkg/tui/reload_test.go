@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDXFView_Reload(t *testing.T) {
+	drawing := func(names ...string) *data.ExtractedData {
+		d := &data.ExtractedData{DXFVersion: "R2020"}
+		for _, name := range names {
+			d.Layers = append(d.Layers, data.LayerInfo{Name: name, IsOn: true, Entities: []data.Entity{
+				&data.LineInfo{Layer: name, EndPoint: data.Point{X: 1}},
+			}})
+		}
+		return d
+	}
+
+	t.Run("without a handler nothing happens", func(t *testing.T) {
+		view := NewDXFView(tview.NewApplication())
+		view.Update(drawing("Walls"))
+		assert.NotPanics(t, view.Reload)
+	})
+
+	t.Run("the open layer stays open", func(t *testing.T) {
+		view := NewDXFView(tview.NewApplication())
+		view.SetSourcePath("plan.dxf")
+		view.Update(drawing("Walls", "Doors"))
+		view.showLayerDetails(1)
+
+		var reloaded string
+		view.SetReloadHandler(func(path string) {
+			reloaded = path
+			view.Update(drawing("Walls", "Doors", "Windows"))
+		})
+		view.Reload()
+
+		assert.Equal(t, "plan.dxf", reloaded)
+		page, _ := view.pages.GetFrontPage()
+		assert.Equal(t, "entities", page)
+		assert.Equal(t, 1, view.currentLayerIndex)
+		assert.Equal(t, 3, view.LayerCount())
+	})
+
+	t.Run("the selected layer stays selected", func(t *testing.T) {
+		view := NewDXFView(tview.NewApplication())
+		view.Update(drawing("Walls", "Doors", "Windows"))
+		view.layers.SetCurrentItem(2)
+
+		var reloaded string
+		view.SetReloadHandler(func(path string) {
+			reloaded = path
+			view.Update(drawing("Walls", "Doors", "Windows"))
+		})
+		view.Reload()
+
+		assert.Empty(t, reloaded, "sample data is reloaded with an empty path")
+		page, _ := view.pages.GetFrontPage()
+		assert.Equal(t, "layers", page)
+		assert.Equal(t, 2, view.layers.GetCurrentItem())
+	})
+
+	t.Run("the layer selected in a search stays selected", func(t *testing.T) {
+		view := NewDXFView(tview.NewApplication())
+		view.Update(drawing("Walls", "Doors", "Windows"))
+		view.searchInput.SetText("win")
+		require.Equal(t, "Windows", view.layerNameAt(view.layers.GetCurrentItem()))
+
+		view.SetReloadHandler(func(string) {
+			view.Update(drawing("Walls", "Doors", "Windows"))
+		})
+		view.Reload()
+
+		assert.Equal(t, "Windows", view.layerNameAt(view.layers.GetCurrentItem()))
+	})
+
+	t.Run("a layer that is gone falls back to the layers list", func(t *testing.T) {
+		view := NewDXFView(tview.NewApplication())
+		view.Update(drawing("Walls", "Doors"))
+		view.showLayerDetails(1)
+
+		view.SetReloadHandler(func(string) {
+			view.Update(drawing("Walls"))
+		})
+		view.Reload()
+
+		page, _ := view.pages.GetFrontPage()
+		assert.Equal(t, "layers", page)
+		require.Nil(t, view.reload)
+	})
+
+	t.Run("another drawing shown instead drops the state", func(t *testing.T) {
+		view := NewDXFView(tview.NewApplication())
+		view.SetSourcePath("plan.dxf")
+		view.Update(drawing("Walls", "Doors"))
+		view.showLayerDetails(1)
+
+		view.SetReloadHandler(func(string) {})
+		view.Reload()
+		view.SetSourcePath("other.dxf")
+		view.Update(drawing("Walls", "Doors"))
+
+		page, _ := view.pages.GetFrontPage()
+		assert.Equal(t, "layers", page)
+	})
+}
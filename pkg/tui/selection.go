@@ -123,6 +123,14 @@ func (cs *EnhancedCategorySelector) selectLayer(index int) error {
 		case *data.TextInfo:
 			itemText = fmt.Sprintf("Text: %s at (%.1f,%.1f)",
 				e.Value, e.InsertionPoint.X, e.InsertionPoint.Y)
+		case *data.PolylineInfo:
+			itemText = fmt.Sprintf("Polyline with %d points", len(e.Points))
+		case *data.DimensionInfo:
+			itemText = fmt.Sprintf("Dimension: %s (%s)", e.DisplayText(), e.DimensionType)
+		case *data.PointInfo:
+			itemText = fmt.Sprintf("Point (%.1f, %.1f)", e.Location.X, e.Location.Y)
+		case *data.HatchInfo:
+			itemText = fmt.Sprintf("Hatch: pattern %s (%d boundary points)", e.PatternName, e.BoundaryPointCount)
 		default:
 			itemText = fmt.Sprintf("Entity: %T", entity)
 		}
@@ -136,13 +144,7 @@ func (cs *EnhancedCategorySelector) selectLayer(index int) error {
 	}
 
 	// Update the details view with layer information
-	cs.view.textView.Clear()
-	fmt.Fprintf(cs.view.textView, "[green]Layer:[-] %s\n", layer.Name)
-	fmt.Fprintf(cs.view.textView, "[green]Color:[-] %d\n", layer.Color)
-	fmt.Fprintf(cs.view.textView, "[green]Status:[-] %s\n", map[bool]string{true: "ON", false: "OFF"}[layer.IsOn])
-	fmt.Fprintf(cs.view.textView, "[green]Frozen:[-] %v\n", layer.IsFrozen)
-	fmt.Fprintf(cs.view.textView, "[green]Line Type:[-] %s\n", layer.LineType)
-	fmt.Fprintf(cs.view.textView, "[green]Entities:[-] %d\n\n", len(layer.Entities))
+	cs.view.writeLayerSummary(layer, len(layer.Entities))
 
 	return nil
 }
@@ -151,13 +153,11 @@ func (cs *EnhancedCategorySelector) selectLayer(index int) error {
 func (cs *EnhancedCategorySelector) selectBlockCategory(index int) error {
 	// Collect all block entities from all layers
 	var blocks []*data.BlockInfo
-	for _, layer := range cs.view.data.Layers {
-		for _, entity := range layer.Entities {
-			if block, ok := entity.(*data.BlockInfo); ok {
-				blocks = append(blocks, block)
-			}
+	cs.view.data.ForEachEntity(func(_ *data.LayerInfo, entity data.Entity) {
+		if block, ok := entity.(*data.BlockInfo); ok {
+			blocks = append(blocks, block)
 		}
-	}
+	})
 
 	// Clear the current list and add blocks
 	cs.view.layers.Clear()
@@ -199,13 +199,11 @@ func (cs *EnhancedCategorySelector) selectBlockCategory(index int) error {
 func (cs *EnhancedCategorySelector) selectTextCategory(index int) error {
 	// Collect all text entities from all layers
 	var texts []*data.TextInfo
-	for _, layer := range cs.view.data.Layers {
-		for _, entity := range layer.Entities {
-			if text, ok := entity.(*data.TextInfo); ok {
-				texts = append(texts, text)
-			}
+	cs.view.data.ForEachEntity(func(_ *data.LayerInfo, entity data.Entity) {
+		if text, ok := entity.(*data.TextInfo); ok {
+			texts = append(texts, text)
 		}
-	}
+	})
 
 	// Clear the current list and add texts
 	cs.view.layers.Clear()
@@ -244,21 +242,26 @@ func (cs *EnhancedCategorySelector) updateEntityDetails(entity data.Entity) {
 		fmt.Fprintf(cs.view.textView, "[green]Line Entity[-]\n\n")
 		fmt.Fprintf(cs.view.textView, "[green]Start Point:[-] (%.1f, %.1f)\n", e.StartPoint.X, e.StartPoint.Y)
 		fmt.Fprintf(cs.view.textView, "[green]End Point:[-] (%.1f, %.1f)\n", e.EndPoint.X, e.EndPoint.Y)
+		fmt.Fprintf(cs.view.textView, "[green]Length:[-] %.2f\n", e.Length())
 		fmt.Fprintf(cs.view.textView, "[green]Layer:[-] %s\n", e.Layer)
-		fmt.Fprintf(cs.view.textView, "[green]Color:[-] %d\n", e.Color)
+		fmt.Fprintf(cs.view.textView, "[green]Color:[-] %s\n", cs.view.entityColorText(e))
 
 	case *data.CircleInfo:
 		fmt.Fprintf(cs.view.textView, "[green]Circle Entity[-]\n\n")
 		fmt.Fprintf(cs.view.textView, "[green]Center:[-] (%.1f, %.1f)\n", e.Center.X, e.Center.Y)
 		fmt.Fprintf(cs.view.textView, "[green]Radius:[-] %.1f\n", e.Radius)
 		fmt.Fprintf(cs.view.textView, "[green]Layer:[-] %s\n", e.Layer)
-		fmt.Fprintf(cs.view.textView, "[green]Color:[-] %d\n", e.Color)
+		fmt.Fprintf(cs.view.textView, "[green]Color:[-] %s\n", cs.view.entityColorText(e))
 
 	case *data.TextInfo:
 		fmt.Fprintf(cs.view.textView, "[green]Text Entity[-]\n\n")
 		fmt.Fprintf(cs.view.textView, "[green]Value:[-] %s\n", e.Value)
 		fmt.Fprintf(cs.view.textView, "[green]Insertion Point:[-] (%.1f, %.1f)\n", e.InsertionPoint.X, e.InsertionPoint.Y)
 		fmt.Fprintf(cs.view.textView, "[green]Height:[-] %.1f\n", e.Height)
+		fmt.Fprintf(cs.view.textView, "[green]Rotation:[-] %.1f\n", e.Rotation)
+		if e.HorizontalAlign != "" || e.VerticalAlign != "" {
+			fmt.Fprintf(cs.view.textView, "[green]Alignment:[-] %s\n", textAlignment(e))
+		}
 		fmt.Fprintf(cs.view.textView, "[green]Layer:[-] %s\n", e.Layer)
 
 	case *data.BlockInfo:
@@ -276,12 +279,55 @@ func (cs *EnhancedCategorySelector) updateEntityDetails(entity data.Entity) {
 			}
 		}
 
+	case *data.PolylineInfo:
+		fmt.Fprintf(cs.view.textView, "[green]Polyline Entity[-]\n\n")
+		fmt.Fprintf(cs.view.textView, "[green]Points:[-] %d\n", len(e.Points))
+		fmt.Fprintf(cs.view.textView, "[green]Closed:[-] %v\n", e.IsClosed)
+		fmt.Fprintf(cs.view.textView, "[green]Length:[-] %.2f\n", e.Length())
+		fmt.Fprintf(cs.view.textView, "[green]Area:[-] %.2f\n", e.Area())
+		fmt.Fprintf(cs.view.textView, "[green]Layer:[-] %s\n", e.Layer)
+		fmt.Fprintf(cs.view.textView, "[green]Color:[-] %s\n", cs.view.entityColorText(e))
+
+	case *data.DimensionInfo:
+		fmt.Fprintf(cs.view.textView, "[green]Dimension Entity[-]\n\n")
+		fmt.Fprintf(cs.view.textView, "[green]Type:[-] %s\n", e.DimensionType)
+		fmt.Fprintf(cs.view.textView, "[green]Text:[-] %s\n", e.DisplayText())
+		fmt.Fprintf(cs.view.textView, "[green]Measurement:[-] %.2f\n", e.Measurement)
+		fmt.Fprintf(cs.view.textView, "[green]Definition Point:[-] (%.1f, %.1f)\n", e.DefinitionPoint.X, e.DefinitionPoint.Y)
+		fmt.Fprintf(cs.view.textView, "[green]Layer:[-] %s\n", e.Layer)
+
+	case *data.PointInfo:
+		fmt.Fprintf(cs.view.textView, "[green]Point Entity[-]\n\n")
+		fmt.Fprintf(cs.view.textView, "[green]Location:[-] (%.1f, %.1f, %.1f)\n", e.Location.X, e.Location.Y, e.Location.Z)
+		fmt.Fprintf(cs.view.textView, "[green]Layer:[-] %s\n", e.Layer)
+		fmt.Fprintf(cs.view.textView, "[green]Color:[-] %s\n", cs.view.entityColorText(e))
+
+	case *data.HatchInfo:
+		fmt.Fprintf(cs.view.textView, "[green]Hatch Entity[-]\n\n")
+		fmt.Fprintf(cs.view.textView, "[green]Pattern:[-] %s\n", e.PatternName)
+		fmt.Fprintf(cs.view.textView, "[green]Solid:[-] %v\n", e.IsSolid)
+		fmt.Fprintf(cs.view.textView, "[green]Boundary Points:[-] %d\n", e.BoundaryPointCount)
+		fmt.Fprintf(cs.view.textView, "[green]Layer:[-] %s\n", e.Layer)
+		fmt.Fprintf(cs.view.textView, "[green]Color:[-] %s\n", cs.view.entityColorText(e))
+
 	default:
 		fmt.Fprintf(cs.view.textView, "[green]Entity:[-] %T\n", entity)
 		fmt.Fprintf(cs.view.textView, "[green]Layer:[-] %s\n", entity.GetLayer())
 	}
 }
 
+// textAlignment describes a text's alignment, e.g. "Center, Middle"
+func textAlignment(text *data.TextInfo) string {
+	switch {
+	case text.HorizontalAlign == "":
+		return text.VerticalAlign
+	case text.VerticalAlign == "":
+		return text.HorizontalAlign
+	default:
+		return text.HorizontalAlign + ", " + text.VerticalAlign
+	}
+}
+
 // EnhancedItemSelector implements ItemSelector with actual functionality
 type EnhancedItemSelector struct {
 	view      *DXFView
@@ -304,28 +350,42 @@ func (is *EnhancedItemSelector) SelectItem(itemType string, index int) error {
 
 	// Find entities of the specified type
 	var entities []data.Entity
-	for _, layer := range is.view.data.Layers {
-		for _, entity := range layer.Entities {
-			switch itemType {
-			case "line":
-				if _, ok := entity.(*data.LineInfo); ok {
-					entities = append(entities, entity)
-				}
-			case "circle":
-				if _, ok := entity.(*data.CircleInfo); ok {
-					entities = append(entities, entity)
-				}
-			case "text":
-				if _, ok := entity.(*data.TextInfo); ok {
-					entities = append(entities, entity)
-				}
-			case "block":
-				if _, ok := entity.(*data.BlockInfo); ok {
-					entities = append(entities, entity)
-				}
+	is.view.data.ForEachEntity(func(_ *data.LayerInfo, entity data.Entity) {
+		switch itemType {
+		case "line":
+			if _, ok := entity.(*data.LineInfo); ok {
+				entities = append(entities, entity)
+			}
+		case "circle":
+			if _, ok := entity.(*data.CircleInfo); ok {
+				entities = append(entities, entity)
+			}
+		case "text":
+			if _, ok := entity.(*data.TextInfo); ok {
+				entities = append(entities, entity)
+			}
+		case "block":
+			if _, ok := entity.(*data.BlockInfo); ok {
+				entities = append(entities, entity)
+			}
+		case "polyline":
+			if _, ok := entity.(*data.PolylineInfo); ok {
+				entities = append(entities, entity)
+			}
+		case "dimension":
+			if _, ok := entity.(*data.DimensionInfo); ok {
+				entities = append(entities, entity)
+			}
+		case "point":
+			if _, ok := entity.(*data.PointInfo); ok {
+				entities = append(entities, entity)
+			}
+		case "hatch":
+			if _, ok := entity.(*data.HatchInfo); ok {
+				entities = append(entities, entity)
 			}
 		}
-	}
+	})
 
 	if index < 0 || index >= len(entities) {
 		return fmt.Errorf("entity index %d out of range for type %s", index, itemType)
@@ -347,21 +407,26 @@ func (is *EnhancedItemSelector) updateDetailsPane(entity data.Entity) {
 		fmt.Fprintf(is.view.textView, "[green]Line Entity[-]\n\n")
 		fmt.Fprintf(is.view.textView, "[green]Start Point:[-] (%.1f, %.1f)\n", e.StartPoint.X, e.StartPoint.Y)
 		fmt.Fprintf(is.view.textView, "[green]End Point:[-] (%.1f, %.1f)\n", e.EndPoint.X, e.EndPoint.Y)
+		fmt.Fprintf(is.view.textView, "[green]Length:[-] %.2f\n", e.Length())
 		fmt.Fprintf(is.view.textView, "[green]Layer:[-] %s\n", e.Layer)
-		fmt.Fprintf(is.view.textView, "[green]Color:[-] %d\n", e.Color)
+		fmt.Fprintf(is.view.textView, "[green]Color:[-] %s\n", is.view.entityColorText(e))
 
 	case *data.CircleInfo:
 		fmt.Fprintf(is.view.textView, "[green]Circle Entity[-]\n\n")
 		fmt.Fprintf(is.view.textView, "[green]Center:[-] (%.1f, %.1f)\n", e.Center.X, e.Center.Y)
 		fmt.Fprintf(is.view.textView, "[green]Radius:[-] %.1f\n", e.Radius)
 		fmt.Fprintf(is.view.textView, "[green]Layer:[-] %s\n", e.Layer)
-		fmt.Fprintf(is.view.textView, "[green]Color:[-] %d\n", e.Color)
+		fmt.Fprintf(is.view.textView, "[green]Color:[-] %s\n", is.view.entityColorText(e))
 
 	case *data.TextInfo:
 		fmt.Fprintf(is.view.textView, "[green]Text Entity[-]\n\n")
 		fmt.Fprintf(is.view.textView, "[green]Value:[-] %s\n", e.Value)
 		fmt.Fprintf(is.view.textView, "[green]Insertion Point:[-] (%.1f, %.1f)\n", e.InsertionPoint.X, e.InsertionPoint.Y)
 		fmt.Fprintf(is.view.textView, "[green]Height:[-] %.1f\n", e.Height)
+		fmt.Fprintf(is.view.textView, "[green]Rotation:[-] %.1f\n", e.Rotation)
+		if e.HorizontalAlign != "" || e.VerticalAlign != "" {
+			fmt.Fprintf(is.view.textView, "[green]Alignment:[-] %s\n", textAlignment(e))
+		}
 		fmt.Fprintf(is.view.textView, "[green]Layer:[-] %s\n", e.Layer)
 
 	case *data.BlockInfo:
@@ -378,6 +443,37 @@ func (is *EnhancedItemSelector) updateDetailsPane(entity data.Entity) {
 				fmt.Fprintf(is.view.textView, "  %s: %s\n", attr.Tag, attr.Value)
 			}
 		}
+
+	case *data.PolylineInfo:
+		fmt.Fprintf(is.view.textView, "[green]Polyline Entity[-]\n\n")
+		fmt.Fprintf(is.view.textView, "[green]Points:[-] %d\n", len(e.Points))
+		fmt.Fprintf(is.view.textView, "[green]Closed:[-] %v\n", e.IsClosed)
+		fmt.Fprintf(is.view.textView, "[green]Length:[-] %.2f\n", e.Length())
+		fmt.Fprintf(is.view.textView, "[green]Area:[-] %.2f\n", e.Area())
+		fmt.Fprintf(is.view.textView, "[green]Layer:[-] %s\n", e.Layer)
+		fmt.Fprintf(is.view.textView, "[green]Color:[-] %s\n", is.view.entityColorText(e))
+
+	case *data.DimensionInfo:
+		fmt.Fprintf(is.view.textView, "[green]Dimension Entity[-]\n\n")
+		fmt.Fprintf(is.view.textView, "[green]Type:[-] %s\n", e.DimensionType)
+		fmt.Fprintf(is.view.textView, "[green]Text:[-] %s\n", e.DisplayText())
+		fmt.Fprintf(is.view.textView, "[green]Measurement:[-] %.2f\n", e.Measurement)
+		fmt.Fprintf(is.view.textView, "[green]Definition Point:[-] (%.1f, %.1f)\n", e.DefinitionPoint.X, e.DefinitionPoint.Y)
+		fmt.Fprintf(is.view.textView, "[green]Layer:[-] %s\n", e.Layer)
+
+	case *data.PointInfo:
+		fmt.Fprintf(is.view.textView, "[green]Point Entity[-]\n\n")
+		fmt.Fprintf(is.view.textView, "[green]Location:[-] (%.1f, %.1f, %.1f)\n", e.Location.X, e.Location.Y, e.Location.Z)
+		fmt.Fprintf(is.view.textView, "[green]Layer:[-] %s\n", e.Layer)
+		fmt.Fprintf(is.view.textView, "[green]Color:[-] %s\n", is.view.entityColorText(e))
+
+	case *data.HatchInfo:
+		fmt.Fprintf(is.view.textView, "[green]Hatch Entity[-]\n\n")
+		fmt.Fprintf(is.view.textView, "[green]Pattern:[-] %s\n", e.PatternName)
+		fmt.Fprintf(is.view.textView, "[green]Solid:[-] %v\n", e.IsSolid)
+		fmt.Fprintf(is.view.textView, "[green]Boundary Points:[-] %d\n", e.BoundaryPointCount)
+		fmt.Fprintf(is.view.textView, "[green]Layer:[-] %s\n", e.Layer)
+		fmt.Fprintf(is.view.textView, "[green]Color:[-] %s\n", is.view.entityColorText(e))
 	}
 }
 
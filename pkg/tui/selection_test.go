@@ -323,7 +323,19 @@ func TestEntityDetailsFormatting(t *testing.T) {
 				Height:         12.0,
 				Layer:          "TextLayer",
 			},
-			expectedFields: []string{"Text Entity", "Value", "Insertion Point", "Height", "Layer"},
+			expectedFields: []string{"Text Entity", "Value", "Insertion Point", "Height", "Rotation: 0.0", "Layer"},
+		},
+		{
+			name: "Aligned TextInfo formatting",
+			entity: &data.TextInfo{
+				Value:           "Centered",
+				Height:          2.5,
+				Rotation:        30.0,
+				HorizontalAlign: "Center",
+				VerticalAlign:   "Middle",
+				Layer:           "TextLayer",
+			},
+			expectedFields: []string{"Text Entity", "Rotation: 30.0", "Alignment: Center, Middle"},
 		},
 		{
 			name: "BlockInfo formatting with attributes",
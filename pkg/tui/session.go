@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Panes a session can restore focus to
+const (
+	PaneSearch   = "search"
+	PaneLayers   = "layers"
+	PaneEntities = "entities"
+)
+
+// Session is the view state of one drawing, restored when the drawing is opened again
+type Session struct {
+	FilePath    string    `json:"file_path"`
+	ModTime     time.Time `json:"mod_time"` // State of the drawing when the session was saved
+	Size        int64     `json:"size"`
+	FocusedPane string    `json:"focused_pane"`
+	LayerIndex  int       `json:"layer_index"` // -1 when no layer was open
+	SearchText  string    `json:"search_text"`
+	SelectedIDs []string  `json:"selected_ids"`
+}
+
+// sessionFile is the on-disk form of the session file, one session per drawing path
+type sessionFile struct {
+	Sessions map[string]Session `json:"sessions"`
+}
+
+// SessionManager saves and restores the view state of drawings in a JSON session file
+type SessionManager struct {
+	path string
+}
+
+// NewSessionManager creates a session manager that keeps sessions in the file at path
+func NewSessionManager(path string) *SessionManager {
+	return &SessionManager{path: path}
+}
+
+// DefaultSessionPath returns the session file in the user's configuration directory
+func DefaultSessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find user config directory: %w", err)
+	}
+	return filepath.Join(dir, "go-dwg-extractor", "session.json"), nil
+}
+
+// Path returns the session file the manager reads and writes
+func (m *SessionManager) Path() string {
+	return m.path
+}
+
+// Save records session for its drawing, replacing any earlier session of the same drawing.
+// The drawing's current modification time and size are recorded so that Load can tell
+// when the drawing changed since.
+func (m *SessionManager) Save(session Session) error {
+	key, err := sessionKey(session.FilePath)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(session.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat drawing: %w", err)
+	}
+	session.FilePath = key
+	session.ModTime = info.ModTime()
+	session.Size = info.Size()
+
+	// A corrupt session file is replaced rather than reported
+	file := m.read()
+	if file.Sessions == nil {
+		file.Sessions = make(map[string]Session)
+	}
+	file.Sessions[key] = session
+
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	if err := os.WriteFile(m.path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+	return nil
+}
+
+// Load returns the saved session of the drawing at filePath. It reports false when there
+// is none, the session file is missing or corrupt, or the drawing changed since it was saved.
+func (m *SessionManager) Load(filePath string) (Session, bool) {
+	key, err := sessionKey(filePath)
+	if err != nil {
+		return Session{}, false
+	}
+	session, ok := m.read().Sessions[key]
+	if !ok {
+		return Session{}, false
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil || !info.ModTime().Equal(session.ModTime) || info.Size() != session.Size {
+		return Session{}, false
+	}
+	return session, true
+}
+
+// read returns the contents of the session file, empty when it is missing or corrupt
+func (m *SessionManager) read() sessionFile {
+	var file sessionFile
+	content, err := os.ReadFile(m.path)
+	if err != nil {
+		return sessionFile{}
+	}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return sessionFile{}
+	}
+	return file
+}
+
+// sessionKey returns the absolute path sessions of the drawing are stored under
+func sessionKey(filePath string) (string, error) {
+	key, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve drawing path: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDrawing creates a stand-in drawing file and returns its path
+func writeDrawing(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "drawing.dxf")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestSessionManager_SaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	drawing := writeDrawing(t, dir, "0\nEOF\n")
+	manager := NewSessionManager(filepath.Join(dir, "config", "session.json"))
+
+	saved := Session{
+		FilePath:    drawing,
+		FocusedPane: PaneEntities,
+		LayerIndex:  1,
+		SearchText:  "wall",
+		SelectedIDs: []string{"Walls:0"},
+	}
+	require.NoError(t, manager.Save(saved))
+
+	session, ok := manager.Load(drawing)
+	require.True(t, ok)
+	assert.Equal(t, PaneEntities, session.FocusedPane)
+	assert.Equal(t, 1, session.LayerIndex)
+	assert.Equal(t, "wall", session.SearchText)
+	assert.Equal(t, []string{"Walls:0"}, session.SelectedIDs)
+
+	_, ok = manager.Load(filepath.Join(dir, "other.dxf"))
+	assert.False(t, ok, "sessions are matched by path")
+}
+
+func TestSessionManager_IgnoresUnusableSessions(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, manager *SessionManager, drawing string)
+	}{
+		{
+			name:  "missing session file",
+			setup: func(t *testing.T, manager *SessionManager, drawing string) {},
+		},
+		{
+			name: "corrupt session file",
+			setup: func(t *testing.T, manager *SessionManager, drawing string) {
+				require.NoError(t, os.WriteFile(manager.Path(), []byte("{not json"), 0o644))
+			},
+		},
+		{
+			name: "drawing changed since the session was saved",
+			setup: func(t *testing.T, manager *SessionManager, drawing string) {
+				require.NoError(t, manager.Save(Session{FilePath: drawing, LayerIndex: -1}))
+				require.NoError(t, os.WriteFile(drawing, []byte("0\nSECTION\n0\nEOF\n"), 0o644))
+				later := time.Now().Add(time.Minute)
+				require.NoError(t, os.Chtimes(drawing, later, later))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			drawing := writeDrawing(t, dir, "0\nEOF\n")
+			manager := NewSessionManager(filepath.Join(dir, "session.json"))
+			tt.setup(t, manager, drawing)
+
+			_, ok := manager.Load(drawing)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestSessionManager_SaveReplacesCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	drawing := writeDrawing(t, dir, "0\nEOF\n")
+	manager := NewSessionManager(filepath.Join(dir, "session.json"))
+	require.NoError(t, os.WriteFile(manager.Path(), []byte("garbage"), 0o644))
+
+	require.NoError(t, manager.Save(Session{FilePath: drawing, LayerIndex: -1, SearchText: "x"}))
+	session, ok := manager.Load(drawing)
+	require.True(t, ok)
+	assert.Equal(t, "x", session.SearchText)
+}
+
+func TestDXFView_SessionRestoredOnUpdate(t *testing.T) {
+	dir := t.TempDir()
+	drawing := writeDrawing(t, dir, "0\nEOF\n")
+	manager := NewSessionManager(filepath.Join(dir, "session.json"))
+
+	// First launch: open a layer, select an entity and quit
+	app := tview.NewApplication()
+	view := NewDXFView(app)
+	view.SetSession(manager, drawing)
+	view.Update(createTestDataWithMultipleItems())
+	view.showLayerDetails(0)
+	app.SetFocus(view.entityList)
+	view.ToggleEntitySelection(2)
+	view.GetQuitManager().AttemptQuit("force")
+
+	// Next launch on the same file
+	app = tview.NewApplication()
+	view = NewDXFView(app)
+	view.SetSession(manager, drawing)
+	view.Update(createTestDataWithMultipleItems())
+
+	page, _ := view.pages.GetFrontPage()
+	assert.Equal(t, "entities", page)
+	assert.Equal(t, 0, view.currentLayerIndex)
+	assert.Equal(t, view.entityList, app.GetFocus())
+	assert.True(t, view.selection.IsSelected("Layer1:1"))
+
+	// Updating the drawing shown again, as a reload does, keeps the current state
+	view.searchInput.SetText("Layer2")
+	view.ClearSelection()
+	view.Update(createTestDataWithMultipleItems())
+	assert.Equal(t, "Layer2", view.searchInput.GetText())
+	assert.False(t, view.selection.IsSelected("Layer1:1"))
+
+	// Showing sample data in between makes the drawing count as opened again
+	view.SetSourcePath("")
+	view.Update(createTestData())
+	view.SetSourcePath(drawing)
+	view.Update(createTestDataWithMultipleItems())
+	assert.Equal(t, "", view.searchInput.GetText())
+	assert.True(t, view.selection.IsSelected("Layer1:1"))
+}
+
+func TestDXFView_StaleSessionIgnored(t *testing.T) {
+	dir := t.TempDir()
+	drawing := writeDrawing(t, dir, "0\nEOF\n")
+	manager := NewSessionManager(filepath.Join(dir, "session.json"))
+	require.NoError(t, manager.Save(Session{FilePath: drawing, FocusedPane: PaneEntities, LayerIndex: 7, SearchText: "gone"}))
+
+	app := tview.NewApplication()
+	view := NewDXFView(app)
+	view.SetSession(manager, drawing)
+	view.Update(createTestData())
+
+	page, _ := view.pages.GetFrontPage()
+	assert.Equal(t, "layers", page)
+	assert.Equal(t, "", view.searchInput.GetText())
+	assert.Equal(t, view.searchInput, app.GetFocus())
+}
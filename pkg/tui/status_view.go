@@ -1,6 +1,10 @@
 package tui
 
-import "github.com/rivo/tview"
+import (
+	"time"
+
+	"github.com/rivo/tview"
+)
 
 // NewStatusView creates a simple TextView for status/progress/error display
 func NewStatusView() *tview.TextView {
@@ -11,3 +15,115 @@ func NewStatusView() *tview.TextView {
 	view.SetTitle("Status")
 	return view
 }
+
+// StatusLevel is the severity of a status bar message
+type StatusLevel int
+
+const (
+	StatusInfo StatusLevel = iota
+	StatusWarn
+	StatusError
+)
+
+// String returns the string representation of StatusLevel
+func (l StatusLevel) String() string {
+	switch l {
+	case StatusInfo:
+		return "info"
+	case StatusWarn:
+		return "warn"
+	case StatusError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// color returns the tview color name messages of the level are shown in
+func (l StatusLevel) color() string {
+	switch l {
+	case StatusWarn:
+		return "yellow"
+	case StatusError:
+		return "red"
+	default:
+		return "green"
+	}
+}
+
+// DefaultStatusTimeout is how long a status bar message stays before it is cleared
+const DefaultStatusTimeout = 5 * time.Second
+
+// StatusBar is a one-line status display whose messages clear after a timeout
+type StatusBar struct {
+	*tview.TextView
+	app        *tview.Application
+	message    string
+	level      StatusLevel
+	setAt      time.Time
+	timeout    time.Duration
+	generation int              // Incremented by each message so stale timers leave newer ones alone
+	now        func() time.Time // Current time; replaced in tests
+}
+
+// NewStatusBar creates an empty status bar redrawn through app
+func NewStatusBar(app *tview.Application) *StatusBar {
+	return &StatusBar{
+		TextView: tview.NewTextView().SetDynamicColors(true),
+		app:      app,
+		timeout:  DefaultStatusTimeout,
+		now:      time.Now,
+	}
+}
+
+// SetTimeout sets how long messages stay. Zero keeps them until they are replaced or cleared.
+func (sb *StatusBar) SetTimeout(timeout time.Duration) {
+	sb.timeout = timeout
+}
+
+// SetStatus shows a message colored by level, replacing the current one, and clears it
+// after the timeout
+func (sb *StatusBar) SetStatus(message string, level StatusLevel) {
+	sb.generation++
+	sb.message = message
+	sb.level = level
+	sb.setAt = sb.now()
+	sb.render()
+
+	if sb.timeout <= 0 || message == "" || sb.app == nil {
+		return
+	}
+	generation := sb.generation
+	time.AfterFunc(sb.timeout, func() {
+		sb.app.QueueUpdateDraw(func() {
+			if sb.generation == generation {
+				sb.Clear()
+			}
+		})
+	})
+}
+
+// Message returns the message shown and its level, or "" once it has timed out
+func (sb *StatusBar) Message() (string, StatusLevel) {
+	if sb.message != "" && sb.timeout > 0 && sb.now().Sub(sb.setAt) > sb.timeout {
+		sb.Clear()
+	}
+	return sb.message, sb.level
+}
+
+// Clear removes the current message
+func (sb *StatusBar) Clear() {
+	sb.generation++
+	sb.message = ""
+	sb.level = StatusInfo
+	sb.render()
+}
+
+// render writes the current message to the text view
+func (sb *StatusBar) render() {
+	if sb.message == "" {
+		sb.TextView.SetText("")
+		return
+	}
+	sb.TextView.SetText("[" + sb.level.color() + "]" + tview.Escape(sb.message) + "[-]")
+}
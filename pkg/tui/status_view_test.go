@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusBar_SetStatus(t *testing.T) {
+	tests := []struct {
+		level    StatusLevel
+		expected string
+	}{
+		{StatusInfo, "[green]Saved[-]"},
+		{StatusWarn, "[yellow]Saved[-]"},
+		{StatusError, "[red]Saved[-]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			bar := NewStatusBar(tview.NewApplication())
+			bar.SetStatus("Saved", tt.level)
+
+			assert.Equal(t, tt.expected, bar.GetText(false))
+			message, level := bar.Message()
+			assert.Equal(t, "Saved", message)
+			assert.Equal(t, tt.level, level)
+		})
+	}
+
+	t.Run("tags in messages are escaped", func(t *testing.T) {
+		bar := NewStatusBar(tview.NewApplication())
+		bar.SetStatus("Layer [red]", StatusInfo)
+		assert.Equal(t, "Layer [red]", bar.GetText(true))
+	})
+}
+
+func TestStatusBar_Timeout(t *testing.T) {
+	now := time.Now()
+	bar := NewStatusBar(tview.NewApplication())
+	bar.now = func() time.Time { return now }
+
+	bar.SetStatus("Copied", StatusInfo)
+	now = now.Add(DefaultStatusTimeout - time.Second)
+	message, _ := bar.Message()
+	assert.Equal(t, "Copied", message)
+
+	now = now.Add(2 * time.Second)
+	message, _ = bar.Message()
+	assert.Empty(t, message, "Expected the message to clear after the timeout")
+	assert.Empty(t, bar.GetText(true))
+
+	// Without a timeout messages stay
+	bar.SetTimeout(0)
+	bar.SetStatus("Kept", StatusWarn)
+	now = now.Add(time.Hour)
+	message, _ = bar.Message()
+	assert.Equal(t, "Kept", message)
+}
+
+func TestStatusBar_RoutesViewMessages(t *testing.T) {
+	view := NewDXFView(tview.NewApplication())
+	bar := view.GetStatusBar()
+
+	view.statusHandler.ShowCopySuccess(2)
+	message, level := bar.Message()
+	assert.Equal(t, "2 items copied to clipboard", message)
+	assert.Equal(t, StatusInfo, level)
+
+	view.statusHandler.ShowCopyError("no clipboard")
+	message, level = bar.Message()
+	assert.Equal(t, "Failed to copy: no clipboard", message)
+	assert.Equal(t, StatusError, level)
+
+	view.errorHandler.DisplayError(NewUserError("bad input", "Layer not found"), ErrorDisplayStatusBar)
+	message, level = bar.Message()
+	assert.Equal(t, "Layer not found", message)
+	assert.Equal(t, StatusWarn, level)
+
+	view.errorHandler.DisplayError(NewSystemError("disk", nil), ErrorDisplayStatusBar)
+	_, level = bar.Message()
+	assert.Equal(t, StatusError, level)
+
+	view.statusHandler.ClearMessage()
+	message, _ = bar.Message()
+	assert.Empty(t, message)
+}
@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+)
+
+// DefaultUndoDepth is the number of commands an UndoManager keeps by default
+const DefaultUndoDepth = 50
+
+// UndoableCommand is a recorded change that can be reverted and reapplied
+type UndoableCommand interface {
+	Undo()
+	Redo()
+	Description() string
+}
+
+// UndoManager keeps the undo and redo stacks of recorded commands
+type UndoManager struct {
+	undoStack []UndoableCommand
+	redoStack []UndoableCommand
+	maxDepth  int
+}
+
+// NewUndoManager creates an undo manager that keeps at most maxDepth commands.
+// A maxDepth of zero or less uses DefaultUndoDepth.
+func NewUndoManager(maxDepth int) *UndoManager {
+	um := &UndoManager{}
+	um.SetMaxDepth(maxDepth)
+	return um
+}
+
+// SetMaxDepth sets how many commands are kept, dropping the oldest ones beyond it.
+// A depth of zero or less uses DefaultUndoDepth.
+func (um *UndoManager) SetMaxDepth(depth int) {
+	if depth <= 0 {
+		depth = DefaultUndoDepth
+	}
+	um.maxDepth = depth
+	um.undoStack = trimStack(um.undoStack, depth)
+	um.redoStack = trimStack(um.redoStack, depth)
+}
+
+// Record adds an already applied command to the undo stack and clears the redo stack
+func (um *UndoManager) Record(cmd UndoableCommand) {
+	um.undoStack = trimStack(append(um.undoStack, cmd), um.maxDepth)
+	um.redoStack = nil
+}
+
+// Undo reverts the most recent command, returning false when there is nothing to undo
+func (um *UndoManager) Undo() bool {
+	if len(um.undoStack) == 0 {
+		return false
+	}
+
+	cmd := um.undoStack[len(um.undoStack)-1]
+	um.undoStack = um.undoStack[:len(um.undoStack)-1]
+	cmd.Undo()
+	um.redoStack = append(um.redoStack, cmd)
+	return true
+}
+
+// Redo reapplies the most recently undone command, returning false when there is nothing to redo
+func (um *UndoManager) Redo() bool {
+	if len(um.redoStack) == 0 {
+		return false
+	}
+
+	cmd := um.redoStack[len(um.redoStack)-1]
+	um.redoStack = um.redoStack[:len(um.redoStack)-1]
+	cmd.Redo()
+	um.undoStack = append(um.undoStack, cmd)
+	return true
+}
+
+// CanUndo returns whether there is a command to undo
+func (um *UndoManager) CanUndo() bool {
+	return len(um.undoStack) > 0
+}
+
+// CanRedo returns whether there is a command to redo
+func (um *UndoManager) CanRedo() bool {
+	return len(um.redoStack) > 0
+}
+
+// UndoCount returns the number of commands that can be undone
+func (um *UndoManager) UndoCount() int {
+	return len(um.undoStack)
+}
+
+// trimStack drops the oldest commands so that at most depth remain
+func trimStack(stack []UndoableCommand, depth int) []UndoableCommand {
+	if len(stack) <= depth {
+		return stack
+	}
+	return slices.Clone(stack[len(stack)-depth:])
+}
+
+// layerVisibilityCommand records a layer visibility toggle
+type layerVisibilityCommand struct {
+	view         *DXFView
+	layerName    string
+	previousIsOn bool
+}
+
+// Undo restores the previous visibility
+func (c *layerVisibilityCommand) Undo() {
+	c.view.setLayerVisibility(c.layerName, c.previousIsOn)
+}
+
+// Redo applies the toggle again
+func (c *layerVisibilityCommand) Redo() {
+	c.view.setLayerVisibility(c.layerName, !c.previousIsOn)
+}
+
+// Description describes the command
+func (c *layerVisibilityCommand) Description() string {
+	return fmt.Sprintf("Toggle visibility of layer %s", c.layerName)
+}
+
+// layerState is the visibility and frozen state of a named layer
+type layerState struct {
+	name     string
+	isOn     bool
+	isFrozen bool
+}
+
+// layerStatesCommand records a change of several layers at once, such as hiding every layer
+type layerStatesCommand struct {
+	view        *DXFView
+	description string
+	before      []layerState
+	after       []layerState
+}
+
+// Undo restores the layers' states from before the change
+func (c *layerStatesCommand) Undo() {
+	c.view.setLayerStates(c.before)
+}
+
+// Redo restores the layers' states from after the change
+func (c *layerStatesCommand) Redo() {
+	c.view.setLayerStates(c.after)
+}
+
+// Description describes the command
+func (c *layerStatesCommand) Description() string {
+	return c.description
+}
+
+// selectionCommand records a change of the selected entities
+type selectionCommand struct {
+	view        *DXFView
+	description string
+	before      []string
+	after       []string
+}
+
+// Undo restores the selection from before the change
+func (c *selectionCommand) Undo() {
+	c.view.restoreSelection(c.before)
+}
+
+// Redo restores the selection from after the change
+func (c *selectionCommand) Redo() {
+	c.view.restoreSelection(c.after)
+}
+
+// Description describes the command
+func (c *selectionCommand) Description() string {
+	return c.description
+}
+
+// sortedSelection returns the selected IDs in a stable order
+func sortedSelection(s *SelectionState) []string {
+	ids := s.GetSelectedItemIDs()
+	slices.Sort(ids)
+	return ids
+}
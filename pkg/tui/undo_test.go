@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/remym/go-dwg-extractor/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// counterCommand is a test command that adds to a counter
+type counterCommand struct {
+	value *int
+	delta int
+}
+
+func (c *counterCommand) Undo()               { *c.value -= c.delta }
+func (c *counterCommand) Redo()               { *c.value += c.delta }
+func (c *counterCommand) Description() string { return "add" }
+
+func TestUndoManager(t *testing.T) {
+	value := 0
+	um := NewUndoManager(0)
+	assert.False(t, um.Undo(), "Empty stack has nothing to undo")
+	assert.False(t, um.Redo(), "Empty stack has nothing to redo")
+
+	for _, delta := range []int{1, 10} {
+		value += delta
+		um.Record(&counterCommand{value: &value, delta: delta})
+	}
+
+	assert.True(t, um.Undo())
+	assert.Equal(t, 1, value)
+	assert.True(t, um.CanRedo())
+	assert.True(t, um.Redo())
+	assert.Equal(t, 11, value)
+
+	// Recording a new command discards the redo history
+	assert.True(t, um.Undo())
+	value += 100
+	um.Record(&counterCommand{value: &value, delta: 100})
+	assert.False(t, um.CanRedo())
+	assert.Equal(t, 2, um.UndoCount())
+}
+
+func TestUndoManager_MaxDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDepth int
+		records  int
+		want     int
+	}{
+		{"default depth", 0, 60, DefaultUndoDepth},
+		{"custom depth", 3, 5, 3},
+		{"below depth", 10, 4, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := 0
+			um := NewUndoManager(tt.maxDepth)
+			for i := 0; i < tt.records; i++ {
+				value++
+				um.Record(&counterCommand{value: &value, delta: 1})
+			}
+			assert.Equal(t, tt.want, um.UndoCount())
+
+			// Only the newest commands can be undone
+			for um.Undo() {
+			}
+			assert.Equal(t, tt.records-tt.want, value)
+		})
+	}
+}
+
+func TestDXFView_UndoLayerVisibility(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(&data.ExtractedData{
+		Layers: []data.LayerInfo{
+			{Name: "Walls", IsOn: true},
+			{Name: "Frozen", IsOn: true, IsFrozen: true},
+		},
+	})
+
+	view.ToggleLayerVisibility(0)
+	require.False(t, view.data.Layers[0].IsOn)
+
+	// Frozen layers don't toggle and leave no undo entry
+	view.ToggleLayerVisibility(1)
+	assert.Equal(t, 1, view.GetUndoManager().UndoCount())
+
+	capture := view.layers.GetInputCapture()
+	capture(tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModCtrl))
+	assert.True(t, view.data.Layers[0].IsOn, "Ctrl+Z should restore the previous visibility")
+	mainText, _ := view.layers.GetItemText(0)
+	assert.Contains(t, mainText, "ON")
+
+	capture(tcell.NewEventKey(tcell.KeyCtrlY, 0, tcell.ModCtrl))
+	assert.False(t, view.data.Layers[0].IsOn, "Ctrl+Y should toggle the layer again")
+}
+
+func TestDXFView_UndoSelection(t *testing.T) {
+	app := SetupTestApp(t)
+	view := NewDXFView(app)
+	view.Update(createTestDataWithMultipleItems())
+	view.showLayerDetails(0)
+
+	view.ToggleEntitySelection(1)
+	view.SelectAllVisible()
+	assert.Equal(t, 3, view.SelectedCount())
+
+	// Selecting everything again changes nothing and records nothing
+	view.SelectAllVisible()
+	assert.Equal(t, 2, view.GetUndoManager().UndoCount())
+
+	capture := view.entityList.GetInputCapture()
+	capture(tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModCtrl))
+	assert.Equal(t, 1, view.SelectedCount())
+	assert.Equal(t, "1 selected", view.selectionStatus.GetText(true))
+
+	capture(tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModCtrl))
+	assert.Equal(t, 0, view.SelectedCount())
+
+	capture(tcell.NewEventKey(tcell.KeyCtrlY, 0, tcell.ModCtrl))
+	assert.Equal(t, 1, view.SelectedCount())
+	assert.True(t, view.GetSelectionState().IsSelected(entityID("Layer1", 0, nil)))
+}